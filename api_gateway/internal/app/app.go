@@ -5,10 +5,13 @@ import (
 	"log/slog"
 
 	"api_gateway/internal/app/config"
+	"api_gateway/internal/infrastructure/auth"
+	"api_gateway/internal/infrastructure/ratelimit"
 	"api_gateway/internal/transport/http"
 
 	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
 	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/mtls"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -18,19 +21,63 @@ type App struct {
 }
 
 func New(ctx context.Context, log *slog.Logger, cfg *config.Config) (*App, error) {
-	storageConn, err := grpc.DialContext(ctx, cfg.Storage.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	var verifier *auth.Verifier
+	if cfg.Auth.Disabled {
+		log.Warn("authentication is disabled")
+	} else {
+		var err error
+		verifier, err = auth.NewVerifier(cfg.Auth.JWTSecret, cfg.Auth.JWKSFile, cfg.Auth.Issuer, cfg.Auth.Audience)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS.Enabled() {
+		var err error
+		creds, err = mtls.ClientCredentials(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("mtls enabled")
+	}
+
+	// личность пользователя уходит сервисам в metadata каждого вызова
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(auth.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(auth.StreamClientInterceptor()),
+	}
+
+	storageConn, err := grpc.DialContext(ctx, cfg.Storage.Addr, dialOptions...)
 	if err != nil {
 		return nil, err
 	}
 	storageClient := storagepb.NewStorageClient(storageConn)
 
-	analysisConn, err := grpc.DialContext(ctx, cfg.Analysis.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	analysisConn, err := grpc.DialContext(ctx, cfg.Analysis.Addr, dialOptions...)
 	if err != nil {
 		return nil, err
 	}
 	analysisClient := plagiarismpb.NewPlagiarismClient(analysisConn)
 
-	httpServer := http.NewServer(log, cfg.HTTP.Port, storageClient, analysisClient)
+	var rateLimits *http.RateLimits
+	if !cfg.RateLimit.Disabled {
+		// без Redis корзины только в памяти процесса
+		var store ratelimit.Store
+		if cfg.RateLimit.RedisAddr != "" {
+			store = ratelimit.NewRedisStore(cfg.RateLimit.RedisAddr, cfg.RateLimit.RedisPassword, cfg.RateLimit.RedisDB, log)
+		}
+		rateLimits = &http.RateLimits{
+			Limiter:    ratelimit.New(log, store),
+			Analysis:   ratelimit.Limit{PerMinute: cfg.RateLimit.AnalysisPerMinute, Burst: cfg.RateLimit.AnalysisBurst},
+			Upload:     ratelimit.Limit{PerMinute: cfg.RateLimit.UploadPerMinute, Burst: cfg.RateLimit.UploadBurst},
+			WordCloud:  ratelimit.Limit{PerMinute: cfg.RateLimit.WordCloudPerMinute, Burst: cfg.RateLimit.WordCloudBurst},
+			TrustProxy: cfg.RateLimit.TrustProxy,
+		}
+	}
+
+	httpServer := http.NewServer(log, cfg.HTTP.Port, storageClient, analysisClient, verifier, cfg.Auth.AllowUnscopedTasks, rateLimits)
 
 	return &App{
 		HTTPServer: httpServer,
@@ -3,10 +3,13 @@ package config
 import "github.com/ilyakaznacheev/cleanenv"
 
 type Config struct {
-	Env      string        `env:"ENV" env-default:"local"`
-	HTTP     HTTPConfig    `env-prefix:"HTTP_"`
-	Storage  StorageConfig `env-prefix:"STORAGE_"`
-	Analysis AnalysisConfig `env-prefix:"ANALYSIS_"`
+	Env       string          `env:"ENV" env-default:"local"`
+	HTTP      HTTPConfig      `env-prefix:"HTTP_"`
+	Storage   StorageConfig   `env-prefix:"STORAGE_"`
+	Analysis  AnalysisConfig  `env-prefix:"ANALYSIS_"`
+	Auth      AuthConfig      `env-prefix:"AUTH_"`
+	TLS       TLSConfig       `env-prefix:"TLS_"`
+	RateLimit RateLimitConfig `env-prefix:"RATE_LIMIT_"`
 }
 
 type HTTPConfig struct {
@@ -21,12 +24,62 @@ type AnalysisConfig struct {
 	Addr string `env:"ADDR" env-default:"plagiarism-service:6001"`
 }
 
+// AuthConfig - проверка JWT: общий ключ HS* и/или JWKS-файл с открытыми ключами RS*/ES*.
+// Disabled отключает аутентификацию (только для локальной отладки). AllowUnscopedTasks открывает задачи
+// без курса и незаведённые задачи любому преподавателю; без него они доступны только администраторам.
+type AuthConfig struct {
+	Disabled           bool   `env:"DISABLED" env-default:"false"`
+	JWTSecret          string `env:"JWT_SECRET"`
+	JWKSFile           string `env:"JWKS_FILE"`
+	Issuer             string `env:"ISSUER"`
+	Audience           string `env:"AUDIENCE"`
+	AllowUnscopedTasks bool   `env:"ALLOW_UNSCOPED_TASKS" env-default:"false"`
+}
+
+// TLSConfig - клиентский сертификат гейтвея для mTLS с сервисами и CA, которым подписаны их сертификаты.
+// Пустые пути - соединения без TLS.
+type TLSConfig struct {
+	CertFile string `env:"CERT_FILE"`
+	KeyFile  string `env:"KEY_FILE"`
+	CAFile   string `env:"CA_FILE"`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+// RateLimitConfig - корзины токенов для дорогих маршрутов: запуск анализа, выдача ссылок на загрузку и облако слов.
+// Счёт ведётся по пользователю из токена, без аутентификации - по IP. С RedisAddr корзины общие для всех копий
+// гейтвея, иначе (и пока Redis недоступен) - в памяти процесса. TrustProxy берёт IP из X-Forwarded-For.
+type RateLimitConfig struct {
+	Disabled      bool   `env:"DISABLED" env-default:"false"`
+	RedisAddr     string `env:"REDIS_ADDR"`
+	RedisPassword string `env:"REDIS_PASSWORD"`
+	RedisDB       int    `env:"REDIS_DB" env-default:"0"`
+	TrustProxy    bool   `env:"TRUST_PROXY" env-default:"false"`
+
+	AnalysisPerMinute  float64 `env:"ANALYSIS_PER_MINUTE" env-default:"5"`
+	AnalysisBurst      int     `env:"ANALYSIS_BURST" env-default:"3"`
+	UploadPerMinute    float64 `env:"UPLOAD_PER_MINUTE" env-default:"30"`
+	UploadBurst        int     `env:"UPLOAD_BURST" env-default:"10"`
+	WordCloudPerMinute float64 `env:"WORDCLOUD_PER_MINUTE" env-default:"10"`
+	WordCloudBurst     int     `env:"WORDCLOUD_BURST" env-default:"3"`
+}
+
 func MustLoad() *Config {
 	var cfg Config
 	if err := cleanenv.ReadEnv(&cfg); err != nil {
 		panic("failed to read config: " + err.Error())
 	}
+	if cfg.TLS.Enabled() && (cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "" || cfg.TLS.CAFile == "") {
+		panic("TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE must be set together")
+	}
+	if rl := cfg.RateLimit; rl.AnalysisPerMinute <= 0 || rl.UploadPerMinute <= 0 || rl.WordCloudPerMinute <= 0 ||
+		rl.AnalysisBurst < 1 || rl.UploadBurst < 1 || rl.WordCloudBurst < 1 {
+		panic("rate limits must be positive and bursts at least 1")
+	}
+	if !cfg.Auth.Disabled && cfg.Auth.JWTSecret == "" && cfg.Auth.JWKSFile == "" {
+		panic("AUTH_JWT_SECRET or AUTH_JWKS_FILE is required unless AUTH_DISABLED=true")
+	}
 	return &cfg
 }
-
-
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type Role string
+
+const (
+	RoleStudent Role = "student"
+	RoleTeacher Role = "teacher"
+	RoleAdmin   Role = "admin"
+)
+
+func (r Role) Valid() bool {
+	switch r {
+	case RoleStudent, RoleTeacher, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Ключи gRPC metadata, в которых гейтвей передаёт сервисам личность пользователя; сервисы читают их пакетом identity.
+const (
+	MetadataUserID   = identity.MetadataUserID
+	MetadataUserRole = identity.MetadataUserRole
+)
+
+// Identity - пользователь из проверенного токена.
+type Identity struct {
+	UserID string
+	Role   Role
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext возвращает пользователя запроса; nil, если аутентификация выключена.
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	identity := FromContext(ctx)
+	if identity == nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		MetadataUserID, identity.UserID,
+		MetadataUserRole, string(identity.Role),
+	)
+}
+
+// UnaryClientInterceptor добавляет личность пользователя запроса в metadata вызовов сервисов.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor - то же для потоковых вызовов.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,324 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// leeway - допустимое расхождение часов гейтвея и издателя токенов.
+const leeway = 30 * time.Second
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// Verifier проверяет JWT, подписанные общим ключом (HS256/384/512) или ключами из JWKS (RS*, ES*).
+type Verifier struct {
+	secret   []byte
+	keys     map[string]crypto.PublicKey
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewVerifier создаёт проверку токенов. secret и jwksFile можно задать вместе; пустые issuer и audience не проверяются.
+func NewVerifier(secret, jwksFile, issuer, audience string) (*Verifier, error) {
+	v := &Verifier{
+		secret:   []byte(secret),
+		keys:     make(map[string]crypto.PublicKey),
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
+	}
+
+	if jwksFile != "" {
+		data, err := os.ReadFile(jwksFile)
+		if err != nil {
+			return nil, fmt.Errorf("read jwks: %w", err)
+		}
+		if err := v.loadJWKS(data); err != nil {
+			return nil, fmt.Errorf("parse jwks: %w", err)
+		}
+	}
+
+	if len(v.secret) == 0 && len(v.keys) == 0 {
+		return nil, errors.New("no signing key configured")
+	}
+
+	return v, nil
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (v *Verifier) loadJWKS(data []byte) error {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return err
+	}
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		var (
+			pub crypto.PublicKey
+			err error
+		)
+		switch key.Kty {
+		case "RSA":
+			pub, err = rsaPublicKey(key)
+		case "EC":
+			pub, err = ecdsaPublicKey(key)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("key %q: %w", key.Kid, err)
+		}
+		v.keys[key.Kid] = pub
+	}
+
+	if len(v.keys) == 0 {
+		return errors.New("no signing keys")
+	}
+	return nil
+}
+
+func rsaPublicKey(key jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 {
+		return nil, errors.New("invalid rsa exponent")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func ecdsaPublicKey(key jwk) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch key.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("point is not on curve")
+	}
+	return pub, nil
+}
+
+type claims struct {
+	Subject   string   `json:"sub"`
+	Role      Role     `json:"role"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+}
+
+// audience - claim aud, который бывает строкой или массивом строк.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Verify проверяет подпись и сроки токена и возвращает личность из claims sub и role.
+// Токен без exp не принимается.
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := v.verifySignature(header.Alg, header.Kid, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	now := v.now()
+	if c.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: exp is required", ErrInvalidToken)
+	}
+	if now.After(time.Unix(*c.ExpiresAt, 0).Add(leeway)) {
+		return nil, ErrTokenExpired
+	}
+	if c.NotBefore != nil && now.Add(leeway).Before(time.Unix(*c.NotBefore, 0)) {
+		return nil, fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.audience != "" && !slices.Contains(c.Audience, v.audience) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: sub is required", ErrInvalidToken)
+	}
+	if !c.Role.Valid() {
+		return nil, fmt.Errorf("%w: unknown role", ErrInvalidToken)
+	}
+
+	return &Identity{UserID: c.Subject, Role: c.Role}, nil
+}
+
+func (v *Verifier) verifySignature(alg, kid, signed string, signature []byte) error {
+	var hashFunc crypto.Hash
+	switch alg[min(2, len(alg)):] {
+	case "256":
+		hashFunc = crypto.SHA256
+	case "384":
+		hashFunc = crypto.SHA384
+	case "512":
+		hashFunc = crypto.SHA512
+	default:
+		return fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, alg)
+	}
+
+	switch alg[:2] {
+	case "HS":
+		if len(v.secret) == 0 {
+			return fmt.Errorf("%w: hmac tokens are not accepted", ErrInvalidToken)
+		}
+		mac := hmac.New(hashConstructor(hashFunc), v.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+
+	case "RS":
+		key, ok := v.keys[kid].(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+		}
+		if err := rsa.VerifyPKCS1v15(key, hashFunc, digest(hashFunc, signed), signature); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+
+	case "ES":
+		key, ok := v.keys[kid].(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest(hashFunc, signed), r, s) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, alg)
+}
+
+func hashConstructor(h crypto.Hash) func() hash.Hash {
+	switch h {
+	case crypto.SHA384:
+		return sha512.New384
+	case crypto.SHA512:
+		return sha512.New
+	default:
+		return sha256.New
+	}
+}
+
+func digest(h crypto.Hash, signed string) []byte {
+	hasher := hashConstructor(h)()
+	hasher.Write([]byte(signed))
+	return hasher.Sum(nil)
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,377 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret"
+
+var testNow = time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  map[string]*ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := &testKeys{rsa: rsaKey, ec: make(map[string]*ecdsa.PrivateKey)}
+	for alg, curve := range map[string]elliptic.Curve{"ES256": elliptic.P256(), "ES384": elliptic.P384(), "ES512": elliptic.P521()} {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys.ec[alg] = key
+	}
+	return keys
+}
+
+// writeJWKS сохраняет открытые ключи в JWKS-файл: RSA с kid "rsa", EC с kid по алгоритму ("ES256" и т.д.).
+func (k *testKeys) writeJWKS(t *testing.T) string {
+	t.Helper()
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	keys := []map[string]string{{
+		"kid": "rsa",
+		"kty": "RSA",
+		"use": "sig",
+		"n":   b64(k.rsa.N.Bytes()),
+		"e":   b64(big.NewInt(int64(k.rsa.E)).Bytes()),
+	}}
+	for alg, key := range k.ec {
+		size := (key.Curve.Params().BitSize + 7) / 8
+		keys = append(keys, map[string]string{
+			"kid": alg,
+			"kty": "EC",
+			"crv": key.Curve.Params().Name,
+			"x":   b64(key.X.FillBytes(make([]byte, size))),
+			"y":   b64(key.Y.FillBytes(make([]byte, size))),
+		})
+	}
+
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func hashForAlg(alg string) crypto.Hash {
+	switch alg[2:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	}
+	return crypto.SHA256
+}
+
+// sign собирает токен с заголовком header и claims и подписывает его по header["alg"].
+func (k *testKeys) sign(t *testing.T, header, claims map[string]any) string {
+	t.Helper()
+
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(header) + "." + encode(claims)
+
+	alg, _ := header["alg"].(string)
+	var signature []byte
+	switch {
+	case len(alg) == 5 && alg[:2] == "HS":
+		mac := hmac.New(hashConstructor(hashForAlg(alg)), []byte(testSecret))
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case len(alg) == 5 && alg[:2] == "RS":
+		h := hashForAlg(alg)
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, h, digest(h, signed))
+		if err != nil {
+			t.Fatal(err)
+		}
+	case len(alg) == 5 && alg[:2] == "ES":
+		key := k.ec[alg]
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest(hashForAlg(alg), signed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":  "teacher_1",
+		"role": "teacher",
+		"iss":  "issuer",
+		"aud":  "gateway",
+		"exp":  testNow.Add(time.Hour).Unix(),
+	}
+}
+
+func with(claims map[string]any, key string, value any) map[string]any {
+	result := make(map[string]any, len(claims))
+	for k, v := range claims {
+		result[k] = v
+	}
+	if value == nil {
+		delete(result, key)
+	} else {
+		result[key] = value
+	}
+	return result
+}
+
+func headerFor(alg string) map[string]any {
+	header := map[string]any{"alg": alg, "typ": "JWT"}
+	switch alg[:2] {
+	case "RS":
+		header["kid"] = "rsa"
+	case "ES":
+		header["kid"] = alg
+	}
+	return header
+}
+
+func TestVerifierAlgorithms(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier, err := NewVerifier(testSecret, keys.writeJWKS(t), "issuer", "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.now = func() time.Time { return testNow }
+
+	for _, alg := range []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512"} {
+		t.Run(alg, func(t *testing.T) {
+			identity, err := verifier.Verify(keys.sign(t, headerFor(alg), validClaims()))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if identity.UserID != "teacher_1" || identity.Role != RoleTeacher {
+				t.Errorf("identity = %+v", identity)
+			}
+		})
+	}
+}
+
+func TestVerifierRejects(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier, err := NewVerifier(testSecret, keys.writeJWKS(t), "issuer", "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.now = func() time.Time { return testNow }
+
+	valid := validClaims()
+
+	tamper := func(token string) string {
+		// первый байт подписи меняется, а сама подпись остаётся корректным base64
+		dot := strings.LastIndexByte(token, '.')
+		signature, err := base64.RawURLEncoding.DecodeString(token[dot+1:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature[0] ^= 0xFF
+		return token[:dot+1] + base64.RawURLEncoding.EncodeToString(signature)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:    "alg none",
+			token:   keys.sign(t, map[string]any{"alg": "none"}, valid),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "unknown alg",
+			token:   keys.sign(t, map[string]any{"alg": "PS256", "kid": "rsa"}, valid),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "empty alg",
+			token:   keys.sign(t, map[string]any{}, valid),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "bad hmac signature",
+			token:   tamper(keys.sign(t, headerFor("HS256"), valid)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "bad rsa signature",
+			token:   tamper(keys.sign(t, headerFor("RS256"), valid)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "bad ecdsa signature",
+			token:   tamper(keys.sign(t, headerFor("ES256"), valid)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "unknown kid",
+			token:   keys.sign(t, map[string]any{"alg": "RS256", "kid": "other"}, valid),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "rsa kid for ecdsa alg",
+			token:   keys.sign(t, map[string]any{"alg": "ES256", "kid": "rsa"}, valid),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "missing exp",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "exp", nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "expired",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "exp", testNow.Add(-time.Minute).Unix())),
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name:    "not valid yet",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "nbf", testNow.Add(time.Minute).Unix())),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "wrong issuer",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "iss", "other")),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "wrong audience string",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "aud", "other")),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "wrong audience array",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "aud", []string{"a", "b"})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "missing sub",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "sub", nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "unknown role",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "role", "superuser")),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "missing role",
+			token:   keys.sign(t, headerFor("HS256"), with(valid, "role", nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "malformed",
+			token:   "not.a-token",
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v (identity %+v)", err, tt.wantErr, identity)
+			}
+		})
+	}
+}
+
+func TestVerifierAccepts(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier, err := NewVerifier(testSecret, keys.writeJWKS(t), "issuer", "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.now = func() time.Time { return testNow }
+
+	valid := validClaims()
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+	}{
+		{name: "audience array", claims: with(valid, "aud", []string{"other", "gateway"})},
+		{name: "expired within leeway", claims: with(valid, "exp", testNow.Add(-leeway/2).Unix())},
+		{name: "nbf within leeway", claims: with(valid, "nbf", testNow.Add(leeway/2).Unix())},
+		{name: "nbf in the past", claims: with(valid, "nbf", testNow.Add(-time.Hour).Unix())},
+		{name: "student role", claims: with(valid, "role", "student")},
+		{name: "admin role", claims: with(valid, "role", "admin")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := verifier.Verify(keys.sign(t, headerFor("HS256"), tt.claims)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifierWithoutIssuerAndAudience(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier, err := NewVerifier(testSecret, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.now = func() time.Time { return testNow }
+
+	claims := with(with(validClaims(), "iss", nil), "aud", nil)
+	if _, err := verifier.Verify(keys.sign(t, headerFor("HS256"), claims)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// без JWKS токены RS* и ES* не принимаются
+	if _, err := verifier.Verify(keys.sign(t, headerFor("RS256"), claims)); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestVerifierHMACDisabledWithoutSecret(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier, err := NewVerifier("", keys.writeJWKS(t), "issuer", "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.now = func() time.Time { return testNow }
+
+	if _, err := verifier.Verify(keys.sign(t, headerFor("HS256"), validClaims())); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidToken)
+	}
+}
@@ -0,0 +1,160 @@
+package graph_export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Graph - неориентированный взвешенный граф схожести работ: вершины - студенты, рёбра - пары.
+type Graph struct {
+	Name  string
+	Nodes []Node
+	Edges []Edge
+}
+
+type Node struct {
+	ID string
+	// SubmittedAt - время сдачи версии файла, участвовавшей в анализе
+	SubmittedAt time.Time
+	// FileStatus и FileUpdatedAt - текущее состояние файла в хранилище
+	FileStatus    string
+	FileUpdatedAt time.Time
+}
+
+type Edge struct {
+	Source string
+	Target string
+	Weight float64
+}
+
+// DOT выгружает граф для Graphviz. Толщина ребра растёт со схожестью.
+func (g *Graph) DOT() []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "graph %s {\n", dotQuote(g.Name))
+	b.WriteString("  node [shape=ellipse];\n")
+
+	for _, n := range g.Nodes {
+		attrs := []string{"label=" + dotQuote(n.ID)}
+		if !n.SubmittedAt.IsZero() {
+			attrs = append(attrs, "submitted_at="+dotQuote(formatTime(n.SubmittedAt)))
+		}
+		if n.FileStatus != "" {
+			attrs = append(attrs, "file_status="+dotQuote(n.FileStatus))
+		}
+		if !n.FileUpdatedAt.IsZero() {
+			attrs = append(attrs, "file_updated_at="+dotQuote(formatTime(n.FileUpdatedAt)))
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(n.ID), strings.Join(attrs, ", "))
+	}
+
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -- %s [weight=%.4f, label=\"%.2f\", penwidth=%.2f];\n",
+			dotQuote(e.Source), dotQuote(e.Target), e.Weight, e.Weight, 1+4*e.Weight)
+	}
+
+	b.WriteString("}\n")
+
+	return b.Bytes()
+}
+
+// GraphML выгружает граф для Gephi и других инструментов, понимающих GraphML.
+func (g *Graph) GraphML() []byte {
+	var b bytes.Buffer
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.WriteString(`  <key id="submitted_at" for="node" attr.name="submitted_at" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="file_status" for="node" attr.name="file_status" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="file_updated_at" for="node" attr.name="file_updated_at" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="weight" for="edge" attr.name="weight" attr.type="double"/>` + "\n")
+	fmt.Fprintf(&b, `  <graph id="%s" edgedefault="undirected">`+"\n", xmlEscape(g.Name))
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, `    <node id="%s">`+"\n", xmlEscape(n.ID))
+		if !n.SubmittedAt.IsZero() {
+			fmt.Fprintf(&b, `      <data key="submitted_at">%s</data>`+"\n", formatTime(n.SubmittedAt))
+		}
+		if n.FileStatus != "" {
+			fmt.Fprintf(&b, `      <data key="file_status">%s</data>`+"\n", xmlEscape(n.FileStatus))
+		}
+		if !n.FileUpdatedAt.IsZero() {
+			fmt.Fprintf(&b, `      <data key="file_updated_at">%s</data>`+"\n", formatTime(n.FileUpdatedAt))
+		}
+		b.WriteString("    </node>\n")
+	}
+
+	for i, e := range g.Edges {
+		fmt.Fprintf(&b, `    <edge id="e%d" source="%s" target="%s">`+"\n", i, xmlEscape(e.Source), xmlEscape(e.Target))
+		fmt.Fprintf(&b, `      <data key="weight">%.4f</data>`+"\n", e.Weight)
+		b.WriteString("    </edge>\n")
+	}
+
+	b.WriteString("  </graph>\n</graphml>\n")
+
+	return b.Bytes()
+}
+
+// JSON выгружает граф в формате node-link (nodes/edges), который читают d3 и networkx.
+func (g *Graph) JSON() ([]byte, error) {
+	type node struct {
+		ID            string     `json:"id"`
+		SubmittedAt   *time.Time `json:"submitted_at,omitempty"`
+		FileStatus    string     `json:"file_status,omitempty"`
+		FileUpdatedAt *time.Time `json:"file_updated_at,omitempty"`
+	}
+	type edge struct {
+		Source string  `json:"source"`
+		Target string  `json:"target"`
+		Weight float64 `json:"weight"`
+	}
+
+	nodes := make([]node, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes = append(nodes, node{
+			ID:            n.ID,
+			SubmittedAt:   optionalTime(n.SubmittedAt),
+			FileStatus:    n.FileStatus,
+			FileUpdatedAt: optionalTime(n.FileUpdatedAt),
+		})
+	}
+
+	edges := make([]edge, 0, len(g.Edges))
+	for _, e := range g.Edges {
+		edges = append(edges, edge{Source: e.Source, Target: e.Target, Weight: e.Weight})
+	}
+
+	return json.Marshal(map[string]any{
+		"name":     g.Name,
+		"directed": false,
+		"nodes":    nodes,
+		"edges":    edges,
+	})
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,162 @@
+package graph_export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var submittedAt = time.Date(2024, 1, 1, 15, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+func testGraph() *Graph {
+	return &Graph{
+		Name: "task_1",
+		Nodes: []Node{
+			{ID: `a"b\c`, SubmittedAt: submittedAt, FileStatus: "uploaded"},
+			{ID: "<s2&>"},
+		},
+		Edges: []Edge{{Source: `a"b\c`, Target: "<s2&>", Weight: 0.8}},
+	}
+}
+
+func TestDOT(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    *Graph
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "empty task",
+			graph:    &Graph{Name: "task_1"},
+			contains: []string{"graph \"task_1\" {\n", "}\n"},
+			excludes: []string{" -- "},
+		},
+		{
+			name:  "quotes and times",
+			graph: testGraph(),
+			contains: []string{
+				`"a\"b\\c" [label="a\"b\\c", submitted_at="2024-01-01T12:00:00Z", file_status="uploaded"];`,
+				`"<s2&>" [label="<s2&>"];`,
+				`"a\"b\\c" -- "<s2&>" [weight=0.8000, label="0.80", penwidth=4.20];`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dot := string(tt.graph.DOT())
+			for _, s := range tt.contains {
+				if !strings.Contains(dot, s) {
+					t.Errorf("DOT does not contain %s:\n%s", s, dot)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(dot, s) {
+					t.Errorf("DOT contains %s:\n%s", s, dot)
+				}
+			}
+		})
+	}
+}
+
+func TestGraphML(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph *Graph
+		nodes int
+		edges int
+	}{
+		{name: "empty task", graph: &Graph{Name: "task_1"}},
+		{name: "escaped ids", graph: testGraph(), nodes: 2, edges: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.graph.GraphML()
+
+			// документ должен разбираться XML-парсером целиком
+			var nodes, edges int
+			decoder := xml.NewDecoder(bytes.NewReader(data))
+			for {
+				token, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("GraphML is not valid XML: %v\n%s", err, data)
+				}
+				if start, ok := token.(xml.StartElement); ok {
+					switch start.Name.Local {
+					case "node":
+						nodes++
+					case "edge":
+						edges++
+					}
+				}
+			}
+
+			if nodes != tt.nodes || edges != tt.edges {
+				t.Errorf("nodes = %d, edges = %d, want %d and %d", nodes, edges, tt.nodes, tt.edges)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type node struct {
+		ID            string  `json:"id"`
+		SubmittedAt   *string `json:"submitted_at"`
+		FileStatus    string  `json:"file_status"`
+		FileUpdatedAt *string `json:"file_updated_at"`
+	}
+	type document struct {
+		Name     string            `json:"name"`
+		Directed bool              `json:"directed"`
+		Nodes    []node            `json:"nodes"`
+		Edges    []json.RawMessage `json:"edges"`
+	}
+
+	tests := []struct {
+		name  string
+		graph *Graph
+		nodes int
+		edges int
+	}{
+		{name: "empty task", graph: &Graph{Name: "task_1"}},
+		{name: "nodes and edges", graph: testGraph(), nodes: 2, edges: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.graph.JSON()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var doc document
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("JSON does not decode: %v", err)
+			}
+			// пустые списки выгружаются как [], а не null
+			if doc.Nodes == nil || doc.Edges == nil {
+				t.Errorf("nodes or edges are null: %s", data)
+			}
+			if len(doc.Nodes) != tt.nodes || len(doc.Edges) != tt.edges {
+				t.Errorf("nodes = %d, edges = %d, want %d and %d", len(doc.Nodes), len(doc.Edges), tt.nodes, tt.edges)
+			}
+			if doc.Directed {
+				t.Error("graph is directed")
+			}
+			for _, n := range doc.Nodes {
+				if n.ID == "<s2&>" && (n.SubmittedAt != nil || n.FileUpdatedAt != nil) {
+					t.Errorf("unknown times are not omitted: %s", data)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package heatmap
+
+import (
+	"fmt"
+	"image/color"
+	"unicode/utf8"
+)
+
+const (
+	// размер поля матрицы, если клеток немного; большие матрицы уменьшают клетку до minCellSize
+	targetMatrixSize = 720
+	minCellSize      = 6
+	maxCellSize      = 36
+	// подписи значений помещаются только в крупные клетки
+	valueLabelMinCell = 28
+
+	maxLabelRunes = 24
+	charWidth     = 7
+	charHeight    = 13
+	padding       = 10
+	titleHeight   = 24
+	legendHeight  = 12
+	legendWidth   = 240
+)
+
+// Heatmap - квадратная матрица значений от 0 до 1 с подписями строк и столбцов.
+type Heatmap struct {
+	Title  string
+	Labels []string
+	Values [][]float64
+	// Threshold отмечается на шкале цветов (например, порог плагиата); 0 - без отметки
+	Threshold float64
+}
+
+// layout - размеры и отступы изображения, общие для SVG и PNG.
+type layout struct {
+	cell        int
+	labelWidth  int
+	headerSize  int
+	matrixSize  int
+	width       int
+	height      int
+	legendTop   int
+	labels      []string
+	valueLabels bool
+}
+
+func (h Heatmap) layout(headerSize func(labelWidth int) int) layout {
+	n := len(h.Labels)
+
+	cell := maxCellSize
+	if n > 0 {
+		cell = min(max(targetMatrixSize/n, minCellSize), maxCellSize)
+	}
+
+	labels := make([]string, n)
+	longest := 0
+	for i, label := range h.Labels {
+		labels[i] = truncate(label, maxLabelRunes)
+		longest = max(longest, utf8.RuneCountInString(labels[i]))
+	}
+
+	l := layout{
+		cell:        cell,
+		labelWidth:  longest*charWidth + padding,
+		matrixSize:  n * cell,
+		labels:      labels,
+		valueLabels: cell >= valueLabelMinCell,
+	}
+	l.headerSize = headerSize(l.labelWidth)
+
+	l.width = padding + l.labelWidth + max(l.matrixSize, legendWidth) + padding
+	l.legendTop = titleHeight + l.headerSize + l.matrixSize + padding*2
+	l.height = l.legendTop + legendHeight + charHeight + padding*2
+
+	return l
+}
+
+// Color переводит значение от 0 до 1 в цвет шкалы: белый - нет сходства, тёмно-красный - совпадение.
+func Color(value float64) color.RGBA {
+	stops := []struct {
+		at float64
+		c  color.RGBA
+	}{
+		{0, color.RGBA{255, 255, 255, 255}},
+		{0.4, color.RGBA{255, 230, 153, 255}},
+		{0.7, color.RGBA{244, 162, 97, 255}},
+		{1, color.RGBA{178, 24, 43, 255}},
+	}
+
+	value = min(max(value, 0), 1)
+	for i := 1; i < len(stops); i++ {
+		if value > stops[i].at {
+			continue
+		}
+
+		from, to := stops[i-1], stops[i]
+		t := (value - from.at) / (to.at - from.at)
+		return color.RGBA{
+			R: lerp(from.c.R, to.c.R, t),
+			G: lerp(from.c.G, to.c.G, t),
+			B: lerp(from.c.B, to.c.B, t),
+			A: 255,
+		}
+	}
+
+	return stops[len(stops)-1].c
+}
+
+// textColor подбирает цвет подписи, читаемый на фоне клетки.
+func textColor(value float64) color.RGBA {
+	if value > 0.8 {
+		return color.RGBA{255, 255, 255, 255}
+	}
+	return color.RGBA{0, 0, 0, 255}
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
+
+func formatValue(value float64) string {
+	return fmt.Sprintf("%.2f", value)
+}
+
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	// многоточие из точек: в растровом шрифте PNG нет символа "…"
+	runes := []rune(s)
+	return string(runes[:limit-3]) + "..."
+}
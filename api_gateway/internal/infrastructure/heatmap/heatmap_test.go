@@ -0,0 +1,129 @@
+package heatmap
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		want  color.RGBA
+	}{
+		{name: "below zero is clamped", value: -0.5, want: color.RGBA{255, 255, 255, 255}},
+		{name: "zero", value: 0, want: color.RGBA{255, 255, 255, 255}},
+		{name: "exactly on a stop", value: 0.4, want: color.RGBA{255, 230, 153, 255}},
+		{name: "between stops", value: 0.55, want: color.RGBA{250, 196, 125, 255}},
+		{name: "one", value: 1, want: color.RGBA{178, 24, 43, 255}},
+		{name: "above one is clamped", value: 1.5, want: color.RGBA{178, 24, 43, 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Color(tt.value); got != tt.want {
+				t.Errorf("Color(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "short", s: "s1", want: "s1"},
+		{name: "exactly the limit", s: "abcdef", want: "abcdef"},
+		{name: "long", s: "abcdefg", want: "abc..."},
+		{name: "counted in runes", s: "студентка", want: "сту..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncate(tt.s, 6); got != tt.want {
+				t.Errorf("truncate(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           int
+		cell        int
+		valueLabels bool
+	}{
+		{name: "empty task", n: 0, cell: maxCellSize, valueLabels: true},
+		{name: "few students", n: 3, cell: maxCellSize, valueLabels: true},
+		{name: "smallest cell with values", n: 25, cell: 28, valueLabels: true},
+		{name: "values no longer fit", n: 26, cell: 27, valueLabels: false},
+		{name: "large task", n: 1000, cell: minCellSize, valueLabels: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Heatmap{Labels: make([]string, tt.n)}
+			l := h.layout(func(labelWidth int) int { return labelWidth })
+
+			if l.cell != tt.cell || l.valueLabels != tt.valueLabels {
+				t.Errorf("cell = %d, valueLabels = %v, want %d and %v", l.cell, l.valueLabels, tt.cell, tt.valueLabels)
+			}
+			if l.matrixSize != tt.n*tt.cell {
+				t.Errorf("matrixSize = %d, want %d", l.matrixSize, tt.n*tt.cell)
+			}
+		})
+	}
+}
+
+func TestSVGThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		marked    bool
+	}{
+		{name: "no threshold", threshold: 0, marked: false},
+		{name: "plagiarism threshold", threshold: 0.8, marked: true},
+		{name: "threshold at the end of the scale", threshold: 1, marked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Heatmap{
+				Title:     "task <1>",
+				Labels:    []string{"s1", "s2"},
+				Values:    [][]float64{{1, 0.8}, {0.8, 1}},
+				Threshold: tt.threshold,
+			}
+			svg := string(h.SVG())
+
+			if marked := strings.Contains(svg, "<line "); marked != tt.marked {
+				t.Errorf("threshold marked = %v, want %v", marked, tt.marked)
+			}
+			if !strings.Contains(svg, "task &lt;1&gt;") {
+				t.Error("title is not escaped")
+			}
+		})
+	}
+}
+
+func TestEmptyHeatmap(t *testing.T) {
+	h := Heatmap{Title: "empty"}
+
+	if svg := h.SVG(); !bytes.HasSuffix(svg, []byte("</svg>\n")) {
+		t.Errorf("SVG of empty task is incomplete: %s", svg)
+	}
+
+	data, err := h.PNG()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+		t.Errorf("PNG of empty task does not decode: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package heatmap
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// PNG рисует тепловую карту растровым шрифтом. Повернуть текст им нельзя,
+// поэтому столбцы подписаны номерами, а строки - номером и именем студента.
+func (h Heatmap) PNG() ([]byte, error) {
+	numbered := h
+	numbered.Labels = make([]string, len(h.Labels))
+	for i, label := range h.Labels {
+		numbered.Labels[i] = strconv.Itoa(i+1) + ". " + truncate(label, maxLabelRunes)
+	}
+
+	l := numbered.layout(func(int) int { return charHeight + padding })
+
+	img := image.NewRGBA(image.Rect(0, 0, l.width, l.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	black := color.RGBA{0, 0, 0, 255}
+	drawText(img, padding, titleHeight-8, h.Title, black)
+
+	left := padding + l.labelWidth
+	top := titleHeight + l.headerSize
+
+	for i, label := range l.labels {
+		center := i*l.cell + l.cell/2
+		drawText(img, padding, top+center+charHeight/2-2, label, black)
+
+		// номера столбцов выводим, только если они помещаются над клеткой
+		number := strconv.Itoa(i + 1)
+		if len(number)*charWidth <= l.cell {
+			drawText(img, left+center-len(number)*charWidth/2, top-4, number, black)
+		}
+	}
+
+	for i, row := range h.Values {
+		for j, value := range row {
+			x := left + j*l.cell
+			y := top + i*l.cell
+
+			rect := image.Rect(x, y, x+l.cell, y+l.cell)
+			draw.Draw(img, rect, image.NewUniform(Color(value)), image.Point{}, draw.Src)
+
+			if l.valueLabels {
+				text := formatValue(value)
+				drawText(img, x+(l.cell-len(text)*charWidth)/2, y+l.cell/2+charHeight/2-2, text, textColor(value))
+			}
+		}
+	}
+
+	h.pngLegend(img, l)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (h Heatmap) pngLegend(img *image.RGBA, l layout) {
+	left := padding + l.labelWidth
+	black := color.RGBA{0, 0, 0, 255}
+
+	for x := 0; x < legendWidth; x++ {
+		c := Color(float64(x) / float64(legendWidth-1))
+		draw.Draw(img, image.Rect(left+x, l.legendTop, left+x+1, l.legendTop+legendHeight), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+
+	labelY := l.legendTop + legendHeight + charHeight
+	drawText(img, left, labelY, "0", black)
+	drawText(img, left+legendWidth-charWidth, labelY, "1", black)
+
+	if h.Threshold > 0 && h.Threshold < 1 {
+		x := left + int(h.Threshold*legendWidth)
+		draw.Draw(img, image.Rect(x, l.legendTop-2, x+1, l.legendTop+legendHeight+2), image.NewUniform(black), image.Point{}, draw.Src)
+
+		text := formatValue(h.Threshold)
+		drawText(img, x-len(text)*charWidth/2, labelY, text, black)
+	}
+}
+
+// drawText пишет строку базовым шрифтом 7x13; y - базовая линия текста.
+func drawText(img *image.RGBA, x, y int, text string, c color.Color) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
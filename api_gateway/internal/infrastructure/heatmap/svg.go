@@ -0,0 +1,84 @@
+package heatmap
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+)
+
+// SVG рисует тепловую карту. Подписи столбцов повёрнуты вертикально над матрицей.
+func (h Heatmap) SVG() []byte {
+	l := h.layout(func(labelWidth int) int { return labelWidth })
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="11">`+"\n",
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", l.width, l.height)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="14" font-weight="bold">%s</text>`+"\n",
+		padding, titleHeight-8, html.EscapeString(h.Title))
+
+	left := padding + l.labelWidth
+	top := titleHeight + l.headerSize
+
+	for i, label := range l.labels {
+		center := i*l.cell + l.cell/2
+
+		// подпись строки
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" dominant-baseline="middle"><title>%s</title>%s</text>`+"\n",
+			left-4, top+center, html.EscapeString(h.Labels[i]), html.EscapeString(label))
+		// подпись столбца
+		fmt.Fprintf(&b, `<text transform="translate(%d,%d) rotate(-90)" dominant-baseline="middle"><title>%s</title>%s</text>`+"\n",
+			left+center, top-4, html.EscapeString(h.Labels[i]), html.EscapeString(label))
+	}
+
+	for i, row := range h.Values {
+		for j, value := range row {
+			x := left + j*l.cell
+			y := top + i*l.cell
+
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="#eeeeee" stroke-width="0.5"><title>%s / %s: %s</title></rect>`+"\n",
+				x, y, l.cell, l.cell, hex(Color(value)),
+				html.EscapeString(h.Labels[i]), html.EscapeString(h.Labels[j]), formatValue(value))
+
+			if l.valueLabels {
+				fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="middle" font-size="9" fill="%s">%s</text>`+"\n",
+					x+l.cell/2, y+l.cell/2, hex(textColor(value)), formatValue(value))
+			}
+		}
+	}
+
+	h.svgLegend(&b, l)
+
+	b.WriteString("</svg>\n")
+
+	return b.Bytes()
+}
+
+func (h Heatmap) svgLegend(b *bytes.Buffer, l layout) {
+	left := padding + l.labelWidth
+
+	b.WriteString(`<defs><linearGradient id="scale">`)
+	for _, at := range []float64{0, 0.25, 0.4, 0.55, 0.7, 0.85, 1} {
+		fmt.Fprintf(b, `<stop offset="%.2f" stop-color="%s"/>`, at, hex(Color(at)))
+	}
+	b.WriteString("</linearGradient></defs>\n")
+
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="url(#scale)" stroke="#999999" stroke-width="0.5"/>`+"\n",
+		left, l.legendTop, legendWidth, legendHeight)
+
+	labelY := l.legendTop + legendHeight + charHeight
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="start">0</text>`+"\n", left, labelY)
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">1</text>`+"\n", left+legendWidth, labelY)
+
+	if h.Threshold > 0 && h.Threshold < 1 {
+		x := left + int(h.Threshold*legendWidth)
+		fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#000000"/>`+"\n",
+			x, l.legendTop-2, x, l.legendTop+legendHeight+2)
+		fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="middle">%s</text>`+"\n", x, labelY, formatValue(h.Threshold))
+	}
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,265 @@
+package pair_viewer
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/pkg/text_extractor"
+)
+
+// maxExcerptWords - длина выдержки фрагмента, которую хранит plagiarism-service.
+const maxExcerptWords = 60
+
+// paletteSize - число цветов подсветки; фрагменты раскрашиваются по кругу.
+const paletteSize = 12
+
+// Fragment - общий фрагмент пары: Length слов очищенного текста с позиций AStart и BStart.
+type Fragment struct {
+	AStart  int
+	BStart  int
+	Length  int
+	Excerpt string
+}
+
+// Page - данные страницы сравнения двух работ.
+type Page struct {
+	TaskID              string
+	StudentA            string
+	StudentB            string
+	Similarity          float64
+	PlagiarismThreshold float64
+	AnalysisStartedAt   time.Time
+	FileAHandedOverAt   time.Time
+	FileBHandedOverAt   time.Time
+	TextA               string
+	TextB               string
+	Fragments           []Fragment
+	// Files - схожесть одноимённых файлов, если сдачи состоят из нескольких файлов
+	Files []FileSimilarity
+}
+
+// FileSimilarity - схожесть одноимённых файлов пары; пустое имя - основной файл.
+type FileSimilarity struct {
+	Name       string
+	Similarity float64
+}
+
+type segment struct {
+	Text     string
+	Fragment int
+	Class    string
+}
+
+type fragmentRow struct {
+	Number  int
+	Length  int
+	Excerpt string
+	Class   string
+	Located bool
+}
+
+type pageData struct {
+	Page
+	SegmentsA []segment
+	SegmentsB []segment
+	Rows      []fragmentRow
+	Missing   int
+}
+
+type span struct {
+	start, end int
+	fragment   int
+}
+
+// Render пишет HTML-страницу: тексты работ рядом, общие фрагменты подсвечены одинаковым цветом,
+// щелчок по фрагменту прокручивает вторую работу к парному фрагменту.
+// Фрагменты, которые не удалось найти в текстах (например, файл заменили после анализа), не подсвечиваются.
+func (p *Page) Render(w io.Writer) error {
+	tokensA := text_extractor.Tokenize(p.TextA)
+	tokensB := text_extractor.Tokenize(p.TextB)
+
+	data := pageData{Page: *p}
+
+	var spansA, spansB []span
+	for i, f := range p.Fragments {
+		row := fragmentRow{
+			Number:  i + 1,
+			Length:  f.Length,
+			Excerpt: f.Excerpt,
+			Class:   fmt.Sprintf("c%d", i%paletteSize),
+		}
+
+		a, okA := locate(tokensA, f.AStart, f.Length, f.Excerpt)
+		b, okB := locate(tokensB, f.BStart, f.Length, f.Excerpt)
+		if okA && okB {
+			row.Located = true
+			spansA = append(spansA, span{start: a.start, end: a.end, fragment: i})
+			spansB = append(spansB, span{start: b.start, end: b.end, fragment: i})
+		} else {
+			data.Missing++
+		}
+
+		data.Rows = append(data.Rows, row)
+	}
+
+	data.SegmentsA = split(p.TextA, spansA)
+	data.SegmentsB = split(p.TextB, spansB)
+
+	return pageTemplate.Execute(w, data)
+}
+
+// locate переводит позиции фрагмента в словах очищенного текста в байтовый диапазон исходного текста
+// и сверяет слова с сохранённой выдержкой.
+func locate(tokens []text_extractor.Token, start, length int, excerpt string) (span, bool) {
+	if start < 0 || length <= 0 || start+length > len(tokens) {
+		return span{}, false
+	}
+
+	words := strings.Fields(excerpt)
+	if length > maxExcerptWords && len(words) > 0 && words[len(words)-1] == "..." {
+		words = words[:len(words)-1]
+	}
+	if len(words) > length {
+		return span{}, false
+	}
+	for i, word := range words {
+		if tokens[start+i].Word != word {
+			return span{}, false
+		}
+	}
+
+	return span{start: tokens[start].Start, end: tokens[start+length-1].End}, true
+}
+
+// split режет текст на куски вне фрагментов и внутри них.
+func split(text string, spans []span) []segment {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	var segments []segment
+	pos := 0
+	for _, s := range spans {
+		if s.start < pos {
+			// фрагменты не пересекаются; пересечение означает рассинхронизацию с анализом
+			continue
+		}
+		if s.start > pos {
+			segments = append(segments, segment{Text: text[pos:s.start], Fragment: -1})
+		}
+		segments = append(segments, segment{
+			Text:     text[s.start:s.end],
+			Fragment: s.fragment + 1,
+			Class:    fmt.Sprintf("frag c%d", s.fragment%paletteSize),
+		})
+		pos = s.end
+	}
+	if pos < len(text) {
+		segments = append(segments, segment{Text: text[pos:], Fragment: -1})
+	}
+
+	return segments
+}
+
+var pageTemplate = template.Must(template.New("pair").Funcs(template.FuncMap{
+	"percent": func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+	"time": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format("2006-01-02 15:04:05 UTC")
+	},
+}).Parse(pageHTML))
+
+const pageHTML = `<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.StudentA}} / {{.StudentB}} - task {{.TaskID}}</title>
+<style>
+body { font-family: sans-serif; margin: 0; color: #222; }
+header { padding: 12px 20px; border-bottom: 1px solid #ddd; }
+header h1 { font-size: 18px; margin: 0 0 6px; }
+header .meta { font-size: 13px; color: #555; }
+.suspicious { color: #b00020; font-weight: bold; }
+.warning { margin: 8px 20px 0; padding: 8px 12px; background: #fff4e5; border: 1px solid #f0c36d; font-size: 13px; }
+main { display: flex; gap: 12px; padding: 12px 20px; }
+section { flex: 1; min-width: 0; }
+section h2 { font-size: 15px; margin: 0 0 6px; }
+section .when { font-size: 12px; color: #777; margin-bottom: 6px; }
+.text { height: 70vh; overflow-y: auto; border: 1px solid #ddd; padding: 10px; white-space: pre-wrap; font-family: monospace; font-size: 13px; line-height: 1.5; }
+.frag { cursor: pointer; border-radius: 2px; }
+.frag.active { outline: 2px solid #333; }
+details { padding: 0 20px 20px; font-size: 13px; }
+table { border-collapse: collapse; width: 100%; }
+td, th { border-bottom: 1px solid #eee; padding: 4px 6px; text-align: left; vertical-align: top; }
+tr.row { cursor: pointer; }
+tr.missing { color: #999; cursor: default; }
+.swatch { display: inline-block; width: 12px; height: 12px; border-radius: 2px; }
+.c0 { background: #ffe08a; } .c1 { background: #a8e6a1; } .c2 { background: #9fd3ff; }
+.c3 { background: #ffb3c7; } .c4 { background: #d4b8ff; } .c5 { background: #ffc99a; }
+.c6 { background: #9ff0e0; } .c7 { background: #e6e68a; } .c8 { background: #ffb0a0; }
+.c9 { background: #b8c8ff; } .c10 { background: #c8f0a0; } .c11 { background: #f0b8e8; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.StudentA}} / {{.StudentB}}</h1>
+<div class="meta">
+Task {{.TaskID}} &middot; similarity <span{{if ge .Similarity .PlagiarismThreshold}} class="suspicious"{{end}}>{{percent .Similarity}}</span>
+(threshold {{percent .PlagiarismThreshold}}) &middot; {{len .Fragments}} matched fragments &middot; analysis started {{time .AnalysisStartedAt}}
+</div>
+</header>
+{{if .Missing}}<div class="warning">{{.Missing}} of {{len .Fragments}} fragments could not be located in the current files and are not highlighted. The files were probably replaced after the analysis; run the analysis again to refresh the evidence.</div>{{end}}
+<main>
+<section>
+<h2>{{.StudentA}}</h2>
+<div class="when">handed over {{time .FileAHandedOverAt}}</div>
+<div class="text" id="text-a">{{range .SegmentsA}}{{if ge .Fragment 0}}<span class="{{.Class}}" id="a-{{.Fragment}}" data-side="a" data-fragment="{{.Fragment}}">{{.Text}}</span>{{else}}{{.Text}}{{end}}{{end}}</div>
+</section>
+<section>
+<h2>{{.StudentB}}</h2>
+<div class="when">handed over {{time .FileBHandedOverAt}}</div>
+<div class="text" id="text-b">{{range .SegmentsB}}{{if ge .Fragment 0}}<span class="{{.Class}}" id="b-{{.Fragment}}" data-side="b" data-fragment="{{.Fragment}}">{{.Text}}</span>{{else}}{{.Text}}{{end}}{{end}}</div>
+</section>
+</main>
+{{if .Files}}<details open>
+<summary>Files</summary>
+<table>
+<tr><th>File</th><th>Similarity</th></tr>
+{{range .Files}}<tr><td>{{if .Name}}{{.Name}}{{else}}(main file){{end}}</td><td><span{{if ge .Similarity $.PlagiarismThreshold}} class="suspicious"{{end}}>{{percent .Similarity}}</span></td></tr>
+{{end}}</table>
+</details>{{end}}
+{{if .Rows}}<details open>
+<summary>Matched fragments</summary>
+<table>
+<tr><th></th><th>#</th><th>Words</th><th>Normalized excerpt</th></tr>
+{{range .Rows}}<tr class="{{if .Located}}row{{else}}missing{{end}}" data-fragment="{{.Number}}"><td><span class="swatch {{.Class}}"></span></td><td>{{.Number}}</td><td>{{.Length}}</td><td>{{.Excerpt}}</td></tr>
+{{end}}</table>
+</details>{{end}}
+<script>
+function reveal(side, n) {
+  var el = document.getElementById(side + "-" + n);
+  if (!el) return;
+  var pane = el.parentElement;
+  pane.scrollTo({ top: el.offsetTop - pane.offsetTop - pane.clientHeight / 3, behavior: "smooth" });
+  el.classList.add("active");
+  setTimeout(function () { el.classList.remove("active"); }, 1500);
+}
+document.querySelectorAll(".frag").forEach(function (el) {
+  el.addEventListener("click", function () {
+    reveal(el.dataset.side === "a" ? "b" : "a", el.dataset.fragment);
+  });
+});
+document.querySelectorAll("tr.row").forEach(function (row) {
+  row.addEventListener("click", function () {
+    reveal("a", row.dataset.fragment);
+    reveal("b", row.dataset.fragment);
+  });
+});
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock - время MemoryStore, которое тест двигает сам.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	store := NewMemoryStore()
+	store.now = clock.Now
+	return store, clock
+}
+
+func take(t *testing.T, store *MemoryStore, key string, limit Limit) (bool, time.Duration) {
+	t.Helper()
+
+	allowed, retryAfter, err := store.Take(context.Background(), key, limit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return allowed, retryAfter
+}
+
+// about сравнивает длительности с точностью до миллисекунды: токены считаются в float64.
+func about(got, want time.Duration) bool {
+	return (got - want).Abs() < time.Millisecond
+}
+
+func TestMemoryStoreBurst(t *testing.T) {
+	store, _ := newTestStore()
+	limit := Limit{PerMinute: 6, Burst: 3}
+
+	for i := range 3 {
+		if allowed, _ := take(t, store, "k", limit); !allowed {
+			t.Fatalf("request %d within burst was refused", i+1)
+		}
+	}
+
+	allowed, retryAfter := take(t, store, "k", limit)
+	if allowed {
+		t.Fatal("request over burst was allowed")
+	}
+	// 6 в минуту - токен раз в 10 секунд
+	if !about(retryAfter, 10*time.Second) {
+		t.Errorf("retryAfter = %v, want 10s", retryAfter)
+	}
+}
+
+func TestMemoryStoreRefill(t *testing.T) {
+	store, clock := newTestStore()
+	limit := Limit{PerMinute: 6, Burst: 2}
+
+	take(t, store, "k", limit)
+	take(t, store, "k", limit)
+
+	clock.Advance(4 * time.Second)
+	allowed, retryAfter := take(t, store, "k", limit)
+	if allowed {
+		t.Fatal("request before refill was allowed")
+	}
+	if !about(retryAfter, 6*time.Second) {
+		t.Errorf("retryAfter = %v, want 6s", retryAfter)
+	}
+
+	clock.Advance(6 * time.Second)
+	if allowed, _ := take(t, store, "k", limit); !allowed {
+		t.Fatal("request after refill was refused")
+	}
+	if allowed, _ := take(t, store, "k", limit); allowed {
+		t.Fatal("only one token should have been refilled")
+	}
+}
+
+func TestMemoryStoreRefillIsCappedByBurst(t *testing.T) {
+	store, clock := newTestStore()
+	limit := Limit{PerMinute: 60, Burst: 2}
+
+	take(t, store, "k", limit)
+	take(t, store, "k", limit)
+
+	// за час накопилось бы 3600 токенов, но корзина вмещает только Burst
+	clock.Advance(time.Hour)
+	for i := range 2 {
+		if allowed, _ := take(t, store, "k", limit); !allowed {
+			t.Fatalf("request %d after refill was refused", i+1)
+		}
+	}
+	if allowed, _ := take(t, store, "k", limit); allowed {
+		t.Fatal("request over burst was allowed after a long pause")
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	store, _ := newTestStore()
+	limit := Limit{PerMinute: 1, Burst: 1}
+
+	if allowed, _ := take(t, store, "a", limit); !allowed {
+		t.Fatal("first request for a was refused")
+	}
+	if allowed, _ := take(t, store, "b", limit); !allowed {
+		t.Fatal("first request for b was refused")
+	}
+	if allowed, _ := take(t, store, "a", limit); allowed {
+		t.Fatal("second request for a was allowed")
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	store, clock := newTestStore()
+	limit := Limit{PerMinute: 60, Burst: 1}
+
+	take(t, store, "old", limit)
+	clock.Advance(2 * sweepInterval)
+	take(t, store, "new", limit)
+
+	if _, ok := store.buckets["old"]; ok {
+		t.Error("refilled bucket was not swept")
+	}
+	if _, ok := store.buckets["new"]; !ok {
+		t.Error("active bucket was swept")
+	}
+}
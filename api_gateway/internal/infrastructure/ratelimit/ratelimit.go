@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// ErrStoreUnavailable - хранилище временно не опрашивается после череды отказов.
+var ErrStoreUnavailable = errors.New("rate limit store is unavailable")
+
+// Limit - корзина токенов: PerMinute запросов в минуту в среднем и до Burst подряд.
+type Limit struct {
+	PerMinute float64
+	Burst     int
+}
+
+func (l Limit) perSecond() float64 {
+	return l.PerMinute / 60
+}
+
+// Store списывает токен из корзины key. Если токена нет, возвращает время до появления следующего.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// Limiter списывает токены в общем хранилище, а при его недоступности - в памяти процесса.
+type Limiter struct {
+	logger   *slog.Logger
+	store    Store
+	fallback *MemoryStore
+}
+
+// New создаёт ограничитель; store может быть nil - тогда корзины только в памяти.
+func New(logger *slog.Logger, store Store) *Limiter {
+	return &Limiter{
+		logger:   logger,
+		store:    store,
+		fallback: NewMemoryStore(),
+	}
+}
+
+func (l *Limiter) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration) {
+	if l.store != nil {
+		allowed, retryAfter, err := l.store.Take(ctx, key, limit)
+		if err == nil {
+			return allowed, retryAfter
+		}
+		// об отказах, из-за которых хранилище отключено, уже сообщено, поэтому отключённое пишется в лог только при отладке
+		if errors.Is(err, ErrStoreUnavailable) {
+			l.logger.Debug("rate limit store is disabled, using in-memory buckets", "error", err)
+		} else {
+			l.logger.Warn("rate limit store is unavailable, using in-memory buckets", "error", err)
+		}
+	}
+
+	allowed, retryAfter, _ := l.fallback.Take(ctx, key, limit)
+	return allowed, retryAfter
+}
@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sony/gobreaker/v2"
+)
+
+const (
+	redisTimeout  = 500 * time.Millisecond
+	redisPoolSize = 16
+	// breakerFailures - после стольких отказов Redis подряд запросы к нему прекращаются на breakerTimeout
+	breakerFailures = 3
+	breakerTimeout  = 10 * time.Second
+)
+
+// takeScript атомарно пополняет корзину по времени сервера Redis и списывает токен.
+// Возвращает {1, 0}, если токен списан, и {0, ms} - через сколько миллисекунд он появится.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1]) / 1000
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, wait}
+`)
+
+// RedisStore хранит корзины в Redis (или совместимом сервере), общем для всех копий гейтвея.
+// Неудачный запрос повторяется один раз с паузой; после breakerFailures отказов подряд Redis
+// не опрашивается breakerTimeout, и Take сразу возвращает ErrStoreUnavailable.
+type RedisStore struct {
+	client  *redis.Client
+	prefix  string
+	breaker *gobreaker.CircuitBreaker[[]int64]
+}
+
+func NewRedisStore(addr, password string, db int, logger *slog.Logger) *RedisStore {
+	client := redis.NewClient(&redis.Options{
+		Addr:                  addr,
+		Password:              password,
+		DB:                    db,
+		DialTimeout:           redisTimeout,
+		DialerRetries:         2,
+		DialerRetryTimeout:    10 * time.Millisecond,
+		ReadTimeout:           redisTimeout,
+		WriteTimeout:          redisTimeout,
+		ContextTimeoutEnabled: true,
+		PoolSize:              redisPoolSize,
+		MaxRetries:            1,
+		MinRetryBackoff:       10 * time.Millisecond,
+		MaxRetryBackoff:       50 * time.Millisecond,
+	})
+
+	breaker := gobreaker.NewCircuitBreaker[[]int64](gobreaker.Settings{
+		Name:        "ratelimit-redis",
+		MaxRequests: 1,
+		Timeout:     breakerTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= breakerFailures
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			logger.Warn("rate limit store circuit breaker changed state", "from", from.String(), "to", to.String())
+		},
+		// отменённый клиентом запрос ничего не говорит о Redis
+		IsExcluded: func(err error) bool {
+			return errors.Is(err, context.Canceled)
+		},
+	})
+
+	return &RedisStore{
+		client:  client,
+		prefix:  "ratelimit:",
+		breaker: breaker,
+	}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	values, err := s.breaker.Execute(func() ([]int64, error) {
+		return takeScript.Run(ctx, s.client, []string{s.prefix + key},
+			strconv.FormatFloat(limit.perSecond(), 'f', -1, 64), limit.Burst).Int64Slice()
+	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return false, 0, fmt.Errorf("%w: %w", ErrStoreUnavailable, err)
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	if len(values) != 2 {
+		return false, 0, fmt.Errorf("unexpected reply %v", values)
+	}
+
+	return values[0] == 1, time.Duration(values[1]) * time.Millisecond, nil
+}
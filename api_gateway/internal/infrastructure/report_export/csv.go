@@ -0,0 +1,66 @@
+package report_export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// utf8BOM нужен Excel, чтобы верно открыть CSV с кириллицей.
+const utf8BOM = "\ufeff"
+
+// WritePairsCSV пишет одну строку на пару.
+func (r *Report) WritePairsCSV(w io.Writer) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"student_a", "student_b", "similarity", "suspicious", "file_a_handed_over_at", "file_b_handed_over_at", "file_a_version", "file_b_version", "verdict"})
+
+	for _, p := range r.Pairs {
+		_ = cw.Write([]string{
+			p.StudentA,
+			p.StudentB,
+			strconv.FormatFloat(p.Similarity, 'f', 4, 64),
+			strconv.FormatBool(p.Suspicious),
+			formatTime(p.FileAHandedOverAt),
+			formatTime(p.FileBHandedOverAt),
+			strconv.Itoa(p.FileAVersion),
+			strconv.Itoa(p.FileBVersion),
+			p.Verdict,
+		})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSummaryCSV пишет одну строку на студента; несдавшие студенты курса идут в конце с submitted=false.
+func (r *Report) WriteSummaryCSV(w io.Writer) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"student", "pairs", "suspicious_pairs", "max_similarity", "most_similar_student", "submitted_at", "submitted"})
+
+	for _, s := range r.Summary() {
+		_ = cw.Write([]string{
+			s.Student,
+			strconv.Itoa(s.Pairs),
+			strconv.Itoa(s.SuspiciousPairs),
+			strconv.FormatFloat(s.MaxSimilarity, 'f', 4, 64),
+			s.MostSimilarStudent,
+			formatTime(s.SubmittedAt),
+			"true",
+		})
+	}
+
+	for _, student := range r.MissingStudents {
+		_ = cw.Write([]string{student, "0", "0", "", "", "", "false"})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
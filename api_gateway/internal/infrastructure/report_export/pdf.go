@@ -0,0 +1,322 @@
+package report_export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Страница A4 в пунктах PDF.
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	pageMargin   = 50
+	bodyFontSize = 9
+	// ширина символа Courier - 0.6 кегля: (595 - 2*50) / (9 * 0.6)
+	bodyLineChars  = 91
+	bodyLineHeight = bodyFontSize + 3
+
+	// maxFragmentsInPDF ограничивает число фрагментов на пару, чтобы отчёт оставался читаемым
+	maxFragmentsInPDF = 10
+)
+
+// Шрифты из стандартного набора PDF - их не нужно встраивать в документ.
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+	fontMono    = "F3"
+)
+
+// PDF формирует отчёт для печати: параметры анализа, подозрительные пары с общими фрагментами
+// сводку по студентам и список несдавших. Стандартные шрифты PDF не содержат кириллицы, поэтому текст транслитерируется.
+func (r *Report) PDF() []byte {
+	d := newPDFLayout()
+
+	d.heading(fontBold, 16, "Plagiarism analysis report")
+	d.gap(6)
+	d.mono(fmt.Sprintf("Task:                 %s", r.TaskID))
+	d.mono(fmt.Sprintf("Analysis started at:  %s UTC", formatTime(r.AnalysisStartedAt)))
+	d.mono(fmt.Sprintf("Report generated at:  %s UTC", formatTime(r.GeneratedAt)))
+	d.mono(fmt.Sprintf("Plagiarism threshold: %.2f", r.PlagiarismThreshold))
+	d.mono(fmt.Sprintf("N-gram size:          %d words", r.NGramSize))
+	d.mono(fmt.Sprintf("Min fragment length:  %d words", r.MinFragmentWords))
+
+	summary := r.Summary()
+	suspicious := r.SuspiciousPairs()
+
+	d.mono(fmt.Sprintf("Students compared:    %d", len(summary)))
+	d.mono(fmt.Sprintf("Pairs compared:       %d", len(r.Pairs)))
+	d.mono(fmt.Sprintf("Suspicious pairs:     %d", len(suspicious)))
+	if len(r.MissingStudents) > 0 {
+		d.mono(fmt.Sprintf("Not submitted:        %d", len(r.MissingStudents)))
+	}
+
+	d.gap(12)
+	d.heading(fontBold, 12, "Suspicious pairs")
+	if len(suspicious) == 0 {
+		d.mono("No pairs reached the plagiarism threshold.")
+	} else {
+		d.mono(fmt.Sprintf("%-4s %-24s %-24s %10s  %-21s", "#", "Student A", "Student B", "Similarity", "Verdict"))
+		for i, p := range suspicious {
+			d.mono(fmt.Sprintf("%-4d %-24s %-24s %10.2f  %-21s",
+				i+1, clip(p.StudentA, 24), clip(p.StudentB, 24), p.Similarity, verdictLabel(p.Verdict)))
+		}
+	}
+
+	for i, p := range suspicious {
+		d.gap(12)
+		d.heading(fontBold, 11, fmt.Sprintf("%d. %s - %s (%.2f)", i+1, p.StudentA, p.StudentB, p.Similarity))
+		d.mono(fmt.Sprintf("Handed over: %s / %s", formatTime(p.FileAHandedOverAt), formatTime(p.FileBHandedOverAt)))
+		d.mono(fmt.Sprintf("Versions: v%d / v%d", p.FileAVersion, p.FileBVersion))
+		for _, f := range p.Files {
+			name := f.Name
+			if name == "" {
+				name = "(main file)"
+			}
+			d.mono(fmt.Sprintf("File %s: %.2f", clip(name, 60), f.Similarity))
+		}
+		d.mono(fmt.Sprintf("Verdict: %s", verdictLabel(p.Verdict)))
+
+		if len(p.Fragments) == 0 {
+			d.mono("No matching fragments were recorded for this pair.")
+			continue
+		}
+
+		for j, fragment := range p.Fragments {
+			if j == maxFragmentsInPDF {
+				d.mono(fmt.Sprintf("... and %d more fragments", len(p.Fragments)-maxFragmentsInPDF))
+				break
+			}
+			d.gap(4)
+			d.wrapped(fmt.Sprintf("[%d] ", j+1), fragment)
+		}
+	}
+
+	d.gap(12)
+	d.heading(fontBold, 12, "Students")
+	d.mono(fmt.Sprintf("%-24s %5s %10s %8s  %-24s", "Student", "Pairs", "Suspicious", "Max", "Most similar"))
+	for _, s := range summary {
+		d.mono(fmt.Sprintf("%-24s %5d %10d %8.2f  %-24s",
+			clip(s.Student, 24), s.Pairs, s.SuspiciousPairs, s.MaxSimilarity, clip(s.MostSimilarStudent, 24)))
+	}
+
+	if len(r.MissingStudents) > 0 {
+		d.gap(12)
+		d.heading(fontBold, 12, "Not submitted")
+		for _, student := range r.MissingStudents {
+			d.mono(student)
+		}
+	}
+
+	return d.render()
+}
+
+// pdfLayout раскладывает строки по страницам сверху вниз.
+type pdfLayout struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func newPDFLayout() *pdfLayout {
+	d := &pdfLayout{}
+	d.newPage()
+	return d
+}
+
+func (d *pdfLayout) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - pageMargin
+}
+
+func (d *pdfLayout) ensure(height float64) {
+	if d.y-height < pageMargin {
+		d.newPage()
+	}
+}
+
+func (d *pdfLayout) gap(height float64) {
+	d.y -= height
+}
+
+func (d *pdfLayout) text(font string, size float64, s string) {
+	d.ensure(size + 4)
+	d.y -= size + 4
+
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %d %.1f Td (%s) Tj ET\n", font, size, pageMargin, d.y, pdfString(s))
+}
+
+func (d *pdfLayout) heading(font string, size float64, s string) {
+	// заголовок не должен оставаться последней строкой страницы
+	d.ensure(size + 4 + 2*bodyLineHeight)
+	d.text(font, size, s)
+	d.gap(2)
+}
+
+func (d *pdfLayout) mono(s string) {
+	d.ensure(bodyLineHeight)
+	d.y -= bodyLineHeight
+
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %d Tf %d %.1f Td (%s) Tj ET\n", fontMono, bodyFontSize, pageMargin, d.y, pdfString(s))
+}
+
+// wrapped переносит текст по словам; продолжение выравнивается по ширине префикса.
+func (d *pdfLayout) wrapped(prefix, s string) {
+	indent := strings.Repeat(" ", len(prefix))
+	width := bodyLineChars - len(prefix)
+
+	line := ""
+	first := true
+	flush := func() {
+		if first {
+			d.mono(prefix + line)
+			first = false
+		} else {
+			d.mono(indent + line)
+		}
+		line = ""
+	}
+
+	// длины считаются в рунах: после transliterate остаются символы Latin-1 длиной в два байта
+	for _, field := range strings.Fields(transliterate(s)) {
+		word := []rune(field)
+		for len(word) > width {
+			if line != "" {
+				flush()
+			}
+			line = string(word[:width])
+			word = word[width:]
+			flush()
+		}
+
+		switch {
+		case line == "":
+			line = string(word)
+		case utf8.RuneCountInString(line)+1+len(word) <= width:
+			line += " " + string(word)
+		default:
+			flush()
+			line = string(word)
+		}
+	}
+
+	if line != "" || first {
+		flush()
+	}
+}
+
+// render собирает документ: каталог, дерево страниц, шрифты, страницы с номерами и таблицу перекрёстных ссылок.
+func (d *pdfLayout) render() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 - каталог, 2 - дерево страниц, 3..5 - шрифты, далее по паре объектов (страница, содержимое) на страницу
+	const firstPageObject = 6
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		fmt.Fprintf(page, "BT /%s 8 Tf %d %d Td (Page %d of %d) Tj ET\n", fontRegular, pageWidth-pageMargin-60, pageMargin/2, i+1, len(d.pages))
+
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /%s 3 0 R /%s 4 0 R /%s 5 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, fontMono, firstPageObject+2*i+1,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// pdfString приводит текст к Latin-1 и экранирует спецсимволы строкового литерала PDF.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range transliterate(s) {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x80:
+			b.WriteRune(r)
+		case r <= 0xff:
+			// WinAnsiEncoding совпадает с Latin-1 в верхней половине таблицы
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// transliterate заменяет кириллицу латиницей, сохраняя регистр первой буквы.
+func transliterate(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		lower := r
+		if r >= 'А' && r <= 'Я' {
+			lower = r + ('а' - 'А')
+		} else if r == 'Ё' {
+			lower = 'ё'
+		}
+
+		latin, ok := cyrillicToLatin[lower]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		if lower != r && latin != "" {
+			latin = strings.ToUpper(latin[:1]) + latin[1:]
+		}
+		b.WriteString(latin)
+	}
+	return b.String()
+}
+
+func verdictLabel(verdict string) string {
+	if verdict == "" {
+		return "not reviewed"
+	}
+	return verdict
+}
+
+// clip обрезает строку до n символов, чтобы не разъезжались столбцы таблиц.
+func clip(s string, n int) string {
+	s = transliterate(s)
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-3]) + "..."
+}
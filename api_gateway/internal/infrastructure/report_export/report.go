@@ -0,0 +1,115 @@
+package report_export
+
+import (
+	"sort"
+	"time"
+)
+
+// Report - результаты анализа задачи для выгрузки в документ.
+type Report struct {
+	TaskID              string
+	AnalysisStartedAt   time.Time
+	GeneratedAt         time.Time
+	PlagiarismThreshold float64
+	NGramSize           int
+	MinFragmentWords    int
+	// Pairs упорядочены по убыванию схожести
+	Pairs []Pair
+	// MissingStudents - студенты курса задачи, которые ничего не сдали
+	MissingStudents []string
+}
+
+type Pair struct {
+	StudentA          string
+	StudentB          string
+	Similarity        float64
+	Suspicious        bool
+	FileAHandedOverAt time.Time
+	FileBHandedOverAt time.Time
+	// FileAVersion, FileBVersion - версии файлов, на которых посчитана схожесть
+	FileAVersion int
+	FileBVersion int
+	// Verdict - действующее решение проверяющего, пусто если пару не проверяли
+	Verdict string
+	// Fragments - тексты общих фрагментов (только у подозрительных пар)
+	Fragments []string
+	// Files - схожесть одноимённых файлов у сдач из нескольких файлов
+	Files []PairFile
+}
+
+// PairFile - схожесть одноимённых файлов пары; пустое имя - основной файл.
+type PairFile struct {
+	Name       string
+	Similarity float64
+}
+
+// StudentSummary - сводка по студенту: сколько у него пар и насколько похожа самая близкая работа.
+type StudentSummary struct {
+	Student            string
+	Pairs              int
+	SuspiciousPairs    int
+	MaxSimilarity      float64
+	MostSimilarStudent string
+	SubmittedAt        time.Time
+}
+
+// SuspiciousPairs возвращает пары со схожестью не ниже порога плагиата.
+func (r *Report) SuspiciousPairs() []Pair {
+	var result []Pair
+	for _, p := range r.Pairs {
+		if p.Suspicious {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Summary строит сводку по студентам, упорядоченную по убыванию максимальной схожести.
+func (r *Report) Summary() []StudentSummary {
+	byStudent := make(map[string]*StudentSummary)
+	add := func(student, other string, similarity float64, suspicious bool, submittedAt time.Time) {
+		s, ok := byStudent[student]
+		if !ok {
+			s = &StudentSummary{Student: student, SubmittedAt: submittedAt}
+			byStudent[student] = s
+		}
+
+		s.Pairs++
+		if suspicious {
+			s.SuspiciousPairs++
+		}
+		if s.MostSimilarStudent == "" || similarity > s.MaxSimilarity {
+			s.MaxSimilarity = similarity
+			s.MostSimilarStudent = other
+		}
+		if !submittedAt.IsZero() && (s.SubmittedAt.IsZero() || submittedAt.Before(s.SubmittedAt)) {
+			s.SubmittedAt = submittedAt
+		}
+	}
+
+	for _, p := range r.Pairs {
+		add(p.StudentA, p.StudentB, p.Similarity, p.Suspicious, p.FileAHandedOverAt)
+		add(p.StudentB, p.StudentA, p.Similarity, p.Suspicious, p.FileBHandedOverAt)
+	}
+
+	result := make([]StudentSummary, 0, len(byStudent))
+	for _, s := range byStudent {
+		result = append(result, *s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MaxSimilarity != result[j].MaxSimilarity {
+			return result[i].MaxSimilarity > result[j].MaxSimilarity
+		}
+		return result[i].Student < result[j].Student
+	})
+
+	return result
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
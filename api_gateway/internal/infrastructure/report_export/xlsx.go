@@ -0,0 +1,219 @@
+package report_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Минимальный набор частей книги Office Open XML. Строки записываются как inline-строки,
+// поэтому таблица общих строк не нужна.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/worksheets/sheet2.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+<sheet name="Pairs" sheetId="1" r:id="rId1"/>
+<sheet name="Students" sheetId="2" r:id="rId2"/>
+</sheets>
+</workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+	// стиль 1 - жирный заголовок, стиль 2 - число с двумя знаками, стиль 3 - число на красном фоне (подозрительная пара)
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFF4B6B6"/><bgColor indexed="64"/></patternFill></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="4">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="2" fontId="0" fillId="2" borderId="0" xfId="0" applyNumberFormat="1" applyFill="1"/>
+</cellXfs>
+</styleSheet>`
+)
+
+const (
+	styleDefault    = 0
+	styleHeader     = 1
+	styleNumber     = 2
+	styleSuspicious = 3
+)
+
+type xlsxCell struct {
+	text   string
+	number float64
+	isNum  bool
+	style  int
+}
+
+func textCell(s string) xlsxCell { return xlsxCell{text: s} }
+
+func numberCell(v float64, style int) xlsxCell { return xlsxCell{number: v, isNum: true, style: style} }
+
+// XLSX собирает книгу из двух листов: Pairs - одна строка на пару, Students - сводка по студентам.
+func (r *Report) XLSX() ([]byte, error) {
+	pairs := [][]xlsxCell{headerRow("Student A", "Student B", "Similarity", "Suspicious", "File A handed over at", "File B handed over at", "File A version", "File B version", "Verdict")}
+	for _, p := range r.Pairs {
+		style := styleNumber
+		suspicious := "no"
+		if p.Suspicious {
+			style = styleSuspicious
+			suspicious = "yes"
+		}
+
+		pairs = append(pairs, []xlsxCell{
+			textCell(p.StudentA),
+			textCell(p.StudentB),
+			numberCell(p.Similarity, style),
+			textCell(suspicious),
+			textCell(formatTime(p.FileAHandedOverAt)),
+			textCell(formatTime(p.FileBHandedOverAt)),
+			numberCell(float64(p.FileAVersion), styleDefault),
+			numberCell(float64(p.FileBVersion), styleDefault),
+			textCell(p.Verdict),
+		})
+	}
+
+	students := [][]xlsxCell{headerRow("Student", "Pairs", "Suspicious pairs", "Max similarity", "Most similar student", "Submitted at", "Submitted")}
+	for _, s := range r.Summary() {
+		style := styleNumber
+		if s.SuspiciousPairs > 0 {
+			style = styleSuspicious
+		}
+
+		students = append(students, []xlsxCell{
+			textCell(s.Student),
+			numberCell(float64(s.Pairs), styleDefault),
+			numberCell(float64(s.SuspiciousPairs), styleDefault),
+			numberCell(s.MaxSimilarity, style),
+			textCell(s.MostSimilarStudent),
+			textCell(formatTime(s.SubmittedAt)),
+			textCell("yes"),
+		})
+	}
+	for _, student := range r.MissingStudents {
+		students = append(students, []xlsxCell{
+			textCell(student),
+			numberCell(0, styleDefault),
+			numberCell(0, styleDefault),
+			textCell(""),
+			textCell(""),
+			textCell(""),
+			textCell("no"),
+		})
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	parts := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", []byte(xlsxContentTypes)},
+		{"_rels/.rels", []byte(xlsxRootRels)},
+		{"xl/workbook.xml", []byte(xlsxWorkbook)},
+		{"xl/_rels/workbook.xml.rels", []byte(xlsxWorkbookRels)},
+		{"xl/styles.xml", []byte(xlsxStyles)},
+		{"xl/worksheets/sheet1.xml", sheetXML(pairs, []float64{20, 20, 12, 12, 22, 22, 14, 14, 22})},
+		{"xl/worksheets/sheet2.xml", sheetXML(students, []float64{20, 8, 16, 16, 22, 22, 10})},
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = f.Write(part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func headerRow(titles ...string) []xlsxCell {
+	row := make([]xlsxCell, 0, len(titles))
+	for _, t := range titles {
+		row = append(row, xlsxCell{text: t, style: styleHeader})
+	}
+	return row
+}
+
+// sheetXML записывает лист; первая строка закреплена как заголовок.
+func sheetXML(rows [][]xlsxCell, widths []float64) []byte {
+	var b bytes.Buffer
+
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+
+	b.WriteString("<cols>")
+	for i, w := range widths {
+		fmt.Fprintf(&b, `<col min="%d" max="%d" width="%.1f" customWidth="1"/>`, i+1, i+1, w)
+	}
+	b.WriteString("</cols>")
+
+	b.WriteString("<sheetData>")
+	for i, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			ref := columnName(j) + strconv.Itoa(i+1)
+			if cell.isNum {
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.style, strconv.FormatFloat(cell.number, 'f', -1, 64))
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, ref, cell.style)
+			writeEscaped(&b, cell.text)
+			b.WriteString(`</t></is></c>`)
+		}
+		b.WriteString("</row>")
+	}
+	b.WriteString("</sheetData></worksheet>")
+
+	return b.Bytes()
+}
+
+// columnName переводит номер столбца (с нуля) в буквенное имя: 0 - A, 25 - Z, 26 - AA.
+func columnName(i int) string {
+	name := ""
+	for i >= 0 {
+		name = string(rune('A'+i%26)) + name
+		i = i/26 - 1
+	}
+	return name
+}
+
+func writeEscaped(w io.Writer, s string) {
+	_ = xml.EscapeText(w, []byte(s))
+}
@@ -19,6 +19,17 @@ func NewTextExtractor() *TextExtractor {
 }
 
 func (e *TextExtractor) ExtractFromURL(fileURL string) (string, error) {
+	text, err := e.ExtractRawFromURL(fileURL)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(strings.Fields(text), " "), nil
+}
+
+// ExtractRawFromURL извлекает текст с сохранением переносов строк и отступов - для показа пользователю.
+// Порядок слов тот же, что у ExtractFromURL.
+func (e *TextExtractor) ExtractRawFromURL(fileURL string) (string, error) {
 	resp, err := e.httpClient.Get(fileURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to download file from URL %s: %w", fileURL, err)
@@ -58,5 +69,5 @@ func (e *TextExtractor) ExtractFromURL(fileURL string) (string, error) {
 		return ' '
 	}, text)
 
-	return strings.Join(strings.Fields(text), " "), nil
+	return text, nil
 }
@@ -0,0 +1,381 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handleCreateAppeal подаёт апелляцию студента на подтверждённый плагиат по его паре.
+func (s *Server) handleCreateAppeal(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	type appealRequest struct {
+		StudentID      string `json:"student_id"`
+		OtherStudentID string `json:"other_student_id"`
+		Explanation    string `json:"explanation"`
+	}
+
+	var req appealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	if req.StudentID == "" || req.OtherStudentID == "" || req.Explanation == "" {
+		writeError(w, http.StatusBadRequest, "student_id, other_student_id and explanation are required")
+		return
+	}
+
+	if !s.authorizeSubmission(w, r, taskID, req.StudentID) {
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.analysisClient.CreateAppeal(ctx, &plagiarismpb.CreateAppealRequest{
+		TaskId:         taskID,
+		StudentId:      req.StudentID,
+		OtherStudentId: req.OtherStudentID,
+		Explanation:    req.Explanation,
+	})
+	if err != nil {
+		s.writeAppealError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, appealPayload(resp.GetAppeal(), nil))
+}
+
+// handleListAppeals возвращает апелляции задачи; по умолчанию только открытые.
+func (s *Server) handleListAppeals(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	switch state {
+	case "":
+		state = "open"
+	case "all":
+		state = ""
+	case "open", "upheld", "rejected":
+	default:
+		writeError(w, http.StatusBadRequest, "state must be open, upheld, rejected or all")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.analysisClient.ListAppeals(ctx, &plagiarismpb.ListAppealsRequest{
+		TaskId: taskID,
+		State:  state,
+	})
+	if err != nil {
+		s.writeAppealError(w, err)
+		return
+	}
+
+	appeals := make([]map[string]any, 0, len(resp.GetAppeals()))
+	for _, appeal := range resp.GetAppeals() {
+		appeals = append(appeals, appealPayload(appeal, nil))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task_id": taskID,
+		"appeals": appeals,
+	})
+}
+
+// handleGetAppeal возвращает апелляцию со ссылками на скачивание загруженных приложений.
+func (s *Server) handleGetAppeal(w http.ResponseWriter, r *http.Request) {
+	appealID := chi.URLParam(r, "appeal_id")
+	if appealID == "" {
+		writeError(w, http.StatusBadRequest, "appeal_id is required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.analysisClient.GetAppeal(ctx, &plagiarismpb.GetAppealRequest{
+		AppealId: appealID,
+	})
+	if err != nil {
+		s.writeAppealError(w, err)
+		return
+	}
+
+	if !s.authorizeSubmission(w, r, resp.GetAppeal().GetTaskId(), resp.GetAppeal().GetStudentId()) {
+		return
+	}
+
+	downloads := make(map[string]string, len(resp.GetAppeal().GetAttachments()))
+	for _, attachment := range resp.GetAppeal().GetAttachments() {
+		downloadResp, err := s.storageClient.GenerateAttachmentDownloadURL(ctx, &storagepb.GenerateAttachmentDownloadURLRequest{
+			AttachmentId: attachment.GetAttachmentId(),
+			FromInside:   false,
+		})
+		if err != nil {
+			// файл ещё не загружен или не проверен - отдаём приложение без ссылки
+			if status.Code(err) == codes.FailedPrecondition || status.Code(err) == codes.NotFound {
+				continue
+			}
+			s.writeGrpcError(w, err)
+			return
+		}
+		downloads[attachment.GetAttachmentId()] = downloadResp.GetUrl()
+	}
+
+	writeJSON(w, http.StatusOK, appealPayload(resp.GetAppeal(), downloads))
+}
+
+// handleAddAppealAttachment регистрирует приложение к апелляции в storage-service
+// и возвращает ссылку для загрузки; после загрузки файл нужно подтвердить через verify.
+func (s *Server) handleAddAppealAttachment(w http.ResponseWriter, r *http.Request) {
+	appealID := chi.URLParam(r, "appeal_id")
+	if appealID == "" {
+		writeError(w, http.StatusBadRequest, "appeal_id is required")
+		return
+	}
+
+	type attachmentRequest struct {
+		StudentID string `json:"student_id"`
+		FileName  string `json:"file_name"`
+	}
+
+	var req attachmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	if req.StudentID == "" || req.FileName == "" {
+		writeError(w, http.StatusBadRequest, "student_id and file_name are required")
+		return
+	}
+
+	ctx := r.Context()
+	appealResp, err := s.analysisClient.GetAppeal(ctx, &plagiarismpb.GetAppealRequest{
+		AppealId: appealID,
+	})
+	if err != nil {
+		s.writeAppealError(w, err)
+		return
+	}
+
+	appeal := appealResp.GetAppeal()
+	if !s.authorizeSubmission(w, r, appeal.GetTaskId(), req.StudentID) {
+		return
+	}
+	if appeal.GetStudentId() != req.StudentID {
+		writeError(w, http.StatusForbidden, "only the author can add attachments to the appeal")
+		return
+	}
+	if appeal.GetState() != "open" {
+		writeError(w, http.StatusConflict, "appeal is already resolved")
+		return
+	}
+
+	uploadResp, err := s.storageClient.GenerateAttachmentUploadURL(ctx, &storagepb.GenerateAttachmentUploadURLRequest{
+		TaskId:   appeal.GetTaskId(),
+		OwnerId:  req.StudentID,
+		FileName: req.FileName,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	resp, err := s.analysisClient.AddAppealAttachment(ctx, &plagiarismpb.AddAppealAttachmentRequest{
+		AppealId:     appealID,
+		StudentId:    req.StudentID,
+		AttachmentId: uploadResp.GetAttachmentId(),
+		FileName:     req.FileName,
+	})
+	if err != nil {
+		s.writeAppealError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"attachment_id": uploadResp.GetAttachmentId(),
+		"upload_url":    uploadResp.GetUrl(),
+		"upload_fields": uploadResp.GetFields(),
+		"appeal":        appealPayload(resp.GetAppeal(), nil),
+	})
+}
+
+// handleVerifyAppealAttachment подтверждает, что приложение апелляции загружено в хранилище.
+func (s *Server) handleVerifyAppealAttachment(w http.ResponseWriter, r *http.Request) {
+	appealID := chi.URLParam(r, "appeal_id")
+	attachmentID := chi.URLParam(r, "attachment_id")
+	if appealID == "" || attachmentID == "" {
+		writeError(w, http.StatusBadRequest, "appeal_id and attachment_id are required")
+		return
+	}
+
+	ctx := r.Context()
+	appealResp, err := s.analysisClient.GetAppeal(ctx, &plagiarismpb.GetAppealRequest{
+		AppealId: appealID,
+	})
+	if err != nil {
+		s.writeAppealError(w, err)
+		return
+	}
+
+	appeal := appealResp.GetAppeal()
+	if !s.authorizeSubmission(w, r, appeal.GetTaskId(), appeal.GetStudentId()) {
+		return
+	}
+
+	attached := false
+	for _, attachment := range appeal.GetAttachments() {
+		if attachment.GetAttachmentId() == attachmentID {
+			attached = true
+			break
+		}
+	}
+	if !attached {
+		writeError(w, http.StatusNotFound, "attachment not found")
+		return
+	}
+
+	resp, err := s.storageClient.VerifyAttachment(ctx, &storagepb.VerifyAttachmentRequest{
+		AttachmentId: attachmentID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"attachment_id": resp.GetAttachment().GetAttachmentId(),
+		"file_name":     resp.GetAttachment().GetFileName(),
+		"status":        resp.GetAttachment().GetStatus(),
+	})
+}
+
+// handleResolveAppeal закрывает апелляцию решением проверяющего.
+func (s *Server) handleResolveAppeal(w http.ResponseWriter, r *http.Request) {
+	appealID := chi.URLParam(r, "appeal_id")
+	if appealID == "" {
+		writeError(w, http.StatusBadRequest, "appeal_id is required")
+		return
+	}
+
+	type resolveRequest struct {
+		Outcome    string `json:"outcome"`
+		Comment    string `json:"comment"`
+		ReviewerID string `json:"reviewer_id"`
+		Verdict    string `json:"verdict"`
+	}
+
+	var req resolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	req.ReviewerID = actorID(r, req.ReviewerID)
+	if req.Outcome == "" || req.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "outcome and reviewer_id are required")
+		return
+	}
+
+	ctx := r.Context()
+	appealResp, err := s.analysisClient.GetAppeal(ctx, &plagiarismpb.GetAppealRequest{
+		AppealId: appealID,
+	})
+	if err != nil {
+		s.writeAppealError(w, err)
+		return
+	}
+
+	if !s.authorizeTaskTeacher(w, r, appealResp.GetAppeal().GetTaskId()) {
+		return
+	}
+
+	resp, err := s.analysisClient.ResolveAppeal(ctx, &plagiarismpb.ResolveAppealRequest{
+		AppealId:   appealID,
+		Outcome:    req.Outcome,
+		Comment:    req.Comment,
+		ReviewerId: req.ReviewerID,
+		Verdict:    req.Verdict,
+	})
+	if err != nil {
+		s.writeAppealError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, appealPayload(resp.GetAppeal(), nil))
+}
+
+// writeAppealError дополняет writeGrpcError ответами на нарушения процесса апелляции.
+func (s *Server) writeAppealError(w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		writeError(w, http.StatusConflict, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		writeError(w, http.StatusForbidden, status.Convert(err).Message())
+	default:
+		s.writeGrpcError(w, err)
+	}
+}
+
+// appealPayload собирает апелляцию со ссылками на доказательства пары; downloads - ссылки
+// на скачивание приложений по их id.
+func appealPayload(appeal *plagiarismpb.Appeal, downloads map[string]string) map[string]any {
+	pairPath := fmt.Sprintf("/api/analysis/%s/pairs/%s/%s",
+		url.PathEscape(appeal.GetTaskId()), url.PathEscape(appeal.GetStudentA()), url.PathEscape(appeal.GetStudentB()))
+
+	attachments := make([]map[string]any, 0, len(appeal.GetAttachments()))
+	for _, a := range appeal.GetAttachments() {
+		item := map[string]any{
+			"attachment_id": a.GetAttachmentId(),
+			"file_name":     a.GetFileName(),
+			"added_at":      a.GetAddedAt().AsTime(),
+		}
+		if downloads != nil {
+			item["download_url"] = downloads[a.GetAttachmentId()]
+		}
+		attachments = append(attachments, item)
+	}
+
+	payload := map[string]any{
+		"id":                 appeal.GetId(),
+		"task_id":            appeal.GetTaskId(),
+		"student_a":          appeal.GetStudentA(),
+		"student_b":          appeal.GetStudentB(),
+		"student_id":         appeal.GetStudentId(),
+		"appellant_id":       appeal.GetAppellantId(),
+		"review_id":          appeal.GetReviewId(),
+		"state":              appeal.GetState(),
+		"explanation":        appeal.GetExplanation(),
+		"submitted_at":       appeal.GetSubmittedAt().AsTime(),
+		"respond_by":         appeal.GetRespondBy().AsTime(),
+		"overdue":            appeal.GetOverdue(),
+		"resolver_id":        appeal.GetResolverId(),
+		"resolution_comment": appeal.GetResolutionComment(),
+		"attachments":        attachments,
+		"evidence_url":       pairPath + "/view",
+		"reviews_url":        pairPath + "/reviews",
+	}
+	if appeal.GetResolvedAt() != nil {
+		payload["resolved_at"] = appeal.GetResolvedAt().AsTime()
+	}
+	if appeal.GetResolutionReviewId() != 0 {
+		payload["resolution_review_id"] = appeal.GetResolutionReviewId()
+	}
+
+	return payload
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"api_gateway/internal/infrastructure/auth"
+
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authenticate проверяет bearer-токен и кладёт пользователя в контекст запроса.
+// Без настроенной проверки (AUTH_DISABLED) пропускает все запросы как есть.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.verifier == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer`)
+			writeError(w, http.StatusUnauthorized, "bearer token is required")
+			return
+		}
+
+		identity, err := s.verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			message := "invalid token"
+			if errors.Is(err, auth.ErrTokenExpired) {
+				message = "token expired"
+			}
+			s.logger.Warn("authentication failed", "error", err, "path", r.URL.Path)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeError(w, http.StatusUnauthorized, message)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	})
+}
+
+// requireRole пропускает только пользователей с одной из ролей.
+func requireRole(roles ...auth.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity := auth.FromContext(r.Context())
+			if identity != nil && !slices.Contains(roles, identity.Role) {
+				writeError(w, http.StatusForbidden, "forbidden")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireTaskTeacher пропускает к маршрутам с {task_id} только преподавателей курса задачи и администраторов.
+func (s *Server) requireTaskTeacher(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.authorizeTaskTeacher(w, r, chi.URLParam(r, "task_id")) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// requireSubmissionAccess пропускает к сдаче {task_id}/{student_id} её автора, преподавателей курса и администраторов.
+func (s *Server) requireSubmissionAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.authorizeSubmission(w, r, chi.URLParam(r, "task_id"), chi.URLParam(r, "student_id")) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// authorizeTaskTeacher проверяет, что пользователь - преподаватель курса задачи или администратор.
+// У задачи без курса (или не заведённой) доступ есть только у администратора, а с allowUnscopedTasks - у любого
+// преподавателя. При отказе пишет ответ и возвращает false.
+func (s *Server) authorizeTaskTeacher(w http.ResponseWriter, r *http.Request, taskID string) bool {
+	identity := auth.FromContext(r.Context())
+	if identity == nil || identity.Role == auth.RoleAdmin {
+		return true
+	}
+	if identity.Role != auth.RoleTeacher {
+		writeError(w, http.StatusForbidden, "only teachers of the course can access the task")
+		return false
+	}
+
+	resp, err := s.storageClient.GetTask(r.Context(), &storagepb.GetTaskRequest{
+		TaskId: taskID,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		s.writeGrpcError(w, err)
+		return false
+	}
+
+	// у незаведённой задачи и задачи без курса нет списка преподавателей
+	courseID := resp.GetTask().GetSettings().GetCourseId()
+	if courseID == "" {
+		if s.allowUnscopedTasks {
+			return true
+		}
+		writeError(w, http.StatusForbidden, "task has no course, only administrators can access it")
+		return false
+	}
+	return s.authorizeCourseTeacher(w, r, courseID)
+}
+
+// authorizeCourseTeacher проверяет, что пользователь - преподаватель курса или администратор.
+func (s *Server) authorizeCourseTeacher(w http.ResponseWriter, r *http.Request, courseID string) bool {
+	identity := auth.FromContext(r.Context())
+	if identity == nil || identity.Role == auth.RoleAdmin {
+		return true
+	}
+
+	if identity.Role == auth.RoleTeacher {
+		resp, err := s.storageClient.GetCourse(r.Context(), &storagepb.GetCourseRequest{
+			CourseId: courseID,
+		})
+		if err != nil {
+			s.writeGrpcError(w, err)
+			return false
+		}
+
+		for _, member := range resp.GetCourse().GetMembers() {
+			if member.GetUserId() == identity.UserID && member.GetRole() == string(auth.RoleTeacher) {
+				return true
+			}
+		}
+	}
+
+	writeError(w, http.StatusForbidden, "only teachers of the course can access it")
+	return false
+}
+
+// authorizeTaskCourse проверяет, что пользователь может завести задачу в курсе courseID или перенести её туда:
+// преподаватель - только в курс, который ведёт, задачу без курса - только администратор
+// (с allowUnscopedTasks - любой преподаватель, которому такие задачи и так доступны).
+func (s *Server) authorizeTaskCourse(w http.ResponseWriter, r *http.Request, courseID string) bool {
+	if courseID != "" {
+		return s.authorizeCourseTeacher(w, r, courseID)
+	}
+
+	identity := auth.FromContext(r.Context())
+	if identity == nil || identity.Role == auth.RoleAdmin || s.allowUnscopedTasks {
+		return true
+	}
+	writeError(w, http.StatusForbidden, "only administrators can manage tasks without a course")
+	return false
+}
+
+// authorizeSubmission проверяет доступ к сдаче studentID по задаче: студенту - только к своей
+// (или своей группы), преподавателю - к сдачам задач своего курса, администратору - ко всем.
+func (s *Server) authorizeSubmission(w http.ResponseWriter, r *http.Request, taskID, studentID string) bool {
+	identity := auth.FromContext(r.Context())
+	if identity == nil || identity.Role == auth.RoleAdmin {
+		return true
+	}
+	if identity.Role == auth.RoleTeacher {
+		return s.authorizeTaskTeacher(w, r, taskID)
+	}
+
+	if studentID == identity.UserID {
+		return true
+	}
+
+	resp, err := s.storageClient.ListTaskGroups(r.Context(), &storagepb.ListTaskGroupsRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return false
+	}
+	for _, group := range resp.GetGroups() {
+		if group.GetGroupId() == studentID && slices.Contains(group.GetMemberIds(), identity.UserID) {
+			return true
+		}
+	}
+
+	writeError(w, http.StatusForbidden, "students can access only their own submission")
+	return false
+}
+
+// actorID возвращает id пользователя из токена; без аутентификации - значение из запроса.
+func actorID(r *http.Request, requested string) string {
+	if identity := auth.FromContext(r.Context()); identity != nil {
+		return identity.UserID
+	}
+	return requested
+}
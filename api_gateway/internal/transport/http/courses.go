@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxRosterSize - ограничение на размер тела запроса со списком курса.
+const maxRosterSize = 1 << 20
+
+type courseMemberRequest struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// handleCreateCourse заводит курс, сразу с участниками если они переданы.
+func (s *Server) handleCreateCourse(w http.ResponseWriter, r *http.Request) {
+	type courseRequest struct {
+		CourseID string                `json:"course_id"`
+		Title    string                `json:"title"`
+		Members  []courseMemberRequest `json:"members"`
+	}
+
+	var req courseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	if req.CourseID == "" || req.Title == "" {
+		writeError(w, http.StatusBadRequest, "course_id and title are required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.CreateCourse(ctx, &storagepb.CreateCourseRequest{
+		CourseId: req.CourseID,
+		Title:    req.Title,
+		Members:  toProtoCourseMembers(req.Members),
+	})
+	if err != nil {
+		s.writeTaskError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, coursePayload(resp.GetCourse()))
+}
+
+func (s *Server) handleGetCourse(w http.ResponseWriter, r *http.Request) {
+	courseID := chi.URLParam(r, "course_id")
+	if courseID == "" {
+		writeError(w, http.StatusBadRequest, "course_id is required")
+		return
+	}
+
+	if !s.authorizeCourseTeacher(w, r, courseID) {
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.GetCourse(ctx, &storagepb.GetCourseRequest{
+		CourseId: courseID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, coursePayload(resp.GetCourse()))
+}
+
+// handleImportCourseRoster добавляет участников курса из CSV (столбцы user_id,role, заголовок необязателен)
+// или из JSON {"members": [...]}. С replace=true список курса заменяется целиком.
+func (s *Server) handleImportCourseRoster(w http.ResponseWriter, r *http.Request) {
+	courseID := chi.URLParam(r, "course_id")
+	if courseID == "" {
+		writeError(w, http.StatusBadRequest, "course_id is required")
+		return
+	}
+
+	if !s.authorizeCourseTeacher(w, r, courseID) {
+		return
+	}
+
+	replace := r.URL.Query().Get("replace") == "true"
+	body := http.MaxBytesReader(w, r.Body, maxRosterSize)
+
+	var members []courseMemberRequest
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		var err error
+		members, err = parseRosterCSV(body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	} else {
+		var req struct {
+			Members []courseMemberRequest `json:"members"`
+			Replace bool                  `json:"replace"`
+		}
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid json payload")
+			return
+		}
+		members = req.Members
+		replace = replace || req.Replace
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.ImportCourseRoster(ctx, &storagepb.ImportCourseRosterRequest{
+		CourseId: courseID,
+		Members:  toProtoCourseMembers(members),
+		Replace:  replace,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, coursePayload(resp.GetCourse()))
+}
+
+// handleRemoveCourseMember исключает участника из курса.
+func (s *Server) handleRemoveCourseMember(w http.ResponseWriter, r *http.Request) {
+	courseID := chi.URLParam(r, "course_id")
+	userID := chi.URLParam(r, "user_id")
+	if courseID == "" || userID == "" {
+		writeError(w, http.StatusBadRequest, "course_id and user_id are required")
+		return
+	}
+
+	if !s.authorizeCourseTeacher(w, r, courseID) {
+		return
+	}
+
+	ctx := r.Context()
+	_, err := s.storageClient.RemoveCourseMember(ctx, &storagepb.RemoveCourseMemberRequest{
+		CourseId: courseID,
+		UserId:   userID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseRosterCSV читает список курса: user_id и необязательная роль (по умолчанию student).
+// Первая строка пропускается, если это заголовок user_id,role.
+func parseRosterCSV(r io.Reader) ([]courseMemberRequest, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var members []courseMemberRequest
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		userID := strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff"))
+		if line == 1 && strings.EqualFold(userID, "user_id") {
+			continue
+		}
+		if userID == "" {
+			continue
+		}
+
+		role := "student"
+		if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			role = strings.ToLower(strings.TrimSpace(record[1]))
+		}
+		members = append(members, courseMemberRequest{UserID: userID, Role: role})
+	}
+
+	return members, nil
+}
+
+// missingStudents возвращает студентов курса задачи без сдачи; для задачи без настроек - пустой список.
+func (s *Server) missingStudents(ctx context.Context, taskID string) ([]string, error) {
+	resp, err := s.storageClient.ListMissingSubmissions(ctx, &storagepb.ListMissingSubmissionsRequest{
+		TaskId: taskID,
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetStudentIds(), nil
+}
+
+func toProtoCourseMembers(members []courseMemberRequest) []*storagepb.CourseMember {
+	result := make([]*storagepb.CourseMember, 0, len(members))
+	for _, member := range members {
+		role := member.Role
+		if role == "" {
+			role = "student"
+		}
+		result = append(result, &storagepb.CourseMember{
+			UserId: member.UserID,
+			Role:   role,
+		})
+	}
+	return result
+}
+
+func coursePayload(course *storagepb.CourseInfo) map[string]any {
+	members := make([]map[string]any, 0, len(course.GetMembers()))
+	for _, member := range course.GetMembers() {
+		members = append(members, map[string]any{
+			"user_id": member.GetUserId(),
+			"role":    member.GetRole(),
+		})
+	}
+
+	return map[string]any{
+		"course_id":  course.GetCourseId(),
+		"title":      course.GetTitle(),
+		"members":    members,
+		"created_at": course.GetCreatedAt().AsTime(),
+	}
+}
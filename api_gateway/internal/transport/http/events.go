@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/status"
+)
+
+// sseKeepAliveInterval - как часто отправлять комментарий, чтобы прокси не закрыли молчащее соединение.
+const sseKeepAliveInterval = 15 * time.Second
+
+// handleAnalysisEvents транслирует ход анализа задачи как Server-Sent Events:
+// progress - состояние задания и новые подозрительные пары, done - задание завершено,
+// error - поток прервался после начала ответа.
+func (s *Server) handleAnalysisEvents(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	ctx := r.Context()
+	stream, err := s.analysisClient.WatchAnalysis(ctx, &plagiarismpb.WatchAnalysisRequest{
+		TaskId: taskID,
+		JobId:  r.URL.Query().Get("job_id"),
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	// первое событие читаем до заголовков, чтобы ошибки (например, нет заданий) вернуть обычным статусом
+	first, err := stream.Recv()
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	type received struct {
+		event *plagiarismpb.AnalysisEvent
+		err   error
+	}
+
+	events := make(chan received, 1)
+	events <- received{event: first}
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			select {
+			case events <- received{event: event, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	var last *plagiarismpb.AnalysisJob
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case rec, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if errors.Is(rec.err, io.EOF) {
+				writeSSE(w, "done", analysisJobPayload(last))
+				flusher.Flush()
+				return
+			}
+			if rec.err != nil {
+				s.logger.Error("analysis event stream failed", "error", rec.err, "task_id", taskID)
+				writeSSE(w, "error", map[string]string{"error": status.Convert(rec.err).Message()})
+				flusher.Flush()
+				return
+			}
+
+			last = rec.event.GetJob()
+			writeSSE(w, "progress", analysisEventPayload(rec.event))
+			flusher.Flush()
+		}
+	}
+}
+
+func analysisEventPayload(event *plagiarismpb.AnalysisEvent) map[string]any {
+	type suspiciousPair struct {
+		StudentA   string  `json:"student_a"`
+		StudentB   string  `json:"student_b"`
+		Similarity float64 `json:"similarity"`
+	}
+
+	pairs := make([]suspiciousPair, 0, len(event.GetNewSuspiciousPairs()))
+	for _, pair := range event.GetNewSuspiciousPairs() {
+		pairs = append(pairs, suspiciousPair{
+			StudentA:   pair.GetStudentA(),
+			StudentB:   pair.GetStudentB(),
+			Similarity: pair.GetSimilarity(),
+		})
+	}
+
+	return map[string]any{
+		"job":                  analysisJobPayload(event.GetJob()),
+		"new_suspicious_pairs": pairs,
+	}
+}
+
+func writeSSE(w io.Writer, event string, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		data = []byte(`{"error":"internal error"}`)
+	}
+
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"api_gateway/internal/infrastructure/graph_export"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+)
+
+// handleSimilarityGraph выгружает граф схожести задачи для Gephi и Graphviz:
+// вершины - все студенты задачи с атрибутами файла, рёбра - пары не ниже min_similarity.
+func (s *Server) handleSimilarityGraph(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "dot" && format != "graphml" {
+		writeError(w, http.StatusBadRequest, "format must be dot, graphml or json")
+		return
+	}
+
+	minSimilarity, ok := parseMinSimilarity(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	pairsResp, err := s.analysisClient.ListPairReports(ctx, &plagiarismpb.ListPairReportsRequest{
+		TaskId:        taskID,
+		MinSimilarity: minSimilarity,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	filesResp, err := s.storageClient.ListTaskFiles(ctx, &storagepb.ListTaskFilesRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	graph := buildSimilarityGraph(taskID, pairsResp.GetPairs(), filesResp.GetItems())
+
+	var (
+		body        []byte
+		contentType string
+	)
+	switch format {
+	case "dot":
+		body, contentType = graph.DOT(), "text/vnd.graphviz; charset=utf-8"
+	case "graphml":
+		body, contentType = graph.GraphML(), "application/graphml+xml; charset=utf-8"
+	case "json":
+		body, err = graph.JSON()
+		if err != nil {
+			s.logger.Error("failed to encode graph", "error", err, "task_id", taskID)
+			writeError(w, http.StatusInternalServerError, "failed to encode graph")
+			return
+		}
+		contentType = "application/json"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if format != "json" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "similarity_" + taskID + "." + format}))
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// parseMinSimilarity читает необязательный параметр min_similarity; nil - порог плагиата по умолчанию.
+// При ошибке сам отвечает 400 и возвращает false.
+func parseMinSimilarity(w http.ResponseWriter, r *http.Request) (*float64, bool) {
+	raw := r.URL.Query().Get("min_similarity")
+	if raw == "" {
+		return nil, true
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < 0 || value > 1 {
+		writeError(w, http.StatusBadRequest, "min_similarity must be a number between 0 and 1")
+		return nil, false
+	}
+
+	return &value, true
+}
+
+// buildSimilarityGraph собирает вершины из файлов задачи и участников пар,
+// чтобы студенты без подозрительных пар тоже были видны как изолированные вершины.
+func buildSimilarityGraph(taskID string, pairs []*plagiarismpb.PairReport, files []*storagepb.FileInfo) *graph_export.Graph {
+	nodes := make(map[string]*graph_export.Node)
+	node := func(studentID string) *graph_export.Node {
+		if n, ok := nodes[studentID]; ok {
+			return n
+		}
+		n := &graph_export.Node{ID: studentID}
+		nodes[studentID] = n
+		return n
+	}
+	submitted := func(n *graph_export.Node, at time.Time) {
+		if n.SubmittedAt.IsZero() || at.Before(n.SubmittedAt) {
+			n.SubmittedAt = at
+		}
+	}
+
+	for _, f := range files {
+		n := node(f.GetStudentId())
+		n.FileStatus = f.GetStatus()
+		if f.GetUpdatedAt() != nil {
+			n.FileUpdatedAt = f.GetUpdatedAt().AsTime()
+		}
+	}
+
+	graph := &graph_export.Graph{Name: "task_" + taskID}
+	for _, p := range pairs {
+		a, b := node(p.GetStudentA()), node(p.GetStudentB())
+		if p.GetFileAHandedOverAt() != nil {
+			submitted(a, p.GetFileAHandedOverAt().AsTime())
+		}
+		if p.GetFileBHandedOverAt() != nil {
+			submitted(b, p.GetFileBHandedOverAt().AsTime())
+		}
+
+		graph.Edges = append(graph.Edges, graph_export.Edge{
+			Source: p.GetStudentA(),
+			Target: p.GetStudentB(),
+			Weight: p.GetSimilarity(),
+		})
+	}
+
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		n := nodes[id]
+		// у студента без пар выше порога время сдачи берём из хранилища
+		if n.SubmittedAt.IsZero() {
+			n.SubmittedAt = n.FileUpdatedAt
+		}
+		graph.Nodes = append(graph.Nodes, *n)
+	}
+
+	return graph
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"api_gateway/internal/infrastructure/graph_export"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBuildSimilarityGraph(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	file := func(studentID string, updatedAt time.Time) *storagepb.FileInfo {
+		return &storagepb.FileInfo{StudentId: studentID, Status: "uploaded", UpdatedAt: timestamppb.New(updatedAt)}
+	}
+	pair := func(a, b string, similarity float64, aAt, bAt time.Time) *plagiarismpb.PairReport {
+		return &plagiarismpb.PairReport{
+			StudentA:          a,
+			StudentB:          b,
+			Similarity:        similarity,
+			FileAHandedOverAt: timestamppb.New(aAt),
+			FileBHandedOverAt: timestamppb.New(bAt),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		pairs []*plagiarismpb.PairReport
+		files []*storagepb.FileInfo
+		nodes []graph_export.Node
+		edges []graph_export.Edge
+	}{
+		{
+			name: "empty task",
+		},
+		{
+			name:  "student without pairs is an isolated node",
+			files: []*storagepb.FileInfo{file("s1", at)},
+			nodes: []graph_export.Node{{ID: "s1", SubmittedAt: at, FileStatus: "uploaded", FileUpdatedAt: at}},
+		},
+		{
+			name: "earliest handed over time wins",
+			pairs: []*plagiarismpb.PairReport{
+				pair("s1", "s2", 0.9, at.Add(time.Hour), at),
+				pair("s1", "s3", 0.8, at, at),
+			},
+			files: []*storagepb.FileInfo{file("s3", at.Add(2*time.Hour)), file("s2", at.Add(2*time.Hour)), file("s1", at.Add(2*time.Hour))},
+			nodes: []graph_export.Node{
+				{ID: "s1", SubmittedAt: at, FileStatus: "uploaded", FileUpdatedAt: at.Add(2 * time.Hour)},
+				{ID: "s2", SubmittedAt: at, FileStatus: "uploaded", FileUpdatedAt: at.Add(2 * time.Hour)},
+				{ID: "s3", SubmittedAt: at, FileStatus: "uploaded", FileUpdatedAt: at.Add(2 * time.Hour)},
+			},
+			edges: []graph_export.Edge{{Source: "s1", Target: "s2", Weight: 0.9}, {Source: "s1", Target: "s3", Weight: 0.8}},
+		},
+		{
+			name:  "student removed from storage keeps the pair",
+			pairs: []*plagiarismpb.PairReport{pair("s1", "s2", 0.9, at, at)},
+			files: []*storagepb.FileInfo{file("s1", at)},
+			nodes: []graph_export.Node{
+				{ID: "s1", SubmittedAt: at, FileStatus: "uploaded", FileUpdatedAt: at},
+				{ID: "s2", SubmittedAt: at},
+			},
+			edges: []graph_export.Edge{{Source: "s1", Target: "s2", Weight: 0.9}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := buildSimilarityGraph("t1", tt.pairs, tt.files)
+
+			if graph.Name != "task_t1" {
+				t.Errorf("name = %q, want task_t1", graph.Name)
+			}
+			if !reflect.DeepEqual(graph.Nodes, tt.nodes) {
+				t.Errorf("nodes = %+v, want %+v", graph.Nodes, tt.nodes)
+			}
+			if !reflect.DeepEqual(graph.Edges, tt.edges) {
+				t.Errorf("edges = %+v, want %+v", graph.Edges, tt.edges)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+)
+
+func (s *Server) handleSuspiciousGroups(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	var minSimilarity float64
+	if raw := r.URL.Query().Get("min_similarity"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 || value > 1 {
+			writeError(w, http.StatusBadRequest, "min_similarity must be a number between 0 and 1")
+			return
+		}
+		minSimilarity = value
+	}
+
+	ctx := r.Context()
+	resp, err := s.analysisClient.ListSuspiciousGroups(ctx, &plagiarismpb.ListSuspiciousGroupsRequest{
+		TaskId:        taskID,
+		MinSimilarity: minSimilarity,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	groups := make([]map[string]any, 0, len(resp.GetGroups()))
+	for _, group := range resp.GetGroups() {
+		communities := make([]map[string]any, 0, len(group.GetCommunities()))
+		for _, community := range group.GetCommunities() {
+			communities = append(communities, groupStatsPayload(community))
+		}
+
+		payload := groupStatsPayload(group.GetStats())
+		payload["group_id"] = group.GetGroupId()
+		payload["communities"] = communities
+		groups = append(groups, payload)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task_id": taskID,
+		"groups":  groups,
+	})
+}
+
+func groupStatsPayload(stats *plagiarismpb.GroupStats) map[string]any {
+	payload := map[string]any{
+		"members":        stats.GetMembers(),
+		"score":          stats.GetScore(),
+		"max_similarity": stats.GetMaxSimilarity(),
+		"density":        stats.GetDensity(),
+		"origin":         stats.GetOrigin(),
+	}
+
+	if stats.GetOriginSubmittedAt() != nil {
+		payload["origin_submitted_at"] = stats.GetOriginSubmittedAt().AsTime()
+	}
+
+	return payload
+}
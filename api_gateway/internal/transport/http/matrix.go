@@ -0,0 +1,84 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+
+	"api_gateway/internal/infrastructure/heatmap"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+)
+
+// handleSimilarityMatrix возвращает полную матрицу схожести задачи в JSON (по умолчанию)
+// или тепловой картой в SVG/PNG.
+func (s *Server) handleSimilarityMatrix(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "svg" && format != "png" {
+		writeError(w, http.StatusBadRequest, "format must be json, svg or png")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.analysisClient.GetSimilarityMatrix(ctx, &plagiarismpb.GetSimilarityMatrixRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	matrix := make([][]float64, 0, len(resp.GetRows()))
+	for _, row := range resp.GetRows() {
+		matrix = append(matrix, row.GetSimilarities())
+	}
+
+	if format == "json" {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"task_id":    taskID,
+			"started_at": resp.GetStartedAt().AsTime(),
+			"students":   resp.GetStudents(),
+			"matrix":     matrix,
+			"threshold":  resp.GetPlagiarismThreshold(),
+		})
+		return
+	}
+
+	hm := heatmap.Heatmap{
+		Title:     "Task " + taskID + ": pairwise similarity",
+		Labels:    resp.GetStudents(),
+		Values:    matrix,
+		Threshold: resp.GetPlagiarismThreshold(),
+	}
+
+	var (
+		image       []byte
+		contentType string
+	)
+	switch format {
+	case "svg":
+		image, contentType = hm.SVG(), "image/svg+xml"
+	case "png":
+		image, err = hm.PNG()
+		if err != nil {
+			s.logger.Error("failed to render heatmap", "error", err, "task_id", taskID)
+			writeError(w, http.StatusInternalServerError, "failed to render heatmap")
+			return
+		}
+		contentType = "image/png"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": "similarity_" + taskID + "." + format}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
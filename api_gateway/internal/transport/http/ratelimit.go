@@ -0,0 +1,76 @@
+package http
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"api_gateway/internal/infrastructure/auth"
+	"api_gateway/internal/infrastructure/ratelimit"
+)
+
+// RateLimits - бюджеты запросов для дорогих маршрутов; nil в NewServer отключает ограничения.
+type RateLimits struct {
+	Limiter   *ratelimit.Limiter
+	Analysis  ratelimit.Limit
+	Upload    ratelimit.Limit
+	WordCloud ratelimit.Limit
+	// TrustProxy - брать IP клиента из X-Forwarded-For (гейтвей за доверенным прокси)
+	TrustProxy bool
+}
+
+// rateLimit ограничивает маршрут корзинами name: своей у каждого IP и, с аутентификацией, своей у каждого пользователя.
+// Запрос списывает токен из обеих корзин и проходит, только если токен был в каждой: иначе один пользователь
+// обходил бы предел с разных адресов, а один адрес - с разными токенами.
+// При исчерпании отвечает 429 с Retry-After в секундах.
+func (s *Server) rateLimit(name string, limit ratelimit.Limit) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if s.rateLimits == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			keys := []string{name + ":ip:" + s.clientIP(r)}
+			if identity := auth.FromContext(r.Context()); identity != nil {
+				keys = append(keys, name+":user:"+identity.UserID)
+			}
+
+			allowed := true
+			var retryAfter time.Duration
+			for _, key := range keys {
+				ok, wait := s.rateLimits.Limiter.Take(r.Context(), key, limit)
+				if !ok {
+					allowed = false
+					retryAfter = max(retryAfter, wait)
+				}
+			}
+			if !allowed {
+				s.logger.Warn("rate limit exceeded", "keys", keys, "path", r.URL.Path)
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (s *Server) clientIP(r *http.Request) string {
+	if s.rateLimits.TrustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"api_gateway/internal/infrastructure/report_export"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+var reportContentTypes = map[string]string{
+	"json": "application/json",
+	"csv":  "text/csv",
+	"xlsx": xlsxContentType,
+	"pdf":  "application/pdf",
+}
+
+// reportFormat выбирает формат отчёта: параметр format важнее заголовка Accept,
+// при отсутствии обоих отдаётся JSON.
+func reportFormat(r *http.Request) (string, bool) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		_, ok := reportContentTypes[format]
+		return format, ok
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		for format, contentType := range reportContentTypes {
+			if mediaType == contentType {
+				return format, true
+			}
+		}
+	}
+
+	return "json", true
+}
+
+// exportReport выгружает все пары задачи файлом для деканата: CSV (sheet=pairs|summary), XLSX или PDF.
+func (s *Server) exportReport(w http.ResponseWriter, r *http.Request, taskID, format string) {
+	sheet := r.URL.Query().Get("sheet")
+	if sheet == "" {
+		sheet = "pairs"
+	}
+	if sheet != "pairs" && sheet != "summary" {
+		writeError(w, http.StatusBadRequest, "sheet must be pairs or summary")
+		return
+	}
+
+	var allPairs float64
+
+	ctx := r.Context()
+	resp, err := s.analysisClient.ListPairReports(ctx, &plagiarismpb.ListPairReportsRequest{
+		TaskId:           taskID,
+		MinSimilarity:    &allPairs,
+		IncludeFragments: format == "pdf",
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	report := buildReport(taskID, resp)
+	report.MissingStudents, err = s.missingStudents(ctx, taskID)
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	var (
+		body     bytes.Buffer
+		filename = fmt.Sprintf("report_%s.%s", taskID, format)
+	)
+	switch format {
+	case "csv":
+		if sheet == "summary" {
+			err = report.WriteSummaryCSV(&body)
+			filename = fmt.Sprintf("report_%s_summary.csv", taskID)
+		} else {
+			err = report.WritePairsCSV(&body)
+		}
+	case "xlsx":
+		var data []byte
+		data, err = report.XLSX()
+		body.Write(data)
+	case "pdf":
+		body.Write(report.PDF())
+	}
+	if err != nil {
+		s.logger.Error("failed to export report", "error", err, "task_id", taskID, "format", format)
+		writeError(w, http.StatusInternalServerError, "failed to export report")
+		return
+	}
+
+	contentType := reportContentTypes[format]
+	if format == "csv" {
+		contentType += "; charset=utf-8"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+func buildReport(taskID string, resp *plagiarismpb.ListPairReportsResponse) *report_export.Report {
+	report := &report_export.Report{
+		TaskID:              taskID,
+		GeneratedAt:         time.Now(),
+		PlagiarismThreshold: resp.GetPlagiarismThreshold(),
+		NGramSize:           int(resp.GetNGramSize()),
+		MinFragmentWords:    int(resp.GetMinFragmentWords()),
+	}
+	if resp.GetStartedAt() != nil {
+		report.AnalysisStartedAt = resp.GetStartedAt().AsTime()
+	}
+
+	for _, p := range resp.GetPairs() {
+		pair := report_export.Pair{
+			StudentA:     p.GetStudentA(),
+			StudentB:     p.GetStudentB(),
+			Similarity:   p.GetSimilarity(),
+			Suspicious:   p.GetSuspicious(),
+			FileAVersion: int(p.GetFileAVersion()),
+			FileBVersion: int(p.GetFileBVersion()),
+		}
+		if p.GetFileAHandedOverAt() != nil {
+			pair.FileAHandedOverAt = p.GetFileAHandedOverAt().AsTime()
+		}
+		if p.GetFileBHandedOverAt() != nil {
+			pair.FileBHandedOverAt = p.GetFileBHandedOverAt().AsTime()
+		}
+		if p.GetVerdict() != nil {
+			pair.Verdict = p.GetVerdict().GetVerdict()
+		}
+		for _, f := range p.GetFiles() {
+			pair.Files = append(pair.Files, report_export.PairFile{
+				Name:       f.GetFileName(),
+				Similarity: f.GetSimilarity(),
+			})
+		}
+		for _, f := range p.GetFragments() {
+			pair.Fragments = append(pair.Fragments, f.GetExcerpt())
+		}
+
+		report.Pairs = append(report.Pairs, pair)
+	}
+
+	return report
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+)
+
+// handleReviewPair записывает решение проверяющего по паре.
+func (s *Server) handleReviewPair(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	studentA := chi.URLParam(r, "student_a")
+	studentB := chi.URLParam(r, "student_b")
+	if taskID == "" || studentA == "" || studentB == "" {
+		writeError(w, http.StatusBadRequest, "task_id, student_a and student_b are required")
+		return
+	}
+
+	type reviewRequest struct {
+		Verdict    string `json:"verdict"`
+		Comment    string `json:"comment"`
+		ReviewerID string `json:"reviewer_id"`
+	}
+
+	var req reviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	req.ReviewerID = actorID(r, req.ReviewerID)
+	if req.Verdict == "" || req.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "verdict and reviewer_id are required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.analysisClient.ReviewPair(ctx, &plagiarismpb.ReviewPairRequest{
+		TaskId:     taskID,
+		StudentA:   studentA,
+		StudentB:   studentB,
+		Verdict:    req.Verdict,
+		Comment:    req.Comment,
+		ReviewerId: req.ReviewerID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, reviewPayload(resp.GetReview()))
+}
+
+// handleListPairReviews возвращает историю решений по паре.
+func (s *Server) handleListPairReviews(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	studentA := chi.URLParam(r, "student_a")
+	studentB := chi.URLParam(r, "student_b")
+	if taskID == "" || studentA == "" || studentB == "" {
+		writeError(w, http.StatusBadRequest, "task_id, student_a and student_b are required")
+		return
+	}
+
+	s.listReviews(w, r, &plagiarismpb.ListPairReviewsRequest{
+		TaskId:   taskID,
+		StudentA: studentA,
+		StudentB: studentB,
+	})
+}
+
+// handleListTaskReviews возвращает историю решений по всем парам задачи.
+func (s *Server) handleListTaskReviews(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	s.listReviews(w, r, &plagiarismpb.ListPairReviewsRequest{
+		TaskId: taskID,
+	})
+}
+
+func (s *Server) listReviews(w http.ResponseWriter, r *http.Request, req *plagiarismpb.ListPairReviewsRequest) {
+	ctx := r.Context()
+	resp, err := s.analysisClient.ListPairReviews(ctx, req)
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	reviews := make([]map[string]any, 0, len(resp.GetReviews()))
+	for _, review := range resp.GetReviews() {
+		reviews = append(reviews, reviewPayload(review))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task_id": req.GetTaskId(),
+		"reviews": reviews,
+	})
+}
+
+func reviewPayload(review *plagiarismpb.PairReview) map[string]any {
+	return map[string]any{
+		"id":                    review.GetId(),
+		"student_a":             review.GetStudentA(),
+		"student_b":             review.GetStudentB(),
+		"verdict":               review.GetVerdict(),
+		"comment":               review.GetComment(),
+		"reviewer_id":           review.GetReviewerId(),
+		"created_at":            review.GetCreatedAt().AsTime(),
+		"file_a_handed_over_at": review.GetFileAHandedOverAt().AsTime(),
+		"file_b_handed_over_at": review.GetFileBHandedOverAt().AsTime(),
+		"outdated":              review.GetOutdated(),
+		"current":               review.GetCurrent(),
+	}
+}
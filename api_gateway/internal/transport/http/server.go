@@ -6,15 +6,20 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"mime"
 	"net/http"
+	"path"
+	"strings"
 	"time"
 
+	"api_gateway/internal/infrastructure/auth"
 	"api_gateway/internal/infrastructure/text_extractor"
 	"api_gateway/internal/infrastructure/wordcloud"
 
 	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
 	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
 	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -25,23 +30,86 @@ type Server struct {
 	analysisClient  plagiarismpb.PlagiarismClient
 	wordCloudClient *wordcloud.QuickChartClient
 	textExtractor   *text_extractor.TextExtractor
+	// verifier проверяет bearer-токены; nil - аутентификация выключена
+	verifier *auth.Verifier
+	// allowUnscopedTasks - задачи без курса и незаведённые задачи доступны любому преподавателю
+	allowUnscopedTasks bool
+	// rateLimits - бюджеты дорогих маршрутов; nil - без ограничений
+	rateLimits *RateLimits
 }
 
-func NewServer(logger *slog.Logger, port int, storage storagepb.StorageClient, analysis plagiarismpb.PlagiarismClient) *Server {
+func NewServer(logger *slog.Logger, port int, storage storagepb.StorageClient, analysis plagiarismpb.PlagiarismClient, verifier *auth.Verifier, allowUnscopedTasks bool, rateLimits *RateLimits) *Server {
 	s := &Server{
-		logger:          logger,
-		storageClient:   storage,
-		analysisClient:  analysis,
-		wordCloudClient: wordcloud.NewQuickChartClient(),
-		textExtractor:   text_extractor.NewTextExtractor(),
+		logger:             logger,
+		storageClient:      storage,
+		analysisClient:     analysis,
+		wordCloudClient:    wordcloud.NewQuickChartClient(),
+		textExtractor:      text_extractor.NewTextExtractor(),
+		verifier:           verifier,
+		allowUnscopedTasks: allowUnscopedTasks,
+		rateLimits:         rateLimits,
+	}
+
+	var budgets RateLimits
+	if rateLimits != nil {
+		budgets = *rateLimits
 	}
 
 	r := chi.NewRouter()
-	r.Post("/api/files", s.handleGenerateUploadURL)
+	r.Use(s.authenticate)
+
+	// загрузка и скачивание своей сдачи; для загрузки автор проверяется по телу запроса
+	r.With(s.rateLimit("upload", budgets.Upload)).Post("/api/files", s.handleGenerateUploadURL)
 	r.Post("/api/files/verify", s.handleVerifyFile)
-	r.Get("/api/files/{task_id}/{student_id}/download", s.handleDownloadURL)
-	r.Post("/api/analysis/{task_id}", s.handleAnalyze)
-	r.Get("/api/files/{task_id}/{student_id}/wordcloud", s.handleWordCloud)
+	r.With(s.rateLimit("upload", budgets.Upload)).Post("/api/submissions", s.handleUploadSubmission)
+	r.Group(func(r chi.Router) {
+		r.Use(s.requireSubmissionAccess)
+		r.Get("/api/files/{task_id}/{student_id}/download", s.handleDownloadURL)
+		r.Get("/api/files/{task_id}/{student_id}/versions", s.handleListFileVersions)
+		r.Get("/api/files/{task_id}/{student_id}/versions/{version}/download", s.handleVersionDownloadURL)
+		r.With(s.rateLimit("wordcloud", budgets.WordCloud)).Get("/api/files/{task_id}/{student_id}/wordcloud", s.handleWordCloud)
+	})
+
+	// анализ и управление задачей - только преподаватели курса задачи
+	r.Group(func(r chi.Router) {
+		r.Use(s.requireTaskTeacher)
+		r.With(s.rateLimit("analysis", budgets.Analysis)).Post("/api/analysis/{task_id}", s.handleAnalyze)
+		r.Get("/api/analysis/{task_id}", s.handleGetReport)
+		r.Get("/api/analysis/{task_id}/events", s.handleAnalysisEvents)
+		r.Get("/api/analysis/{task_id}/matrix", s.handleSimilarityMatrix)
+		r.Get("/api/analysis/{task_id}/groups", s.handleSuspiciousGroups)
+		r.Get("/api/analysis/{task_id}/graph", s.handleSimilarityGraph)
+		r.Get("/api/analysis/{task_id}/pairs/{student_a}/{student_b}/view", s.handlePairView)
+		r.Post("/api/analysis/{task_id}/pairs/{student_a}/{student_b}/reviews", s.handleReviewPair)
+		r.Get("/api/analysis/{task_id}/pairs/{student_a}/{student_b}/reviews", s.handleListPairReviews)
+		r.Get("/api/analysis/{task_id}/reviews", s.handleListTaskReviews)
+		r.Get("/api/analysis/{task_id}/appeals", s.handleListAppeals)
+		r.Post("/api/tasks/{task_id}/groups", s.handleCreateGroup)
+		r.Get("/api/tasks/{task_id}/groups", s.handleListTaskGroups)
+		r.Get("/api/tasks/{task_id}/files", s.handleListTaskFiles)
+		r.Put("/api/tasks/{task_id}", s.handleUpdateTask)
+		r.Delete("/api/tasks/{task_id}", s.handleDeleteTask)
+		r.Put("/api/tasks/{task_id}/groups/{group_id}", s.handleUpdateGroupMembers)
+		r.Delete("/api/tasks/{task_id}/groups/{group_id}", s.handleDeleteGroup)
+	})
+
+	// апелляции и задания анализа проверяют доступ после чтения задачи, к которой относятся
+	r.Post("/api/analysis/{task_id}/appeals", s.handleCreateAppeal)
+	r.Get("/api/appeals/{appeal_id}", s.handleGetAppeal)
+	r.With(s.rateLimit("upload", budgets.Upload)).Post("/api/appeals/{appeal_id}/attachments", s.handleAddAppealAttachment)
+	r.Post("/api/appeals/{appeal_id}/attachments/{attachment_id}/verify", s.handleVerifyAppealAttachment)
+	r.Post("/api/appeals/{appeal_id}/resolve", s.handleResolveAppeal)
+	r.Get("/api/analysis/jobs/{job_id}", s.handleGetAnalysisJob)
+	r.Delete("/api/analysis/jobs/{job_id}", s.handleCancelAnalysisJob)
+
+	r.Get("/api/tasks", s.handleListTasks)
+	r.Get("/api/tasks/{task_id}", s.handleGetTask)
+	r.With(requireRole(auth.RoleTeacher, auth.RoleAdmin)).Post("/api/tasks", s.handleCreateTask)
+
+	r.With(requireRole(auth.RoleAdmin)).Post("/api/courses", s.handleCreateCourse)
+	r.Get("/api/courses/{course_id}", s.handleGetCourse)
+	r.Post("/api/courses/{course_id}/roster", s.handleImportCourseRoster)
+	r.Delete("/api/courses/{course_id}/members/{user_id}", s.handleRemoveCourseMember)
 
 	s.httpServer = &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
@@ -66,6 +134,11 @@ func (s *Server) handleGenerateUploadURL(w http.ResponseWriter, r *http.Request)
 	type generateUploadRequest struct {
 		TaskID    string `json:"task_id"`
 		StudentID string `json:"student_id"`
+		// FileName - имя файла в сдаче из нескольких файлов, пустое для основного файла
+		FileName string `json:"file_name"`
+		// OriginalName и ContentType - исходное имя и тип файла, под этим именем его потом скачивают
+		OriginalName string `json:"original_name"`
+		ContentType  string `json:"content_type"`
 	}
 
 	var req generateUploadRequest
@@ -79,25 +152,45 @@ func (s *Server) handleGenerateUploadURL(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if !s.authorizeSubmission(w, r, req.TaskID, req.StudentID) {
+		return
+	}
+
 	ctx := r.Context()
 	resp, err := s.storageClient.GenerateUploadURL(ctx, &storagepb.GenerateUploadURLRequest{
-		StudentId: req.StudentID,
-		TaskId:    req.TaskID,
+		StudentId:    req.StudentID,
+		TaskId:       req.TaskID,
+		FileName:     req.FileName,
+		OriginalName: req.OriginalName,
+		ContentType:  req.ContentType,
 	})
 	if err != nil {
-		writeGrpcError(w, err)
+		s.writeUploadError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{
-		"upload_url": resp.GetUrl(),
-	})
+	payload := map[string]any{
+		"upload_url":    resp.GetUrl(),
+		"upload_fields": resp.GetFields(),
+	}
+	if req.FileName != "" {
+		payload["file_name"] = req.FileName
+	}
+	if req.OriginalName != "" {
+		payload["original_name"] = req.OriginalName
+	}
+	if resp.GetGroupId() != "" {
+		payload["group_id"] = resp.GetGroupId()
+	}
+
+	writeJSON(w, http.StatusOK, payload)
 }
 
 func (s *Server) handleVerifyFile(w http.ResponseWriter, r *http.Request) {
 	type verifyRequest struct {
 		TaskID    string `json:"task_id"`
 		StudentID string `json:"student_id"`
+		FileName  string `json:"file_name"`
 	}
 
 	var req verifyRequest
@@ -111,19 +204,54 @@ func (s *Server) handleVerifyFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.authorizeSubmission(w, r, req.TaskID, req.StudentID) {
+		return
+	}
+
 	ctx := r.Context()
 	resp, err := s.storageClient.VerifyUploadedFile(ctx, &storagepb.VerifyUploadedFileRequest{
 		StudentId: req.StudentID,
 		TaskId:    req.TaskID,
+		FileName:  req.FileName,
 	})
 	if err != nil {
-		writeGrpcError(w, err)
+		s.writeUploadError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{
+	payload := map[string]any{
 		"file_id": resp.GetFileId(),
-	})
+		"version": fileVersionPayload(req.TaskID, req.StudentID, resp.GetVersion()),
+	}
+	addDuplicates(r, payload, resp.GetDuplicates())
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+// addDuplicates отмечает в ответе на загрузку, совпал ли файл байт в байт с чужой сдачей.
+// Сами совпавшие сдачи перечисляются только преподавателям и администраторам: студенту они
+// раскрыли бы чужих авторов и позволили бы проверять, сдавал ли кто-то такое же содержимое.
+func addDuplicates(r *http.Request, payload map[string]any, duplicates []*storagepb.DuplicateFile) {
+	payload["exact_duplicate"] = len(duplicates) > 0
+
+	identity := auth.FromContext(r.Context())
+	if identity == nil || identity.Role != auth.RoleStudent {
+		payload["duplicates"] = duplicatesPayload(duplicates)
+	}
+}
+
+func duplicatesPayload(duplicates []*storagepb.DuplicateFile) []map[string]any {
+	result := make([]map[string]any, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		result = append(result, map[string]any{
+			"student_id": duplicate.GetStudentId(),
+			"task_id":    duplicate.GetTaskId(),
+			"file_name":  duplicate.GetFileName(),
+			"version":    duplicate.GetVersion(),
+			"created_at": duplicate.GetCreatedAt().AsTime(),
+		})
+	}
+	return result
 }
 
 func (s *Server) handleDownloadURL(w http.ResponseWriter, r *http.Request) {
@@ -138,10 +266,11 @@ func (s *Server) handleDownloadURL(w http.ResponseWriter, r *http.Request) {
 	resp, err := s.storageClient.GenerateDownloadURL(ctx, &storagepb.GenerateDownloadURLRequest{
 		StudentId:  studentID,
 		TaskId:     taskID,
+		FileName:   r.URL.Query().Get("file_name"),
 		FromInside: false,
 	})
 	if err != nil {
-		writeGrpcError(w, err)
+		s.writeGrpcError(w, err)
 		return
 	}
 
@@ -157,20 +286,60 @@ func (s *Server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ctx := r.Context()
+	resp, err := s.analysisClient.StartAnalysis(ctx, &plagiarismpb.StartAnalysisRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	jobURL := "/api/analysis/jobs/" + resp.GetJobId()
+
+	w.Header().Set("Location", jobURL)
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"task_id":    taskID,
+		"job_id":     resp.GetJobId(),
+		"status_url": jobURL,
+		"report_url": "/api/analysis/" + taskID,
+		"attached":   resp.GetAttached(),
+	})
+}
+
+func (s *Server) handleGetReport(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	format, ok := reportFormat(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "format must be json, csv, xlsx or pdf")
+		return
+	}
+	if format != "json" {
+		s.exportReport(w, r, taskID, format)
+		return
+	}
+
 	ctx := r.Context()
 	resp, err := s.analysisClient.GetPlagiarismReport(ctx, &plagiarismpb.GetPlagiarismReportRequest{
 		TaskId: taskID,
 	})
 	if err != nil {
-		writeGrpcError(w, err)
+		s.writeGrpcError(w, err)
 		return
 	}
 
 	type report struct {
-		Student                string    `json:"student"`
-		StudentWithSimilarFile string    `json:"student_with_similar_file"`
-		MaxSimilarity          float64   `json:"max_similarity"`
-		FileHandedOverAt       time.Time `json:"file_handed_over_at,omitempty"`
+		Student                string         `json:"student"`
+		StudentWithSimilarFile string         `json:"student_with_similar_file"`
+		MaxSimilarity          float64        `json:"max_similarity"`
+		FileHandedOverAt       time.Time      `json:"file_handed_over_at,omitempty"`
+		FileVersion            int32          `json:"file_version"`
+		Verdict                map[string]any `json:"verdict,omitempty"`
 	}
 
 	var reports []report
@@ -179,19 +348,119 @@ func (s *Server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
 		if rep.GetFileHandedOverAt() != nil {
 			handed = rep.GetFileHandedOverAt().AsTime()
 		}
-		reports = append(reports, report{
+		item := report{
 			Student:                rep.GetStudent(),
 			StudentWithSimilarFile: rep.GetStudentWithSimilarFile(),
 			MaxSimilarity:          rep.GetMaxSimilarity(),
 			FileHandedOverAt:       handed,
-		})
+			FileVersion:            rep.GetFileVersion(),
+		}
+		if rep.GetVerdict() != nil {
+			item.Verdict = reviewPayload(rep.GetVerdict())
+		}
+		reports = append(reports, item)
+	}
+
+	missing, err := s.missingStudents(ctx, taskID)
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+	if missing == nil {
+		missing = []string{}
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
-		"task_id":    taskID,
-		"started_at": resp.GetStartedAt().AsTime(),
-		"reports":    reports,
+		"task_id":          taskID,
+		"started_at":       resp.GetStartedAt().AsTime(),
+		"reports":          reports,
+		"missing_students": missing,
+	})
+}
+
+func (s *Server) handleGetAnalysisJob(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "job_id")
+	if jobID == "" {
+		writeError(w, http.StatusBadRequest, "job_id is required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.analysisClient.GetAnalysisJob(ctx, &plagiarismpb.GetAnalysisJobRequest{
+		JobId: jobID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	if !s.authorizeTaskTeacher(w, r, resp.GetJob().GetTaskId()) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, analysisJobPayload(resp.GetJob()))
+}
+
+func (s *Server) handleCancelAnalysisJob(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "job_id")
+	if jobID == "" {
+		writeError(w, http.StatusBadRequest, "job_id is required")
+		return
+	}
+
+	ctx := r.Context()
+	jobResp, err := s.analysisClient.GetAnalysisJob(ctx, &plagiarismpb.GetAnalysisJobRequest{
+		JobId: jobID,
 	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	if !s.authorizeTaskTeacher(w, r, jobResp.GetJob().GetTaskId()) {
+		return
+	}
+
+	resp, err := s.analysisClient.CancelAnalysisJob(ctx, &plagiarismpb.CancelAnalysisJobRequest{
+		JobId: jobID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, analysisJobPayload(resp.GetJob()))
+}
+
+func analysisJobPayload(job *plagiarismpb.AnalysisJob) map[string]any {
+	payload := map[string]any{
+		"job_id":           job.GetJobId(),
+		"task_id":          job.GetTaskId(),
+		"state":            job.GetState(),
+		"progress_done":    job.GetProgressDone(),
+		"progress_total":   job.GetProgressTotal(),
+		"files_extracted":  job.GetFilesExtracted(),
+		"files_total":      job.GetFilesTotal(),
+		"suspicious_pairs": job.GetSuspiciousPairs(),
+		"attempts":         job.GetAttempts(),
+		"max_attempts":     job.GetMaxAttempts(),
+		"created_at":       job.GetCreatedAt().AsTime(),
+	}
+
+	if job.GetError() != "" {
+		payload["error"] = job.GetError()
+	}
+	if job.GetStartedAt() != nil {
+		payload["started_at"] = job.GetStartedAt().AsTime()
+	}
+	if job.GetFinishedAt() != nil {
+		payload["finished_at"] = job.GetFinishedAt().AsTime()
+	}
+	if job.GetNextRunAt() != nil {
+		payload["next_run_at"] = job.GetNextRunAt().AsTime()
+	}
+
+	return payload
 }
 
 func (s *Server) handleWordCloud(w http.ResponseWriter, r *http.Request) {
@@ -211,17 +480,13 @@ func (s *Server) handleWordCloud(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	downloadResp, err := s.storageClient.GenerateDownloadURL(ctx, &storagepb.GenerateDownloadURLRequest{
-		StudentId:  studentID,
-		TaskId:     taskID,
-		FromInside: true,
-	})
+	urls, originalName, err := s.submissionDownloadURLs(ctx, taskID, studentID)
 	if err != nil {
-		writeGrpcError(w, err)
+		s.writeGrpcError(w, err)
 		return
 	}
 
-	text, err := s.textExtractor.ExtractFromURL(downloadResp.GetUrl())
+	text, err := extractSubmission(urls, s.textExtractor.ExtractFromURL)
 	if err != nil {
 		s.logger.Error("failed to extract text from file", "error", err, "task_id", taskID, "student_id", studentID)
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to extract text from file: %v", err))
@@ -253,8 +518,13 @@ func (s *Server) handleWordCloud(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fileName := fmt.Sprintf("wordcloud_%s_%s.%s", taskID, studentID, format)
+	if originalName != "" {
+		fileName = fmt.Sprintf("wordcloud_%s.%s", strings.TrimSuffix(originalName, path.Ext(originalName)), format)
+	}
+
 	w.Header().Set("Content-Type", contentType)
-	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"wordcloud_%s_%s.%s\"", taskID, studentID, format))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": fileName}))
 	w.WriteHeader(http.StatusOK)
 	w.Write(imageData)
 }
@@ -270,19 +540,24 @@ func writeJSON(w http.ResponseWriter, code int, payload any) {
 	_ = json.NewEncoder(w).Encode(payload)
 }
 
-func writeGrpcError(w http.ResponseWriter, err error) {
+// writeGrpcError переводит ошибку вызова сервиса в HTTP-статус. Отказ в доступе от сервиса означает,
+// что ACL или сертификаты mTLS гейтвея настроены неверно: клиент тут ни при чём, поэтому это 502 с записью в лог.
+func (s *Server) writeGrpcError(w http.ResponseWriter, err error) {
 	st, ok := status.FromError(err)
 	if !ok {
 		writeError(w, http.StatusInternalServerError, "internal error")
 		return
 	}
 	switch st.Code() {
-	case 3:
+	case codes.InvalidArgument:
 		writeError(w, http.StatusBadRequest, st.Message())
-	case 5:
+	case codes.NotFound:
 		writeError(w, http.StatusNotFound, st.Message())
-	case 9, 10, 11, 14:
+	case codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.Unavailable:
 		writeError(w, http.StatusBadGateway, st.Message())
+	case codes.PermissionDenied, codes.Unauthenticated:
+		s.logger.Error("service rejected the gateway", "code", st.Code().String(), "error", st.Message())
+		writeError(w, http.StatusBadGateway, "upstream service rejected the gateway")
 	default:
 		writeError(w, http.StatusInternalServerError, st.Message())
 	}
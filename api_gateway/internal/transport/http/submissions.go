@@ -0,0 +1,178 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	plagiarismtext "github.com/Nikita-Smirnov-idk/plagiarism-service/pkg/text_extractor"
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+)
+
+// handleListTaskFiles возвращает сдачи задачи по авторам вместе со списком файлов каждой сдачи.
+func (s *Server) handleListTaskFiles(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.ListTaskFiles(ctx, &storagepb.ListTaskFilesRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	submissions := make([]map[string]any, 0, len(resp.GetItems()))
+	for _, item := range resp.GetItems() {
+		files := make([]map[string]any, 0, len(item.GetFiles()))
+		for _, file := range item.GetFiles() {
+			payload := fileVersionPayload(taskID, item.GetStudentId(), file)
+			payload["exact_duplicate"] = file.GetExactDuplicate()
+			if file.GetExactDuplicate() {
+				payload["duplicates"] = duplicatesPayload(file.GetDuplicates())
+			}
+			files = append(files, payload)
+		}
+
+		submission := map[string]any{
+			"student_id":      item.GetStudentId(),
+			"status":          item.GetStatus(),
+			"updated_at":      item.GetUpdatedAt().AsTime(),
+			"exact_duplicate": item.GetExactDuplicate(),
+			"original_name":   item.GetOriginalName(),
+			"content_type":    item.GetContentType(),
+			"mime_type":       item.GetMimeType(),
+			"etag":            item.GetEtag(),
+			"late":            item.GetLate(),
+			"files":           files,
+			"validation":      validationPayload(item.GetValidation()),
+		}
+		if len(item.GetMemberIds()) > 0 {
+			submission["member_ids"] = item.GetMemberIds()
+			submission["uploaded_by"] = item.GetUploadedBy()
+		}
+		submissions = append(submissions, submission)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task_id":     taskID,
+		"submissions": submissions,
+	})
+}
+
+// submissionDownloadURLs возвращает ссылки на последние версии всех файлов сдачи по порядку имён -
+// в том же порядке plagiarism-service склеивает их текст - и исходное имя сдачи: имя основного файла
+// или единственного файла сдачи, пустое если оно неизвестно. studentID может быть id группы или её участника.
+// Если сдачи нет среди загруженных, ошибку возвращает запрос основного файла.
+func (s *Server) submissionDownloadURLs(ctx context.Context, taskID, studentID string) ([]string, string, error) {
+	filesResp, err := s.storageClient.ListTaskFiles(ctx, &storagepb.ListTaskFilesRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var names []string
+	var originalName string
+	for _, item := range filesResp.GetItems() {
+		if item.GetStudentId() != studentID && !slices.Contains(item.GetMemberIds(), studentID) {
+			continue
+		}
+		for _, file := range item.GetFiles() {
+			names = append(names, file.GetName())
+		}
+		originalName = item.GetOriginalName()
+		if originalName == "" && len(item.GetFiles()) == 1 {
+			originalName = item.GetFiles()[0].GetOriginalName()
+		}
+	}
+	if len(names) == 0 {
+		names = []string{""}
+	}
+	slices.Sort(names)
+
+	urls := make([]string, 0, len(names))
+	for _, name := range names {
+		downloadResp, err := s.storageClient.GenerateDownloadURL(ctx, &storagepb.GenerateDownloadURLRequest{
+			StudentId:  studentID,
+			TaskId:     taskID,
+			FileName:   name,
+			FromInside: true,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		urls = append(urls, downloadResp.GetUrl())
+	}
+
+	return urls, originalName, nil
+}
+
+// analyzedSubmissionURLs возвращает ссылки на те версии файлов сдачи, на которых анализ считал пару, по порядку имён.
+// versions - версии файлов из отчёта пары по именам. Файл, которого нет в отчёте (он есть только в одной сдаче пары),
+// берётся в последней версии, загруженной до начала анализа analyzedAt; загруженные позже файлы в текст не входят.
+func (s *Server) analyzedSubmissionURLs(ctx context.Context, taskID, studentID string, versions map[string]int32, analyzedAt time.Time) ([]string, error) {
+	history, err := s.storageClient.ListFileVersions(ctx, &storagepb.ListFileVersionsRequest{
+		StudentId: studentID,
+		TaskId:    taskID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	analyzed := make(map[string]int32)
+	for _, version := range history.GetVersions() {
+		name := version.GetName()
+		if pinned := versions[name]; pinned > 0 {
+			analyzed[name] = pinned
+			continue
+		}
+		if !analyzedAt.IsZero() && version.GetCreatedAt().AsTime().After(analyzedAt) {
+			continue
+		}
+		analyzed[name] = max(analyzed[name], version.GetVersion())
+	}
+
+	names := make([]string, 0, len(analyzed))
+	for name := range analyzed {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	urls := make([]string, 0, len(names))
+	for _, name := range names {
+		downloadResp, err := s.storageClient.GenerateVersionDownloadURL(ctx, &storagepb.GenerateVersionDownloadURLRequest{
+			StudentId:  studentID,
+			TaskId:     taskID,
+			FileName:   name,
+			Version:    analyzed[name],
+			FromInside: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, downloadResp.GetUrl())
+	}
+
+	return urls, nil
+}
+
+// extractSubmission извлекает текст каждого файла сдачи и склеивает их так же, как plagiarism-service.
+func extractSubmission(urls []string, extract func(url string) (string, error)) (string, error) {
+	texts := make([]string, 0, len(urls))
+	for _, url := range urls {
+		text, err := extract(url)
+		if err != nil {
+			return "", err
+		}
+		texts = append(texts, text)
+	}
+
+	return plagiarismtext.JoinFiles(texts), nil
+}
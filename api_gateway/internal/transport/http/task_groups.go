@@ -0,0 +1,159 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handleCreateGroup создаёт группу, которая сдаёт одну работу на задачу.
+func (s *Server) handleCreateGroup(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	type groupRequest struct {
+		GroupID   string   `json:"group_id"`
+		MemberIDs []string `json:"member_ids"`
+	}
+
+	var req groupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	if req.GroupID == "" || len(req.MemberIDs) == 0 {
+		writeError(w, http.StatusBadRequest, "group_id and member_ids are required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.CreateGroup(ctx, &storagepb.CreateGroupRequest{
+		TaskId:    taskID,
+		GroupId:   req.GroupID,
+		MemberIds: req.MemberIDs,
+	})
+	if err != nil {
+		s.writeGroupError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, groupPayload(resp.GetGroup()))
+}
+
+// handleListTaskGroups возвращает группы задачи.
+func (s *Server) handleListTaskGroups(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.ListTaskGroups(ctx, &storagepb.ListTaskGroupsRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	groups := make([]map[string]any, 0, len(resp.GetGroups()))
+	for _, group := range resp.GetGroups() {
+		groups = append(groups, groupPayload(group))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task_id": taskID,
+		"groups":  groups,
+	})
+}
+
+// handleUpdateGroupMembers заменяет состав группы.
+func (s *Server) handleUpdateGroupMembers(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	groupID := chi.URLParam(r, "group_id")
+	if taskID == "" || groupID == "" {
+		writeError(w, http.StatusBadRequest, "task_id and group_id are required")
+		return
+	}
+
+	type membersRequest struct {
+		MemberIDs []string `json:"member_ids"`
+	}
+
+	var req membersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	if len(req.MemberIDs) == 0 {
+		writeError(w, http.StatusBadRequest, "member_ids are required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.UpdateGroupMembers(ctx, &storagepb.UpdateGroupMembersRequest{
+		TaskId:    taskID,
+		GroupId:   groupID,
+		MemberIds: req.MemberIDs,
+	})
+	if err != nil {
+		s.writeGroupError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, groupPayload(resp.GetGroup()))
+}
+
+// handleDeleteGroup удаляет группу, которая ещё ничего не сдала.
+func (s *Server) handleDeleteGroup(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	groupID := chi.URLParam(r, "group_id")
+	if taskID == "" || groupID == "" {
+		writeError(w, http.StatusBadRequest, "task_id and group_id are required")
+		return
+	}
+
+	ctx := r.Context()
+	_, err := s.storageClient.DeleteGroup(ctx, &storagepb.DeleteGroupRequest{
+		TaskId:  taskID,
+		GroupId: groupID,
+	})
+	if err != nil {
+		s.writeGroupError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeGroupError отвечает 409 на занятые id и попытку удалить группу со сдачей.
+func (s *Server) writeGroupError(w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		writeError(w, http.StatusConflict, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		writeError(w, http.StatusForbidden, status.Convert(err).Message())
+	default:
+		s.writeGrpcError(w, err)
+	}
+}
+
+func groupPayload(group *storagepb.GroupInfo) map[string]any {
+	return map[string]any{
+		"group_id":   group.GetGroupId(),
+		"task_id":    group.GetTaskId(),
+		"member_ids": group.GetMemberIds(),
+		"created_at": group.GetCreatedAt().AsTime(),
+		"submitted":  group.GetSubmitted(),
+	}
+}
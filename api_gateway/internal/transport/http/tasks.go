@@ -0,0 +1,231 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// taskRequest - настройки задачи в запросах создания и изменения; пустые opens_at и deadline - без ограничения.
+type taskRequest struct {
+	TaskID       string     `json:"task_id"`
+	Title        string     `json:"title"`
+	CourseID     string     `json:"course_id"`
+	OpensAt      *time.Time `json:"opens_at"`
+	Deadline     *time.Time `json:"deadline"`
+	LatePolicy   string     `json:"late_policy"`
+	AllowedTypes []string   `json:"allowed_types"`
+	// MaxFileSize - предельный размер файла в байтах, 0 - 500 МБ; AllowedMimeTypes - допустимые типы по содержимому
+	MaxFileSize      int64    `json:"max_file_size"`
+	AllowedMimeTypes []string `json:"allowed_mime_types"`
+}
+
+func (req *taskRequest) settings() *storagepb.TaskSettings {
+	settings := &storagepb.TaskSettings{
+		Title:        req.Title,
+		CourseId:     req.CourseID,
+		LatePolicy:   req.LatePolicy,
+		AllowedTypes: req.AllowedTypes,
+
+		MaxFileSize:      req.MaxFileSize,
+		AllowedMimeTypes: req.AllowedMimeTypes,
+	}
+	if req.OpensAt != nil {
+		settings.OpensAt = timestamppb.New(*req.OpensAt)
+	}
+	if req.Deadline != nil {
+		settings.Deadline = timestamppb.New(*req.Deadline)
+	}
+	return settings
+}
+
+// handleCreateTask заводит задачу со сроками сдачи и ограничениями на файлы.
+func (s *Server) handleCreateTask(w http.ResponseWriter, r *http.Request) {
+	var req taskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	if req.TaskID == "" || req.Title == "" {
+		writeError(w, http.StatusBadRequest, "task_id and title are required")
+		return
+	}
+
+	if !s.authorizeTaskCourse(w, r, req.CourseID) {
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.CreateTask(ctx, &storagepb.CreateTaskRequest{
+		TaskId:   req.TaskID,
+		Settings: req.settings(),
+	})
+	if err != nil {
+		s.writeTaskError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, taskPayload(resp.GetTask()))
+}
+
+// handleListTasks возвращает задачи, параметр course_id оставляет задачи одного курса.
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resp, err := s.storageClient.ListTasks(ctx, &storagepb.ListTasksRequest{
+		CourseId: r.URL.Query().Get("course_id"),
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	tasks := make([]map[string]any, 0, len(resp.GetTasks()))
+	for _, task := range resp.GetTasks() {
+		tasks = append(tasks, taskPayload(task))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"tasks": tasks,
+	})
+}
+
+func (s *Server) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.GetTask(ctx, &storagepb.GetTaskRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, taskPayload(resp.GetTask()))
+}
+
+// handleUpdateTask заменяет настройки задачи целиком.
+func (s *Server) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	var req taskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json payload")
+		return
+	}
+
+	if req.Title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+
+	// перенести задачу можно только в курс, который пользователь тоже ведёт; убрать курс - только администратор
+	if !s.authorizeTaskCourse(w, r, req.CourseID) {
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.UpdateTask(ctx, &storagepb.UpdateTaskRequest{
+		TaskId:   taskID,
+		Settings: req.settings(),
+	})
+	if err != nil {
+		s.writeTaskError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, taskPayload(resp.GetTask()))
+}
+
+// handleDeleteTask удаляет настройки задачи; сдачи остаются.
+func (s *Server) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	if taskID == "" {
+		writeError(w, http.StatusBadRequest, "task_id is required")
+		return
+	}
+
+	ctx := r.Context()
+	_, err := s.storageClient.DeleteTask(ctx, &storagepb.DeleteTaskRequest{
+		TaskId: taskID,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTaskError отвечает 409 на уже заведённую задачу или курс.
+func (s *Server) writeTaskError(w http.ResponseWriter, err error) {
+	if status.Code(err) == codes.AlreadyExists {
+		writeError(w, http.StatusConflict, status.Convert(err).Message())
+		return
+	}
+	s.writeGrpcError(w, err)
+}
+
+// writeUploadError отвечает 403 на загрузку вне сроков задачи или студентом не из курса задачи
+// и 409 на повторное подтверждение той же загрузки.
+func (s *Server) writeUploadError(w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.FailedPrecondition, codes.PermissionDenied:
+		writeError(w, http.StatusForbidden, status.Convert(err).Message())
+	case codes.AlreadyExists:
+		writeError(w, http.StatusConflict, status.Convert(err).Message())
+	default:
+		s.writeGrpcError(w, err)
+	}
+}
+
+func taskPayload(task *storagepb.TaskInfo) map[string]any {
+	settings := task.GetSettings()
+
+	allowedTypes := settings.GetAllowedTypes()
+	if allowedTypes == nil {
+		allowedTypes = []string{}
+	}
+	allowedMimeTypes := settings.GetAllowedMimeTypes()
+	if allowedMimeTypes == nil {
+		allowedMimeTypes = []string{}
+	}
+
+	payload := map[string]any{
+		"task_id":       task.GetTaskId(),
+		"title":         settings.GetTitle(),
+		"course_id":     settings.GetCourseId(),
+		"opens_at":      nil,
+		"deadline":      nil,
+		"late_policy":   settings.GetLatePolicy(),
+		"allowed_types": allowedTypes,
+		"created_at":    task.GetCreatedAt().AsTime(),
+		"updated_at":    task.GetUpdatedAt().AsTime(),
+
+		"max_file_size":      settings.GetMaxFileSize(),
+		"allowed_mime_types": allowedMimeTypes,
+	}
+	if settings.GetOpensAt() != nil {
+		payload["opens_at"] = settings.GetOpensAt().AsTime()
+	}
+	if settings.GetDeadline() != nil {
+		payload["deadline"] = settings.GetDeadline().AsTime()
+	}
+
+	return payload
+}
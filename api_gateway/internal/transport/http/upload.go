@@ -0,0 +1,150 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+)
+
+const (
+	// maxSubmissionSize - предельный размер файла сдачи, как и у загрузки по ссылке.
+	maxSubmissionSize = 500_000_000
+	// maxFormOverhead - запас на поля формы и заголовки частей multipart.
+	maxFormOverhead = 1 << 20
+	// maxFormValueSize - предельная длина текстового поля формы.
+	maxFormValueSize = 1 << 10
+	// uploadChunkSize - размер куска файла в потоке к storage service.
+	uploadChunkSize = 64 << 10
+)
+
+// handleUploadSubmission принимает файл сдачи в multipart/form-data и передаёт его потоком
+// в storage service, который сохраняет и проверяет его за один вызов. Поля task_id, student_id
+// и необязательное file_name должны идти в форме до части file, чтобы файл не приходилось буферизовать.
+func (s *Server) handleUploadSubmission(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSubmissionSize+maxFormOverhead)
+
+	form, err := r.MultipartReader()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "multipart/form-data body is required")
+		return
+	}
+
+	fields := make(map[string]string)
+	for {
+		part, err := form.NextPart()
+		if errors.Is(err, io.EOF) {
+			writeError(w, http.StatusBadRequest, "file is required")
+			return
+		}
+		if err != nil {
+			writeBodyError(w, err, "invalid multipart body")
+			return
+		}
+
+		if part.FormName() != "file" {
+			value, err := io.ReadAll(io.LimitReader(part, maxFormValueSize+1))
+			if err != nil {
+				writeBodyError(w, err, "invalid multipart body")
+				return
+			}
+			if len(value) > maxFormValueSize {
+				writeError(w, http.StatusBadRequest, "form field "+part.FormName()+" is too long")
+				return
+			}
+			fields[part.FormName()] = strings.TrimSpace(string(value))
+			continue
+		}
+
+		taskID, studentID := fields["task_id"], fields["student_id"]
+		if taskID == "" || studentID == "" {
+			writeError(w, http.StatusBadRequest, "task_id and student_id are required before the file")
+			return
+		}
+
+		if !s.authorizeSubmission(w, r, taskID, studentID) {
+			return
+		}
+
+		s.streamSubmission(w, r, part, &storagepb.UploadFileMetadata{
+			StudentId:    studentID,
+			TaskId:       taskID,
+			FileName:     fields["file_name"],
+			OriginalName: part.FileName(),
+			ContentType:  part.Header.Get("Content-Type"),
+		})
+		return
+	}
+}
+
+// streamSubmission отправляет метаданные и содержимое файла в UploadFile. При ошибке чтения тела
+// поток отменяется, чтобы storage service не сохранил обрезанный файл.
+func (s *Server) streamSubmission(w http.ResponseWriter, r *http.Request, file io.Reader, meta *storagepb.UploadFileMetadata) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	stream, err := s.storageClient.UploadFile(ctx)
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	err = stream.Send(&storagepb.UploadFileRequest{
+		Payload: &storagepb.UploadFileRequest_Metadata{Metadata: meta},
+	})
+
+	for err == nil {
+		chunk := make([]byte, uploadChunkSize)
+		n, readErr := io.ReadFull(file, chunk)
+		if n > 0 {
+			err = stream.Send(&storagepb.UploadFileRequest{
+				Payload: &storagepb.UploadFileRequest_Chunk{Chunk: chunk[:n]},
+			})
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			cancel()
+			s.logger.Warn("failed to read uploaded file", "error", readErr)
+			writeBodyError(w, readErr, "failed to read file")
+			return
+		}
+	}
+
+	// при отказе storage service Send возвращает io.EOF, а саму ошибку отдаёт CloseAndRecv
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		s.writeUploadError(w, err)
+		return
+	}
+
+	payload := map[string]any{
+		"file_id": resp.GetFileId(),
+		"version": fileVersionPayload(meta.GetTaskId(), meta.GetStudentId(), resp.GetVersion()),
+	}
+	addDuplicates(r, payload, resp.GetDuplicates())
+	if resp.GetGroupId() != "" {
+		payload["group_id"] = resp.GetGroupId()
+	}
+
+	writeJSON(w, http.StatusCreated, payload)
+}
+
+// writeBodyError отвечает 413, если тело запроса превысило предел, и 400 с message в остальных случаях.
+func writeBodyError(w http.ResponseWriter, err error, message string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, "file is too large")
+		return
+	}
+	writeError(w, http.StatusBadRequest, message)
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+)
+
+// handleListFileVersions возвращает историю сдачи студента по задаче: все проверенные загрузки всех файлов сдачи
+// (или только файла file_name), от первой к последней. latest_version - последняя версия основного файла,
+// validation - проверка последней загрузки, по которой студент видит причину отказа (статус сдачи rejected).
+func (s *Server) handleListFileVersions(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	studentID := chi.URLParam(r, "student_id")
+	if taskID == "" || studentID == "" {
+		writeError(w, http.StatusBadRequest, "task_id and student_id are required")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.ListFileVersions(ctx, &storagepb.ListFileVersionsRequest{
+		StudentId: studentID,
+		TaskId:    taskID,
+		FileName:  r.URL.Query().Get("file_name"),
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	versions := make([]map[string]any, 0, len(resp.GetVersions()))
+	var latest int32
+	for _, version := range resp.GetVersions() {
+		versions = append(versions, fileVersionPayload(taskID, studentID, version))
+		if version.GetName() == "" {
+			latest = max(latest, version.GetVersion())
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"task_id":        taskID,
+		"student_id":     studentID,
+		"status":         resp.GetStatus(),
+		"latest_version": latest,
+		"versions":       versions,
+		"validation":     validationPayload(resp.GetValidation()),
+	})
+}
+
+// handleVersionDownloadURL выдаёт ссылку на скачивание конкретной версии работы.
+func (s *Server) handleVersionDownloadURL(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	studentID := chi.URLParam(r, "student_id")
+	if taskID == "" || studentID == "" {
+		writeError(w, http.StatusBadRequest, "task_id and student_id are required")
+		return
+	}
+
+	version, err := strconv.ParseInt(chi.URLParam(r, "version"), 10, 32)
+	if err != nil || version < 1 {
+		writeError(w, http.StatusBadRequest, "version must be a positive integer")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := s.storageClient.GenerateVersionDownloadURL(ctx, &storagepb.GenerateVersionDownloadURLRequest{
+		StudentId:  studentID,
+		TaskId:     taskID,
+		FileName:   r.URL.Query().Get("file_name"),
+		Version:    int32(version),
+		FromInside: false,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	payload := fileVersionPayload(taskID, studentID, resp.GetVersion())
+	payload["url"] = resp.GetUrl()
+	writeJSON(w, http.StatusOK, payload)
+}
+
+// validationPayload - результат проверки загрузки; nil, если проверки не было.
+func validationPayload(report *storagepb.ValidationReport) map[string]any {
+	if report == nil {
+		return nil
+	}
+
+	steps := make([]map[string]any, 0, len(report.GetSteps()))
+	for _, step := range report.GetSteps() {
+		payload := map[string]any{
+			"validator": step.GetValidator(),
+			"passed":    step.GetPassed(),
+		}
+		if step.GetReason() != "" {
+			payload["reason"] = step.GetReason()
+		}
+		steps = append(steps, payload)
+	}
+
+	return map[string]any{
+		"file_name":        report.GetFileName(),
+		"rejected":         report.GetRejectionReason() != "",
+		"rejection_reason": report.GetRejectionReason(),
+		"steps":            steps,
+		"checked_at":       report.GetCheckedAt().AsTime(),
+	}
+}
+
+func fileVersionPayload(taskID, studentID string, version *storagepb.FileVersion) map[string]any {
+	if version == nil {
+		return nil
+	}
+
+	downloadURL := fmt.Sprintf("/api/files/%s/%s/versions/%d/download", taskID, studentID, version.GetVersion())
+	if version.GetName() != "" {
+		downloadURL += "?file_name=" + url.QueryEscape(version.GetName())
+	}
+
+	return map[string]any{
+		"file_name":    version.GetName(),
+		"version":      version.GetVersion(),
+		"size":         version.GetSize(),
+		"sha256":       version.GetSha256(),
+		"uploaded_by":  version.GetUploadedBy(),
+		"created_at":   version.GetCreatedAt().AsTime(),
+		"download_url": downloadURL,
+
+		"original_name": version.GetOriginalName(),
+		"content_type":  version.GetContentType(),
+		"mime_type":     version.GetMimeType(),
+		"etag":          version.GetEtag(),
+		"late":          version.GetLate(),
+	}
+}
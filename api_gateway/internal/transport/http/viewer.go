@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"api_gateway/internal/infrastructure/pair_viewer"
+
+	plagiarismpb "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/go-chi/chi/v5"
+)
+
+// handlePairView показывает две работы рядом с подсветкой общих фрагментов.
+// Фрагменты берутся из последнего анализа, тексты - из тех версий файлов, на которых анализ считал пару,
+// чтобы повторная загрузка после анализа не сдвигала подсветку; файлы сдачи показываются подряд по порядку имён.
+func (s *Server) handlePairView(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task_id")
+	studentA := chi.URLParam(r, "student_a")
+	studentB := chi.URLParam(r, "student_b")
+	if taskID == "" || studentA == "" || studentB == "" {
+		writeError(w, http.StatusBadRequest, "task_id, student_a and student_b are required")
+		return
+	}
+
+	ctx := r.Context()
+	evidence, err := s.analysisClient.GetPairEvidence(ctx, &plagiarismpb.GetPairEvidenceRequest{
+		TaskId:   taskID,
+		StudentA: studentA,
+		StudentB: studentB,
+	})
+	if err != nil {
+		s.writeGrpcError(w, err)
+		return
+	}
+
+	pair := evidence.GetPair()
+	var analyzedAt time.Time
+	if evidence.GetStartedAt() != nil {
+		analyzedAt = evidence.GetStartedAt().AsTime()
+	}
+
+	versionsA := map[string]int32{"": pair.GetFileAVersion()}
+	versionsB := map[string]int32{"": pair.GetFileBVersion()}
+	for _, f := range pair.GetFiles() {
+		versionsA[f.GetFileName()] = f.GetFileAVersion()
+		versionsB[f.GetFileName()] = f.GetFileBVersion()
+	}
+
+	texts := make([]string, 0, 2)
+	for i, studentID := range []string{studentA, studentB} {
+		versions := versionsA
+		if i == 1 {
+			versions = versionsB
+		}
+
+		urls, err := s.analyzedSubmissionURLs(ctx, taskID, studentID, versions, analyzedAt)
+		if err != nil {
+			s.writeGrpcError(w, err)
+			return
+		}
+
+		text, err := extractSubmission(urls, s.textExtractor.ExtractRawFromURL)
+		if err != nil {
+			s.logger.Error("failed to extract text from file", "error", err, "task_id", taskID, "student_id", studentID)
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to extract text from file: %v", err))
+			return
+		}
+		texts = append(texts, text)
+	}
+
+	page := pair_viewer.Page{
+		TaskID:              taskID,
+		StudentA:            pair.GetStudentA(),
+		StudentB:            pair.GetStudentB(),
+		Similarity:          pair.GetSimilarity(),
+		PlagiarismThreshold: evidence.GetPlagiarismThreshold(),
+		TextA:               texts[0],
+		TextB:               texts[1],
+	}
+	page.AnalysisStartedAt = analyzedAt
+	if pair.GetFileAHandedOverAt() != nil {
+		page.FileAHandedOverAt = pair.GetFileAHandedOverAt().AsTime()
+	}
+	if pair.GetFileBHandedOverAt() != nil {
+		page.FileBHandedOverAt = pair.GetFileBHandedOverAt().AsTime()
+	}
+	for _, f := range pair.GetFiles() {
+		page.Files = append(page.Files, pair_viewer.FileSimilarity{
+			Name:       f.GetFileName(),
+			Similarity: f.GetSimilarity(),
+		})
+	}
+	for _, f := range pair.GetFragments() {
+		page.Fragments = append(page.Fragments, pair_viewer.Fragment{
+			AStart:  int(f.GetAStart()),
+			BStart:  int(f.GetBStart()),
+			Length:  int(f.GetLength()),
+			Excerpt: f.GetExcerpt(),
+		})
+	}
+
+	var body bytes.Buffer
+	if err = page.Render(&body); err != nil {
+		s.logger.Error("failed to render pair view", "error", err, "task_id", taskID)
+		writeError(w, http.StatusInternalServerError, "failed to render pair view")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
@@ -32,6 +32,7 @@ func main() {
 	}
 
 	go application.GRPCSrv.MustRun()
+	go application.Worker.MustRun()
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
@@ -41,6 +42,7 @@ func main() {
 	log.Info("stopping application", slog.String("signal", sign.String()))
 
 	application.GRPCSrv.Stop()
+	application.Worker.Stop()
 
 	log.Info("application stopped")
 }
@@ -127,8 +127,12 @@ type PlagiarismReport struct {
 	StudentWithSimilarFile string                 `protobuf:"bytes,2,opt,name=StudentWithSimilarFile,proto3" json:"StudentWithSimilarFile,omitempty"`
 	MaxSimilarity          float64                `protobuf:"fixed64,3,opt,name=MaxSimilarity,proto3" json:"MaxSimilarity,omitempty"`
 	FileHandedOverAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=FileHandedOverAt,proto3" json:"FileHandedOverAt,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// Verdict in force for the pair Student / StudentWithSimilarFile, if any
+	Verdict *PairReview `protobuf:"bytes,5,opt,name=Verdict,proto3" json:"Verdict,omitempty"`
+	// Version of Student's submission in the storage service the report was computed on
+	FileVersion   int32 `protobuf:"varint,6,opt,name=FileVersion,proto3" json:"FileVersion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PlagiarismReport) Reset() {
@@ -189,24 +193,2979 @@ func (x *PlagiarismReport) GetFileHandedOverAt() *timestamppb.Timestamp {
 	return nil
 }
 
-var File_antiplagiat_proto protoreflect.FileDescriptor
+func (x *PlagiarismReport) GetVerdict() *PairReview {
+	if x != nil {
+		return x.Verdict
+	}
+	return nil
+}
 
-const file_antiplagiat_proto_rawDesc = "" +
-	"\n" +
-	"\x11antiplagiat.proto\x12\astorage\x1a\x1fgoogle/protobuf/timestamp.proto\"4\n" +
-	"\x1aGetPlagiarismReportRequest\x12\x16\n" +
-	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"\x8c\x01\n" +
-	"\x1bGetPlagiarismReportResponse\x123\n" +
-	"\aReports\x18\x01 \x03(\v2\x19.storage.PlagiarismReportR\aReports\x128\n" +
-	"\tStartedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tStartedAt\"\xd2\x01\n" +
-	"\x10PlagiarismReport\x12\x18\n" +
-	"\aStudent\x18\x01 \x01(\tR\aStudent\x126\n" +
-	"\x16StudentWithSimilarFile\x18\x02 \x01(\tR\x16StudentWithSimilarFile\x12$\n" +
-	"\rMaxSimilarity\x18\x03 \x01(\x01R\rMaxSimilarity\x12F\n" +
-	"\x10FileHandedOverAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x10FileHandedOverAt2p\n" +
-	"\n" +
-	"Plagiarism\x12b\n" +
-	"\x13GetPlagiarismReport\x12#.storage.GetPlagiarismReportRequest\x1a$.storage.GetPlagiarismReportResponse\"\x00BPZNgithub.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go;plagiarismpbb\x06proto3"
+func (x *PlagiarismReport) GetFileVersion() int32 {
+	if x != nil {
+		return x.FileVersion
+	}
+	return 0
+}
+
+// Request for starting analysis
+type StartAnalysisRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAnalysisRequest) Reset() {
+	*x = StartAnalysisRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAnalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAnalysisRequest) ProtoMessage() {}
+
+func (x *StartAnalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAnalysisRequest.ProtoReflect.Descriptor instead.
+func (*StartAnalysisRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StartAnalysisRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Response with id of the started job
+type StartAnalysisResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=JobId,proto3" json:"JobId,omitempty"`
+	// True if the task already had a queued or running job and its id is returned instead of a new one
+	Attached      bool `protobuf:"varint,2,opt,name=Attached,proto3" json:"Attached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAnalysisResponse) Reset() {
+	*x = StartAnalysisResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAnalysisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAnalysisResponse) ProtoMessage() {}
+
+func (x *StartAnalysisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAnalysisResponse.ProtoReflect.Descriptor instead.
+func (*StartAnalysisResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StartAnalysisResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *StartAnalysisResponse) GetAttached() bool {
+	if x != nil {
+		return x.Attached
+	}
+	return false
+}
+
+// Request for analysis job state
+type GetAnalysisJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=JobId,proto3" json:"JobId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnalysisJobRequest) Reset() {
+	*x = GetAnalysisJobRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnalysisJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnalysisJobRequest) ProtoMessage() {}
+
+func (x *GetAnalysisJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnalysisJobRequest.ProtoReflect.Descriptor instead.
+func (*GetAnalysisJobRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAnalysisJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Response with analysis job state
+type GetAnalysisJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *AnalysisJob           `protobuf:"bytes,1,opt,name=Job,proto3" json:"Job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnalysisJobResponse) Reset() {
+	*x = GetAnalysisJobResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnalysisJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnalysisJobResponse) ProtoMessage() {}
+
+func (x *GetAnalysisJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnalysisJobResponse.ProtoReflect.Descriptor instead.
+func (*GetAnalysisJobResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAnalysisJobResponse) GetJob() *AnalysisJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// Request for cancelling analysis job
+type CancelAnalysisJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=JobId,proto3" json:"JobId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAnalysisJobRequest) Reset() {
+	*x = CancelAnalysisJobRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAnalysisJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAnalysisJobRequest) ProtoMessage() {}
+
+func (x *CancelAnalysisJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAnalysisJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelAnalysisJobRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelAnalysisJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Response with analysis job state after cancellation
+type CancelAnalysisJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *AnalysisJob           `protobuf:"bytes,1,opt,name=Job,proto3" json:"Job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAnalysisJobResponse) Reset() {
+	*x = CancelAnalysisJobResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAnalysisJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAnalysisJobResponse) ProtoMessage() {}
+
+func (x *CancelAnalysisJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAnalysisJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelAnalysisJobResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelAnalysisJobResponse) GetJob() *AnalysisJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// Asynchronous analysis job
+type AnalysisJob struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	JobId  string                 `protobuf:"bytes,1,opt,name=JobId,proto3" json:"JobId,omitempty"`
+	TaskId string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// queued, running, succeeded, failed, cancelled or dead (retries exhausted)
+	State         string                 `protobuf:"bytes,3,opt,name=State,proto3" json:"State,omitempty"`
+	ProgressDone  int32                  `protobuf:"varint,4,opt,name=ProgressDone,proto3" json:"ProgressDone,omitempty"`
+	ProgressTotal int32                  `protobuf:"varint,5,opt,name=ProgressTotal,proto3" json:"ProgressTotal,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=Error,proto3" json:"Error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=StartedAt,proto3" json:"StartedAt,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=FinishedAt,proto3" json:"FinishedAt,omitempty"`
+	Attempts      int32                  `protobuf:"varint,10,opt,name=Attempts,proto3" json:"Attempts,omitempty"`
+	MaxAttempts   int32                  `protobuf:"varint,11,opt,name=MaxAttempts,proto3" json:"MaxAttempts,omitempty"`
+	// Time of the next attempt for a queued job
+	NextRunAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=NextRunAt,proto3" json:"NextRunAt,omitempty"`
+	// Files downloaded and parsed by the current attempt
+	FilesExtracted int32 `protobuf:"varint,13,opt,name=FilesExtracted,proto3" json:"FilesExtracted,omitempty"`
+	FilesTotal     int32 `protobuf:"varint,14,opt,name=FilesTotal,proto3" json:"FilesTotal,omitempty"`
+	// Pairs above the plagiarism threshold found by the current attempt
+	SuspiciousPairs int32 `protobuf:"varint,15,opt,name=SuspiciousPairs,proto3" json:"SuspiciousPairs,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AnalysisJob) Reset() {
+	*x = AnalysisJob{}
+	mi := &file_antiplagiat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisJob) ProtoMessage() {}
+
+func (x *AnalysisJob) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisJob.ProtoReflect.Descriptor instead.
+func (*AnalysisJob) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AnalysisJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *AnalysisJob) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *AnalysisJob) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *AnalysisJob) GetProgressDone() int32 {
+	if x != nil {
+		return x.ProgressDone
+	}
+	return 0
+}
+
+func (x *AnalysisJob) GetProgressTotal() int32 {
+	if x != nil {
+		return x.ProgressTotal
+	}
+	return 0
+}
+
+func (x *AnalysisJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AnalysisJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AnalysisJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *AnalysisJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *AnalysisJob) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *AnalysisJob) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *AnalysisJob) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *AnalysisJob) GetFilesExtracted() int32 {
+	if x != nil {
+		return x.FilesExtracted
+	}
+	return 0
+}
+
+func (x *AnalysisJob) GetFilesTotal() int32 {
+	if x != nil {
+		return x.FilesTotal
+	}
+	return 0
+}
+
+func (x *AnalysisJob) GetSuspiciousPairs() int32 {
+	if x != nil {
+		return x.SuspiciousPairs
+	}
+	return 0
+}
+
+// Request for watching analysis progress
+type WatchAnalysisRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// Optional: the latest job of the task is watched if empty
+	JobId         string `protobuf:"bytes,2,opt,name=JobId,proto3" json:"JobId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAnalysisRequest) Reset() {
+	*x = WatchAnalysisRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAnalysisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAnalysisRequest) ProtoMessage() {}
+
+func (x *WatchAnalysisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAnalysisRequest.ProtoReflect.Descriptor instead.
+func (*WatchAnalysisRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchAnalysisRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *WatchAnalysisRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Analysis progress event
+type AnalysisEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Job   *AnalysisJob           `protobuf:"bytes,1,opt,name=Job,proto3" json:"Job,omitempty"`
+	// Suspicious pairs found since the previous event
+	NewSuspiciousPairs []*SuspiciousPair `protobuf:"bytes,2,rep,name=NewSuspiciousPairs,proto3" json:"NewSuspiciousPairs,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AnalysisEvent) Reset() {
+	*x = AnalysisEvent{}
+	mi := &file_antiplagiat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalysisEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalysisEvent) ProtoMessage() {}
+
+func (x *AnalysisEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalysisEvent.ProtoReflect.Descriptor instead.
+func (*AnalysisEvent) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AnalysisEvent) GetJob() *AnalysisJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *AnalysisEvent) GetNewSuspiciousPairs() []*SuspiciousPair {
+	if x != nil {
+		return x.NewSuspiciousPairs
+	}
+	return nil
+}
+
+// Pair of students with similarity above the plagiarism threshold
+type SuspiciousPair struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StudentA      string                 `protobuf:"bytes,1,opt,name=StudentA,proto3" json:"StudentA,omitempty"`
+	StudentB      string                 `protobuf:"bytes,2,opt,name=StudentB,proto3" json:"StudentB,omitempty"`
+	Similarity    float64                `protobuf:"fixed64,3,opt,name=Similarity,proto3" json:"Similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspiciousPair) Reset() {
+	*x = SuspiciousPair{}
+	mi := &file_antiplagiat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspiciousPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspiciousPair) ProtoMessage() {}
+
+func (x *SuspiciousPair) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspiciousPair.ProtoReflect.Descriptor instead.
+func (*SuspiciousPair) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SuspiciousPair) GetStudentA() string {
+	if x != nil {
+		return x.StudentA
+	}
+	return ""
+}
+
+func (x *SuspiciousPair) GetStudentB() string {
+	if x != nil {
+		return x.StudentB
+	}
+	return ""
+}
+
+func (x *SuspiciousPair) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+// Request for similarity matrix
+type GetSimilarityMatrixRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimilarityMatrixRequest) Reset() {
+	*x = GetSimilarityMatrixRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimilarityMatrixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimilarityMatrixRequest) ProtoMessage() {}
+
+func (x *GetSimilarityMatrixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimilarityMatrixRequest.ProtoReflect.Descriptor instead.
+func (*GetSimilarityMatrixRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetSimilarityMatrixRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Response with similarity matrix
+type GetSimilarityMatrixResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Students ordered by hierarchical clustering, similar works are adjacent
+	Students []string `protobuf:"bytes,1,rep,name=Students,proto3" json:"Students,omitempty"`
+	// Rows[i].Similarities[j] is the similarity of Students[i] and Students[j]
+	Rows      []*SimilarityRow       `protobuf:"bytes,2,rep,name=Rows,proto3" json:"Rows,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=StartedAt,proto3" json:"StartedAt,omitempty"`
+	// Similarity from which a pair is considered suspicious
+	PlagiarismThreshold float64 `protobuf:"fixed64,4,opt,name=PlagiarismThreshold,proto3" json:"PlagiarismThreshold,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetSimilarityMatrixResponse) Reset() {
+	*x = GetSimilarityMatrixResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimilarityMatrixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimilarityMatrixResponse) ProtoMessage() {}
+
+func (x *GetSimilarityMatrixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimilarityMatrixResponse.ProtoReflect.Descriptor instead.
+func (*GetSimilarityMatrixResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSimilarityMatrixResponse) GetStudents() []string {
+	if x != nil {
+		return x.Students
+	}
+	return nil
+}
+
+func (x *GetSimilarityMatrixResponse) GetRows() []*SimilarityRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *GetSimilarityMatrixResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetSimilarityMatrixResponse) GetPlagiarismThreshold() float64 {
+	if x != nil {
+		return x.PlagiarismThreshold
+	}
+	return 0
+}
+
+// Row of similarity matrix
+type SimilarityRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Similarities  []float64              `protobuf:"fixed64,1,rep,packed,name=Similarities,proto3" json:"Similarities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarityRow) Reset() {
+	*x = SimilarityRow{}
+	mi := &file_antiplagiat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarityRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarityRow) ProtoMessage() {}
+
+func (x *SimilarityRow) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarityRow.ProtoReflect.Descriptor instead.
+func (*SimilarityRow) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SimilarityRow) GetSimilarities() []float64 {
+	if x != nil {
+		return x.Similarities
+	}
+	return nil
+}
+
+// Request for suspicious groups
+type ListSuspiciousGroupsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// Optional: minimal similarity of a pair to link students, the plagiarism threshold if 0
+	MinSimilarity float64 `protobuf:"fixed64,2,opt,name=MinSimilarity,proto3" json:"MinSimilarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuspiciousGroupsRequest) Reset() {
+	*x = ListSuspiciousGroupsRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuspiciousGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuspiciousGroupsRequest) ProtoMessage() {}
+
+func (x *ListSuspiciousGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuspiciousGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListSuspiciousGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListSuspiciousGroupsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListSuspiciousGroupsRequest) GetMinSimilarity() float64 {
+	if x != nil {
+		return x.MinSimilarity
+	}
+	return 0
+}
+
+// Response with suspicious groups ordered by score
+type ListSuspiciousGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*SuspiciousGroup     `protobuf:"bytes,1,rep,name=Groups,proto3" json:"Groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuspiciousGroupsResponse) Reset() {
+	*x = ListSuspiciousGroupsResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuspiciousGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuspiciousGroupsResponse) ProtoMessage() {}
+
+func (x *ListSuspiciousGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuspiciousGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListSuspiciousGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSuspiciousGroupsResponse) GetGroups() []*SuspiciousGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// Connected component of the graph of suspicious pairs
+type SuspiciousGroup struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	GroupId string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	Stats   *GroupStats            `protobuf:"bytes,2,opt,name=Stats,proto3" json:"Stats,omitempty"`
+	// Dense communities inside the component, empty if the component is a single community
+	Communities   []*GroupStats `protobuf:"bytes,3,rep,name=Communities,proto3" json:"Communities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspiciousGroup) Reset() {
+	*x = SuspiciousGroup{}
+	mi := &file_antiplagiat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspiciousGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspiciousGroup) ProtoMessage() {}
+
+func (x *SuspiciousGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspiciousGroup.ProtoReflect.Descriptor instead.
+func (*SuspiciousGroup) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SuspiciousGroup) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *SuspiciousGroup) GetStats() *GroupStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *SuspiciousGroup) GetCommunities() []*GroupStats {
+	if x != nil {
+		return x.Communities
+	}
+	return nil
+}
+
+// Members and aggregated scores of a group
+type GroupStats struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Members []string               `protobuf:"bytes,1,rep,name=Members,proto3" json:"Members,omitempty"`
+	// Mean similarity of suspicious pairs inside the group
+	Score         float64 `protobuf:"fixed64,2,opt,name=Score,proto3" json:"Score,omitempty"`
+	MaxSimilarity float64 `protobuf:"fixed64,3,opt,name=MaxSimilarity,proto3" json:"MaxSimilarity,omitempty"`
+	// Share of suspicious pairs among all pairs of the group
+	Density float64 `protobuf:"fixed64,4,opt,name=Density,proto3" json:"Density,omitempty"`
+	// Likely origin: the member with the earliest submission
+	Origin            string                 `protobuf:"bytes,5,opt,name=Origin,proto3" json:"Origin,omitempty"`
+	OriginSubmittedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=OriginSubmittedAt,proto3" json:"OriginSubmittedAt,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GroupStats) Reset() {
+	*x = GroupStats{}
+	mi := &file_antiplagiat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupStats) ProtoMessage() {}
+
+func (x *GroupStats) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupStats.ProtoReflect.Descriptor instead.
+func (*GroupStats) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GroupStats) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *GroupStats) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GroupStats) GetMaxSimilarity() float64 {
+	if x != nil {
+		return x.MaxSimilarity
+	}
+	return 0
+}
+
+func (x *GroupStats) GetDensity() float64 {
+	if x != nil {
+		return x.Density
+	}
+	return 0
+}
+
+func (x *GroupStats) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *GroupStats) GetOriginSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OriginSubmittedAt
+	}
+	return nil
+}
+
+// Request for pair reports
+type ListPairReportsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// Minimal similarity of returned pairs, the plagiarism threshold if not set
+	MinSimilarity *float64 `protobuf:"fixed64,2,opt,name=MinSimilarity,proto3,oneof" json:"MinSimilarity,omitempty"`
+	// Return matched fragments of suspicious pairs
+	IncludeFragments bool `protobuf:"varint,3,opt,name=IncludeFragments,proto3" json:"IncludeFragments,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListPairReportsRequest) Reset() {
+	*x = ListPairReportsRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPairReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPairReportsRequest) ProtoMessage() {}
+
+func (x *ListPairReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPairReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListPairReportsRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListPairReportsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListPairReportsRequest) GetMinSimilarity() float64 {
+	if x != nil && x.MinSimilarity != nil {
+		return *x.MinSimilarity
+	}
+	return 0
+}
+
+func (x *ListPairReportsRequest) GetIncludeFragments() bool {
+	if x != nil {
+		return x.IncludeFragments
+	}
+	return false
+}
+
+// Response with pair reports ordered by similarity, most similar first
+type ListPairReportsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Pairs     []*PairReport          `protobuf:"bytes,1,rep,name=Pairs,proto3" json:"Pairs,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=StartedAt,proto3" json:"StartedAt,omitempty"`
+	// Settings the analysis was run with
+	PlagiarismThreshold float64 `protobuf:"fixed64,3,opt,name=PlagiarismThreshold,proto3" json:"PlagiarismThreshold,omitempty"`
+	NGramSize           int32   `protobuf:"varint,4,opt,name=NGramSize,proto3" json:"NGramSize,omitempty"`
+	MinFragmentWords    int32   `protobuf:"varint,5,opt,name=MinFragmentWords,proto3" json:"MinFragmentWords,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListPairReportsResponse) Reset() {
+	*x = ListPairReportsResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPairReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPairReportsResponse) ProtoMessage() {}
+
+func (x *ListPairReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPairReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListPairReportsResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListPairReportsResponse) GetPairs() []*PairReport {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *ListPairReportsResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ListPairReportsResponse) GetPlagiarismThreshold() float64 {
+	if x != nil {
+		return x.PlagiarismThreshold
+	}
+	return 0
+}
+
+func (x *ListPairReportsResponse) GetNGramSize() int32 {
+	if x != nil {
+		return x.NGramSize
+	}
+	return 0
+}
+
+func (x *ListPairReportsResponse) GetMinFragmentWords() int32 {
+	if x != nil {
+		return x.MinFragmentWords
+	}
+	return 0
+}
+
+// Similarity of one pair of works
+type PairReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	StudentA          string                 `protobuf:"bytes,1,opt,name=StudentA,proto3" json:"StudentA,omitempty"`
+	StudentB          string                 `protobuf:"bytes,2,opt,name=StudentB,proto3" json:"StudentB,omitempty"`
+	Similarity        float64                `protobuf:"fixed64,3,opt,name=Similarity,proto3" json:"Similarity,omitempty"`
+	FileAHandedOverAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=FileAHandedOverAt,proto3" json:"FileAHandedOverAt,omitempty"`
+	FileBHandedOverAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=FileBHandedOverAt,proto3" json:"FileBHandedOverAt,omitempty"`
+	Suspicious        bool                   `protobuf:"varint,6,opt,name=Suspicious,proto3" json:"Suspicious,omitempty"`
+	Fragments         []*PairFragment        `protobuf:"bytes,7,rep,name=Fragments,proto3" json:"Fragments,omitempty"`
+	// Verdict in force for the pair, if any
+	Verdict *PairReview `protobuf:"bytes,8,opt,name=Verdict,proto3" json:"Verdict,omitempty"`
+	// Versions of the main files of the submissions in the storage service the similarity was computed on
+	FileAVersion int32 `protobuf:"varint,9,opt,name=FileAVersion,proto3" json:"FileAVersion,omitempty"`
+	FileBVersion int32 `protobuf:"varint,10,opt,name=FileBVersion,proto3" json:"FileBVersion,omitempty"`
+	// Similarity of same-named files, when at least one submission of the pair has several files.
+	// Similarity of the pair itself is computed on all files of the submissions together
+	Files         []*PairFileReport `protobuf:"bytes,11,rep,name=Files,proto3" json:"Files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairReport) Reset() {
+	*x = PairReport{}
+	mi := &file_antiplagiat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairReport) ProtoMessage() {}
+
+func (x *PairReport) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairReport.ProtoReflect.Descriptor instead.
+func (*PairReport) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PairReport) GetStudentA() string {
+	if x != nil {
+		return x.StudentA
+	}
+	return ""
+}
+
+func (x *PairReport) GetStudentB() string {
+	if x != nil {
+		return x.StudentB
+	}
+	return ""
+}
+
+func (x *PairReport) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *PairReport) GetFileAHandedOverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FileAHandedOverAt
+	}
+	return nil
+}
+
+func (x *PairReport) GetFileBHandedOverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FileBHandedOverAt
+	}
+	return nil
+}
+
+func (x *PairReport) GetSuspicious() bool {
+	if x != nil {
+		return x.Suspicious
+	}
+	return false
+}
+
+func (x *PairReport) GetFragments() []*PairFragment {
+	if x != nil {
+		return x.Fragments
+	}
+	return nil
+}
+
+func (x *PairReport) GetVerdict() *PairReview {
+	if x != nil {
+		return x.Verdict
+	}
+	return nil
+}
+
+func (x *PairReport) GetFileAVersion() int32 {
+	if x != nil {
+		return x.FileAVersion
+	}
+	return 0
+}
+
+func (x *PairReport) GetFileBVersion() int32 {
+	if x != nil {
+		return x.FileBVersion
+	}
+	return 0
+}
+
+func (x *PairReport) GetFiles() []*PairFileReport {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// Similarity of same-named files of a pair
+type PairFileReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the file within the submission, empty for the main file
+	FileName      string  `protobuf:"bytes,1,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	Similarity    float64 `protobuf:"fixed64,2,opt,name=Similarity,proto3" json:"Similarity,omitempty"`
+	Suspicious    bool    `protobuf:"varint,3,opt,name=Suspicious,proto3" json:"Suspicious,omitempty"`
+	FileAVersion  int32   `protobuf:"varint,4,opt,name=FileAVersion,proto3" json:"FileAVersion,omitempty"`
+	FileBVersion  int32   `protobuf:"varint,5,opt,name=FileBVersion,proto3" json:"FileBVersion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairFileReport) Reset() {
+	*x = PairFileReport{}
+	mi := &file_antiplagiat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairFileReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairFileReport) ProtoMessage() {}
+
+func (x *PairFileReport) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairFileReport.ProtoReflect.Descriptor instead.
+func (*PairFileReport) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PairFileReport) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *PairFileReport) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *PairFileReport) GetSuspicious() bool {
+	if x != nil {
+		return x.Suspicious
+	}
+	return false
+}
+
+func (x *PairFileReport) GetFileAVersion() int32 {
+	if x != nil {
+		return x.FileAVersion
+	}
+	return 0
+}
+
+func (x *PairFileReport) GetFileBVersion() int32 {
+	if x != nil {
+		return x.FileBVersion
+	}
+	return 0
+}
+
+// Common fragment of a pair. Positions are word indexes in the normalized texts
+// (lowercase, letters and digits only, stop words removed)
+type PairFragment struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	AStart int32                  `protobuf:"varint,1,opt,name=AStart,proto3" json:"AStart,omitempty"`
+	BStart int32                  `protobuf:"varint,2,opt,name=BStart,proto3" json:"BStart,omitempty"`
+	Length int32                  `protobuf:"varint,3,opt,name=Length,proto3" json:"Length,omitempty"`
+	// Normalized text of the fragment, at most 60 words
+	Excerpt       string `protobuf:"bytes,4,opt,name=Excerpt,proto3" json:"Excerpt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairFragment) Reset() {
+	*x = PairFragment{}
+	mi := &file_antiplagiat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairFragment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairFragment) ProtoMessage() {}
+
+func (x *PairFragment) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairFragment.ProtoReflect.Descriptor instead.
+func (*PairFragment) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PairFragment) GetAStart() int32 {
+	if x != nil {
+		return x.AStart
+	}
+	return 0
+}
+
+func (x *PairFragment) GetBStart() int32 {
+	if x != nil {
+		return x.BStart
+	}
+	return 0
+}
+
+func (x *PairFragment) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *PairFragment) GetExcerpt() string {
+	if x != nil {
+		return x.Excerpt
+	}
+	return ""
+}
+
+// Request for evidence of one pair. Students may be given in any order
+type GetPairEvidenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	StudentA      string                 `protobuf:"bytes,2,opt,name=StudentA,proto3" json:"StudentA,omitempty"`
+	StudentB      string                 `protobuf:"bytes,3,opt,name=StudentB,proto3" json:"StudentB,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPairEvidenceRequest) Reset() {
+	*x = GetPairEvidenceRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPairEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPairEvidenceRequest) ProtoMessage() {}
+
+func (x *GetPairEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPairEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*GetPairEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetPairEvidenceRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GetPairEvidenceRequest) GetStudentA() string {
+	if x != nil {
+		return x.StudentA
+	}
+	return ""
+}
+
+func (x *GetPairEvidenceRequest) GetStudentB() string {
+	if x != nil {
+		return x.StudentB
+	}
+	return ""
+}
+
+// Pair report oriented as requested: StudentA, FileAHandedOverAt and AStart of fragments
+// refer to the StudentA of the request
+type GetPairEvidenceResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Pair                *PairReport            `protobuf:"bytes,1,opt,name=Pair,proto3" json:"Pair,omitempty"`
+	StartedAt           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=StartedAt,proto3" json:"StartedAt,omitempty"`
+	PlagiarismThreshold float64                `protobuf:"fixed64,3,opt,name=PlagiarismThreshold,proto3" json:"PlagiarismThreshold,omitempty"`
+	MinFragmentWords    int32                  `protobuf:"varint,4,opt,name=MinFragmentWords,proto3" json:"MinFragmentWords,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetPairEvidenceResponse) Reset() {
+	*x = GetPairEvidenceResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPairEvidenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPairEvidenceResponse) ProtoMessage() {}
+
+func (x *GetPairEvidenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPairEvidenceResponse.ProtoReflect.Descriptor instead.
+func (*GetPairEvidenceResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetPairEvidenceResponse) GetPair() *PairReport {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *GetPairEvidenceResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetPairEvidenceResponse) GetPlagiarismThreshold() float64 {
+	if x != nil {
+		return x.PlagiarismThreshold
+	}
+	return 0
+}
+
+func (x *GetPairEvidenceResponse) GetMinFragmentWords() int32 {
+	if x != nil {
+		return x.MinFragmentWords
+	}
+	return 0
+}
+
+// Verdict on a pair. Verdict is one of confirmed, false_positive, allowed_collaboration, needs_discussion
+type ReviewPairRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	StudentA      string                 `protobuf:"bytes,2,opt,name=StudentA,proto3" json:"StudentA,omitempty"`
+	StudentB      string                 `protobuf:"bytes,3,opt,name=StudentB,proto3" json:"StudentB,omitempty"`
+	Verdict       string                 `protobuf:"bytes,4,opt,name=Verdict,proto3" json:"Verdict,omitempty"`
+	Comment       string                 `protobuf:"bytes,5,opt,name=Comment,proto3" json:"Comment,omitempty"`
+	ReviewerId    string                 `protobuf:"bytes,6,opt,name=ReviewerId,proto3" json:"ReviewerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPairRequest) Reset() {
+	*x = ReviewPairRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPairRequest) ProtoMessage() {}
+
+func (x *ReviewPairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPairRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPairRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReviewPairRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ReviewPairRequest) GetStudentA() string {
+	if x != nil {
+		return x.StudentA
+	}
+	return ""
+}
+
+func (x *ReviewPairRequest) GetStudentB() string {
+	if x != nil {
+		return x.StudentB
+	}
+	return ""
+}
+
+func (x *ReviewPairRequest) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+func (x *ReviewPairRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ReviewPairRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+type ReviewPairResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *PairReview            `protobuf:"bytes,1,opt,name=Review,proto3" json:"Review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPairResponse) Reset() {
+	*x = ReviewPairResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPairResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPairResponse) ProtoMessage() {}
+
+func (x *ReviewPairResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPairResponse.ProtoReflect.Descriptor instead.
+func (*ReviewPairResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReviewPairResponse) GetReview() *PairReview {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+// Request for verdict history. Without students the history of the whole task is returned
+type ListPairReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	StudentA      string                 `protobuf:"bytes,2,opt,name=StudentA,proto3" json:"StudentA,omitempty"`
+	StudentB      string                 `protobuf:"bytes,3,opt,name=StudentB,proto3" json:"StudentB,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPairReviewsRequest) Reset() {
+	*x = ListPairReviewsRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPairReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPairReviewsRequest) ProtoMessage() {}
+
+func (x *ListPairReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPairReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListPairReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListPairReviewsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListPairReviewsRequest) GetStudentA() string {
+	if x != nil {
+		return x.StudentA
+	}
+	return ""
+}
+
+func (x *ListPairReviewsRequest) GetStudentB() string {
+	if x != nil {
+		return x.StudentB
+	}
+	return ""
+}
+
+type ListPairReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*PairReview          `protobuf:"bytes,1,rep,name=Reviews,proto3" json:"Reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPairReviewsResponse) Reset() {
+	*x = ListPairReviewsResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPairReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPairReviewsResponse) ProtoMessage() {}
+
+func (x *ListPairReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPairReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListPairReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListPairReviewsResponse) GetReviews() []*PairReview {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+// Reviewer's verdict on a pair. A verdict applies to the file versions it was made for
+type PairReview struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	StudentA   string                 `protobuf:"bytes,2,opt,name=StudentA,proto3" json:"StudentA,omitempty"`
+	StudentB   string                 `protobuf:"bytes,3,opt,name=StudentB,proto3" json:"StudentB,omitempty"`
+	Verdict    string                 `protobuf:"bytes,4,opt,name=Verdict,proto3" json:"Verdict,omitempty"`
+	Comment    string                 `protobuf:"bytes,5,opt,name=Comment,proto3" json:"Comment,omitempty"`
+	ReviewerId string                 `protobuf:"bytes,6,opt,name=ReviewerId,proto3" json:"ReviewerId,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	// Versions of the files the reviewer saw
+	FileAHandedOverAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=FileAHandedOverAt,proto3" json:"FileAHandedOverAt,omitempty"`
+	FileBHandedOverAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=FileBHandedOverAt,proto3" json:"FileBHandedOverAt,omitempty"`
+	// At least one file changed since the verdict, the pair needs a new review
+	Outdated bool `protobuf:"varint,10,opt,name=Outdated,proto3" json:"Outdated,omitempty"`
+	// The latest verdict that still applies to the pair
+	Current       bool `protobuf:"varint,11,opt,name=Current,proto3" json:"Current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairReview) Reset() {
+	*x = PairReview{}
+	mi := &file_antiplagiat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairReview) ProtoMessage() {}
+
+func (x *PairReview) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairReview.ProtoReflect.Descriptor instead.
+func (*PairReview) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PairReview) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PairReview) GetStudentA() string {
+	if x != nil {
+		return x.StudentA
+	}
+	return ""
+}
+
+func (x *PairReview) GetStudentB() string {
+	if x != nil {
+		return x.StudentB
+	}
+	return ""
+}
+
+func (x *PairReview) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+func (x *PairReview) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *PairReview) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *PairReview) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PairReview) GetFileAHandedOverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FileAHandedOverAt
+	}
+	return nil
+}
+
+func (x *PairReview) GetFileBHandedOverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FileBHandedOverAt
+	}
+	return nil
+}
+
+func (x *PairReview) GetOutdated() bool {
+	if x != nil {
+		return x.Outdated
+	}
+	return false
+}
+
+func (x *PairReview) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type CreateAppealRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// Author of the appeal, one of the students of the pair
+	StudentId      string `protobuf:"bytes,2,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	OtherStudentId string `protobuf:"bytes,3,opt,name=OtherStudentId,proto3" json:"OtherStudentId,omitempty"`
+	Explanation    string `protobuf:"bytes,4,opt,name=Explanation,proto3" json:"Explanation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateAppealRequest) Reset() {
+	*x = CreateAppealRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAppealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAppealRequest) ProtoMessage() {}
+
+func (x *CreateAppealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAppealRequest.ProtoReflect.Descriptor instead.
+func (*CreateAppealRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateAppealRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *CreateAppealRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *CreateAppealRequest) GetOtherStudentId() string {
+	if x != nil {
+		return x.OtherStudentId
+	}
+	return ""
+}
+
+func (x *CreateAppealRequest) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+type CreateAppealResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appeal        *Appeal                `protobuf:"bytes,1,opt,name=Appeal,proto3" json:"Appeal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAppealResponse) Reset() {
+	*x = CreateAppealResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAppealResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAppealResponse) ProtoMessage() {}
+
+func (x *CreateAppealResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAppealResponse.ProtoReflect.Descriptor instead.
+func (*CreateAppealResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateAppealResponse) GetAppeal() *Appeal {
+	if x != nil {
+		return x.Appeal
+	}
+	return nil
+}
+
+type AddAppealAttachmentRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AppealId  string                 `protobuf:"bytes,1,opt,name=AppealId,proto3" json:"AppealId,omitempty"`
+	StudentId string                 `protobuf:"bytes,2,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	// Id of the attachment in the storage service
+	AttachmentId  string `protobuf:"bytes,3,opt,name=AttachmentId,proto3" json:"AttachmentId,omitempty"`
+	FileName      string `protobuf:"bytes,4,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAppealAttachmentRequest) Reset() {
+	*x = AddAppealAttachmentRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAppealAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAppealAttachmentRequest) ProtoMessage() {}
+
+func (x *AddAppealAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAppealAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*AddAppealAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AddAppealAttachmentRequest) GetAppealId() string {
+	if x != nil {
+		return x.AppealId
+	}
+	return ""
+}
+
+func (x *AddAppealAttachmentRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *AddAppealAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *AddAppealAttachmentRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+type AddAppealAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appeal        *Appeal                `protobuf:"bytes,1,opt,name=Appeal,proto3" json:"Appeal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAppealAttachmentResponse) Reset() {
+	*x = AddAppealAttachmentResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAppealAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAppealAttachmentResponse) ProtoMessage() {}
+
+func (x *AddAppealAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAppealAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*AddAppealAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AddAppealAttachmentResponse) GetAppeal() *Appeal {
+	if x != nil {
+		return x.Appeal
+	}
+	return nil
+}
+
+// Request to resolve an appeal. Outcome is upheld or rejected; an upheld appeal replaces
+// the confirmed verdict with Verdict (false_positive by default)
+type ResolveAppealRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppealId      string                 `protobuf:"bytes,1,opt,name=AppealId,proto3" json:"AppealId,omitempty"`
+	Outcome       string                 `protobuf:"bytes,2,opt,name=Outcome,proto3" json:"Outcome,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=Comment,proto3" json:"Comment,omitempty"`
+	ReviewerId    string                 `protobuf:"bytes,4,opt,name=ReviewerId,proto3" json:"ReviewerId,omitempty"`
+	Verdict       string                 `protobuf:"bytes,5,opt,name=Verdict,proto3" json:"Verdict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveAppealRequest) Reset() {
+	*x = ResolveAppealRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveAppealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveAppealRequest) ProtoMessage() {}
+
+func (x *ResolveAppealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveAppealRequest.ProtoReflect.Descriptor instead.
+func (*ResolveAppealRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ResolveAppealRequest) GetAppealId() string {
+	if x != nil {
+		return x.AppealId
+	}
+	return ""
+}
+
+func (x *ResolveAppealRequest) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *ResolveAppealRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ResolveAppealRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ResolveAppealRequest) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+type ResolveAppealResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appeal        *Appeal                `protobuf:"bytes,1,opt,name=Appeal,proto3" json:"Appeal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveAppealResponse) Reset() {
+	*x = ResolveAppealResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveAppealResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveAppealResponse) ProtoMessage() {}
+
+func (x *ResolveAppealResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveAppealResponse.ProtoReflect.Descriptor instead.
+func (*ResolveAppealResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ResolveAppealResponse) GetAppeal() *Appeal {
+	if x != nil {
+		return x.Appeal
+	}
+	return nil
+}
+
+type GetAppealRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppealId      string                 `protobuf:"bytes,1,opt,name=AppealId,proto3" json:"AppealId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppealRequest) Reset() {
+	*x = GetAppealRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppealRequest) ProtoMessage() {}
+
+func (x *GetAppealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppealRequest.ProtoReflect.Descriptor instead.
+func (*GetAppealRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetAppealRequest) GetAppealId() string {
+	if x != nil {
+		return x.AppealId
+	}
+	return ""
+}
+
+type GetAppealResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appeal        *Appeal                `protobuf:"bytes,1,opt,name=Appeal,proto3" json:"Appeal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppealResponse) Reset() {
+	*x = GetAppealResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppealResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppealResponse) ProtoMessage() {}
+
+func (x *GetAppealResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppealResponse.ProtoReflect.Descriptor instead.
+func (*GetAppealResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetAppealResponse) GetAppeal() *Appeal {
+	if x != nil {
+		return x.Appeal
+	}
+	return nil
+}
+
+// Request for appeals of a task. Empty state means appeals in any state
+type ListAppealsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAppealsRequest) Reset() {
+	*x = ListAppealsRequest{}
+	mi := &file_antiplagiat_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAppealsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppealsRequest) ProtoMessage() {}
+
+func (x *ListAppealsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppealsRequest.ProtoReflect.Descriptor instead.
+func (*ListAppealsRequest) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListAppealsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListAppealsRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type ListAppealsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appeals       []*Appeal              `protobuf:"bytes,1,rep,name=Appeals,proto3" json:"Appeals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAppealsResponse) Reset() {
+	*x = ListAppealsResponse{}
+	mi := &file_antiplagiat_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAppealsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAppealsResponse) ProtoMessage() {}
+
+func (x *ListAppealsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAppealsResponse.ProtoReflect.Descriptor instead.
+func (*ListAppealsResponse) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListAppealsResponse) GetAppeals() []*Appeal {
+	if x != nil {
+		return x.Appeals
+	}
+	return nil
+}
+
+// Student's appeal against a confirmed verdict
+type Appeal struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	TaskId    string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	StudentA  string                 `protobuf:"bytes,3,opt,name=StudentA,proto3" json:"StudentA,omitempty"`
+	StudentB  string                 `protobuf:"bytes,4,opt,name=StudentB,proto3" json:"StudentB,omitempty"`
+	StudentId string                 `protobuf:"bytes,5,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	// Appealed verdict
+	ReviewId int64 `protobuf:"varint,6,opt,name=ReviewId,proto3" json:"ReviewId,omitempty"`
+	// open, upheld or rejected
+	State       string                 `protobuf:"bytes,7,opt,name=State,proto3" json:"State,omitempty"`
+	Explanation string                 `protobuf:"bytes,8,opt,name=Explanation,proto3" json:"Explanation,omitempty"`
+	SubmittedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=SubmittedAt,proto3" json:"SubmittedAt,omitempty"`
+	// Deadline for the reviewer's response
+	RespondBy         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=RespondBy,proto3" json:"RespondBy,omitempty"`
+	ResolvedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=ResolvedAt,proto3" json:"ResolvedAt,omitempty"`
+	ResolverId        string                 `protobuf:"bytes,12,opt,name=ResolverId,proto3" json:"ResolverId,omitempty"`
+	ResolutionComment string                 `protobuf:"bytes,13,opt,name=ResolutionComment,proto3" json:"ResolutionComment,omitempty"`
+	// Verdict recorded when the appeal was upheld
+	ResolutionReviewId int64 `protobuf:"varint,14,opt,name=ResolutionReviewId,proto3" json:"ResolutionReviewId,omitempty"`
+	// The appeal is open past its deadline
+	Overdue     bool                `protobuf:"varint,15,opt,name=Overdue,proto3" json:"Overdue,omitempty"`
+	Attachments []*AppealAttachment `protobuf:"bytes,16,rep,name=Attachments,proto3" json:"Attachments,omitempty"`
+	// User who filed the appeal; for a group submission - one of the group members
+	AppellantId   string `protobuf:"bytes,17,opt,name=AppellantId,proto3" json:"AppellantId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Appeal) Reset() {
+	*x = Appeal{}
+	mi := &file_antiplagiat_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Appeal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Appeal) ProtoMessage() {}
+
+func (x *Appeal) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Appeal.ProtoReflect.Descriptor instead.
+func (*Appeal) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Appeal) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Appeal) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *Appeal) GetStudentA() string {
+	if x != nil {
+		return x.StudentA
+	}
+	return ""
+}
+
+func (x *Appeal) GetStudentB() string {
+	if x != nil {
+		return x.StudentB
+	}
+	return ""
+}
+
+func (x *Appeal) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *Appeal) GetReviewId() int64 {
+	if x != nil {
+		return x.ReviewId
+	}
+	return 0
+}
+
+func (x *Appeal) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Appeal) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+func (x *Appeal) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+func (x *Appeal) GetRespondBy() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RespondBy
+	}
+	return nil
+}
+
+func (x *Appeal) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *Appeal) GetResolverId() string {
+	if x != nil {
+		return x.ResolverId
+	}
+	return ""
+}
+
+func (x *Appeal) GetResolutionComment() string {
+	if x != nil {
+		return x.ResolutionComment
+	}
+	return ""
+}
+
+func (x *Appeal) GetResolutionReviewId() int64 {
+	if x != nil {
+		return x.ResolutionReviewId
+	}
+	return 0
+}
+
+func (x *Appeal) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+func (x *Appeal) GetAttachments() []*AppealAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *Appeal) GetAppellantId() string {
+	if x != nil {
+		return x.AppellantId
+	}
+	return ""
+}
+
+type AppealAttachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=AttachmentId,proto3" json:"AttachmentId,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=AddedAt,proto3" json:"AddedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppealAttachment) Reset() {
+	*x = AppealAttachment{}
+	mi := &file_antiplagiat_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppealAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppealAttachment) ProtoMessage() {}
+
+func (x *AppealAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_antiplagiat_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppealAttachment.ProtoReflect.Descriptor instead.
+func (*AppealAttachment) Descriptor() ([]byte, []int) {
+	return file_antiplagiat_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AppealAttachment) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *AppealAttachment) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *AppealAttachment) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+var File_antiplagiat_proto protoreflect.FileDescriptor
+
+const file_antiplagiat_proto_rawDesc = "" +
+	"\n" +
+	"\x11antiplagiat.proto\x12\astorage\x1a\x1fgoogle/protobuf/timestamp.proto\"4\n" +
+	"\x1aGetPlagiarismReportRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"\x8c\x01\n" +
+	"\x1bGetPlagiarismReportResponse\x123\n" +
+	"\aReports\x18\x01 \x03(\v2\x19.storage.PlagiarismReportR\aReports\x128\n" +
+	"\tStartedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tStartedAt\"\xa3\x02\n" +
+	"\x10PlagiarismReport\x12\x18\n" +
+	"\aStudent\x18\x01 \x01(\tR\aStudent\x126\n" +
+	"\x16StudentWithSimilarFile\x18\x02 \x01(\tR\x16StudentWithSimilarFile\x12$\n" +
+	"\rMaxSimilarity\x18\x03 \x01(\x01R\rMaxSimilarity\x12F\n" +
+	"\x10FileHandedOverAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x10FileHandedOverAt\x12-\n" +
+	"\aVerdict\x18\x05 \x01(\v2\x13.storage.PairReviewR\aVerdict\x12 \n" +
+	"\vFileVersion\x18\x06 \x01(\x05R\vFileVersion\".\n" +
+	"\x14StartAnalysisRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"I\n" +
+	"\x15StartAnalysisResponse\x12\x14\n" +
+	"\x05JobId\x18\x01 \x01(\tR\x05JobId\x12\x1a\n" +
+	"\bAttached\x18\x02 \x01(\bR\bAttached\"-\n" +
+	"\x15GetAnalysisJobRequest\x12\x14\n" +
+	"\x05JobId\x18\x01 \x01(\tR\x05JobId\"@\n" +
+	"\x16GetAnalysisJobResponse\x12&\n" +
+	"\x03Job\x18\x01 \x01(\v2\x14.storage.AnalysisJobR\x03Job\"0\n" +
+	"\x18CancelAnalysisJobRequest\x12\x14\n" +
+	"\x05JobId\x18\x01 \x01(\tR\x05JobId\"C\n" +
+	"\x19CancelAnalysisJobResponse\x12&\n" +
+	"\x03Job\x18\x01 \x01(\v2\x14.storage.AnalysisJobR\x03Job\"\xcb\x04\n" +
+	"\vAnalysisJob\x12\x14\n" +
+	"\x05JobId\x18\x01 \x01(\tR\x05JobId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x14\n" +
+	"\x05State\x18\x03 \x01(\tR\x05State\x12\"\n" +
+	"\fProgressDone\x18\x04 \x01(\x05R\fProgressDone\x12$\n" +
+	"\rProgressTotal\x18\x05 \x01(\x05R\rProgressTotal\x12\x14\n" +
+	"\x05Error\x18\x06 \x01(\tR\x05Error\x128\n" +
+	"\tCreatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
+	"\tStartedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tStartedAt\x12:\n" +
+	"\n" +
+	"FinishedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"FinishedAt\x12\x1a\n" +
+	"\bAttempts\x18\n" +
+	" \x01(\x05R\bAttempts\x12 \n" +
+	"\vMaxAttempts\x18\v \x01(\x05R\vMaxAttempts\x128\n" +
+	"\tNextRunAt\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tNextRunAt\x12&\n" +
+	"\x0eFilesExtracted\x18\r \x01(\x05R\x0eFilesExtracted\x12\x1e\n" +
+	"\n" +
+	"FilesTotal\x18\x0e \x01(\x05R\n" +
+	"FilesTotal\x12(\n" +
+	"\x0fSuspiciousPairs\x18\x0f \x01(\x05R\x0fSuspiciousPairs\"D\n" +
+	"\x14WatchAnalysisRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x14\n" +
+	"\x05JobId\x18\x02 \x01(\tR\x05JobId\"\x80\x01\n" +
+	"\rAnalysisEvent\x12&\n" +
+	"\x03Job\x18\x01 \x01(\v2\x14.storage.AnalysisJobR\x03Job\x12G\n" +
+	"\x12NewSuspiciousPairs\x18\x02 \x03(\v2\x17.storage.SuspiciousPairR\x12NewSuspiciousPairs\"h\n" +
+	"\x0eSuspiciousPair\x12\x1a\n" +
+	"\bStudentA\x18\x01 \x01(\tR\bStudentA\x12\x1a\n" +
+	"\bStudentB\x18\x02 \x01(\tR\bStudentB\x12\x1e\n" +
+	"\n" +
+	"Similarity\x18\x03 \x01(\x01R\n" +
+	"Similarity\"4\n" +
+	"\x1aGetSimilarityMatrixRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"\xd1\x01\n" +
+	"\x1bGetSimilarityMatrixResponse\x12\x1a\n" +
+	"\bStudents\x18\x01 \x03(\tR\bStudents\x12*\n" +
+	"\x04Rows\x18\x02 \x03(\v2\x16.storage.SimilarityRowR\x04Rows\x128\n" +
+	"\tStartedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tStartedAt\x120\n" +
+	"\x13PlagiarismThreshold\x18\x04 \x01(\x01R\x13PlagiarismThreshold\"3\n" +
+	"\rSimilarityRow\x12\"\n" +
+	"\fSimilarities\x18\x01 \x03(\x01R\fSimilarities\"[\n" +
+	"\x1bListSuspiciousGroupsRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12$\n" +
+	"\rMinSimilarity\x18\x02 \x01(\x01R\rMinSimilarity\"P\n" +
+	"\x1cListSuspiciousGroupsResponse\x120\n" +
+	"\x06Groups\x18\x01 \x03(\v2\x18.storage.SuspiciousGroupR\x06Groups\"\x8d\x01\n" +
+	"\x0fSuspiciousGroup\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12)\n" +
+	"\x05Stats\x18\x02 \x01(\v2\x13.storage.GroupStatsR\x05Stats\x125\n" +
+	"\vCommunities\x18\x03 \x03(\v2\x13.storage.GroupStatsR\vCommunities\"\xde\x01\n" +
+	"\n" +
+	"GroupStats\x12\x18\n" +
+	"\aMembers\x18\x01 \x03(\tR\aMembers\x12\x14\n" +
+	"\x05Score\x18\x02 \x01(\x01R\x05Score\x12$\n" +
+	"\rMaxSimilarity\x18\x03 \x01(\x01R\rMaxSimilarity\x12\x18\n" +
+	"\aDensity\x18\x04 \x01(\x01R\aDensity\x12\x16\n" +
+	"\x06Origin\x18\x05 \x01(\tR\x06Origin\x12H\n" +
+	"\x11OriginSubmittedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x11OriginSubmittedAt\"\x99\x01\n" +
+	"\x16ListPairReportsRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12)\n" +
+	"\rMinSimilarity\x18\x02 \x01(\x01H\x00R\rMinSimilarity\x88\x01\x01\x12*\n" +
+	"\x10IncludeFragments\x18\x03 \x01(\bR\x10IncludeFragmentsB\x10\n" +
+	"\x0e_MinSimilarity\"\xfa\x01\n" +
+	"\x17ListPairReportsResponse\x12)\n" +
+	"\x05Pairs\x18\x01 \x03(\v2\x13.storage.PairReportR\x05Pairs\x128\n" +
+	"\tStartedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tStartedAt\x120\n" +
+	"\x13PlagiarismThreshold\x18\x03 \x01(\x01R\x13PlagiarismThreshold\x12\x1c\n" +
+	"\tNGramSize\x18\x04 \x01(\x05R\tNGramSize\x12*\n" +
+	"\x10MinFragmentWords\x18\x05 \x01(\x05R\x10MinFragmentWords\"\xf3\x03\n" +
+	"\n" +
+	"PairReport\x12\x1a\n" +
+	"\bStudentA\x18\x01 \x01(\tR\bStudentA\x12\x1a\n" +
+	"\bStudentB\x18\x02 \x01(\tR\bStudentB\x12\x1e\n" +
+	"\n" +
+	"Similarity\x18\x03 \x01(\x01R\n" +
+	"Similarity\x12H\n" +
+	"\x11FileAHandedOverAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x11FileAHandedOverAt\x12H\n" +
+	"\x11FileBHandedOverAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x11FileBHandedOverAt\x12\x1e\n" +
+	"\n" +
+	"Suspicious\x18\x06 \x01(\bR\n" +
+	"Suspicious\x123\n" +
+	"\tFragments\x18\a \x03(\v2\x15.storage.PairFragmentR\tFragments\x12-\n" +
+	"\aVerdict\x18\b \x01(\v2\x13.storage.PairReviewR\aVerdict\x12\"\n" +
+	"\fFileAVersion\x18\t \x01(\x05R\fFileAVersion\x12\"\n" +
+	"\fFileBVersion\x18\n" +
+	" \x01(\x05R\fFileBVersion\x12-\n" +
+	"\x05Files\x18\v \x03(\v2\x17.storage.PairFileReportR\x05Files\"\xb4\x01\n" +
+	"\x0ePairFileReport\x12\x1a\n" +
+	"\bFileName\x18\x01 \x01(\tR\bFileName\x12\x1e\n" +
+	"\n" +
+	"Similarity\x18\x02 \x01(\x01R\n" +
+	"Similarity\x12\x1e\n" +
+	"\n" +
+	"Suspicious\x18\x03 \x01(\bR\n" +
+	"Suspicious\x12\"\n" +
+	"\fFileAVersion\x18\x04 \x01(\x05R\fFileAVersion\x12\"\n" +
+	"\fFileBVersion\x18\x05 \x01(\x05R\fFileBVersion\"p\n" +
+	"\fPairFragment\x12\x16\n" +
+	"\x06AStart\x18\x01 \x01(\x05R\x06AStart\x12\x16\n" +
+	"\x06BStart\x18\x02 \x01(\x05R\x06BStart\x12\x16\n" +
+	"\x06Length\x18\x03 \x01(\x05R\x06Length\x12\x18\n" +
+	"\aExcerpt\x18\x04 \x01(\tR\aExcerpt\"h\n" +
+	"\x16GetPairEvidenceRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x1a\n" +
+	"\bStudentA\x18\x02 \x01(\tR\bStudentA\x12\x1a\n" +
+	"\bStudentB\x18\x03 \x01(\tR\bStudentB\"\xda\x01\n" +
+	"\x17GetPairEvidenceResponse\x12'\n" +
+	"\x04Pair\x18\x01 \x01(\v2\x13.storage.PairReportR\x04Pair\x128\n" +
+	"\tStartedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tStartedAt\x120\n" +
+	"\x13PlagiarismThreshold\x18\x03 \x01(\x01R\x13PlagiarismThreshold\x12*\n" +
+	"\x10MinFragmentWords\x18\x04 \x01(\x05R\x10MinFragmentWords\"\xb7\x01\n" +
+	"\x11ReviewPairRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x1a\n" +
+	"\bStudentA\x18\x02 \x01(\tR\bStudentA\x12\x1a\n" +
+	"\bStudentB\x18\x03 \x01(\tR\bStudentB\x12\x18\n" +
+	"\aVerdict\x18\x04 \x01(\tR\aVerdict\x12\x18\n" +
+	"\aComment\x18\x05 \x01(\tR\aComment\x12\x1e\n" +
+	"\n" +
+	"ReviewerId\x18\x06 \x01(\tR\n" +
+	"ReviewerId\"A\n" +
+	"\x12ReviewPairResponse\x12+\n" +
+	"\x06Review\x18\x01 \x01(\v2\x13.storage.PairReviewR\x06Review\"h\n" +
+	"\x16ListPairReviewsRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x1a\n" +
+	"\bStudentA\x18\x02 \x01(\tR\bStudentA\x12\x1a\n" +
+	"\bStudentB\x18\x03 \x01(\tR\bStudentB\"H\n" +
+	"\x17ListPairReviewsResponse\x12-\n" +
+	"\aReviews\x18\x01 \x03(\v2\x13.storage.PairReviewR\aReviews\"\xac\x03\n" +
+	"\n" +
+	"PairReview\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\x03R\x02Id\x12\x1a\n" +
+	"\bStudentA\x18\x02 \x01(\tR\bStudentA\x12\x1a\n" +
+	"\bStudentB\x18\x03 \x01(\tR\bStudentB\x12\x18\n" +
+	"\aVerdict\x18\x04 \x01(\tR\aVerdict\x12\x18\n" +
+	"\aComment\x18\x05 \x01(\tR\aComment\x12\x1e\n" +
+	"\n" +
+	"ReviewerId\x18\x06 \x01(\tR\n" +
+	"ReviewerId\x128\n" +
+	"\tCreatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x12H\n" +
+	"\x11FileAHandedOverAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x11FileAHandedOverAt\x12H\n" +
+	"\x11FileBHandedOverAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x11FileBHandedOverAt\x12\x1a\n" +
+	"\bOutdated\x18\n" +
+	" \x01(\bR\bOutdated\x12\x18\n" +
+	"\aCurrent\x18\v \x01(\bR\aCurrent\"\x95\x01\n" +
+	"\x13CreateAppealRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x1c\n" +
+	"\tStudentId\x18\x02 \x01(\tR\tStudentId\x12&\n" +
+	"\x0eOtherStudentId\x18\x03 \x01(\tR\x0eOtherStudentId\x12 \n" +
+	"\vExplanation\x18\x04 \x01(\tR\vExplanation\"?\n" +
+	"\x14CreateAppealResponse\x12'\n" +
+	"\x06Appeal\x18\x01 \x01(\v2\x0f.storage.AppealR\x06Appeal\"\x96\x01\n" +
+	"\x1aAddAppealAttachmentRequest\x12\x1a\n" +
+	"\bAppealId\x18\x01 \x01(\tR\bAppealId\x12\x1c\n" +
+	"\tStudentId\x18\x02 \x01(\tR\tStudentId\x12\"\n" +
+	"\fAttachmentId\x18\x03 \x01(\tR\fAttachmentId\x12\x1a\n" +
+	"\bFileName\x18\x04 \x01(\tR\bFileName\"F\n" +
+	"\x1bAddAppealAttachmentResponse\x12'\n" +
+	"\x06Appeal\x18\x01 \x01(\v2\x0f.storage.AppealR\x06Appeal\"\xa0\x01\n" +
+	"\x14ResolveAppealRequest\x12\x1a\n" +
+	"\bAppealId\x18\x01 \x01(\tR\bAppealId\x12\x18\n" +
+	"\aOutcome\x18\x02 \x01(\tR\aOutcome\x12\x18\n" +
+	"\aComment\x18\x03 \x01(\tR\aComment\x12\x1e\n" +
+	"\n" +
+	"ReviewerId\x18\x04 \x01(\tR\n" +
+	"ReviewerId\x12\x18\n" +
+	"\aVerdict\x18\x05 \x01(\tR\aVerdict\"@\n" +
+	"\x15ResolveAppealResponse\x12'\n" +
+	"\x06Appeal\x18\x01 \x01(\v2\x0f.storage.AppealR\x06Appeal\".\n" +
+	"\x10GetAppealRequest\x12\x1a\n" +
+	"\bAppealId\x18\x01 \x01(\tR\bAppealId\"<\n" +
+	"\x11GetAppealResponse\x12'\n" +
+	"\x06Appeal\x18\x01 \x01(\v2\x0f.storage.AppealR\x06Appeal\"B\n" +
+	"\x12ListAppealsRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x14\n" +
+	"\x05State\x18\x02 \x01(\tR\x05State\"@\n" +
+	"\x13ListAppealsResponse\x12)\n" +
+	"\aAppeals\x18\x01 \x03(\v2\x0f.storage.AppealR\aAppeals\"\x85\x05\n" +
+	"\x06Appeal\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\tR\x02Id\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x1a\n" +
+	"\bStudentA\x18\x03 \x01(\tR\bStudentA\x12\x1a\n" +
+	"\bStudentB\x18\x04 \x01(\tR\bStudentB\x12\x1c\n" +
+	"\tStudentId\x18\x05 \x01(\tR\tStudentId\x12\x1a\n" +
+	"\bReviewId\x18\x06 \x01(\x03R\bReviewId\x12\x14\n" +
+	"\x05State\x18\a \x01(\tR\x05State\x12 \n" +
+	"\vExplanation\x18\b \x01(\tR\vExplanation\x12<\n" +
+	"\vSubmittedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vSubmittedAt\x128\n" +
+	"\tRespondBy\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tRespondBy\x12:\n" +
+	"\n" +
+	"ResolvedAt\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"ResolvedAt\x12\x1e\n" +
+	"\n" +
+	"ResolverId\x18\f \x01(\tR\n" +
+	"ResolverId\x12,\n" +
+	"\x11ResolutionComment\x18\r \x01(\tR\x11ResolutionComment\x12.\n" +
+	"\x12ResolutionReviewId\x18\x0e \x01(\x03R\x12ResolutionReviewId\x12\x18\n" +
+	"\aOverdue\x18\x0f \x01(\bR\aOverdue\x12;\n" +
+	"\vAttachments\x18\x10 \x03(\v2\x19.storage.AppealAttachmentR\vAttachments\x12 \n" +
+	"\vAppellantId\x18\x11 \x01(\tR\vAppellantId\"\x88\x01\n" +
+	"\x10AppealAttachment\x12\"\n" +
+	"\fAttachmentId\x18\x01 \x01(\tR\fAttachmentId\x12\x1a\n" +
+	"\bFileName\x18\x02 \x01(\tR\bFileName\x124\n" +
+	"\aAddedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aAddedAt2\xf4\n" +
+	"\n" +
+	"\n" +
+	"Plagiarism\x12b\n" +
+	"\x13GetPlagiarismReport\x12#.storage.GetPlagiarismReportRequest\x1a$.storage.GetPlagiarismReportResponse\"\x00\x12P\n" +
+	"\rStartAnalysis\x12\x1d.storage.StartAnalysisRequest\x1a\x1e.storage.StartAnalysisResponse\"\x00\x12S\n" +
+	"\x0eGetAnalysisJob\x12\x1e.storage.GetAnalysisJobRequest\x1a\x1f.storage.GetAnalysisJobResponse\"\x00\x12\\\n" +
+	"\x11CancelAnalysisJob\x12!.storage.CancelAnalysisJobRequest\x1a\".storage.CancelAnalysisJobResponse\"\x00\x12J\n" +
+	"\rWatchAnalysis\x12\x1d.storage.WatchAnalysisRequest\x1a\x16.storage.AnalysisEvent\"\x000\x01\x12b\n" +
+	"\x13GetSimilarityMatrix\x12#.storage.GetSimilarityMatrixRequest\x1a$.storage.GetSimilarityMatrixResponse\"\x00\x12e\n" +
+	"\x14ListSuspiciousGroups\x12$.storage.ListSuspiciousGroupsRequest\x1a%.storage.ListSuspiciousGroupsResponse\"\x00\x12V\n" +
+	"\x0fListPairReports\x12\x1f.storage.ListPairReportsRequest\x1a .storage.ListPairReportsResponse\"\x00\x12V\n" +
+	"\x0fGetPairEvidence\x12\x1f.storage.GetPairEvidenceRequest\x1a .storage.GetPairEvidenceResponse\"\x00\x12G\n" +
+	"\n" +
+	"ReviewPair\x12\x1a.storage.ReviewPairRequest\x1a\x1b.storage.ReviewPairResponse\"\x00\x12V\n" +
+	"\x0fListPairReviews\x12\x1f.storage.ListPairReviewsRequest\x1a .storage.ListPairReviewsResponse\"\x00\x12M\n" +
+	"\fCreateAppeal\x12\x1c.storage.CreateAppealRequest\x1a\x1d.storage.CreateAppealResponse\"\x00\x12b\n" +
+	"\x13AddAppealAttachment\x12#.storage.AddAppealAttachmentRequest\x1a$.storage.AddAppealAttachmentResponse\"\x00\x12P\n" +
+	"\rResolveAppeal\x12\x1d.storage.ResolveAppealRequest\x1a\x1e.storage.ResolveAppealResponse\"\x00\x12D\n" +
+	"\tGetAppeal\x12\x19.storage.GetAppealRequest\x1a\x1a.storage.GetAppealResponse\"\x00\x12J\n" +
+	"\vListAppeals\x12\x1b.storage.ListAppealsRequest\x1a\x1c.storage.ListAppealsResponse\"\x00BPZNgithub.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go;plagiarismpbb\x06proto3"
 
 var (
 	file_antiplagiat_proto_rawDescOnce sync.Once
@@ -220,24 +3179,134 @@ func file_antiplagiat_proto_rawDescGZIP() []byte {
 	return file_antiplagiat_proto_rawDescData
 }
 
-var file_antiplagiat_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_antiplagiat_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_antiplagiat_proto_goTypes = []any{
-	(*GetPlagiarismReportRequest)(nil),  // 0: storage.GetPlagiarismReportRequest
-	(*GetPlagiarismReportResponse)(nil), // 1: storage.GetPlagiarismReportResponse
-	(*PlagiarismReport)(nil),            // 2: storage.PlagiarismReport
-	(*timestamppb.Timestamp)(nil),       // 3: google.protobuf.Timestamp
+	(*GetPlagiarismReportRequest)(nil),   // 0: storage.GetPlagiarismReportRequest
+	(*GetPlagiarismReportResponse)(nil),  // 1: storage.GetPlagiarismReportResponse
+	(*PlagiarismReport)(nil),             // 2: storage.PlagiarismReport
+	(*StartAnalysisRequest)(nil),         // 3: storage.StartAnalysisRequest
+	(*StartAnalysisResponse)(nil),        // 4: storage.StartAnalysisResponse
+	(*GetAnalysisJobRequest)(nil),        // 5: storage.GetAnalysisJobRequest
+	(*GetAnalysisJobResponse)(nil),       // 6: storage.GetAnalysisJobResponse
+	(*CancelAnalysisJobRequest)(nil),     // 7: storage.CancelAnalysisJobRequest
+	(*CancelAnalysisJobResponse)(nil),    // 8: storage.CancelAnalysisJobResponse
+	(*AnalysisJob)(nil),                  // 9: storage.AnalysisJob
+	(*WatchAnalysisRequest)(nil),         // 10: storage.WatchAnalysisRequest
+	(*AnalysisEvent)(nil),                // 11: storage.AnalysisEvent
+	(*SuspiciousPair)(nil),               // 12: storage.SuspiciousPair
+	(*GetSimilarityMatrixRequest)(nil),   // 13: storage.GetSimilarityMatrixRequest
+	(*GetSimilarityMatrixResponse)(nil),  // 14: storage.GetSimilarityMatrixResponse
+	(*SimilarityRow)(nil),                // 15: storage.SimilarityRow
+	(*ListSuspiciousGroupsRequest)(nil),  // 16: storage.ListSuspiciousGroupsRequest
+	(*ListSuspiciousGroupsResponse)(nil), // 17: storage.ListSuspiciousGroupsResponse
+	(*SuspiciousGroup)(nil),              // 18: storage.SuspiciousGroup
+	(*GroupStats)(nil),                   // 19: storage.GroupStats
+	(*ListPairReportsRequest)(nil),       // 20: storage.ListPairReportsRequest
+	(*ListPairReportsResponse)(nil),      // 21: storage.ListPairReportsResponse
+	(*PairReport)(nil),                   // 22: storage.PairReport
+	(*PairFileReport)(nil),               // 23: storage.PairFileReport
+	(*PairFragment)(nil),                 // 24: storage.PairFragment
+	(*GetPairEvidenceRequest)(nil),       // 25: storage.GetPairEvidenceRequest
+	(*GetPairEvidenceResponse)(nil),      // 26: storage.GetPairEvidenceResponse
+	(*ReviewPairRequest)(nil),            // 27: storage.ReviewPairRequest
+	(*ReviewPairResponse)(nil),           // 28: storage.ReviewPairResponse
+	(*ListPairReviewsRequest)(nil),       // 29: storage.ListPairReviewsRequest
+	(*ListPairReviewsResponse)(nil),      // 30: storage.ListPairReviewsResponse
+	(*PairReview)(nil),                   // 31: storage.PairReview
+	(*CreateAppealRequest)(nil),          // 32: storage.CreateAppealRequest
+	(*CreateAppealResponse)(nil),         // 33: storage.CreateAppealResponse
+	(*AddAppealAttachmentRequest)(nil),   // 34: storage.AddAppealAttachmentRequest
+	(*AddAppealAttachmentResponse)(nil),  // 35: storage.AddAppealAttachmentResponse
+	(*ResolveAppealRequest)(nil),         // 36: storage.ResolveAppealRequest
+	(*ResolveAppealResponse)(nil),        // 37: storage.ResolveAppealResponse
+	(*GetAppealRequest)(nil),             // 38: storage.GetAppealRequest
+	(*GetAppealResponse)(nil),            // 39: storage.GetAppealResponse
+	(*ListAppealsRequest)(nil),           // 40: storage.ListAppealsRequest
+	(*ListAppealsResponse)(nil),          // 41: storage.ListAppealsResponse
+	(*Appeal)(nil),                       // 42: storage.Appeal
+	(*AppealAttachment)(nil),             // 43: storage.AppealAttachment
+	(*timestamppb.Timestamp)(nil),        // 44: google.protobuf.Timestamp
 }
 var file_antiplagiat_proto_depIdxs = []int32{
-	2, // 0: storage.GetPlagiarismReportResponse.Reports:type_name -> storage.PlagiarismReport
-	3, // 1: storage.GetPlagiarismReportResponse.StartedAt:type_name -> google.protobuf.Timestamp
-	3, // 2: storage.PlagiarismReport.FileHandedOverAt:type_name -> google.protobuf.Timestamp
-	0, // 3: storage.Plagiarism.GetPlagiarismReport:input_type -> storage.GetPlagiarismReportRequest
-	1, // 4: storage.Plagiarism.GetPlagiarismReport:output_type -> storage.GetPlagiarismReportResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2,  // 0: storage.GetPlagiarismReportResponse.Reports:type_name -> storage.PlagiarismReport
+	44, // 1: storage.GetPlagiarismReportResponse.StartedAt:type_name -> google.protobuf.Timestamp
+	44, // 2: storage.PlagiarismReport.FileHandedOverAt:type_name -> google.protobuf.Timestamp
+	31, // 3: storage.PlagiarismReport.Verdict:type_name -> storage.PairReview
+	9,  // 4: storage.GetAnalysisJobResponse.Job:type_name -> storage.AnalysisJob
+	9,  // 5: storage.CancelAnalysisJobResponse.Job:type_name -> storage.AnalysisJob
+	44, // 6: storage.AnalysisJob.CreatedAt:type_name -> google.protobuf.Timestamp
+	44, // 7: storage.AnalysisJob.StartedAt:type_name -> google.protobuf.Timestamp
+	44, // 8: storage.AnalysisJob.FinishedAt:type_name -> google.protobuf.Timestamp
+	44, // 9: storage.AnalysisJob.NextRunAt:type_name -> google.protobuf.Timestamp
+	9,  // 10: storage.AnalysisEvent.Job:type_name -> storage.AnalysisJob
+	12, // 11: storage.AnalysisEvent.NewSuspiciousPairs:type_name -> storage.SuspiciousPair
+	15, // 12: storage.GetSimilarityMatrixResponse.Rows:type_name -> storage.SimilarityRow
+	44, // 13: storage.GetSimilarityMatrixResponse.StartedAt:type_name -> google.protobuf.Timestamp
+	18, // 14: storage.ListSuspiciousGroupsResponse.Groups:type_name -> storage.SuspiciousGroup
+	19, // 15: storage.SuspiciousGroup.Stats:type_name -> storage.GroupStats
+	19, // 16: storage.SuspiciousGroup.Communities:type_name -> storage.GroupStats
+	44, // 17: storage.GroupStats.OriginSubmittedAt:type_name -> google.protobuf.Timestamp
+	22, // 18: storage.ListPairReportsResponse.Pairs:type_name -> storage.PairReport
+	44, // 19: storage.ListPairReportsResponse.StartedAt:type_name -> google.protobuf.Timestamp
+	44, // 20: storage.PairReport.FileAHandedOverAt:type_name -> google.protobuf.Timestamp
+	44, // 21: storage.PairReport.FileBHandedOverAt:type_name -> google.protobuf.Timestamp
+	24, // 22: storage.PairReport.Fragments:type_name -> storage.PairFragment
+	31, // 23: storage.PairReport.Verdict:type_name -> storage.PairReview
+	23, // 24: storage.PairReport.Files:type_name -> storage.PairFileReport
+	22, // 25: storage.GetPairEvidenceResponse.Pair:type_name -> storage.PairReport
+	44, // 26: storage.GetPairEvidenceResponse.StartedAt:type_name -> google.protobuf.Timestamp
+	31, // 27: storage.ReviewPairResponse.Review:type_name -> storage.PairReview
+	31, // 28: storage.ListPairReviewsResponse.Reviews:type_name -> storage.PairReview
+	44, // 29: storage.PairReview.CreatedAt:type_name -> google.protobuf.Timestamp
+	44, // 30: storage.PairReview.FileAHandedOverAt:type_name -> google.protobuf.Timestamp
+	44, // 31: storage.PairReview.FileBHandedOverAt:type_name -> google.protobuf.Timestamp
+	42, // 32: storage.CreateAppealResponse.Appeal:type_name -> storage.Appeal
+	42, // 33: storage.AddAppealAttachmentResponse.Appeal:type_name -> storage.Appeal
+	42, // 34: storage.ResolveAppealResponse.Appeal:type_name -> storage.Appeal
+	42, // 35: storage.GetAppealResponse.Appeal:type_name -> storage.Appeal
+	42, // 36: storage.ListAppealsResponse.Appeals:type_name -> storage.Appeal
+	44, // 37: storage.Appeal.SubmittedAt:type_name -> google.protobuf.Timestamp
+	44, // 38: storage.Appeal.RespondBy:type_name -> google.protobuf.Timestamp
+	44, // 39: storage.Appeal.ResolvedAt:type_name -> google.protobuf.Timestamp
+	43, // 40: storage.Appeal.Attachments:type_name -> storage.AppealAttachment
+	44, // 41: storage.AppealAttachment.AddedAt:type_name -> google.protobuf.Timestamp
+	0,  // 42: storage.Plagiarism.GetPlagiarismReport:input_type -> storage.GetPlagiarismReportRequest
+	3,  // 43: storage.Plagiarism.StartAnalysis:input_type -> storage.StartAnalysisRequest
+	5,  // 44: storage.Plagiarism.GetAnalysisJob:input_type -> storage.GetAnalysisJobRequest
+	7,  // 45: storage.Plagiarism.CancelAnalysisJob:input_type -> storage.CancelAnalysisJobRequest
+	10, // 46: storage.Plagiarism.WatchAnalysis:input_type -> storage.WatchAnalysisRequest
+	13, // 47: storage.Plagiarism.GetSimilarityMatrix:input_type -> storage.GetSimilarityMatrixRequest
+	16, // 48: storage.Plagiarism.ListSuspiciousGroups:input_type -> storage.ListSuspiciousGroupsRequest
+	20, // 49: storage.Plagiarism.ListPairReports:input_type -> storage.ListPairReportsRequest
+	25, // 50: storage.Plagiarism.GetPairEvidence:input_type -> storage.GetPairEvidenceRequest
+	27, // 51: storage.Plagiarism.ReviewPair:input_type -> storage.ReviewPairRequest
+	29, // 52: storage.Plagiarism.ListPairReviews:input_type -> storage.ListPairReviewsRequest
+	32, // 53: storage.Plagiarism.CreateAppeal:input_type -> storage.CreateAppealRequest
+	34, // 54: storage.Plagiarism.AddAppealAttachment:input_type -> storage.AddAppealAttachmentRequest
+	36, // 55: storage.Plagiarism.ResolveAppeal:input_type -> storage.ResolveAppealRequest
+	38, // 56: storage.Plagiarism.GetAppeal:input_type -> storage.GetAppealRequest
+	40, // 57: storage.Plagiarism.ListAppeals:input_type -> storage.ListAppealsRequest
+	1,  // 58: storage.Plagiarism.GetPlagiarismReport:output_type -> storage.GetPlagiarismReportResponse
+	4,  // 59: storage.Plagiarism.StartAnalysis:output_type -> storage.StartAnalysisResponse
+	6,  // 60: storage.Plagiarism.GetAnalysisJob:output_type -> storage.GetAnalysisJobResponse
+	8,  // 61: storage.Plagiarism.CancelAnalysisJob:output_type -> storage.CancelAnalysisJobResponse
+	11, // 62: storage.Plagiarism.WatchAnalysis:output_type -> storage.AnalysisEvent
+	14, // 63: storage.Plagiarism.GetSimilarityMatrix:output_type -> storage.GetSimilarityMatrixResponse
+	17, // 64: storage.Plagiarism.ListSuspiciousGroups:output_type -> storage.ListSuspiciousGroupsResponse
+	21, // 65: storage.Plagiarism.ListPairReports:output_type -> storage.ListPairReportsResponse
+	26, // 66: storage.Plagiarism.GetPairEvidence:output_type -> storage.GetPairEvidenceResponse
+	28, // 67: storage.Plagiarism.ReviewPair:output_type -> storage.ReviewPairResponse
+	30, // 68: storage.Plagiarism.ListPairReviews:output_type -> storage.ListPairReviewsResponse
+	33, // 69: storage.Plagiarism.CreateAppeal:output_type -> storage.CreateAppealResponse
+	35, // 70: storage.Plagiarism.AddAppealAttachment:output_type -> storage.AddAppealAttachmentResponse
+	37, // 71: storage.Plagiarism.ResolveAppeal:output_type -> storage.ResolveAppealResponse
+	39, // 72: storage.Plagiarism.GetAppeal:output_type -> storage.GetAppealResponse
+	41, // 73: storage.Plagiarism.ListAppeals:output_type -> storage.ListAppealsResponse
+	58, // [58:74] is the sub-list for method output_type
+	42, // [42:58] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_antiplagiat_proto_init() }
@@ -245,13 +3314,14 @@ func file_antiplagiat_proto_init() {
 	if File_antiplagiat_proto != nil {
 		return
 	}
+	file_antiplagiat_proto_msgTypes[20].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_antiplagiat_proto_rawDesc), len(file_antiplagiat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
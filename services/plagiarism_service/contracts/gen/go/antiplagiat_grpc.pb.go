@@ -19,7 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Plagiarism_GetPlagiarismReport_FullMethodName = "/storage.Plagiarism/GetPlagiarismReport"
+	Plagiarism_GetPlagiarismReport_FullMethodName  = "/storage.Plagiarism/GetPlagiarismReport"
+	Plagiarism_StartAnalysis_FullMethodName        = "/storage.Plagiarism/StartAnalysis"
+	Plagiarism_GetAnalysisJob_FullMethodName       = "/storage.Plagiarism/GetAnalysisJob"
+	Plagiarism_CancelAnalysisJob_FullMethodName    = "/storage.Plagiarism/CancelAnalysisJob"
+	Plagiarism_WatchAnalysis_FullMethodName        = "/storage.Plagiarism/WatchAnalysis"
+	Plagiarism_GetSimilarityMatrix_FullMethodName  = "/storage.Plagiarism/GetSimilarityMatrix"
+	Plagiarism_ListSuspiciousGroups_FullMethodName = "/storage.Plagiarism/ListSuspiciousGroups"
+	Plagiarism_ListPairReports_FullMethodName      = "/storage.Plagiarism/ListPairReports"
+	Plagiarism_GetPairEvidence_FullMethodName      = "/storage.Plagiarism/GetPairEvidence"
+	Plagiarism_ReviewPair_FullMethodName           = "/storage.Plagiarism/ReviewPair"
+	Plagiarism_ListPairReviews_FullMethodName      = "/storage.Plagiarism/ListPairReviews"
+	Plagiarism_CreateAppeal_FullMethodName         = "/storage.Plagiarism/CreateAppeal"
+	Plagiarism_AddAppealAttachment_FullMethodName  = "/storage.Plagiarism/AddAppealAttachment"
+	Plagiarism_ResolveAppeal_FullMethodName        = "/storage.Plagiarism/ResolveAppeal"
+	Plagiarism_GetAppeal_FullMethodName            = "/storage.Plagiarism/GetAppeal"
+	Plagiarism_ListAppeals_FullMethodName          = "/storage.Plagiarism/ListAppeals"
 )
 
 // PlagiarismClient is the client API for Plagiarism service.
@@ -28,8 +43,38 @@ const (
 //
 // Service for plagiarism analysis
 type PlagiarismClient interface {
-	// Get plagiarism report for a task
+	// Get plagiarism report for a task (read-only, returns results of the last analysis)
 	GetPlagiarismReport(ctx context.Context, in *GetPlagiarismReportRequest, opts ...grpc.CallOption) (*GetPlagiarismReportResponse, error)
+	// Start asynchronous analysis of a task
+	StartAnalysis(ctx context.Context, in *StartAnalysisRequest, opts ...grpc.CallOption) (*StartAnalysisResponse, error)
+	// Get state and progress of an analysis job
+	GetAnalysisJob(ctx context.Context, in *GetAnalysisJobRequest, opts ...grpc.CallOption) (*GetAnalysisJobResponse, error)
+	// Cancel queued or running analysis job
+	CancelAnalysisJob(ctx context.Context, in *CancelAnalysisJobRequest, opts ...grpc.CallOption) (*CancelAnalysisJobResponse, error)
+	// Stream progress and suspicious pairs of an analysis job until it finishes
+	WatchAnalysis(ctx context.Context, in *WatchAnalysisRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalysisEvent], error)
+	// Get full pairwise similarity matrix of a task with students ordered by hierarchical clustering
+	GetSimilarityMatrix(ctx context.Context, in *GetSimilarityMatrixRequest, opts ...grpc.CallOption) (*GetSimilarityMatrixResponse, error)
+	// List groups of students connected by suspicious pairs (possible collusion rings)
+	ListSuspiciousGroups(ctx context.Context, in *ListSuspiciousGroupsRequest, opts ...grpc.CallOption) (*ListSuspiciousGroupsResponse, error)
+	// List all pairs of the last analysis with similarity above the given minimum
+	ListPairReports(ctx context.Context, in *ListPairReportsRequest, opts ...grpc.CallOption) (*ListPairReportsResponse, error)
+	// Get one pair of the last analysis with all its matched fragments
+	GetPairEvidence(ctx context.Context, in *GetPairEvidenceRequest, opts ...grpc.CallOption) (*GetPairEvidenceResponse, error)
+	// Record a reviewer's verdict on a pair of the last analysis
+	ReviewPair(ctx context.Context, in *ReviewPairRequest, opts ...grpc.CallOption) (*ReviewPairResponse, error)
+	// List verdict history of a pair or of the whole task, newest first
+	ListPairReviews(ctx context.Context, in *ListPairReviewsRequest, opts ...grpc.CallOption) (*ListPairReviewsResponse, error)
+	// Submit a student's appeal against a confirmed verdict on their pair
+	CreateAppeal(ctx context.Context, in *CreateAppealRequest, opts ...grpc.CallOption) (*CreateAppealResponse, error)
+	// Attach a file uploaded to the storage service to an open appeal
+	AddAppealAttachment(ctx context.Context, in *AddAppealAttachmentRequest, opts ...grpc.CallOption) (*AddAppealAttachmentResponse, error)
+	// Uphold or reject an open appeal
+	ResolveAppeal(ctx context.Context, in *ResolveAppealRequest, opts ...grpc.CallOption) (*ResolveAppealResponse, error)
+	// Get an appeal with its attachments
+	GetAppeal(ctx context.Context, in *GetAppealRequest, opts ...grpc.CallOption) (*GetAppealResponse, error)
+	// List appeals of a task, the closest response deadline first
+	ListAppeals(ctx context.Context, in *ListAppealsRequest, opts ...grpc.CallOption) (*ListAppealsResponse, error)
 }
 
 type plagiarismClient struct {
@@ -50,14 +95,203 @@ func (c *plagiarismClient) GetPlagiarismReport(ctx context.Context, in *GetPlagi
 	return out, nil
 }
 
+func (c *plagiarismClient) StartAnalysis(ctx context.Context, in *StartAnalysisRequest, opts ...grpc.CallOption) (*StartAnalysisResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartAnalysisResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_StartAnalysis_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) GetAnalysisJob(ctx context.Context, in *GetAnalysisJobRequest, opts ...grpc.CallOption) (*GetAnalysisJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAnalysisJobResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_GetAnalysisJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) CancelAnalysisJob(ctx context.Context, in *CancelAnalysisJobRequest, opts ...grpc.CallOption) (*CancelAnalysisJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelAnalysisJobResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_CancelAnalysisJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) WatchAnalysis(ctx context.Context, in *WatchAnalysisRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalysisEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Plagiarism_ServiceDesc.Streams[0], Plagiarism_WatchAnalysis_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAnalysisRequest, AnalysisEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Plagiarism_WatchAnalysisClient = grpc.ServerStreamingClient[AnalysisEvent]
+
+func (c *plagiarismClient) GetSimilarityMatrix(ctx context.Context, in *GetSimilarityMatrixRequest, opts ...grpc.CallOption) (*GetSimilarityMatrixResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSimilarityMatrixResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_GetSimilarityMatrix_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) ListSuspiciousGroups(ctx context.Context, in *ListSuspiciousGroupsRequest, opts ...grpc.CallOption) (*ListSuspiciousGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSuspiciousGroupsResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_ListSuspiciousGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) ListPairReports(ctx context.Context, in *ListPairReportsRequest, opts ...grpc.CallOption) (*ListPairReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPairReportsResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_ListPairReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) GetPairEvidence(ctx context.Context, in *GetPairEvidenceRequest, opts ...grpc.CallOption) (*GetPairEvidenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPairEvidenceResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_GetPairEvidence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) ReviewPair(ctx context.Context, in *ReviewPairRequest, opts ...grpc.CallOption) (*ReviewPairResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewPairResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_ReviewPair_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) ListPairReviews(ctx context.Context, in *ListPairReviewsRequest, opts ...grpc.CallOption) (*ListPairReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPairReviewsResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_ListPairReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) CreateAppeal(ctx context.Context, in *CreateAppealRequest, opts ...grpc.CallOption) (*CreateAppealResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAppealResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_CreateAppeal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) AddAppealAttachment(ctx context.Context, in *AddAppealAttachmentRequest, opts ...grpc.CallOption) (*AddAppealAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddAppealAttachmentResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_AddAppealAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) ResolveAppeal(ctx context.Context, in *ResolveAppealRequest, opts ...grpc.CallOption) (*ResolveAppealResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveAppealResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_ResolveAppeal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) GetAppeal(ctx context.Context, in *GetAppealRequest, opts ...grpc.CallOption) (*GetAppealResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAppealResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_GetAppeal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plagiarismClient) ListAppeals(ctx context.Context, in *ListAppealsRequest, opts ...grpc.CallOption) (*ListAppealsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAppealsResponse)
+	err := c.cc.Invoke(ctx, Plagiarism_ListAppeals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlagiarismServer is the server API for Plagiarism service.
 // All implementations must embed UnimplementedPlagiarismServer
 // for forward compatibility.
 //
 // Service for plagiarism analysis
 type PlagiarismServer interface {
-	// Get plagiarism report for a task
+	// Get plagiarism report for a task (read-only, returns results of the last analysis)
 	GetPlagiarismReport(context.Context, *GetPlagiarismReportRequest) (*GetPlagiarismReportResponse, error)
+	// Start asynchronous analysis of a task
+	StartAnalysis(context.Context, *StartAnalysisRequest) (*StartAnalysisResponse, error)
+	// Get state and progress of an analysis job
+	GetAnalysisJob(context.Context, *GetAnalysisJobRequest) (*GetAnalysisJobResponse, error)
+	// Cancel queued or running analysis job
+	CancelAnalysisJob(context.Context, *CancelAnalysisJobRequest) (*CancelAnalysisJobResponse, error)
+	// Stream progress and suspicious pairs of an analysis job until it finishes
+	WatchAnalysis(*WatchAnalysisRequest, grpc.ServerStreamingServer[AnalysisEvent]) error
+	// Get full pairwise similarity matrix of a task with students ordered by hierarchical clustering
+	GetSimilarityMatrix(context.Context, *GetSimilarityMatrixRequest) (*GetSimilarityMatrixResponse, error)
+	// List groups of students connected by suspicious pairs (possible collusion rings)
+	ListSuspiciousGroups(context.Context, *ListSuspiciousGroupsRequest) (*ListSuspiciousGroupsResponse, error)
+	// List all pairs of the last analysis with similarity above the given minimum
+	ListPairReports(context.Context, *ListPairReportsRequest) (*ListPairReportsResponse, error)
+	// Get one pair of the last analysis with all its matched fragments
+	GetPairEvidence(context.Context, *GetPairEvidenceRequest) (*GetPairEvidenceResponse, error)
+	// Record a reviewer's verdict on a pair of the last analysis
+	ReviewPair(context.Context, *ReviewPairRequest) (*ReviewPairResponse, error)
+	// List verdict history of a pair or of the whole task, newest first
+	ListPairReviews(context.Context, *ListPairReviewsRequest) (*ListPairReviewsResponse, error)
+	// Submit a student's appeal against a confirmed verdict on their pair
+	CreateAppeal(context.Context, *CreateAppealRequest) (*CreateAppealResponse, error)
+	// Attach a file uploaded to the storage service to an open appeal
+	AddAppealAttachment(context.Context, *AddAppealAttachmentRequest) (*AddAppealAttachmentResponse, error)
+	// Uphold or reject an open appeal
+	ResolveAppeal(context.Context, *ResolveAppealRequest) (*ResolveAppealResponse, error)
+	// Get an appeal with its attachments
+	GetAppeal(context.Context, *GetAppealRequest) (*GetAppealResponse, error)
+	// List appeals of a task, the closest response deadline first
+	ListAppeals(context.Context, *ListAppealsRequest) (*ListAppealsResponse, error)
 	mustEmbedUnimplementedPlagiarismServer()
 }
 
@@ -71,6 +305,51 @@ type UnimplementedPlagiarismServer struct{}
 func (UnimplementedPlagiarismServer) GetPlagiarismReport(context.Context, *GetPlagiarismReportRequest) (*GetPlagiarismReportResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPlagiarismReport not implemented")
 }
+func (UnimplementedPlagiarismServer) StartAnalysis(context.Context, *StartAnalysisRequest) (*StartAnalysisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartAnalysis not implemented")
+}
+func (UnimplementedPlagiarismServer) GetAnalysisJob(context.Context, *GetAnalysisJobRequest) (*GetAnalysisJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnalysisJob not implemented")
+}
+func (UnimplementedPlagiarismServer) CancelAnalysisJob(context.Context, *CancelAnalysisJobRequest) (*CancelAnalysisJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAnalysisJob not implemented")
+}
+func (UnimplementedPlagiarismServer) WatchAnalysis(*WatchAnalysisRequest, grpc.ServerStreamingServer[AnalysisEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAnalysis not implemented")
+}
+func (UnimplementedPlagiarismServer) GetSimilarityMatrix(context.Context, *GetSimilarityMatrixRequest) (*GetSimilarityMatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimilarityMatrix not implemented")
+}
+func (UnimplementedPlagiarismServer) ListSuspiciousGroups(context.Context, *ListSuspiciousGroupsRequest) (*ListSuspiciousGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuspiciousGroups not implemented")
+}
+func (UnimplementedPlagiarismServer) ListPairReports(context.Context, *ListPairReportsRequest) (*ListPairReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPairReports not implemented")
+}
+func (UnimplementedPlagiarismServer) GetPairEvidence(context.Context, *GetPairEvidenceRequest) (*GetPairEvidenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPairEvidence not implemented")
+}
+func (UnimplementedPlagiarismServer) ReviewPair(context.Context, *ReviewPairRequest) (*ReviewPairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewPair not implemented")
+}
+func (UnimplementedPlagiarismServer) ListPairReviews(context.Context, *ListPairReviewsRequest) (*ListPairReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPairReviews not implemented")
+}
+func (UnimplementedPlagiarismServer) CreateAppeal(context.Context, *CreateAppealRequest) (*CreateAppealResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAppeal not implemented")
+}
+func (UnimplementedPlagiarismServer) AddAppealAttachment(context.Context, *AddAppealAttachmentRequest) (*AddAppealAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAppealAttachment not implemented")
+}
+func (UnimplementedPlagiarismServer) ResolveAppeal(context.Context, *ResolveAppealRequest) (*ResolveAppealResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveAppeal not implemented")
+}
+func (UnimplementedPlagiarismServer) GetAppeal(context.Context, *GetAppealRequest) (*GetAppealResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAppeal not implemented")
+}
+func (UnimplementedPlagiarismServer) ListAppeals(context.Context, *ListAppealsRequest) (*ListAppealsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAppeals not implemented")
+}
 func (UnimplementedPlagiarismServer) mustEmbedUnimplementedPlagiarismServer() {}
 func (UnimplementedPlagiarismServer) testEmbeddedByValue()                    {}
 
@@ -110,6 +389,269 @@ func _Plagiarism_GetPlagiarismReport_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Plagiarism_StartAnalysis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartAnalysisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).StartAnalysis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_StartAnalysis_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).StartAnalysis(ctx, req.(*StartAnalysisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_GetAnalysisJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnalysisJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).GetAnalysisJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_GetAnalysisJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).GetAnalysisJob(ctx, req.(*GetAnalysisJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_CancelAnalysisJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAnalysisJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).CancelAnalysisJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_CancelAnalysisJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).CancelAnalysisJob(ctx, req.(*CancelAnalysisJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_WatchAnalysis_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAnalysisRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlagiarismServer).WatchAnalysis(m, &grpc.GenericServerStream[WatchAnalysisRequest, AnalysisEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Plagiarism_WatchAnalysisServer = grpc.ServerStreamingServer[AnalysisEvent]
+
+func _Plagiarism_GetSimilarityMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSimilarityMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).GetSimilarityMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_GetSimilarityMatrix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).GetSimilarityMatrix(ctx, req.(*GetSimilarityMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_ListSuspiciousGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuspiciousGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).ListSuspiciousGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_ListSuspiciousGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).ListSuspiciousGroups(ctx, req.(*ListSuspiciousGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_ListPairReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPairReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).ListPairReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_ListPairReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).ListPairReports(ctx, req.(*ListPairReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_GetPairEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPairEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).GetPairEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_GetPairEvidence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).GetPairEvidence(ctx, req.(*GetPairEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_ReviewPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).ReviewPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_ReviewPair_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).ReviewPair(ctx, req.(*ReviewPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_ListPairReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPairReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).ListPairReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_ListPairReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).ListPairReviews(ctx, req.(*ListPairReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_CreateAppeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAppealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).CreateAppeal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_CreateAppeal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).CreateAppeal(ctx, req.(*CreateAppealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_AddAppealAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAppealAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).AddAppealAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_AddAppealAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).AddAppealAttachment(ctx, req.(*AddAppealAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_ResolveAppeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveAppealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).ResolveAppeal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_ResolveAppeal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).ResolveAppeal(ctx, req.(*ResolveAppealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_GetAppeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAppealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).GetAppeal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_GetAppeal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).GetAppeal(ctx, req.(*GetAppealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Plagiarism_ListAppeals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAppealsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlagiarismServer).ListAppeals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Plagiarism_ListAppeals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlagiarismServer).ListAppeals(ctx, req.(*ListAppealsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Plagiarism_ServiceDesc is the grpc.ServiceDesc for Plagiarism service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,7 +663,69 @@ var Plagiarism_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPlagiarismReport",
 			Handler:    _Plagiarism_GetPlagiarismReport_Handler,
 		},
+		{
+			MethodName: "StartAnalysis",
+			Handler:    _Plagiarism_StartAnalysis_Handler,
+		},
+		{
+			MethodName: "GetAnalysisJob",
+			Handler:    _Plagiarism_GetAnalysisJob_Handler,
+		},
+		{
+			MethodName: "CancelAnalysisJob",
+			Handler:    _Plagiarism_CancelAnalysisJob_Handler,
+		},
+		{
+			MethodName: "GetSimilarityMatrix",
+			Handler:    _Plagiarism_GetSimilarityMatrix_Handler,
+		},
+		{
+			MethodName: "ListSuspiciousGroups",
+			Handler:    _Plagiarism_ListSuspiciousGroups_Handler,
+		},
+		{
+			MethodName: "ListPairReports",
+			Handler:    _Plagiarism_ListPairReports_Handler,
+		},
+		{
+			MethodName: "GetPairEvidence",
+			Handler:    _Plagiarism_GetPairEvidence_Handler,
+		},
+		{
+			MethodName: "ReviewPair",
+			Handler:    _Plagiarism_ReviewPair_Handler,
+		},
+		{
+			MethodName: "ListPairReviews",
+			Handler:    _Plagiarism_ListPairReviews_Handler,
+		},
+		{
+			MethodName: "CreateAppeal",
+			Handler:    _Plagiarism_CreateAppeal_Handler,
+		},
+		{
+			MethodName: "AddAppealAttachment",
+			Handler:    _Plagiarism_AddAppealAttachment_Handler,
+		},
+		{
+			MethodName: "ResolveAppeal",
+			Handler:    _Plagiarism_ResolveAppeal_Handler,
+		},
+		{
+			MethodName: "GetAppeal",
+			Handler:    _Plagiarism_GetAppeal_Handler,
+		},
+		{
+			MethodName: "ListAppeals",
+			Handler:    _Plagiarism_ListAppeals_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAnalysis",
+			Handler:       _Plagiarism_WatchAnalysis_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "antiplagiat.proto",
 }
@@ -9,11 +9,15 @@ import (
 	storageClient "github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/clients/storage"
 	postgresRepo "github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories/postgres"
 	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/transport/grpc"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/transport/worker"
 	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/use_cases"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/mtls"
+	"google.golang.org/grpc/credentials"
 )
 
 type App struct {
 	GRPCSrv *grpc.Server
+	Worker  *worker.Worker
 }
 
 func New(ctx context.Context, log *slog.Logger, cfg *config.Config) (*App, error) {
@@ -30,8 +34,26 @@ func New(ctx context.Context, log *slog.Logger, cfg *config.Config) (*App, error
 	}
 	log.Info("postgres initialized successfully")
 
+	// Инициализация mTLS: один сертификат сервиса и для сервера, и для клиента storage-service
+	var serverCreds, clientCreds credentials.TransportCredentials
+	acl, err := mtls.ParseACL(cfg.TLS.AllowedClients)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse allowed clients: %w", err)
+	}
+	if cfg.TLS.Enabled() {
+		serverCreds, err = mtls.ServerCredentials(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize tls: %w", err)
+		}
+		clientCreds, err = mtls.ClientCredentials(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize tls: %w", err)
+		}
+		log.Info("mtls enabled")
+	}
+
 	// Инициализация клиента storage-service
-	storage, err := storageClient.NewStorageClient(cfg.Storage.Addr)
+	storage, err := storageClient.NewStorageClient(cfg.Storage.Addr, clientCreds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize storage client: %w", err)
 	}
@@ -41,13 +63,22 @@ func New(ctx context.Context, log *slog.Logger, cfg *config.Config) (*App, error
 	dbRepository := postgresRepo.NewFileRepository(dbPool)
 
 	// Создание use case сервиса
-	plagiarismService := use_cases.NewPlagiarismService(log, dbRepository, storage.Client)
+	plagiarismService := use_cases.NewPlagiarismService(log, dbRepository, storage.Client, use_cases.QueueSettings{
+		LeaseDuration:  cfg.Worker.LeaseDuration,
+		MaxAttempts:    cfg.Worker.MaxAttempts,
+		RetryBaseDelay: cfg.Worker.RetryBaseDelay,
+		RetryMaxDelay:  cfg.Worker.RetryMaxDelay,
+	})
 
 	// Инициализация gRPC сервера
-	grpcApp := grpc.New(log, cfg.GRPC.Port, plagiarismService)
+	grpcApp := grpc.New(log, cfg.GRPC.Port, plagiarismService, serverCreds, acl, cfg.TLS.IdentityClients)
+
+	// Инициализация воркеров очереди анализа
+	analysisWorker := worker.New(log, plagiarismService, cfg.Worker.Concurrency, cfg.Worker.PollInterval)
 
 	return &App{
 		GRPCSrv: grpcApp,
+		Worker:  analysisWorker,
 	}, nil
 }
 
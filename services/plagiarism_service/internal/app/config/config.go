@@ -11,6 +11,8 @@ type Config struct {
 	GRPC    GRPCConfig     `env-prefix:"GRPC_"`
 	DB      PostgresConfig `env-prefix:"POSTGRES_"`
 	Storage StorageConfig  `env-prefix:"STORAGE_"`
+	Worker  WorkerConfig   `env-prefix:"WORKER_"`
+	TLS     TLSConfig      `env-prefix:"TLS_"`
 }
 
 type PostgresConfig struct {
@@ -29,6 +31,32 @@ type StorageConfig struct {
 	Addr string `env:"ADDR" env-default:"storage-service:5001"`
 }
 
+type WorkerConfig struct {
+	Concurrency    int           `env:"CONCURRENCY" env-default:"2"`
+	PollInterval   time.Duration `env:"POLL_INTERVAL" env-default:"1s"`
+	LeaseDuration  time.Duration `env:"LEASE_DURATION" env-default:"1m"`
+	MaxAttempts    int           `env:"MAX_ATTEMPTS" env-default:"5"`
+	RetryBaseDelay time.Duration `env:"RETRY_BASE_DELAY" env-default:"5s"`
+	RetryMaxDelay  time.Duration `env:"RETRY_MAX_DELAY" env-default:"5m"`
+}
+
+// TLSConfig - mTLS gRPC-сервера: сертификат сервиса, его ключ и CA, которым подписаны сертификаты клиентов.
+// Пустые пути - соединения без TLS. Тот же сертификат - клиентский при обращении к storage-service.
+// AllowedClients ограничивает вызовы по имени клиента из сертификата: "api-gateway=*";
+// пусто - любому клиенту с верным сертификатом всё.
+type TLSConfig struct {
+	CertFile       string `env:"CERT_FILE"`
+	KeyFile        string `env:"KEY_FILE"`
+	CAFile         string `env:"CA_FILE"`
+	AllowedClients string `env:"ALLOWED_CLIENTS"`
+	// IdentityClients - клиенты, которым сервис верит пользователя из metadata вызова (пакет identity)
+	IdentityClients []string `env:"IDENTITY_CLIENTS" env-default:"api-gateway" env-separator:","`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
 func MustLoad() *Config {
 	var cfg Config
 
@@ -36,5 +64,14 @@ func MustLoad() *Config {
 		panic("failed to read config: " + err.Error())
 	}
 
+	if cfg.TLS.Enabled() && (cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "" || cfg.TLS.CAFile == "") {
+		panic("TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE must be set together")
+	}
+
+	// без mTLS имя клиента неизвестно, и список разрешённых клиентов не применялся бы
+	if cfg.TLS.AllowedClients != "" && !cfg.TLS.Enabled() {
+		panic("TLS_ALLOWED_CLIENTS requires TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE")
+	}
+
 	return &cfg
 }
@@ -14,6 +14,9 @@ type PlagiarismReport struct {
 	Similarity        float64   `json:"similarity" db:"similarity"`
 	FileAHandedOverAt time.Time `json:"file_a_handed_over_at" db:"file_a_handed_over_at"`
 	FileBHandedOverAt time.Time `json:"file_b_handed_over_at" db:"file_b_handed_over_at"`
+	// FileAVersion и FileBVersion - версии сдач в storage-service, по которым посчитан отчёт
+	FileAVersion int `json:"file_a_version" db:"file_a_version"`
+	FileBVersion int `json:"file_b_version" db:"file_b_version"`
 }
 
 type Task struct {
@@ -21,3 +24,181 @@ type Task struct {
 	AnalysisStartedAt time.Time
 	Reports           []PlagiarismReport
 }
+
+// AnalyzedFile фиксирует, какая версия файла студента уже учтена в отчётах задачи.
+type AnalyzedFile struct {
+	TaskID        string    `json:"task_id" db:"task_id"`
+	StudentID     string    `json:"student_id" db:"student_id"`
+	FileUpdatedAt time.Time `json:"file_updated_at" db:"file_updated_at"`
+	AnalyzedAt    time.Time `json:"analyzed_at" db:"analyzed_at"`
+}
+
+// PairFragment - общий фрагмент пары работ. Позиции - номера слов в очищенных текстах StudentA и StudentB.
+type PairFragment struct {
+	TaskID   string `json:"task_id" db:"task_id"`
+	StudentA string `json:"student_a" db:"student_a"`
+	StudentB string `json:"student_b" db:"student_b"`
+	AStart   int    `json:"a_start" db:"a_start"`
+	BStart   int    `json:"b_start" db:"b_start"`
+	Length   int    `json:"length" db:"length"`
+	Excerpt  string `json:"excerpt" db:"excerpt"`
+}
+
+// PairFileReport - схожесть одноимённых файлов пары сдач из нескольких файлов.
+type PairFileReport struct {
+	TaskID       string  `json:"task_id" db:"task_id"`
+	StudentA     string  `json:"student_a" db:"student_a"`
+	StudentB     string  `json:"student_b" db:"student_b"`
+	FileName     string  `json:"file_name" db:"file_name"`
+	Similarity   float64 `json:"similarity" db:"similarity"`
+	FileAVersion int     `json:"file_a_version" db:"file_a_version"`
+	FileBVersion int     `json:"file_b_version" db:"file_b_version"`
+}
+
+// AnalysisResult - изменения отчётов задачи по итогам одного прогона анализа.
+// Применяется целиком, чтобы читатели не видели наполовину обновлённые отчёты.
+type AnalysisResult struct {
+	TaskID            string
+	AnalysisStartedAt time.Time
+	RemovedStudents   []string
+	Reports           []PlagiarismReport
+	// Fragments заменяют фрагменты пар из Reports; у пар без фрагментов прежние удаляются
+	Fragments []PairFragment
+	// PairFiles так же заменяют пофайловую схожесть пар из Reports
+	PairFiles     []PairFileReport
+	AnalyzedFiles []AnalyzedFile
+}
+
+type AnalysisJob struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	TaskID          string     `json:"task_id" db:"task_id"`
+	State           JobState   `json:"state" db:"state"`
+	ProgressDone    int        `json:"progress_done" db:"progress_done"`
+	ProgressTotal   int        `json:"progress_total" db:"progress_total"`
+	FilesExtracted  int        `json:"files_extracted" db:"files_extracted"`
+	FilesTotal      int        `json:"files_total" db:"files_total"`
+	SuspiciousPairs int        `json:"suspicious_pairs" db:"suspicious_pairs"`
+	Error           string     `json:"error" db:"error"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	StartedAt       *time.Time `json:"started_at" db:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at" db:"finished_at"`
+	Attempts        int        `json:"attempts" db:"attempts"`
+	MaxAttempts     int        `json:"max_attempts" db:"max_attempts"`
+	NextRunAt       time.Time  `json:"next_run_at" db:"next_run_at"`
+	LeaseOwner      *string    `json:"lease_owner" db:"lease_owner"`
+	LeaseExpiresAt  *time.Time `json:"lease_expires_at" db:"lease_expires_at"`
+}
+
+// JobProgress - ход выполнения анализа: скачанные файлы, сравненные пары и найденные подозрительные пары.
+type JobProgress struct {
+	FilesExtracted  int
+	FilesTotal      int
+	PairsDone       int
+	PairsTotal      int
+	SuspiciousPairs int
+}
+
+// JobFinding - подозрительная пара, найденная заданием до завершения анализа.
+type JobFinding struct {
+	ID         int64     `json:"id" db:"id"`
+	JobID      string    `json:"job_id" db:"job_id"`
+	StudentA   string    `json:"student_a" db:"student_a"`
+	StudentB   string    `json:"student_b" db:"student_b"`
+	Similarity float64   `json:"similarity" db:"similarity"`
+	FoundAt    time.Time `json:"found_at" db:"found_at"`
+}
+
+type JobState string
+
+const (
+	JobStateQueued    JobState = "queued"
+	JobStateRunning   JobState = "running"
+	JobStateSucceeded JobState = "succeeded"
+	JobStateFailed    JobState = "failed"
+	JobStateCancelled JobState = "cancelled"
+	// JobStateDead - задание исчерпало попытки и больше не берётся из очереди
+	JobStateDead JobState = "dead"
+)
+
+// IsFinished сообщает, что задание больше не будет выполняться.
+func (s JobState) IsFinished() bool {
+	return s == JobStateSucceeded || s == JobStateFailed || s == JobStateCancelled || s == JobStateDead
+}
+
+// PairReview - решение проверяющего по паре. Студенты в каноническом порядке (StudentA < StudentB),
+// FileAHandedOverAt и FileBHandedOverAt - версии файлов из отчёта пары на момент решения.
+type PairReview struct {
+	ID                int64         `json:"id" db:"id"`
+	TaskID            string        `json:"task_id" db:"task_id"`
+	StudentA          string        `json:"student_a" db:"student_a"`
+	StudentB          string        `json:"student_b" db:"student_b"`
+	Verdict           ReviewVerdict `json:"verdict" db:"verdict"`
+	Comment           string        `json:"comment" db:"comment"`
+	ReviewerID        string        `json:"reviewer_id" db:"reviewer_id"`
+	FileAHandedOverAt time.Time     `json:"file_a_handed_over_at" db:"file_a_handed_over_at"`
+	FileBHandedOverAt time.Time     `json:"file_b_handed_over_at" db:"file_b_handed_over_at"`
+	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
+}
+
+// AppliesTo сообщает, что решение принято по тем же версиям файлов, по которым построен отчёт.
+func (r PairReview) AppliesTo(report PlagiarismReport) bool {
+	return r.FileAHandedOverAt.Equal(report.FileAHandedOverAt) && r.FileBHandedOverAt.Equal(report.FileBHandedOverAt)
+}
+
+type ReviewVerdict string
+
+const (
+	VerdictConfirmed            ReviewVerdict = "confirmed"
+	VerdictFalsePositive        ReviewVerdict = "false_positive"
+	VerdictAllowedCollaboration ReviewVerdict = "allowed_collaboration"
+	VerdictNeedsDiscussion      ReviewVerdict = "needs_discussion"
+)
+
+func (v ReviewVerdict) IsValid() bool {
+	switch v {
+	case VerdictConfirmed, VerdictFalsePositive, VerdictAllowedCollaboration, VerdictNeedsDiscussion:
+		return true
+	}
+	return false
+}
+
+// Appeal - апелляция студента на решение confirmed по его паре. StudentID - сдача, от имени которой подана
+// апелляция (у группы - id группы), AppellantID - пользователь, который её подал.
+type Appeal struct {
+	ID                 uuid.UUID   `json:"id" db:"id"`
+	TaskID             string      `json:"task_id" db:"task_id"`
+	StudentA           string      `json:"student_a" db:"student_a"`
+	StudentB           string      `json:"student_b" db:"student_b"`
+	StudentID          string      `json:"student_id" db:"student_id"`
+	AppellantID        string      `json:"appellant_id" db:"appellant_id"`
+	ReviewID           int64       `json:"review_id" db:"review_id"`
+	State              AppealState `json:"state" db:"state"`
+	Explanation        string      `json:"explanation" db:"explanation"`
+	SubmittedAt        time.Time   `json:"submitted_at" db:"submitted_at"`
+	RespondBy          time.Time   `json:"respond_by" db:"respond_by"`
+	ResolvedAt         *time.Time  `json:"resolved_at" db:"resolved_at"`
+	ResolverID         string      `json:"resolver_id" db:"resolver_id"`
+	ResolutionComment  string      `json:"resolution_comment" db:"resolution_comment"`
+	ResolutionReviewID *int64      `json:"resolution_review_id" db:"resolution_review_id"`
+	Attachments        []AppealAttachment
+}
+
+type AppealAttachment struct {
+	AppealID     uuid.UUID `json:"appeal_id" db:"appeal_id"`
+	AttachmentID string    `json:"attachment_id" db:"attachment_id"`
+	FileName     string    `json:"file_name" db:"file_name"`
+	AddedAt      time.Time `json:"added_at" db:"added_at"`
+}
+
+type AppealState string
+
+const (
+	AppealStateOpen AppealState = "open"
+	// AppealStateUpheld - апелляция удовлетворена, решение confirmed заменено новым
+	AppealStateUpheld   AppealState = "upheld"
+	AppealStateRejected AppealState = "rejected"
+)
+
+func (s AppealState) IsValid() bool {
+	return s == AppealStateOpen || s == AppealStateUpheld || s == AppealStateRejected
+}
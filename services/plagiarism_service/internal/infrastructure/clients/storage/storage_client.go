@@ -3,6 +3,7 @@ package storage
 import (
 	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -11,9 +12,14 @@ type Storage struct {
 	conn   *grpc.ClientConn
 }
 
-func NewStorageClient(addr string) (*Storage, error) {
+// NewStorageClient подключается к storage-service; без creds - без TLS.
+func NewStorageClient(addr string, creds credentials.TransportCredentials) (*Storage, error) {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
 	conn, err := grpc.NewClient(addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+		grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, err
 	}
@@ -4,4 +4,9 @@ import "errors"
 
 var (
 	ErrNotFound = errors.New("not found")
+	ErrLocked   = errors.New("locked by another process")
+	// ErrAlreadyExists - нарушено ограничение уникальности
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrConflict - запись изменена другим запросом
+	ErrConflict = errors.New("changed concurrently")
 )
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const uniqueViolation = "23505"
+
+const appealColumns = `id, task_id, student_a, student_b, student_id, appellant_id, review_id, state, explanation, submitted_at,
+	respond_by, resolved_at, resolver_id, resolution_comment, resolution_review_id`
+
+// SaveAppeal сохраняет новую апелляцию. Повторная апелляция того же студента на то же решение - ErrAlreadyExists.
+func (r *FileRepo) SaveAppeal(ctx context.Context, appeal *domain.Appeal) error {
+	query := `INSERT INTO appeals (id, task_id, student_a, student_b, student_id, appellant_id, review_id, state,
+	                               explanation, submitted_at, respond_by)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.pool.Exec(ctx, query,
+		appeal.ID,
+		appeal.TaskID,
+		appeal.StudentA,
+		appeal.StudentB,
+		appeal.StudentID,
+		appeal.AppellantID,
+		appeal.ReviewID,
+		appeal.State,
+		appeal.Explanation,
+		appeal.SubmittedAt,
+		appeal.RespondBy)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrAlreadyExists
+	}
+	return err
+}
+
+// GetAppeal возвращает апелляцию вместе с приложениями.
+func (r *FileRepo) GetAppeal(ctx context.Context, appealID string) (*domain.Appeal, error) {
+	query := `SELECT ` + appealColumns + ` FROM appeals WHERE id = $1`
+
+	appeal, err := scanAppeal(r.pool.QueryRow(ctx, query, appealID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	appeals := []domain.Appeal{*appeal}
+	if err = r.loadAppealAttachments(ctx, appeals); err != nil {
+		return nil, err
+	}
+
+	return &appeals[0], nil
+}
+
+// ListAppealsByTaskID возвращает апелляции задачи в состоянии state (пустое - в любом),
+// сначала те, на которые нужно ответить раньше.
+func (r *FileRepo) ListAppealsByTaskID(ctx context.Context, taskID string, state domain.AppealState) ([]domain.Appeal, error) {
+	query := `SELECT ` + appealColumns + `
+	          FROM appeals
+	          WHERE task_id = $1 AND ($2 = '' OR state = $2)
+	          ORDER BY respond_by, submitted_at`
+
+	rows, err := r.pool.Query(ctx, query, taskID, string(state))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var appeals []domain.Appeal
+	for rows.Next() {
+		appeal, err := scanAppeal(rows)
+		if err != nil {
+			return nil, err
+		}
+		appeals = append(appeals, *appeal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err = r.loadAppealAttachments(ctx, appeals); err != nil {
+		return nil, err
+	}
+
+	return appeals, nil
+}
+
+// AddAppealAttachment привязывает к апелляции файл, загруженный через storage-service.
+func (r *FileRepo) AddAppealAttachment(ctx context.Context, attachment *domain.AppealAttachment) error {
+	query := `INSERT INTO appeal_attachments (appeal_id, attachment_id, file_name, added_at)
+	          VALUES ($1, $2, $3, $4)`
+
+	_, err := r.pool.Exec(ctx, query, attachment.AppealID, attachment.AttachmentID, attachment.FileName, attachment.AddedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrAlreadyExists
+	}
+	return err
+}
+
+// ResolveAppeal закрывает открытую апелляцию и, если review не nil, одной транзакцией записывает новое решение по паре.
+// Если апелляцию уже закрыли другим запросом, возвращает ErrConflict.
+func (r *FileRepo) ResolveAppeal(ctx context.Context, appeal *domain.Appeal, review *domain.PairReview) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if review != nil {
+			if err := saveReview(ctx, tx, review); err != nil {
+				return err
+			}
+			appeal.ResolutionReviewID = &review.ID
+		}
+
+		query := `UPDATE appeals
+		          SET state = $2, resolved_at = $3, resolver_id = $4, resolution_comment = $5, resolution_review_id = $6
+		          WHERE id = $1 AND state = 'open'`
+
+		tag, err := tx.Exec(ctx, query,
+			appeal.ID,
+			appeal.State,
+			appeal.ResolvedAt,
+			appeal.ResolverID,
+			appeal.ResolutionComment,
+			appeal.ResolutionReviewID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return repositories.ErrConflict
+		}
+
+		return nil
+	})
+}
+
+func (r *FileRepo) loadAppealAttachments(ctx context.Context, appeals []domain.Appeal) error {
+	if len(appeals) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(appeals))
+	index := make(map[uuid.UUID]int, len(appeals))
+	for i, a := range appeals {
+		ids = append(ids, a.ID.String())
+		index[a.ID] = i
+	}
+
+	query := `SELECT appeal_id, attachment_id, file_name, added_at
+	          FROM appeal_attachments
+	          WHERE appeal_id = ANY($1)
+	          ORDER BY added_at`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attachment domain.AppealAttachment
+		if err = rows.Scan(&attachment.AppealID, &attachment.AttachmentID, &attachment.FileName, &attachment.AddedAt); err != nil {
+			return err
+		}
+
+		i := index[attachment.AppealID]
+		appeals[i].Attachments = append(appeals[i].Attachments, attachment)
+	}
+
+	return rows.Err()
+}
+
+func scanAppeal(row pgx.Row) (*domain.Appeal, error) {
+	var appeal domain.Appeal
+	err := row.Scan(
+		&appeal.ID,
+		&appeal.TaskID,
+		&appeal.StudentA,
+		&appeal.StudentB,
+		&appeal.StudentID,
+		&appeal.AppellantID,
+		&appeal.ReviewID,
+		&appeal.State,
+		&appeal.Explanation,
+		&appeal.SubmittedAt,
+		&appeal.RespondBy,
+		&appeal.ResolvedAt,
+		&appeal.ResolverID,
+		&appeal.ResolutionComment,
+		&appeal.ResolutionReviewID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appeal, nil
+}
@@ -0,0 +1,376 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+	"github.com/jackc/pgx/v5"
+)
+
+const jobColumns = `id, task_id, state, progress_done, progress_total, error, created_at, started_at, finished_at,
+	attempts, max_attempts, next_run_at, lease_owner, lease_expires_at, files_extracted, files_total, suspicious_pairs`
+
+func (r *FileRepo) SaveJob(ctx context.Context, job *domain.AnalysisJob) error {
+	return saveJob(ctx, r.pool, job)
+}
+
+func saveJob(ctx context.Context, db executor, job *domain.AnalysisJob) error {
+	query := `INSERT INTO analysis_jobs (id, task_id, state, created_at, max_attempts, next_run_at)
+	          VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := db.Exec(ctx, query,
+		job.ID.String(),
+		job.TaskID,
+		string(job.State),
+		job.CreatedAt,
+		job.MaxAttempts,
+		job.NextRunAt)
+	return err
+}
+
+// EnqueueJob ставит задание в очередь, если у задачи ещё нет активного (queued или running) задания,
+// иначе возвращает активное задание и false. Постановка заданий одной задачи сериализуется
+// advisory-блокировкой на время транзакции; уникальный индекс по активным заданиям страхует от гонок.
+func (r *FileRepo) EnqueueJob(ctx context.Context, job *domain.AnalysisJob) (*domain.AnalysisJob, bool, error) {
+	var (
+		active  *domain.AnalysisJob
+		created bool
+	)
+
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, lockSpaceJobEnqueue, job.TaskID)
+		if err != nil {
+			return err
+		}
+
+		query := `SELECT ` + jobColumns + ` FROM analysis_jobs WHERE task_id = $1 AND state IN ($2, $3)`
+
+		existing, err := scanJob(tx.QueryRow(ctx, query,
+			job.TaskID,
+			string(domain.JobStateQueued),
+			string(domain.JobStateRunning)))
+		if err == nil {
+			active = existing
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		if err = saveJob(ctx, tx, job); err != nil {
+			return err
+		}
+
+		active, created = job, true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return active, created, nil
+}
+
+func (r *FileRepo) GetJobByID(ctx context.Context, jobID string) (*domain.AnalysisJob, error) {
+	query := `SELECT ` + jobColumns + ` FROM analysis_jobs WHERE id = $1`
+
+	job, err := scanJob(r.pool.QueryRow(ctx, query, jobID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetLatestJobByTaskID возвращает последнее созданное задание анализа задачи.
+func (r *FileRepo) GetLatestJobByTaskID(ctx context.Context, taskID string) (*domain.AnalysisJob, error) {
+	query := `SELECT ` + jobColumns + ` FROM analysis_jobs
+	          WHERE task_id = $1
+	          ORDER BY created_at DESC
+	          LIMIT 1`
+
+	job, err := scanJob(r.pool.QueryRow(ctx, query, taskID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ClaimJob берёт в аренду одно готовое к запуску задание: из очереди или с истёкшей арендой.
+// Конкурирующие воркеры пропускают строки, заблокированные друг другом (SKIP LOCKED).
+// Если подходящих заданий нет, возвращает repositories.ErrNotFound.
+func (r *FileRepo) ClaimJob(ctx context.Context, owner string, now, leaseExpiresAt time.Time) (*domain.AnalysisJob, error) {
+	query := `UPDATE analysis_jobs
+	          SET state = $1,
+	              lease_owner = $2,
+	              lease_expires_at = $3,
+	              attempts = attempts + 1,
+	              started_at = COALESCE(started_at, $4),
+	              progress_done = 0,
+	              progress_total = 0,
+	              files_extracted = 0,
+	              files_total = 0,
+	              suspicious_pairs = 0
+	          WHERE id = (
+	              SELECT id FROM analysis_jobs
+	              WHERE (state = $5 AND next_run_at <= $4)
+	                 OR (state = $1 AND lease_expires_at < $4 AND attempts < max_attempts)
+	              ORDER BY next_run_at
+	              LIMIT 1
+	              FOR UPDATE SKIP LOCKED
+	          )
+	          RETURNING ` + jobColumns
+
+	job, err := scanJob(r.pool.QueryRow(ctx, query,
+		string(domain.JobStateRunning),
+		owner,
+		leaseExpiresAt,
+		now,
+		string(domain.JobStateQueued)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// BuryExpiredJobs переводит в dead задания с истёкшей арендой, у которых не осталось попыток:
+// воркер, выполнявший их, падал на каждой попытке.
+func (r *FileRepo) BuryExpiredJobs(ctx context.Context, now time.Time) (int64, error) {
+	query := `UPDATE analysis_jobs
+	          SET state = $1,
+	              error = 'job lease expired too many times',
+	              finished_at = $2,
+	              lease_owner = NULL,
+	              lease_expires_at = NULL
+	          WHERE state = $3 AND lease_expires_at < $2 AND attempts >= max_attempts`
+
+	result, err := r.pool.Exec(ctx, query, string(domain.JobStateDead), now, string(domain.JobStateRunning))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ExtendLease продлевает аренду задания. Возвращает false, если задание больше не принадлежит воркеру
+// (отменено или перехвачено другим воркером после истечения аренды).
+func (r *FileRepo) ExtendLease(ctx context.Context, jobID, owner string, leaseExpiresAt time.Time) (bool, error) {
+	query := `UPDATE analysis_jobs SET lease_expires_at = $3
+	          WHERE id = $1 AND lease_owner = $2 AND state = $4`
+
+	result, err := r.pool.Exec(ctx, query, jobID, owner, leaseExpiresAt, string(domain.JobStateRunning))
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *FileRepo) UpdateJobProgress(ctx context.Context, jobID string, progress domain.JobProgress) error {
+	query := `UPDATE analysis_jobs
+	          SET progress_done = $2, progress_total = $3, files_extracted = $4, files_total = $5, suspicious_pairs = $6
+	          WHERE id = $1`
+
+	_, err := r.pool.Exec(ctx, query,
+		jobID,
+		progress.PairsDone,
+		progress.PairsTotal,
+		progress.FilesExtracted,
+		progress.FilesTotal,
+		progress.SuspiciousPairs)
+	return err
+}
+
+// SaveJobFinding сохраняет подозрительную пару, найденную заданием. Повторная попытка задания
+// обновляет уже найденную пару.
+func (r *FileRepo) SaveJobFinding(ctx context.Context, finding *domain.JobFinding) error {
+	query := `INSERT INTO analysis_job_findings (job_id, student_a, student_b, similarity, found_at)
+	          VALUES ($1, $2, $3, $4, $5)
+	          ON CONFLICT (job_id, student_a, student_b) DO UPDATE SET
+	              similarity = EXCLUDED.similarity,
+	              found_at = EXCLUDED.found_at`
+
+	_, err := r.pool.Exec(ctx, query,
+		finding.JobID,
+		finding.StudentA,
+		finding.StudentB,
+		finding.Similarity,
+		finding.FoundAt)
+	return err
+}
+
+// DeleteJobFindings удаляет находки прошлых попыток задания.
+func (r *FileRepo) DeleteJobFindings(ctx context.Context, jobID string) error {
+	query := `DELETE FROM analysis_job_findings WHERE job_id = $1`
+
+	_, err := r.pool.Exec(ctx, query, jobID)
+	return err
+}
+
+// ListJobFindings возвращает находки задания с id больше afterID в порядке появления.
+func (r *FileRepo) ListJobFindings(ctx context.Context, jobID string, afterID int64) ([]domain.JobFinding, error) {
+	query := `SELECT id, job_id, student_a, student_b, similarity, found_at
+	          FROM analysis_job_findings
+	          WHERE job_id = $1 AND id > $2
+	          ORDER BY id`
+
+	rows, err := r.pool.Query(ctx, query, jobID, afterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var findings []domain.JobFinding
+	for rows.Next() {
+		var finding domain.JobFinding
+		err := rows.Scan(
+			&finding.ID,
+			&finding.JobID,
+			&finding.StudentA,
+			&finding.StudentB,
+			&finding.Similarity,
+			&finding.FoundAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, finding)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return findings, nil
+}
+
+// CompleteJob завершает задание, которое воркер держит в аренде.
+func (r *FileRepo) CompleteJob(ctx context.Context, jobID, owner string, state domain.JobState, errMsg string, finishedAt time.Time) (bool, error) {
+	query := `UPDATE analysis_jobs
+	          SET state = $3, error = $4, finished_at = $5, lease_owner = NULL, lease_expires_at = NULL
+	          WHERE id = $1 AND lease_owner = $2 AND state = $6`
+
+	result, err := r.pool.Exec(ctx, query,
+		jobID,
+		owner,
+		string(state),
+		errMsg,
+		finishedAt,
+		string(domain.JobStateRunning))
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// RetryJob возвращает задание в очередь до nextRunAt или, если попытки исчерпаны, переводит его в dead.
+// Возвращает итоговое состояние задания.
+func (r *FileRepo) RetryJob(ctx context.Context, jobID, owner, errMsg string, nextRunAt, now time.Time) (domain.JobState, error) {
+	query := `UPDATE analysis_jobs
+	          SET state = CASE WHEN attempts >= max_attempts THEN $3 ELSE $4 END,
+	              finished_at = CASE WHEN attempts >= max_attempts THEN $5::timestamp END,
+	              next_run_at = $6,
+	              error = $7,
+	              lease_owner = NULL,
+	              lease_expires_at = NULL
+	          WHERE id = $1 AND lease_owner = $2 AND state = $8
+	          RETURNING state`
+
+	var state string
+	err := r.pool.QueryRow(ctx, query,
+		jobID,
+		owner,
+		string(domain.JobStateDead),
+		string(domain.JobStateQueued),
+		now,
+		nextRunAt,
+		errMsg,
+		string(domain.JobStateRunning)).Scan(&state)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", repositories.ErrNotFound
+		}
+		return "", err
+	}
+
+	return domain.JobState(state), nil
+}
+
+// ReleaseJob возвращает задание в очередь без учёта попытки, например при остановке сервиса.
+func (r *FileRepo) ReleaseJob(ctx context.Context, jobID, owner string, nextRunAt time.Time) error {
+	query := `UPDATE analysis_jobs
+	          SET state = $3, attempts = GREATEST(attempts - 1, 0), next_run_at = $4,
+	              lease_owner = NULL, lease_expires_at = NULL
+	          WHERE id = $1 AND lease_owner = $2 AND state = $5`
+
+	_, err := r.pool.Exec(ctx, query, jobID, owner, string(domain.JobStateQueued), nextRunAt, string(domain.JobStateRunning))
+	return err
+}
+
+// FinishJob завершает ещё не завершённое задание независимо от аренды (используется для отмены).
+// Возвращает false, если задание уже было завершено.
+func (r *FileRepo) FinishJob(ctx context.Context, jobID string, state domain.JobState, errMsg string, finishedAt time.Time) (bool, error) {
+	query := `UPDATE analysis_jobs
+	          SET state = $2, error = $3, finished_at = $4, lease_owner = NULL, lease_expires_at = NULL
+	          WHERE id = $1 AND state IN ($5, $6)`
+
+	result, err := r.pool.Exec(ctx, query,
+		jobID,
+		string(state),
+		errMsg,
+		finishedAt,
+		string(domain.JobStateQueued),
+		string(domain.JobStateRunning))
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func scanJob(row pgx.Row) (*domain.AnalysisJob, error) {
+	var job domain.AnalysisJob
+	var state string
+
+	err := row.Scan(
+		&job.ID,
+		&job.TaskID,
+		&state,
+		&job.ProgressDone,
+		&job.ProgressTotal,
+		&job.Error,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.NextRunAt,
+		&job.LeaseOwner,
+		&job.LeaseExpiresAt,
+		&job.FilesExtracted,
+		&job.FilesTotal,
+		&job.SuspiciousPairs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.State = domain.JobState(state)
+
+	return &job, nil
+}
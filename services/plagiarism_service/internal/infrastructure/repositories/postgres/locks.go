@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+)
+
+// Пространства ключей advisory-блокировок. Ключ задачи - hashtext(task_id) внутри пространства.
+const (
+	// lockSpaceJobEnqueue сериализует постановку заданий одной задачи в очередь (на время транзакции).
+	lockSpaceJobEnqueue int32 = 1
+	// lockSpaceTaskAnalysis не даёт двум воркерам одновременно анализировать одну задачу (на время прогона).
+	lockSpaceTaskAnalysis int32 = 2
+)
+
+// TryLockTaskAnalysis берёт сессионную advisory-блокировку анализа задачи на отдельном соединении пула.
+// Блокировка снимается вызовом unlock или автоматически, если соединение с БД оборвалось.
+// Возвращает repositories.ErrLocked, если задачу уже анализирует другой воркер.
+func (r *FileRepo) TryLockTaskAnalysis(ctx context.Context, taskID string) (func(), error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked bool
+	err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1, hashtext($2))`, lockSpaceTaskAnalysis, taskID).Scan(&locked)
+	if err != nil {
+		conn.Release()
+		return nil, err
+	}
+
+	if !locked {
+		conn.Release()
+		return nil, repositories.ErrLocked
+	}
+
+	unlock := func() {
+		// блокировка принадлежит сессии: если снять её не удалось, закрываем соединение, и она снимется сама
+		_, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1, hashtext($2))`, lockSpaceTaskAnalysis, taskID)
+		if err != nil {
+			_ = conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}
+
+	return unlock, nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+func deletePairFiles(ctx context.Context, db executor, report *domain.PlagiarismReport) error {
+	query := `DELETE FROM pair_file_reports WHERE task_id = $1 AND student_a = $2 AND student_b = $3`
+
+	_, err := db.Exec(ctx, query, report.TaskId, report.StudentA, report.StudentB)
+	return err
+}
+
+func savePairFile(ctx context.Context, db executor, file *domain.PairFileReport) error {
+	query := `INSERT INTO pair_file_reports (task_id, student_a, student_b, file_name, similarity, file_a_version, file_b_version)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := db.Exec(ctx, query,
+		file.TaskID,
+		file.StudentA,
+		file.StudentB,
+		file.FileName,
+		file.Similarity,
+		file.FileAVersion,
+		file.FileBVersion)
+	return err
+}
+
+// GetPairFilesByTaskID возвращает пофайловую схожесть всех пар задачи.
+func (r *FileRepo) GetPairFilesByTaskID(ctx context.Context, taskID string) ([]domain.PairFileReport, error) {
+	query := `SELECT task_id, student_a, student_b, file_name, similarity, file_a_version, file_b_version
+	          FROM pair_file_reports
+	          WHERE task_id = $1
+	          ORDER BY student_a, student_b, file_name`
+
+	rows, err := r.pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPairFiles(rows)
+}
+
+// GetPairFilesByPair возвращает пофайловую схожесть пары по имени файла.
+func (r *FileRepo) GetPairFilesByPair(ctx context.Context, taskID, studentA, studentB string) ([]domain.PairFileReport, error) {
+	query := `SELECT task_id, student_a, student_b, file_name, similarity, file_a_version, file_b_version
+	          FROM pair_file_reports
+	          WHERE task_id = $1 AND student_a = $2 AND student_b = $3
+	          ORDER BY file_name`
+
+	rows, err := r.pool.Query(ctx, query, taskID, studentA, studentB)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPairFiles(rows)
+}
+
+func scanPairFiles(rows pgx.Rows) ([]domain.PairFileReport, error) {
+	defer rows.Close()
+
+	var files []domain.PairFileReport
+	for rows.Next() {
+		var file domain.PairFileReport
+		err := rows.Scan(
+			&file.TaskID,
+			&file.StudentA,
+			&file.StudentB,
+			&file.FileName,
+			&file.Similarity,
+			&file.FileAVersion,
+			&file.FileBVersion,
+		)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
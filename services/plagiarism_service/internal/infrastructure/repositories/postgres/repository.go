@@ -8,6 +8,7 @@ import (
 	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
 	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -15,31 +16,50 @@ type FileRepo struct {
 	pool *pgxpool.Pool
 }
 
+// executor - общее у пула и транзакции, чтобы один и тот же запрос выполнялся в обоих.
+type executor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// rowQuerier - пул или транзакция, для запросов с RETURNING.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 func NewFileRepository(pool *pgxpool.Pool) *FileRepo {
 	return &FileRepo{pool: pool}
 }
 
-func (r *FileRepo) SaveTask(ctx context.Context, task *domain.Task) error {
-	query := `INSERT INTO tasks (id, analysis_started_at) VALUES ($1, $2) 
-	          ON CONFLICT (id) DO UPDATE SET analysis_started_at = EXCLUDED.analysis_started_at`
+// EnsureTask заводит запись о задаче без времени анализа, если её ещё нет. Время ставит только ApplyAnalysis.
+func (r *FileRepo) EnsureTask(ctx context.Context, taskID string) error {
+	query := `INSERT INTO tasks (id) VALUES ($1) ON CONFLICT (id) DO NOTHING`
 
-	_, err := r.pool.Exec(ctx, query, task.ID, task.AnalysisStartedAt)
+	_, err := r.pool.Exec(ctx, query, taskID)
 	return err
 }
 
-func (r *FileRepo) SaveReport(ctx context.Context, report *domain.PlagiarismReport) error {
+func upsertReport(ctx context.Context, db executor, report *domain.PlagiarismReport) error {
 	query := `INSERT INTO plagiarism_reports 
-	          (id, task_id, student_a, student_b, similarity, file_a_handed_over_at, file_b_handed_over_at) 
-	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	          (id, task_id, student_a, student_b, similarity, file_a_handed_over_at, file_b_handed_over_at,
+	           file_a_version, file_b_version) 
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	          ON CONFLICT (task_id, student_a, student_b) DO UPDATE SET
+	              similarity = EXCLUDED.similarity,
+	              file_a_handed_over_at = EXCLUDED.file_a_handed_over_at,
+	              file_b_handed_over_at = EXCLUDED.file_b_handed_over_at,
+	              file_a_version = EXCLUDED.file_a_version,
+	              file_b_version = EXCLUDED.file_b_version`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		report.ID.String(),
 		report.TaskId,
 		report.StudentA,
 		report.StudentB,
 		report.Similarity,
 		report.FileAHandedOverAt,
-		report.FileBHandedOverAt)
+		report.FileBHandedOverAt,
+		report.FileAVersion,
+		report.FileBVersion)
 	return err
 }
 
@@ -65,8 +85,17 @@ func (r *FileRepo) DeleteReportsByTaskID(ctx context.Context, taskID string) err
 	return err
 }
 
+func deleteReportsByStudents(ctx context.Context, db executor, taskID string, studentIDs []string) error {
+	query := `DELETE FROM plagiarism_reports 
+	          WHERE task_id = $1 AND (student_a = ANY($2) OR student_b = ANY($2))`
+
+	_, err := db.Exec(ctx, query, taskID, studentIDs)
+	return err
+}
+
 func (r *FileRepo) GetReportsByStudentID(ctx context.Context, studentID string) ([]domain.PlagiarismReport, error) {
-	query := `SELECT id, task_id, student_a, student_b, similarity, file_a_handed_over_at, file_b_handed_over_at 
+	query := `SELECT id, task_id, student_a, student_b, similarity, file_a_handed_over_at, file_b_handed_over_at,
+	                 file_a_version, file_b_version
 	          FROM plagiarism_reports 
 	          WHERE student_a = $1 OR student_b = $1`
 
@@ -87,6 +116,8 @@ func (r *FileRepo) GetReportsByStudentID(ctx context.Context, studentID string)
 			&report.Similarity,
 			&report.FileAHandedOverAt,
 			&report.FileBHandedOverAt,
+			&report.FileAVersion,
+			&report.FileBVersion,
 		)
 		if err != nil {
 			return nil, err
@@ -101,8 +132,100 @@ func (r *FileRepo) GetReportsByStudentID(ctx context.Context, studentID string)
 	return reports, nil
 }
 
+func (r *FileRepo) GetReportsByTaskID(ctx context.Context, taskID string) ([]domain.PlagiarismReport, error) {
+	query := `SELECT id, task_id, student_a, student_b, similarity, file_a_handed_over_at, file_b_handed_over_at,
+	                 file_a_version, file_b_version
+	          FROM plagiarism_reports 
+	          WHERE task_id = $1
+	          ORDER BY student_a, student_b`
+
+	rows, err := r.pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanReports(rows)
+}
+
+// GetReportsByTaskIDAbove возвращает пары задачи со схожестью не ниже minSimilarity, самые похожие первыми.
+func (r *FileRepo) GetReportsByTaskIDAbove(ctx context.Context, taskID string, minSimilarity float64) ([]domain.PlagiarismReport, error) {
+	query := `SELECT id, task_id, student_a, student_b, similarity, file_a_handed_over_at, file_b_handed_over_at,
+	                 file_a_version, file_b_version
+	          FROM plagiarism_reports 
+	          WHERE task_id = $1 AND similarity >= $2
+	          ORDER BY similarity DESC, student_a, student_b`
+
+	rows, err := r.pool.Query(ctx, query, taskID, minSimilarity)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanReports(rows)
+}
+
+// GetReportByPair возвращает отчёт пары; студенты передаются в каноническом порядке (studentA < studentB).
+func (r *FileRepo) GetReportByPair(ctx context.Context, taskID, studentA, studentB string) (*domain.PlagiarismReport, error) {
+	query := `SELECT id, task_id, student_a, student_b, similarity, file_a_handed_over_at, file_b_handed_over_at,
+	                 file_a_version, file_b_version
+	          FROM plagiarism_reports 
+	          WHERE task_id = $1 AND student_a = $2 AND student_b = $3`
+
+	var report domain.PlagiarismReport
+	err := r.pool.QueryRow(ctx, query, taskID, studentA, studentB).Scan(
+		&report.ID,
+		&report.TaskId,
+		&report.StudentA,
+		&report.StudentB,
+		&report.Similarity,
+		&report.FileAHandedOverAt,
+		&report.FileBHandedOverAt,
+		&report.FileAVersion,
+		&report.FileBVersion,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func scanReports(rows pgx.Rows) ([]domain.PlagiarismReport, error) {
+	defer rows.Close()
+
+	var reports []domain.PlagiarismReport
+	for rows.Next() {
+		var report domain.PlagiarismReport
+		err := rows.Scan(
+			&report.ID,
+			&report.TaskId,
+			&report.StudentA,
+			&report.StudentB,
+			&report.Similarity,
+			&report.FileAHandedOverAt,
+			&report.FileBHandedOverAt,
+			&report.FileAVersion,
+			&report.FileBVersion,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// GetTaskByID возвращает задачу, результаты анализа которой уже сохранены; задача, которая только стоит
+// в очереди, не найдена.
 func (r *FileRepo) GetTaskByID(ctx context.Context, taskID string) (*domain.Task, error) {
-	query := `SELECT id, analysis_started_at FROM tasks WHERE id = $1`
+	query := `SELECT id, analysis_started_at FROM tasks WHERE id = $1 AND analysis_started_at IS NOT NULL`
 
 	var task domain.Task
 	err := r.pool.QueryRow(ctx, query, taskID).Scan(&task.ID, &task.AnalysisStartedAt)
@@ -116,10 +239,187 @@ func (r *FileRepo) GetTaskByID(ctx context.Context, taskID string) (*domain.Task
 	return &task, nil
 }
 
-// UpdateTaskAnalysisTime обновляет только время начала анализа для задачи.
-func (r *FileRepo) UpdateTaskAnalysisTime(ctx context.Context, taskID string, analysisStartedAt time.Time) error {
+func updateTaskAnalysisTime(ctx context.Context, db executor, taskID string, analysisStartedAt time.Time) error {
 	query := `UPDATE tasks SET analysis_started_at = $2 WHERE id = $1`
 
-	_, err := r.pool.Exec(ctx, query, taskID, analysisStartedAt)
+	_, err := db.Exec(ctx, query, taskID, analysisStartedAt)
 	return err
 }
+
+func (r *FileRepo) GetAnalyzedFiles(ctx context.Context, taskID string) ([]domain.AnalyzedFile, error) {
+	query := `SELECT task_id, student_id, file_updated_at, analyzed_at 
+	          FROM analyzed_files 
+	          WHERE task_id = $1`
+
+	rows, err := r.pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []domain.AnalyzedFile
+	for rows.Next() {
+		var file domain.AnalyzedFile
+		err := rows.Scan(
+			&file.TaskID,
+			&file.StudentID,
+			&file.FileUpdatedAt,
+			&file.AnalyzedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func saveAnalyzedFile(ctx context.Context, db executor, file *domain.AnalyzedFile) error {
+	query := `INSERT INTO analyzed_files (task_id, student_id, file_updated_at, analyzed_at) 
+	          VALUES ($1, $2, $3, $4)
+	          ON CONFLICT (task_id, student_id) DO UPDATE SET
+	              file_updated_at = EXCLUDED.file_updated_at,
+	              analyzed_at = EXCLUDED.analyzed_at`
+
+	_, err := db.Exec(ctx, query, file.TaskID, file.StudentID, file.FileUpdatedAt, file.AnalyzedAt)
+	return err
+}
+
+func deleteAnalyzedFiles(ctx context.Context, db executor, taskID string, studentIDs []string) error {
+	query := `DELETE FROM analyzed_files WHERE task_id = $1 AND student_id = ANY($2)`
+
+	_, err := db.Exec(ctx, query, taskID, studentIDs)
+	return err
+}
+
+// ApplyAnalysis применяет результат прогона анализа одной транзакцией:
+// удаляет пары ушедших файлов, сохраняет пересчитанные пары и версии файлов, обновляет время анализа.
+func (r *FileRepo) ApplyAnalysis(ctx context.Context, result *domain.AnalysisResult) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if len(result.RemovedStudents) > 0 {
+			if err := deleteReportsByStudents(ctx, tx, result.TaskID, result.RemovedStudents); err != nil {
+				return err
+			}
+			if err := deleteAnalyzedFiles(ctx, tx, result.TaskID, result.RemovedStudents); err != nil {
+				return err
+			}
+		}
+
+		for i := range result.Reports {
+			if err := upsertReport(ctx, tx, &result.Reports[i]); err != nil {
+				return err
+			}
+			if err := deletePairFragments(ctx, tx, &result.Reports[i]); err != nil {
+				return err
+			}
+			if err := deletePairFiles(ctx, tx, &result.Reports[i]); err != nil {
+				return err
+			}
+		}
+
+		for i := range result.Fragments {
+			if err := savePairFragment(ctx, tx, &result.Fragments[i]); err != nil {
+				return err
+			}
+		}
+
+		for i := range result.PairFiles {
+			if err := savePairFile(ctx, tx, &result.PairFiles[i]); err != nil {
+				return err
+			}
+		}
+
+		for i := range result.AnalyzedFiles {
+			if err := saveAnalyzedFile(ctx, tx, &result.AnalyzedFiles[i]); err != nil {
+				return err
+			}
+		}
+
+		return updateTaskAnalysisTime(ctx, tx, result.TaskID, result.AnalysisStartedAt)
+	})
+}
+
+func deletePairFragments(ctx context.Context, db executor, report *domain.PlagiarismReport) error {
+	query := `DELETE FROM pair_fragments WHERE task_id = $1 AND student_a = $2 AND student_b = $3`
+
+	_, err := db.Exec(ctx, query, report.TaskId, report.StudentA, report.StudentB)
+	return err
+}
+
+func savePairFragment(ctx context.Context, db executor, fragment *domain.PairFragment) error {
+	query := `INSERT INTO pair_fragments (task_id, student_a, student_b, a_start, b_start, length, excerpt)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := db.Exec(ctx, query,
+		fragment.TaskID,
+		fragment.StudentA,
+		fragment.StudentB,
+		fragment.AStart,
+		fragment.BStart,
+		fragment.Length,
+		fragment.Excerpt)
+	return err
+}
+
+// GetFragmentsByTaskID возвращает фрагменты всех пар задачи в порядке позиции в тексте StudentA.
+func (r *FileRepo) GetFragmentsByTaskID(ctx context.Context, taskID string) ([]domain.PairFragment, error) {
+	query := `SELECT task_id, student_a, student_b, a_start, b_start, length, excerpt
+	          FROM pair_fragments
+	          WHERE task_id = $1
+	          ORDER BY student_a, student_b, a_start`
+
+	rows, err := r.pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanFragments(rows)
+}
+
+// GetFragmentsByPair возвращает фрагменты пары в порядке позиции в тексте studentA.
+func (r *FileRepo) GetFragmentsByPair(ctx context.Context, taskID, studentA, studentB string) ([]domain.PairFragment, error) {
+	query := `SELECT task_id, student_a, student_b, a_start, b_start, length, excerpt
+	          FROM pair_fragments
+	          WHERE task_id = $1 AND student_a = $2 AND student_b = $3
+	          ORDER BY a_start`
+
+	rows, err := r.pool.Query(ctx, query, taskID, studentA, studentB)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanFragments(rows)
+}
+
+func scanFragments(rows pgx.Rows) ([]domain.PairFragment, error) {
+	defer rows.Close()
+
+	var fragments []domain.PairFragment
+	for rows.Next() {
+		var fragment domain.PairFragment
+		err := rows.Scan(
+			&fragment.TaskID,
+			&fragment.StudentA,
+			&fragment.StudentB,
+			&fragment.AStart,
+			&fragment.BStart,
+			&fragment.Length,
+			&fragment.Excerpt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		fragments = append(fragments, fragment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fragments, nil
+}
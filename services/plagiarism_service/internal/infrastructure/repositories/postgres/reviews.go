@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// SaveReview добавляет решение по паре в историю и заполняет его ID.
+func (r *FileRepo) SaveReview(ctx context.Context, review *domain.PairReview) error {
+	return saveReview(ctx, r.pool, review)
+}
+
+func saveReview(ctx context.Context, db rowQuerier, review *domain.PairReview) error {
+	query := `INSERT INTO pair_reviews (task_id, student_a, student_b, verdict, comment, reviewer_id,
+	                                    file_a_handed_over_at, file_b_handed_over_at, created_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	          RETURNING id`
+
+	return db.QueryRow(ctx, query,
+		review.TaskID,
+		review.StudentA,
+		review.StudentB,
+		review.Verdict,
+		review.Comment,
+		review.ReviewerID,
+		review.FileAHandedOverAt,
+		review.FileBHandedOverAt,
+		review.CreatedAt).Scan(&review.ID)
+}
+
+// ListReviewsByPair возвращает историю решений по паре, новые первыми.
+func (r *FileRepo) ListReviewsByPair(ctx context.Context, taskID, studentA, studentB string) ([]domain.PairReview, error) {
+	query := `SELECT id, task_id, student_a, student_b, verdict, comment, reviewer_id,
+	                 file_a_handed_over_at, file_b_handed_over_at, created_at
+	          FROM pair_reviews
+	          WHERE task_id = $1 AND student_a = $2 AND student_b = $3
+	          ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query, taskID, studentA, studentB)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanReviews(rows)
+}
+
+// ListReviewsByTaskID возвращает историю решений по всем парам задачи, новые первыми.
+func (r *FileRepo) ListReviewsByTaskID(ctx context.Context, taskID string) ([]domain.PairReview, error) {
+	query := `SELECT id, task_id, student_a, student_b, verdict, comment, reviewer_id,
+	                 file_a_handed_over_at, file_b_handed_over_at, created_at
+	          FROM pair_reviews
+	          WHERE task_id = $1
+	          ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanReviews(rows)
+}
+
+func scanReviews(rows pgx.Rows) ([]domain.PairReview, error) {
+	defer rows.Close()
+
+	var reviews []domain.PairReview
+	for rows.Next() {
+		var review domain.PairReview
+		err := rows.Scan(
+			&review.ID,
+			&review.TaskID,
+			&review.StudentA,
+			&review.StudentB,
+			&review.Verdict,
+			&review.Comment,
+			&review.ReviewerID,
+			&review.FileAHandedOverAt,
+			&review.FileBHandedOverAt,
+			&review.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	gen "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/use_cases"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxAppealExplanationLength  = 10000
+	maxAttachmentFileNameLength = 255
+)
+
+func (h *Handler) CreateAppeal(ctx context.Context, req *gen.CreateAppealRequest) (*gen.CreateAppealResponse, error) {
+	const op = "Handler.CreateAppeal"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("StudentId", req.GetStudentId()),
+		slog.String("OtherStudentId", req.GetOtherStudentId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	if err := ValidatePair(req.GetTaskId(), req.GetStudentId(), req.GetOtherStudentId(), h.logger); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(req.GetExplanation()) == "" {
+		logger.Warn("explanation required")
+		return nil, status.Error(codes.InvalidArgument, "explanation required")
+	}
+	if utf8.RuneCountInString(req.GetExplanation()) > maxAppealExplanationLength {
+		logger.Warn("explanation is too long")
+		return nil, status.Error(codes.InvalidArgument, "explanation is too long")
+	}
+
+	appeal, err := h.service.CreateAppeal(
+		ctx,
+		req.GetTaskId(),
+		req.GetStudentId(),
+		req.GetOtherStudentId(),
+		req.GetExplanation(),
+	)
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
+		}
+		if errors.Is(err, use_cases.ErrAppealNotAllowed) {
+			logger.Warn("pair is not confirmed")
+			return nil, status.Error(codes.FailedPrecondition, "pair has no confirmed verdict to appeal")
+		}
+		if errors.Is(err, use_cases.ErrAppealWindowClosed) {
+			logger.Warn("appeal window is closed")
+			return nil, status.Error(codes.FailedPrecondition, "appeal window is closed")
+		}
+		if errors.Is(err, use_cases.ErrAppealExists) {
+			logger.Warn("appeal already exists")
+			return nil, status.Error(codes.AlreadyExists, "appeal against this verdict already exists")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.CreateAppealResponse{
+		Appeal: toProtoAppeal(appeal),
+	}, nil
+}
+
+func (h *Handler) AddAppealAttachment(ctx context.Context, req *gen.AddAppealAttachmentRequest) (*gen.AddAppealAttachmentResponse, error) {
+	const op = "Handler.AddAppealAttachment"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("AppealId", req.GetAppealId()),
+		slog.String("StudentId", req.GetStudentId()),
+		slog.String("AttachmentId", req.GetAttachmentId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	if err := ValidateIdWrapped(req.GetAppealId(), "appeal", logger); err != nil {
+		return nil, err
+	}
+	if err := ValidateIdWrapped(req.GetStudentId(), "student", logger); err != nil {
+		return nil, err
+	}
+	if err := ValidateIdWrapped(req.GetAttachmentId(), "attachment", logger); err != nil {
+		return nil, err
+	}
+
+	if req.GetFileName() == "" {
+		logger.Warn("file name required")
+		return nil, status.Error(codes.InvalidArgument, "file name required")
+	}
+	if utf8.RuneCountInString(req.GetFileName()) > maxAttachmentFileNameLength {
+		logger.Warn("file name is too long")
+		return nil, status.Error(codes.InvalidArgument, "file name is too long")
+	}
+
+	appeal, err := h.service.AddAppealAttachment(
+		ctx,
+		req.GetAppealId(),
+		req.GetStudentId(),
+		req.GetAttachmentId(),
+		req.GetFileName(),
+	)
+	if err != nil {
+		if errors.Is(err, use_cases.ErrAppealNotFound) {
+			logger.Warn("appeal not found")
+			return nil, status.Error(codes.NotFound, "appeal not found")
+		}
+		if errors.Is(err, use_cases.ErrNotAppellant) {
+			logger.Warn("student is not the author of the appeal")
+			return nil, status.Error(codes.PermissionDenied, "only the author can add attachments to the appeal")
+		}
+		if errors.Is(err, use_cases.ErrAppealResolved) {
+			logger.Warn("appeal is already resolved")
+			return nil, status.Error(codes.FailedPrecondition, "appeal is already resolved")
+		}
+		if errors.Is(err, use_cases.ErrTooManyAttachments) {
+			logger.Warn("too many attachments")
+			return nil, status.Error(codes.FailedPrecondition, "too many attachments")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.AddAppealAttachmentResponse{
+		Appeal: toProtoAppeal(appeal),
+	}, nil
+}
+
+func (h *Handler) ResolveAppeal(ctx context.Context, req *gen.ResolveAppealRequest) (*gen.ResolveAppealResponse, error) {
+	const op = "Handler.ResolveAppeal"
+
+	// решение записывается от имени пользователя вызова; поле запроса - для вызовов без пользователя
+	reviewerId := identity.ActorID(ctx, req.GetReviewerId())
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("AppealId", req.GetAppealId()),
+		slog.String("Outcome", req.GetOutcome()),
+		slog.String("ReviewerId", reviewerId),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	if err := ValidateIdWrapped(req.GetAppealId(), "appeal", logger); err != nil {
+		return nil, err
+	}
+	if err := ValidateIdWrapped(reviewerId, "reviewer", logger); err != nil {
+		return nil, err
+	}
+
+	if utf8.RuneCountInString(req.GetComment()) > maxReviewCommentLength {
+		logger.Warn("comment is too long")
+		return nil, status.Error(codes.InvalidArgument, "comment is too long")
+	}
+
+	appeal, err := h.service.ResolveAppeal(
+		ctx,
+		req.GetAppealId(),
+		req.GetOutcome(),
+		req.GetComment(),
+		reviewerId,
+		req.GetVerdict(),
+	)
+	if err != nil {
+		if errors.Is(err, use_cases.ErrInvalidOutcome) {
+			logger.Warn("unknown outcome")
+			return nil, status.Error(codes.InvalidArgument, "outcome must be upheld or rejected")
+		}
+		if errors.Is(err, use_cases.ErrInvalidVerdict) {
+			logger.Warn("invalid verdict")
+			return nil, status.Error(codes.InvalidArgument, "verdict of an upheld appeal must be false_positive, allowed_collaboration or needs_discussion")
+		}
+		if errors.Is(err, use_cases.ErrAppealNotFound) {
+			logger.Warn("appeal not found")
+			return nil, status.Error(codes.NotFound, "appeal not found")
+		}
+		if errors.Is(err, use_cases.ErrAppealResolved) {
+			logger.Warn("appeal is already resolved")
+			return nil, status.Error(codes.FailedPrecondition, "appeal is already resolved")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.ResolveAppealResponse{
+		Appeal: toProtoAppeal(appeal),
+	}, nil
+}
+
+func (h *Handler) GetAppeal(ctx context.Context, req *gen.GetAppealRequest) (*gen.GetAppealResponse, error) {
+	const op = "Handler.GetAppeal"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("AppealId", req.GetAppealId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	if err := ValidateIdWrapped(req.GetAppealId(), "appeal", logger); err != nil {
+		return nil, err
+	}
+
+	appeal, err := h.service.GetAppeal(ctx, req.GetAppealId())
+	if err != nil {
+		if errors.Is(err, use_cases.ErrAppealNotFound) {
+			logger.Warn("appeal not found")
+			return nil, status.Error(codes.NotFound, "appeal not found")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.GetAppealResponse{
+		Appeal: toProtoAppeal(appeal),
+	}, nil
+}
+
+func (h *Handler) ListAppeals(ctx context.Context, req *gen.ListAppealsRequest) (*gen.ListAppealsResponse, error) {
+	const op = "Handler.ListAppeals"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("State", req.GetState()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	if err := ValidateTaskId(req.GetTaskId(), h.logger); err != nil {
+		return nil, err
+	}
+
+	appeals, err := h.service.ListAppeals(ctx, req.GetTaskId(), req.GetState())
+	if err != nil {
+		if errors.Is(err, use_cases.ErrInvalidOutcome) {
+			logger.Warn("unknown state")
+			return nil, status.Error(codes.InvalidArgument, "state must be open, upheld or rejected")
+		}
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	result := make([]*gen.Appeal, 0, len(appeals))
+	for i := range appeals {
+		result = append(result, toProtoAppeal(&appeals[i]))
+	}
+
+	return &gen.ListAppealsResponse{
+		Appeals: result,
+	}, nil
+}
+
+func toProtoAppeal(appeal *use_cases.Appeal) *gen.Appeal {
+	result := &gen.Appeal{
+		Id:                 appeal.ID,
+		TaskId:             appeal.TaskID,
+		StudentA:           appeal.StudentA,
+		StudentB:           appeal.StudentB,
+		StudentId:          appeal.StudentID,
+		AppellantId:        appeal.AppellantID,
+		ReviewId:           appeal.ReviewID,
+		State:              appeal.State,
+		Explanation:        appeal.Explanation,
+		SubmittedAt:        timestamppb.New(appeal.SubmittedAt),
+		RespondBy:          timestamppb.New(appeal.RespondBy),
+		ResolverId:         appeal.ResolverID,
+		ResolutionComment:  appeal.ResolutionComment,
+		ResolutionReviewId: appeal.ResolutionReviewID,
+		Overdue:            appeal.Overdue,
+	}
+	if !appeal.ResolvedAt.IsZero() {
+		result.ResolvedAt = timestamppb.New(appeal.ResolvedAt)
+	}
+
+	for _, a := range appeal.Attachments {
+		result.Attachments = append(result.Attachments, &gen.AppealAttachment{
+			AttachmentId: a.AttachmentID,
+			FileName:     a.FileName,
+			AddedAt:      timestamppb.New(a.AddedAt),
+		})
+	}
+
+	return result
+}
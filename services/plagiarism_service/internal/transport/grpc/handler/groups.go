@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/use_cases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) ListSuspiciousGroups(ctx context.Context, req *gen.ListSuspiciousGroupsRequest) (*gen.ListSuspiciousGroupsResponse, error) {
+	const op = "Handler.ListSuspiciousGroups"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetMinSimilarity() < 0 || req.GetMinSimilarity() > 1 {
+		logger.Warn("min similarity out of range")
+		return nil, status.Error(codes.InvalidArgument, "min similarity must be between 0 and 1")
+	}
+
+	groups, err := h.service.ListSuspiciousGroups(ctx, req.GetTaskId(), req.GetMinSimilarity())
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	result := make([]*gen.SuspiciousGroup, 0, len(groups))
+	for _, group := range groups {
+		communities := make([]*gen.GroupStats, 0, len(group.Communities))
+		for _, community := range group.Communities {
+			communities = append(communities, toProtoGroupStats(community))
+		}
+
+		result = append(result, &gen.SuspiciousGroup{
+			GroupId:     group.ID,
+			Stats:       toProtoGroupStats(group.GroupStats),
+			Communities: communities,
+		})
+	}
+
+	return &gen.ListSuspiciousGroupsResponse{
+		Groups: result,
+	}, nil
+}
+
+func toProtoGroupStats(stats use_cases.GroupStats) *gen.GroupStats {
+	result := &gen.GroupStats{
+		Members:       stats.Members,
+		Score:         stats.Score,
+		MaxSimilarity: stats.MaxSimilarity,
+		Density:       stats.Density,
+		Origin:        stats.Origin,
+	}
+
+	if !stats.OriginSubmittedAt.IsZero() {
+		result.OriginSubmittedAt = timestamppb.New(stats.OriginSubmittedAt)
+	}
+
+	return result
+}
@@ -15,6 +15,21 @@ import (
 
 type PlagiarismService interface {
 	GetPlagiarismReport(ctx context.Context, taskId string) (*use_cases.Task, error)
+	StartAnalysis(ctx context.Context, taskId string) (string, bool, error)
+	GetAnalysisJob(ctx context.Context, jobId string) (*use_cases.AnalysisJob, error)
+	CancelAnalysisJob(ctx context.Context, jobId string) (*use_cases.AnalysisJob, error)
+	WatchAnalysis(ctx context.Context, taskId, jobId string, send func(*use_cases.AnalysisEvent) error) error
+	GetSimilarityMatrix(ctx context.Context, taskId string) (*use_cases.SimilarityMatrix, error)
+	ListSuspiciousGroups(ctx context.Context, taskId string, minSimilarity float64) ([]use_cases.SuspiciousGroup, error)
+	ListPairReports(ctx context.Context, taskId string, minSimilarity *float64, includeFragments bool) (*use_cases.TaskPairs, error)
+	GetPairEvidence(ctx context.Context, taskId, studentA, studentB string) (*use_cases.PairEvidence, error)
+	ReviewPair(ctx context.Context, taskId, studentA, studentB string, verdict, comment, reviewerId string) (*use_cases.PairReview, error)
+	ListPairReviews(ctx context.Context, taskId, studentA, studentB string) ([]use_cases.PairReview, error)
+	CreateAppeal(ctx context.Context, taskId, studentId, otherStudentId, explanation string) (*use_cases.Appeal, error)
+	AddAppealAttachment(ctx context.Context, appealId, studentId, attachmentId, fileName string) (*use_cases.Appeal, error)
+	ResolveAppeal(ctx context.Context, appealId, outcome, comment, reviewerId, verdict string) (*use_cases.Appeal, error)
+	GetAppeal(ctx context.Context, appealId string) (*use_cases.Appeal, error)
+	ListAppeals(ctx context.Context, taskId, state string) ([]use_cases.Appeal, error)
 }
 
 type Handler struct {
@@ -28,7 +43,7 @@ func Register(gRPC *grpc.Server, service PlagiarismService, logger *slog.Logger)
 }
 
 func (h *Handler) GetPlagiarismReport(ctx context.Context, req *gen.GetPlagiarismReportRequest) (*gen.GetPlagiarismReportResponse, error) {
-	const op = "Handler.GenerateUploadURL"
+	const op = "Handler.GetPlagiarismReport"
 
 	logger := h.logger.With(
 		slog.String("op", op),
@@ -44,7 +59,7 @@ func (h *Handler) GetPlagiarismReport(ctx context.Context, req *gen.GetPlagiaris
 		}
 	}()
 
-	logger.Info("starting analysis...")
+	logger.Info("loading report...")
 
 	err := ValidateTaskId(req.GetTaskId(), h.logger)
 	if err != nil {
@@ -54,22 +69,9 @@ func (h *Handler) GetPlagiarismReport(ctx context.Context, req *gen.GetPlagiaris
 	taskReport, err := h.service.GetPlagiarismReport(ctx, req.GetTaskId())
 
 	if err != nil {
-		var analysisErr *use_cases.AnalysisError
-		if errors.As(err, &analysisErr) {
-			logger.Error("analysis failed", "error", err)
-			return nil, status.Error(codes.FailedPrecondition, analysisErr.Error())
-		}
-		if errors.Is(err, use_cases.ErrExternalConnectionFailed) {
-			logger.Error("failed connection with external service", "error", err)
-			return nil, status.Error(codes.Unavailable, "failed to connect to storage service")
-		}
-		if errors.Is(err, use_cases.ErrFileExtractionFailed) {
-			logger.Error("file extraction failed", "error", err)
-			return nil, status.Error(codes.InvalidArgument, "failed to extract text from file: file may be corrupted or unsupported format")
-		}
-		if errors.Is(err, use_cases.ErrFileDownloadFailed) {
-			logger.Error("file download failed", "error", err)
-			return nil, status.Error(codes.Unavailable, "failed to download file from storage")
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
 		}
 		logger.Error("internal error", "error", err)
 		return nil, status.Error(codes.Internal, "internal error")
@@ -89,6 +91,8 @@ func (h *Handler) GetPlagiarismReport(ctx context.Context, req *gen.GetPlagiaris
 			StudentWithSimilarFile: report.StudentWithSimilarFile,
 			MaxSimilarity:          report.MaxSimilarity,
 			FileHandedOverAt:       fileHandedOverAt,
+			Verdict:                toProtoReview(report.Verdict),
+			FileVersion:            int32(report.FileVersion),
 		})
 	}
 
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/use_cases"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) StartAnalysis(ctx context.Context, req *gen.StartAnalysisRequest) (*gen.StartAnalysisResponse, error) {
+	const op = "Handler.StartAnalysis"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	jobId, attached, err := h.service.StartAnalysis(ctx, req.GetTaskId())
+	if err != nil {
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.StartAnalysisResponse{
+		JobId:    jobId,
+		Attached: attached,
+	}, nil
+}
+
+func (h *Handler) GetAnalysisJob(ctx context.Context, req *gen.GetAnalysisJobRequest) (*gen.GetAnalysisJobResponse, error) {
+	const op = "Handler.GetAnalysisJob"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("JobId", req.GetJobId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateJobId(req.GetJobId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := h.service.GetAnalysisJob(ctx, req.GetJobId())
+	if err != nil {
+		return nil, jobError(err, logger)
+	}
+
+	return &gen.GetAnalysisJobResponse{
+		Job: toProtoJob(job),
+	}, nil
+}
+
+func (h *Handler) CancelAnalysisJob(ctx context.Context, req *gen.CancelAnalysisJobRequest) (*gen.CancelAnalysisJobResponse, error) {
+	const op = "Handler.CancelAnalysisJob"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("JobId", req.GetJobId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateJobId(req.GetJobId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := h.service.CancelAnalysisJob(ctx, req.GetJobId())
+	if err != nil {
+		return nil, jobError(err, logger)
+	}
+
+	return &gen.CancelAnalysisJobResponse{
+		Job: toProtoJob(job),
+	}, nil
+}
+
+func (h *Handler) WatchAnalysis(req *gen.WatchAnalysisRequest, stream grpc.ServerStreamingServer[gen.AnalysisEvent]) error {
+	const op = "Handler.WatchAnalysis"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("JobId", req.GetJobId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return err
+	}
+
+	if req.GetJobId() != "" {
+		if err = ValidateJobId(req.GetJobId(), h.logger); err != nil {
+			return err
+		}
+	}
+
+	ctx := stream.Context()
+
+	err = h.service.WatchAnalysis(ctx, req.GetTaskId(), req.GetJobId(), func(event *use_cases.AnalysisEvent) error {
+		pairs := make([]*gen.SuspiciousPair, 0, len(event.NewSuspiciousPairs))
+		for _, pair := range event.NewSuspiciousPairs {
+			pairs = append(pairs, &gen.SuspiciousPair{
+				StudentA:   pair.StudentA,
+				StudentB:   pair.StudentB,
+				Similarity: pair.Similarity,
+			})
+		}
+
+		return stream.Send(&gen.AnalysisEvent{
+			Job:                toProtoJob(event.Job),
+			NewSuspiciousPairs: pairs,
+		})
+	})
+	if err != nil {
+		// клиент закрыл поток - это не ошибка сервиса
+		if ctx.Err() != nil {
+			logger.Info("watcher disconnected")
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return jobError(err, logger)
+	}
+
+	return nil
+}
+
+func jobError(err error, logger *slog.Logger) error {
+	if errors.Is(err, use_cases.ErrJobNotFound) {
+		logger.Warn("job not found")
+		return status.Error(codes.NotFound, "analysis job not found")
+	}
+
+	logger.Error("internal error", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func toProtoJob(job *use_cases.AnalysisJob) *gen.AnalysisJob {
+	result := &gen.AnalysisJob{
+		JobId:           job.ID,
+		TaskId:          job.TaskID,
+		State:           job.State,
+		ProgressDone:    int32(job.ProgressDone),
+		ProgressTotal:   int32(job.ProgressTotal),
+		Error:           job.Error,
+		Attempts:        int32(job.Attempts),
+		MaxAttempts:     int32(job.MaxAttempts),
+		FilesExtracted:  int32(job.FilesExtracted),
+		FilesTotal:      int32(job.FilesTotal),
+		SuspiciousPairs: int32(job.SuspiciousPairs),
+	}
+
+	if !job.CreatedAt.IsZero() {
+		result.CreatedAt = timestamppb.New(job.CreatedAt)
+	}
+	if !job.StartedAt.IsZero() {
+		result.StartedAt = timestamppb.New(job.StartedAt)
+	}
+	if !job.FinishedAt.IsZero() {
+		result.FinishedAt = timestamppb.New(job.FinishedAt)
+	}
+	if !job.NextRunAt.IsZero() {
+		result.NextRunAt = timestamppb.New(job.NextRunAt)
+	}
+
+	return result
+}
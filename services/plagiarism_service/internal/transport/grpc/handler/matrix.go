@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/use_cases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) GetSimilarityMatrix(ctx context.Context, req *gen.GetSimilarityMatrixRequest) (*gen.GetSimilarityMatrixResponse, error) {
+	const op = "Handler.GetSimilarityMatrix"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix, err := h.service.GetSimilarityMatrix(ctx, req.GetTaskId())
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	rows := make([]*gen.SimilarityRow, 0, len(matrix.Similarities))
+	for _, row := range matrix.Similarities {
+		rows = append(rows, &gen.SimilarityRow{
+			Similarities: row,
+		})
+	}
+
+	var startedAt *timestamppb.Timestamp
+
+	if !matrix.StartedAt.IsZero() {
+		startedAt = timestamppb.New(matrix.StartedAt)
+	}
+
+	return &gen.GetSimilarityMatrixResponse{
+		Students:  matrix.Students,
+		Rows:      rows,
+		StartedAt: startedAt,
+
+		PlagiarismThreshold: matrix.PlagiarismThreshold,
+	}, nil
+}
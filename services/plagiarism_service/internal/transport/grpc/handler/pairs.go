@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/use_cases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) ListPairReports(ctx context.Context, req *gen.ListPairReportsRequest) (*gen.ListPairReportsResponse, error) {
+	const op = "Handler.ListPairReports"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.MinSimilarity != nil && (req.GetMinSimilarity() < 0 || req.GetMinSimilarity() > 1) {
+		logger.Warn("min similarity out of range")
+		return nil, status.Error(codes.InvalidArgument, "min similarity must be between 0 and 1")
+	}
+
+	taskPairs, err := h.service.ListPairReports(ctx, req.GetTaskId(), req.MinSimilarity, req.GetIncludeFragments())
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	pairs := make([]*gen.PairReport, 0, len(taskPairs.Pairs))
+	for _, pair := range taskPairs.Pairs {
+		pairs = append(pairs, toProtoPairReport(pair))
+	}
+
+	var startedAt *timestamppb.Timestamp
+
+	if !taskPairs.StartedAt.IsZero() {
+		startedAt = timestamppb.New(taskPairs.StartedAt)
+	}
+
+	return &gen.ListPairReportsResponse{
+		Pairs:               pairs,
+		StartedAt:           startedAt,
+		PlagiarismThreshold: taskPairs.PlagiarismThreshold,
+		NGramSize:           int32(taskPairs.NGramSize),
+		MinFragmentWords:    int32(taskPairs.MinFragmentWords),
+	}, nil
+}
+
+func (h *Handler) GetPairEvidence(ctx context.Context, req *gen.GetPairEvidenceRequest) (*gen.GetPairEvidenceResponse, error) {
+	const op = "Handler.GetPairEvidence"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("StudentA", req.GetStudentA()),
+		slog.String("StudentB", req.GetStudentB()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	if err := ValidatePair(req.GetTaskId(), req.GetStudentA(), req.GetStudentB(), h.logger); err != nil {
+		return nil, err
+	}
+
+	evidence, err := h.service.GetPairEvidence(ctx, req.GetTaskId(), req.GetStudentA(), req.GetStudentB())
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
+		}
+		if errors.Is(err, use_cases.ErrPairNotFound) {
+			logger.Warn("pair was not compared")
+			return nil, status.Error(codes.NotFound, "pair was not compared in the last analysis")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	var startedAt *timestamppb.Timestamp
+
+	if !evidence.StartedAt.IsZero() {
+		startedAt = timestamppb.New(evidence.StartedAt)
+	}
+
+	return &gen.GetPairEvidenceResponse{
+		Pair:                toProtoPairReport(evidence.Pair),
+		StartedAt:           startedAt,
+		PlagiarismThreshold: evidence.PlagiarismThreshold,
+		MinFragmentWords:    int32(evidence.MinFragmentWords),
+	}, nil
+}
+
+func toProtoPairReport(pair use_cases.PairReport) *gen.PairReport {
+	result := &gen.PairReport{
+		StudentA:     pair.StudentA,
+		StudentB:     pair.StudentB,
+		Similarity:   pair.Similarity,
+		Suspicious:   pair.Suspicious,
+		Fragments:    toProtoFragments(pair.Fragments),
+		Verdict:      toProtoReview(pair.Verdict),
+		FileAVersion: int32(pair.FileAVersion),
+		FileBVersion: int32(pair.FileBVersion),
+		Files:        toProtoPairFiles(pair.Files),
+	}
+	if !pair.FileAHandedOverAt.IsZero() {
+		result.FileAHandedOverAt = timestamppb.New(pair.FileAHandedOverAt)
+	}
+	if !pair.FileBHandedOverAt.IsZero() {
+		result.FileBHandedOverAt = timestamppb.New(pair.FileBHandedOverAt)
+	}
+
+	return result
+}
+
+func toProtoPairFiles(files []use_cases.PairFileReport) []*gen.PairFileReport {
+	result := make([]*gen.PairFileReport, 0, len(files))
+	for _, f := range files {
+		result = append(result, &gen.PairFileReport{
+			FileName:     f.FileName,
+			Similarity:   f.Similarity,
+			Suspicious:   f.Suspicious,
+			FileAVersion: int32(f.FileAVersion),
+			FileBVersion: int32(f.FileBVersion),
+		})
+	}
+
+	return result
+}
+
+func toProtoFragments(fragments []use_cases.Fragment) []*gen.PairFragment {
+	result := make([]*gen.PairFragment, 0, len(fragments))
+	for _, f := range fragments {
+		result = append(result, &gen.PairFragment{
+			AStart:  int32(f.AStart),
+			BStart:  int32(f.BStart),
+			Length:  int32(f.Length),
+			Excerpt: f.Excerpt,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"unicode/utf8"
+
+	gen "github.com/Nikita-Smirnov-idk/plagiarism-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/use_cases"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxReviewCommentLength = 2000
+
+func (h *Handler) ReviewPair(ctx context.Context, req *gen.ReviewPairRequest) (*gen.ReviewPairResponse, error) {
+	const op = "Handler.ReviewPair"
+
+	// решение записывается от имени пользователя вызова; поле запроса - для вызовов без пользователя
+	reviewerId := identity.ActorID(ctx, req.GetReviewerId())
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("StudentA", req.GetStudentA()),
+		slog.String("StudentB", req.GetStudentB()),
+		slog.String("ReviewerId", reviewerId),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	if err := ValidatePair(req.GetTaskId(), req.GetStudentA(), req.GetStudentB(), h.logger); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateIdWrapped(reviewerId, "reviewer", logger); err != nil {
+		return nil, err
+	}
+
+	if utf8.RuneCountInString(req.GetComment()) > maxReviewCommentLength {
+		logger.Warn("comment is too long")
+		return nil, status.Error(codes.InvalidArgument, "comment is too long")
+	}
+
+	review, err := h.service.ReviewPair(
+		ctx,
+		req.GetTaskId(),
+		req.GetStudentA(),
+		req.GetStudentB(),
+		req.GetVerdict(),
+		req.GetComment(),
+		reviewerId,
+	)
+	if err != nil {
+		if errors.Is(err, use_cases.ErrInvalidVerdict) {
+			logger.Warn("unknown verdict")
+			return nil, status.Error(codes.InvalidArgument, "verdict must be confirmed, false_positive, allowed_collaboration or needs_discussion")
+		}
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
+		}
+		if errors.Is(err, use_cases.ErrPairNotFound) {
+			logger.Warn("pair was not compared")
+			return nil, status.Error(codes.NotFound, "pair was not compared in the last analysis")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.ReviewPairResponse{
+		Review: toProtoReview(review),
+	}, nil
+}
+
+func (h *Handler) ListPairReviews(ctx context.Context, req *gen.ListPairReviewsRequest) (*gen.ListPairReviewsResponse, error) {
+	const op = "Handler.ListPairReviews"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("StudentA", req.GetStudentA()),
+		slog.String("StudentB", req.GetStudentB()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	var err error
+	if req.GetStudentA() == "" && req.GetStudentB() == "" {
+		err = ValidateTaskId(req.GetTaskId(), h.logger)
+	} else {
+		err = ValidatePair(req.GetTaskId(), req.GetStudentA(), req.GetStudentB(), h.logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := h.service.ListPairReviews(ctx, req.GetTaskId(), req.GetStudentA(), req.GetStudentB())
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task has not been analyzed yet")
+			return nil, status.Error(codes.NotFound, "task has not been analyzed yet")
+		}
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	result := make([]*gen.PairReview, 0, len(reviews))
+	for i := range reviews {
+		result = append(result, toProtoReview(&reviews[i]))
+	}
+
+	return &gen.ListPairReviewsResponse{
+		Reviews: result,
+	}, nil
+}
+
+func toProtoReview(review *use_cases.PairReview) *gen.PairReview {
+	if review == nil {
+		return nil
+	}
+
+	return &gen.PairReview{
+		Id:                review.ID,
+		StudentA:          review.StudentA,
+		StudentB:          review.StudentB,
+		Verdict:           review.Verdict,
+		Comment:           review.Comment,
+		ReviewerId:        review.ReviewerID,
+		CreatedAt:         timestamppb.New(review.CreatedAt),
+		FileAHandedOverAt: timestamppb.New(review.FileAHandedOverAt),
+		FileBHandedOverAt: timestamppb.New(review.FileBHandedOverAt),
+		Outdated:          review.Outdated,
+		Current:           review.Current,
+	}
+}
@@ -25,6 +25,46 @@ func ValidateTaskId(taskId string, log *slog.Logger) error {
 	return nil
 }
 
+func ValidateJobId(jobId string, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidateJobId"
+
+	logger := log.With(
+		slog.String("op", op),
+		slog.String("id", jobId),
+	)
+
+	return ValidateIdWrapped(jobId, "job", logger)
+}
+
+// ValidatePair проверяет задачу и двух разных студентов пары.
+func ValidatePair(taskId, studentA, studentB string, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidatePair"
+
+	logger := log.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+		slog.String("student_a", studentA),
+		slog.String("student_b", studentB),
+	)
+
+	if err := ValidateIdWrapped(taskId, "task", logger); err != nil {
+		return err
+	}
+
+	for _, studentId := range []string{studentA, studentB} {
+		if err := ValidateIdWrapped(studentId, "student", logger); err != nil {
+			return err
+		}
+	}
+
+	if studentA == studentB {
+		logger.Warn("students of the pair are the same")
+		return status.Error(codes.InvalidArgument, "students of the pair must differ")
+	}
+
+	return nil
+}
+
 func ValidateIdWrapped(id, nameOfId string, logger *slog.Logger) error {
 	err := ValidateId(id)
 	if err != nil {
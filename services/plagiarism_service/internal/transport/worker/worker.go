@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobProcessor interface {
+	ProcessNextJob(ctx context.Context, workerID string) (bool, error)
+}
+
+// Worker опрашивает очередь заданий анализа в нескольких горутинах.
+// Каждая горутина - отдельный владелец аренды, поэтому реплики сервиса и горутины не мешают друг другу.
+type Worker struct {
+	logger       *slog.Logger
+	processor    JobProcessor
+	id           string
+	concurrency  int
+	pollInterval time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(logger *slog.Logger, processor JobProcessor, concurrency int, pollInterval time.Duration) *Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Worker{
+		logger:       logger,
+		processor:    processor,
+		id:           workerID(),
+		concurrency:  max(concurrency, 1),
+		pollInterval: pollInterval,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// MustRun запускает обработчики очереди и блокируется до вызова Stop.
+func (w *Worker) MustRun() {
+	const op = "worker.Run"
+
+	w.logger.With(slog.String("op", op)).Info(
+		"starting analysis workers",
+		slog.String("worker_id", w.id),
+		slog.Int("concurrency", w.concurrency),
+	)
+
+	for i := 0; i < w.concurrency; i++ {
+		w.wg.Add(1)
+		go w.loop(fmt.Sprintf("%s-%d", w.id, i))
+	}
+
+	w.wg.Wait()
+}
+
+// Stop прерывает выполняемые задания (они возвращаются в очередь) и ждёт завершения обработчиков.
+func (w *Worker) Stop() {
+	const op = "worker.Stop"
+
+	w.logger.With(slog.String("op", op)).Info("stopping analysis workers", slog.String("worker_id", w.id))
+
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *Worker) loop(workerID string) {
+	defer w.wg.Done()
+
+	logger := w.logger.With(slog.String("worker_id", workerID))
+
+	for {
+		processed, err := w.processor.ProcessNextJob(w.ctx, workerID)
+		if err != nil && w.ctx.Err() == nil {
+			logger.Error("failed to process job", "error", err)
+		}
+
+		// сразу берём следующее задание, если очередь не пуста
+		if processed {
+			if w.ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// workerID формирует имя владельца аренды, уникальное для процесса.
+func workerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "plagiarism-service"
+	}
+
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.NewString()[:8])
+}
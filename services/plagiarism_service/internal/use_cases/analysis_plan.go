@@ -0,0 +1,146 @@
+package use_cases
+
+import (
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+)
+
+const fileStatusUploaded = "uploaded"
+
+// analysisPlan описывает, какие файлы задачи нужно пересчитать, а какие убрать из отчётов.
+type analysisPlan struct {
+	changed map[string]bool // студенты с новым или изменённым файлом
+	removed []string        // студенты, чьих файлов больше нет в задаче
+}
+
+func (p analysisPlan) isEmpty() bool {
+	return len(p.changed) == 0 && len(p.removed) == 0
+}
+
+// pairsToCompare считает пары, которые нужно пересчитать: хотя бы один файл пары изменён.
+func (p analysisPlan) pairsToCompare(files []*storagepb.FileInfo) int {
+	total := 0
+	for i := 0; i < len(files); i++ {
+		for j := i + 1; j < len(files); j++ {
+			if p.needsComparison(files[i], files[j]) {
+				total++
+			}
+		}
+	}
+
+	return total
+}
+
+// filesToExtract считает файлы, которые придётся скачать: участвующие хотя бы в одной пересчитываемой паре,
+// содержимое которой не совпадает байт в байт. У точной копии скачивается только первая по id сдача пары -
+// из её текста строится фрагмент на весь документ.
+func (p analysisPlan) filesToExtract(files []*storagepb.FileInfo) int {
+	extract := make(map[string]bool, len(files))
+	for i := 0; i < len(files); i++ {
+		for j := i + 1; j < len(files); j++ {
+			if !p.needsComparison(files[i], files[j]) {
+				continue
+			}
+			if identicalContent(files[i], files[j]) {
+				extract[min(files[i].GetStudentId(), files[j].GetStudentId())] = true
+				continue
+			}
+			extract[files[i].GetStudentId()] = true
+			extract[files[j].GetStudentId()] = true
+		}
+	}
+
+	return len(extract)
+}
+
+// needsComparison сообщает, что пару нужно пересчитать: хотя бы один файл изменён и у сдач разные авторы.
+func (p analysisPlan) needsComparison(a, b *storagepb.FileInfo) bool {
+	if !p.changed[a.GetStudentId()] && !p.changed[b.GetStudentId()] {
+		return false
+	}
+	return !sameAuthors(a, b)
+}
+
+// sameAuthors сообщает, что у сдач есть общий автор: сдачу группы storage-service отдаёт одной записью
+// с id группы, и её участников нельзя сравнивать ни друг с другом, ни с этой сдачей.
+func sameAuthors(a, b *storagepb.FileInfo) bool {
+	authorsA := authorsOf(a)
+	for author := range authorsOf(b) {
+		if authorsA[author] {
+			return true
+		}
+	}
+	return false
+}
+
+// identicalContent сообщает, что сдачи состоят из одноимённых файлов с одинаковым хэшем содержимого:
+// такую пару можно не скачивать. Хэш пуст у версий, загруженных до его подсчёта.
+func identicalContent(a, b *storagepb.FileInfo) bool {
+	filesA, filesB := submissionFiles(a), submissionFiles(b)
+	if len(filesA) != len(filesB) {
+		return false
+	}
+
+	for i := range filesA {
+		if filesA[i].GetName() != filesB[i].GetName() ||
+			filesA[i].GetSha256() == "" || filesA[i].GetSha256() != filesB[i].GetSha256() {
+			return false
+		}
+	}
+	return true
+}
+
+func authorsOf(f *storagepb.FileInfo) map[string]bool {
+	authors := map[string]bool{f.GetStudentId(): true}
+	for _, memberID := range f.GetMemberIds() {
+		authors[memberID] = true
+	}
+	return authors
+}
+
+// planAnalysis сравнивает текущие файлы задачи с уже учтёнными в отчётах версиями.
+func planAnalysis(files []*storagepb.FileInfo, analyzed []domain.AnalyzedFile) analysisPlan {
+	plan := analysisPlan{changed: make(map[string]bool)}
+
+	analyzedVersions := make(map[string]time.Time, len(analyzed))
+	for _, a := range analyzed {
+		analyzedVersions[a.StudentID] = a.FileUpdatedAt
+	}
+
+	current := make(map[string]bool, len(files))
+	for _, f := range files {
+		current[f.GetStudentId()] = true
+
+		version, ok := analyzedVersions[f.GetStudentId()]
+		if !ok || !version.Equal(fileVersion(f)) {
+			plan.changed[f.GetStudentId()] = true
+		}
+	}
+
+	for studentID := range analyzedVersions {
+		if !current[studentID] {
+			plan.removed = append(plan.removed, studentID)
+		}
+	}
+
+	return plan
+}
+
+// fileVersion возвращает версию файла с точностью, с которой её хранит postgres.
+func fileVersion(f *storagepb.FileInfo) time.Time {
+	return f.GetUpdatedAt().AsTime().Truncate(time.Microsecond)
+}
+
+// uploadedFiles оставляет только файлы, загрузка которых подтверждена.
+func uploadedFiles(files []*storagepb.FileInfo) []*storagepb.FileInfo {
+	result := make([]*storagepb.FileInfo, 0, len(files))
+	for _, f := range files {
+		if f.GetStatus() == fileStatusUploaded {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
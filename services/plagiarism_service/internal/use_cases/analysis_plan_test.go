@@ -0,0 +1,242 @@
+package use_cases
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var uploadedAt = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// submission возвращает сдачу из одного основного файла с хэшем sha256, загруженную в at.
+func submission(studentID, sha256 string, at time.Time, memberIDs ...string) *storagepb.FileInfo {
+	return &storagepb.FileInfo{
+		StudentId: studentID,
+		Status:    fileStatusUploaded,
+		UpdatedAt: timestamppb.New(at),
+		MemberIds: memberIDs,
+		Version:   1,
+		Sha256:    sha256,
+	}
+}
+
+func TestPlanAnalysis(t *testing.T) {
+	files := []*storagepb.FileInfo{
+		submission("s1", "h1", uploadedAt),
+		submission("s2", "h2", uploadedAt.Add(time.Hour)),
+		submission("s3", "h3", uploadedAt.Add(500*time.Nanosecond)),
+		submission("s4", "h4", uploadedAt),
+	}
+	analyzed := []domain.AnalyzedFile{
+		// не изменился
+		{StudentID: "s1", FileUpdatedAt: uploadedAt},
+		// загружен заново после анализа
+		{StudentID: "s2", FileUpdatedAt: uploadedAt},
+		// postgres хранит время с точностью до микросекунды
+		{StudentID: "s3", FileUpdatedAt: uploadedAt},
+		// файла больше нет в задаче
+		{StudentID: "s5", FileUpdatedAt: uploadedAt},
+	}
+
+	plan := planAnalysis(files, analyzed)
+
+	for studentID, want := range map[string]bool{"s1": false, "s2": true, "s3": false, "s4": true} {
+		if plan.changed[studentID] != want {
+			t.Errorf("changed[%s] = %v, want %v", studentID, plan.changed[studentID], want)
+		}
+	}
+	if !slices.Equal(plan.removed, []string{"s5"}) {
+		t.Errorf("removed = %v, want [s5]", plan.removed)
+	}
+	if plan.isEmpty() {
+		t.Error("plan with changes is empty")
+	}
+
+	if plan := planAnalysis(files[:1], analyzed[:1]); !plan.isEmpty() {
+		t.Errorf("plan of unchanged files = %+v, want empty", plan)
+	}
+}
+
+func TestNeedsComparison(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    *storagepb.FileInfo
+		changed []string
+		want    bool
+	}{
+		{
+			name: "unchanged pair is skipped",
+			a:    submission("s1", "h1", uploadedAt),
+			b:    submission("s2", "h2", uploadedAt),
+			want: false,
+		},
+		{
+			name:    "changed file forces comparison",
+			a:       submission("s1", "h1", uploadedAt),
+			b:       submission("s2", "h2", uploadedAt),
+			changed: []string{"s2"},
+			want:    true,
+		},
+		{
+			name:    "group and its member are excluded",
+			a:       submission("g1", "h1", uploadedAt, "s1", "s2"),
+			b:       submission("s1", "h2", uploadedAt),
+			changed: []string{"g1", "s1"},
+			want:    false,
+		},
+		{
+			name:    "groups sharing a member are excluded",
+			a:       submission("g1", "h1", uploadedAt, "s1", "s2"),
+			b:       submission("g2", "h2", uploadedAt, "s2", "s3"),
+			changed: []string{"g2"},
+			want:    false,
+		},
+		{
+			name:    "group and another student are compared",
+			a:       submission("g1", "h1", uploadedAt, "s1", "s2"),
+			b:       submission("s3", "h2", uploadedAt),
+			changed: []string{"g1"},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := analysisPlan{changed: make(map[string]bool)}
+			for _, studentID := range tt.changed {
+				plan.changed[studentID] = true
+			}
+
+			if got := plan.needsComparison(tt.a, tt.b); got != tt.want {
+				t.Errorf("needsComparison = %v, want %v", got, tt.want)
+			}
+			if got := plan.needsComparison(tt.b, tt.a); got != tt.want {
+				t.Errorf("needsComparison reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdenticalContent(t *testing.T) {
+	withFiles := func(studentID string, files ...*storagepb.FileVersion) *storagepb.FileInfo {
+		f := submission(studentID, "", uploadedAt)
+		f.Files = files
+		return f
+	}
+
+	tests := []struct {
+		name string
+		a, b *storagepb.FileInfo
+		want bool
+	}{
+		{
+			name: "same hash",
+			a:    submission("s1", "h1", uploadedAt),
+			b:    submission("s2", "h1", uploadedAt),
+			want: true,
+		},
+		{
+			name: "different hash",
+			a:    submission("s1", "h1", uploadedAt),
+			b:    submission("s2", "h2", uploadedAt),
+			want: false,
+		},
+		{
+			name: "hash unknown",
+			a:    submission("s1", "", uploadedAt),
+			b:    submission("s2", "", uploadedAt),
+			want: false,
+		},
+		{
+			name: "same files in any order",
+			a:    withFiles("s1", &storagepb.FileVersion{Name: "a.go", Sha256: "h1"}, &storagepb.FileVersion{Name: "b.go", Sha256: "h2"}),
+			b:    withFiles("s2", &storagepb.FileVersion{Name: "b.go", Sha256: "h2"}, &storagepb.FileVersion{Name: "a.go", Sha256: "h1"}),
+			want: true,
+		},
+		{
+			name: "same hashes under other names",
+			a:    withFiles("s1", &storagepb.FileVersion{Name: "a.go", Sha256: "h1"}),
+			b:    withFiles("s2", &storagepb.FileVersion{Name: "b.go", Sha256: "h1"}),
+			want: false,
+		},
+		{
+			name: "extra file",
+			a:    withFiles("s1", &storagepb.FileVersion{Name: "a.go", Sha256: "h1"}),
+			b:    withFiles("s2", &storagepb.FileVersion{Name: "a.go", Sha256: "h1"}, &storagepb.FileVersion{Name: "b.go", Sha256: "h2"}),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identicalContent(tt.a, tt.b); got != tt.want {
+				t.Errorf("identicalContent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeStorage отдаёт ссылку на url для скачивания любой версии; остальные методы не нужны анализу.
+type fakeStorage struct {
+	storagepb.StorageClient
+	url string
+}
+
+func (s fakeStorage) GenerateVersionDownloadURL(context.Context, *storagepb.GenerateVersionDownloadURLRequest, ...grpc.CallOption) (*storagepb.GenerateVersionDownloadURLResponse, error) {
+	return &storagepb.GenerateVersionDownloadURLResponse{Url: s.url}, nil
+}
+
+type nopObserver struct{}
+
+func (nopObserver) progress(domain.JobProgress) {}
+
+func (nopObserver) suspiciousPair(domain.PlagiarismReport) {}
+
+func TestCompareChangedFilesExactCopy(t *testing.T) {
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("identical essay about plagiarism detection"))
+	}))
+	defer server.Close()
+
+	service := NewPlagiarismService(slog.New(slog.DiscardHandler), nil, fakeStorage{url: server.URL}, QueueSettings{})
+	files := []*storagepb.FileInfo{
+		submission("s2", "h1", uploadedAt),
+		submission("s1", "h1", uploadedAt),
+	}
+	plan := planAnalysis(files, nil)
+	if got := plan.filesToExtract(files); got != 1 {
+		t.Errorf("filesToExtract = %d, want 1", got)
+	}
+
+	var result domain.AnalysisResult
+	err := service.compareChangedFiles(context.Background(), "t1", files, plan, &result, nopObserver{}, service.logger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Reports) != 1 || result.Reports[0].Similarity != 1 {
+		t.Fatalf("reports = %+v, want one pair with similarity 1", result.Reports)
+	}
+	if result.Reports[0].StudentA != "s1" || result.Reports[0].StudentB != "s2" {
+		t.Errorf("pair = %s/%s, want s1/s2", result.Reports[0].StudentA, result.Reports[0].StudentB)
+	}
+	// тексты не сравниваются: скачивается только одна сдача ради фрагмента на весь документ
+	if downloads != 1 {
+		t.Errorf("downloads = %d, want 1", downloads)
+	}
+	if len(result.Fragments) != 1 || result.Fragments[0].AStart != 0 || result.Fragments[0].BStart != 0 {
+		t.Errorf("fragments = %+v, want one whole-document fragment", result.Fragments)
+	}
+}
@@ -0,0 +1,396 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/identity"
+	"github.com/google/uuid"
+)
+
+const (
+	// appealWindow - сколько студент может оспаривать решение confirmed после его вынесения
+	appealWindow = 14 * 24 * time.Hour
+	// appealResponseTime - срок ответа проверяющего на апелляцию
+	appealResponseTime   = 7 * 24 * time.Hour
+	maxAppealAttachments = 10
+)
+
+// CreateAppeal подаёт апелляцию студента на действующее решение confirmed по его паре.
+// Подавшим апелляцию записывается пользователь вызова, а без него - studentId.
+func (s *PlagiarismService) CreateAppeal(
+	ctx context.Context,
+	taskId, studentId, otherStudentId, explanation string,
+) (*Appeal, error) {
+	const op = "Plagiarism_Service.CreateAppeal"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+		slog.String("student_id", studentId),
+		slog.String("other_student_id", otherStudentId),
+	)
+
+	if _, err := s.db.GetTaskByID(ctx, taskId); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
+
+	key := canonicalPair(studentId, otherStudentId)
+
+	verdict, err := s.currentPairVerdict(ctx, taskId, key)
+	if err != nil {
+		logger.Error("failed to load verdict", "error", err)
+		return nil, err
+	}
+	if verdict == nil || verdict.Verdict != string(domain.VerdictConfirmed) {
+		logger.Info("pair is not confirmed")
+		return nil, ErrAppealNotAllowed
+	}
+
+	now := time.Now()
+	if now.After(verdict.CreatedAt.Add(appealWindow)) {
+		logger.Info("appeal window is closed", "reviewed_at", verdict.CreatedAt)
+		return nil, ErrAppealWindowClosed
+	}
+
+	appeal := domain.Appeal{
+		ID:          uuid.New(),
+		TaskID:      taskId,
+		StudentA:    key[0],
+		StudentB:    key[1],
+		StudentID:   studentId,
+		AppellantID: identity.ActorID(ctx, studentId),
+		ReviewID:    verdict.ID,
+		State:       domain.AppealStateOpen,
+		Explanation: explanation,
+		SubmittedAt: now,
+		RespondBy:   now.Add(appealResponseTime),
+	}
+
+	if err = s.db.SaveAppeal(ctx, &appeal); err != nil {
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			logger.Info("appeal already exists", "review_id", verdict.ID)
+			return nil, ErrAppealExists
+		}
+		logger.Error("failed to save appeal", "error", err)
+		return nil, err
+	}
+
+	logger.Info("appeal submitted", "appeal_id", appeal.ID.String(), "review_id", verdict.ID)
+
+	result := toUseCaseAppeal(appeal, now)
+	return &result, nil
+}
+
+// AddAppealAttachment привязывает к открытой апелляции файл из storage-service. Привязывать файлы может только
+// подавший апелляцию (пользователь вызова, а без него - studentId). Повторная привязка того же файла ничего не меняет.
+func (s *PlagiarismService) AddAppealAttachment(
+	ctx context.Context,
+	appealId, studentId, attachmentId, fileName string,
+) (*Appeal, error) {
+	const op = "Plagiarism_Service.AddAppealAttachment"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("appeal_id", appealId),
+		slog.String("student_id", studentId),
+		slog.String("attachment_id", attachmentId),
+	)
+
+	appeal, err := s.loadAppeal(ctx, appealId, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if appeal.AppellantID != identity.ActorID(ctx, studentId) {
+		logger.Info("student is not the author of the appeal")
+		return nil, ErrNotAppellant
+	}
+	if appeal.State != domain.AppealStateOpen {
+		logger.Info("appeal is already resolved", "state", appeal.State)
+		return nil, ErrAppealResolved
+	}
+
+	for _, a := range appeal.Attachments {
+		if a.AttachmentID == attachmentId {
+			result := toUseCaseAppeal(*appeal, time.Now())
+			return &result, nil
+		}
+	}
+	if len(appeal.Attachments) >= maxAppealAttachments {
+		logger.Info("attachment limit reached", "limit", maxAppealAttachments)
+		return nil, ErrTooManyAttachments
+	}
+
+	attachment := domain.AppealAttachment{
+		AppealID:     appeal.ID,
+		AttachmentID: attachmentId,
+		FileName:     fileName,
+		AddedAt:      time.Now(),
+	}
+
+	if err = s.db.AddAppealAttachment(ctx, &attachment); err != nil && !errors.Is(err, repositories.ErrAlreadyExists) {
+		logger.Error("failed to save attachment", "error", err)
+		return nil, err
+	}
+	if err == nil {
+		appeal.Attachments = append(appeal.Attachments, attachment)
+	}
+
+	logger.Info("attachment added")
+
+	result := toUseCaseAppeal(*appeal, time.Now())
+	return &result, nil
+}
+
+// ResolveAppeal закрывает апелляцию. При outcome = upheld по паре записывается новое решение verdict
+// (по умолчанию false_positive), которое заменяет оспоренное; при rejected решение confirmed остаётся в силе.
+func (s *PlagiarismService) ResolveAppeal(
+	ctx context.Context,
+	appealId, outcome, comment, reviewerId, verdict string,
+) (*Appeal, error) {
+	const op = "Plagiarism_Service.ResolveAppeal"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("appeal_id", appealId),
+		slog.String("outcome", outcome),
+		slog.String("reviewer_id", reviewerId),
+	)
+
+	state := domain.AppealState(outcome)
+	if state != domain.AppealStateUpheld && state != domain.AppealStateRejected {
+		logger.Warn("unknown outcome")
+		return nil, ErrInvalidOutcome
+	}
+
+	if state == domain.AppealStateUpheld && verdict == "" {
+		verdict = string(domain.VerdictFalsePositive)
+	}
+	if state == domain.AppealStateUpheld &&
+		(!domain.ReviewVerdict(verdict).IsValid() || domain.ReviewVerdict(verdict) == domain.VerdictConfirmed) {
+		logger.Warn("upheld appeal needs a verdict other than confirmed", "verdict", verdict)
+		return nil, ErrInvalidVerdict
+	}
+
+	appeal, err := s.loadAppeal(ctx, appealId, logger)
+	if err != nil {
+		return nil, err
+	}
+	if appeal.State != domain.AppealStateOpen {
+		logger.Info("appeal is already resolved", "state", appeal.State)
+		return nil, ErrAppealResolved
+	}
+
+	now := time.Now()
+
+	var review *domain.PairReview
+	if state == domain.AppealStateUpheld {
+		review, err = s.appealReview(ctx, appeal, domain.ReviewVerdict(verdict), comment, reviewerId, now)
+		if err != nil {
+			logger.Error("failed to prepare review", "error", err)
+			return nil, err
+		}
+	}
+
+	appeal.State = state
+	appeal.ResolvedAt = &now
+	appeal.ResolverID = reviewerId
+	appeal.ResolutionComment = comment
+
+	if err = s.db.ResolveAppeal(ctx, appeal, review); err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			logger.Info("appeal was resolved concurrently")
+			return nil, ErrAppealResolved
+		}
+		logger.Error("failed to resolve appeal", "error", err)
+		return nil, err
+	}
+
+	logger.Info("appeal resolved")
+
+	result := toUseCaseAppeal(*appeal, now)
+	return &result, nil
+}
+
+// GetAppeal возвращает апелляцию с приложениями.
+func (s *PlagiarismService) GetAppeal(ctx context.Context, appealId string) (*Appeal, error) {
+	const op = "Plagiarism_Service.GetAppeal"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("appeal_id", appealId),
+	)
+
+	appeal, err := s.loadAppeal(ctx, appealId, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toUseCaseAppeal(*appeal, time.Now())
+	return &result, nil
+}
+
+// ListAppeals возвращает апелляции задачи в состоянии state (пустое - в любом),
+// первыми идут те, срок ответа на которые наступает раньше.
+func (s *PlagiarismService) ListAppeals(ctx context.Context, taskId, state string) ([]Appeal, error) {
+	const op = "Plagiarism_Service.ListAppeals"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+		slog.String("state", state),
+	)
+
+	if state != "" && !domain.AppealState(state).IsValid() {
+		logger.Warn("unknown appeal state")
+		return nil, ErrInvalidOutcome
+	}
+
+	if _, err := s.db.GetTaskByID(ctx, taskId); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
+
+	stored, err := s.db.ListAppealsByTaskID(ctx, taskId, domain.AppealState(state))
+	if err != nil {
+		logger.Error("failed to load appeals", "error", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	appeals := make([]Appeal, 0, len(stored))
+	for _, a := range stored {
+		appeals = append(appeals, toUseCaseAppeal(a, now))
+	}
+
+	return appeals, nil
+}
+
+func (s *PlagiarismService) loadAppeal(ctx context.Context, appealId string, logger *slog.Logger) (*domain.Appeal, error) {
+	appeal, err := s.db.GetAppeal(ctx, appealId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("appeal not found")
+			return nil, ErrAppealNotFound
+		}
+		logger.Error("failed to load appeal", "error", err)
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// currentPairVerdict возвращает действующее решение по паре или nil.
+func (s *PlagiarismService) currentPairVerdict(ctx context.Context, taskID string, key pairKey) (*PairReview, error) {
+	report, err := s.db.GetReportByPair(ctx, taskID, key[0], key[1])
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	stored, err := s.db.ListReviewsByPair(ctx, taskID, key[0], key[1])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, review := range reviewHistory(stored, []domain.PlagiarismReport{*report}) {
+		if review.Current {
+			return &review, nil
+		}
+	}
+	return nil, nil
+}
+
+// appealReview готовит решение, заменяющее оспоренное. Оно привязывается к версиям файлов
+// из текущего отчёта пары, а если пары в отчёте уже нет - к версиям оспоренного решения.
+func (s *PlagiarismService) appealReview(
+	ctx context.Context,
+	appeal *domain.Appeal,
+	verdict domain.ReviewVerdict,
+	comment, reviewerId string,
+	now time.Time,
+) (*domain.PairReview, error) {
+	review := &domain.PairReview{
+		TaskID:     appeal.TaskID,
+		StudentA:   appeal.StudentA,
+		StudentB:   appeal.StudentB,
+		Verdict:    verdict,
+		Comment:    comment,
+		ReviewerID: reviewerId,
+		CreatedAt:  now,
+	}
+
+	report, err := s.db.GetReportByPair(ctx, appeal.TaskID, appeal.StudentA, appeal.StudentB)
+	if err == nil {
+		review.FileAHandedOverAt = report.FileAHandedOverAt
+		review.FileBHandedOverAt = report.FileBHandedOverAt
+		return review, nil
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	stored, err := s.db.ListReviewsByPair(ctx, appeal.TaskID, appeal.StudentA, appeal.StudentB)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range stored {
+		if r.ID == appeal.ReviewID {
+			review.FileAHandedOverAt = r.FileAHandedOverAt
+			review.FileBHandedOverAt = r.FileBHandedOverAt
+			break
+		}
+	}
+
+	return review, nil
+}
+
+func toUseCaseAppeal(appeal domain.Appeal, now time.Time) Appeal {
+	result := Appeal{
+		ID:                appeal.ID.String(),
+		TaskID:            appeal.TaskID,
+		StudentA:          appeal.StudentA,
+		StudentB:          appeal.StudentB,
+		StudentID:         appeal.StudentID,
+		AppellantID:       appeal.AppellantID,
+		ReviewID:          appeal.ReviewID,
+		State:             string(appeal.State),
+		Explanation:       appeal.Explanation,
+		SubmittedAt:       appeal.SubmittedAt,
+		RespondBy:         appeal.RespondBy,
+		ResolverID:        appeal.ResolverID,
+		ResolutionComment: appeal.ResolutionComment,
+		Overdue:           appeal.State == domain.AppealStateOpen && now.After(appeal.RespondBy),
+	}
+	if appeal.ResolvedAt != nil {
+		result.ResolvedAt = *appeal.ResolvedAt
+	}
+	if appeal.ResolutionReviewID != nil {
+		result.ResolutionReviewID = *appeal.ResolutionReviewID
+	}
+
+	for _, a := range appeal.Attachments {
+		result.Attachments = append(result.Attachments, AppealAttachment{
+			AttachmentID: a.AttachmentID,
+			FileName:     a.FileName,
+			AddedAt:      a.AddedAt,
+		})
+	}
+
+	return result
+}
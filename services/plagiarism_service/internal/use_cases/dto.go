@@ -9,6 +9,9 @@ type PlagiarismReport struct {
 	StudentWithSimilarFile string
 	MaxSimilarity          float64
 	FileHandedOverAt       time.Time
+	FileVersion            int
+	// Verdict - действующее решение по паре, nil если пару не проверяли
+	Verdict *PairReview
 }
 
 type Task struct {
@@ -16,3 +19,156 @@ type Task struct {
 	StartedAt time.Time
 	Reports   []PlagiarismReport
 }
+
+type AnalysisJob struct {
+	ID              string
+	TaskID          string
+	State           string
+	ProgressDone    int
+	ProgressTotal   int
+	Error           string
+	CreatedAt       time.Time
+	StartedAt       time.Time
+	FinishedAt      time.Time
+	Attempts        int
+	MaxAttempts     int
+	NextRunAt       time.Time
+	FilesExtracted  int
+	FilesTotal      int
+	SuspiciousPairs int
+}
+
+// AnalysisEvent - состояние задания и подозрительные пары, найденные с прошлого события.
+type AnalysisEvent struct {
+	Job                *AnalysisJob
+	NewSuspiciousPairs []SuspiciousPair
+}
+
+type SuspiciousPair struct {
+	StudentA   string
+	StudentB   string
+	Similarity float64
+}
+
+// SimilarityMatrix - попарная схожесть работ задачи: Similarities[i][j] - схожесть Students[i] и Students[j].
+type SimilarityMatrix struct {
+	TaskID       string
+	StartedAt    time.Time
+	Students     []string
+	Similarities [][]float64
+
+	PlagiarismThreshold float64
+}
+
+// GroupStats - участники группы подозрительных работ и её показатели.
+type GroupStats struct {
+	Members           []string
+	Score             float64
+	MaxSimilarity     float64
+	Density           float64
+	Origin            string
+	OriginSubmittedAt time.Time
+}
+
+// SuspiciousGroup - компонента связности графа подозрительных пар и плотные сообщества внутри неё.
+type SuspiciousGroup struct {
+	GroupStats
+	ID          string
+	Communities []GroupStats
+}
+
+// PairReport - схожесть одной пары работ; пара хранится в каноническом порядке (StudentA < StudentB).
+type PairReport struct {
+	StudentA          string
+	StudentB          string
+	Similarity        float64
+	Suspicious        bool
+	FileAHandedOverAt time.Time
+	FileBHandedOverAt time.Time
+	FileAVersion      int
+	FileBVersion      int
+	// Files - схожесть одноимённых файлов, если хотя бы одна сдача пары состоит из нескольких файлов
+	Files     []PairFileReport
+	Fragments []Fragment
+	Verdict   *PairReview
+}
+
+// PairFileReport - схожесть одноимённых файлов пары.
+type PairFileReport struct {
+	FileName     string
+	Similarity   float64
+	Suspicious   bool
+	FileAVersion int
+	FileBVersion int
+}
+
+// Fragment - общий фрагмент пары; позиции - номера слов в очищенных текстах StudentA и StudentB.
+type Fragment struct {
+	AStart  int
+	BStart  int
+	Length  int
+	Excerpt string
+}
+
+// TaskPairs - пары задачи и настройки анализа, с которыми они получены.
+type TaskPairs struct {
+	TaskID              string
+	StartedAt           time.Time
+	PlagiarismThreshold float64
+	NGramSize           int
+	MinFragmentWords    int
+	Pairs               []PairReport
+}
+
+// PairEvidence - пара с общими фрагментами, развёрнутая в порядке запроса.
+type PairEvidence struct {
+	TaskID              string
+	StartedAt           time.Time
+	PlagiarismThreshold float64
+	MinFragmentWords    int
+	Pair                PairReport
+}
+
+// PairReview - решение проверяющего по паре.
+// Outdated - хотя бы один файл изменился после решения; Current - последнее решение, которое ещё действует.
+type PairReview struct {
+	ID                int64
+	StudentA          string
+	StudentB          string
+	Verdict           string
+	Comment           string
+	ReviewerID        string
+	CreatedAt         time.Time
+	FileAHandedOverAt time.Time
+	FileBHandedOverAt time.Time
+	Outdated          bool
+	Current           bool
+}
+
+// Appeal - апелляция студента на решение confirmed. AppellantID - пользователь, подавший апелляцию от имени
+// сдачи StudentID. Overdue - проверяющий не ответил в срок.
+type Appeal struct {
+	ID                 string
+	TaskID             string
+	StudentA           string
+	StudentB           string
+	StudentID          string
+	AppellantID        string
+	ReviewID           int64
+	State              string
+	Explanation        string
+	SubmittedAt        time.Time
+	RespondBy          time.Time
+	ResolvedAt         time.Time
+	ResolverID         string
+	ResolutionComment  string
+	ResolutionReviewID int64
+	Overdue            bool
+	Attachments        []AppealAttachment
+}
+
+type AppealAttachment struct {
+	AttachmentID string
+	FileName     string
+	AddedAt      time.Time
+}
@@ -9,6 +9,18 @@ var (
 	ErrExternalConnectionFailed = errors.New("failed to connect external service")
 	ErrFileExtractionFailed     = errors.New("failed to extract text from file")
 	ErrFileDownloadFailed       = errors.New("failed to download file")
+	ErrTaskNotFound             = errors.New("task has not been analyzed yet")
+	ErrJobNotFound              = errors.New("analysis job not found")
+	ErrPairNotFound             = errors.New("pair was not compared in the last analysis")
+	ErrInvalidVerdict           = errors.New("unknown review verdict")
+	ErrAppealNotFound           = errors.New("appeal not found")
+	ErrAppealNotAllowed         = errors.New("only a student of a confirmed pair can appeal")
+	ErrAppealWindowClosed       = errors.New("appeal window is closed")
+	ErrAppealExists             = errors.New("appeal against this verdict already exists")
+	ErrAppealResolved           = errors.New("appeal is already resolved")
+	ErrTooManyAttachments       = errors.New("too many attachments")
+	ErrNotAppellant             = errors.New("only the author can change the appeal")
+	ErrInvalidOutcome           = errors.New("unknown appeal outcome")
 )
 
 type AnalysisError struct {
@@ -22,6 +34,9 @@ func (e *AnalysisError) Error() string {
 	if e.StudentA != "" && e.StudentB != "" {
 		return fmt.Sprintf("analysis failed for students %s and %s: %s: %v", e.StudentA, e.StudentB, e.Reason, e.Err)
 	}
+	if e.StudentA != "" {
+		return fmt.Sprintf("analysis failed for student %s: %s: %v", e.StudentA, e.Reason, e.Err)
+	}
 	return fmt.Sprintf("analysis failed: %s: %v", e.Reason, e.Err)
 }
 
@@ -0,0 +1,186 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/pkg/clustering"
+)
+
+// ListSuspiciousGroups находит группы студентов, работы которых связаны цепочками подозрительных пар.
+// Рёбра графа - пары со схожестью не ниже minSimilarity (0 - порог плагиата), группа - компонента связности.
+// Компонента, в которой есть несколько плотных сообществ, дополнительно делится на них.
+func (s *PlagiarismService) ListSuspiciousGroups(ctx context.Context, taskId string, minSimilarity float64) ([]SuspiciousGroup, error) {
+	const op = "Plagiarism_Service.ListSuspiciousGroups"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+	)
+
+	if _, err := s.db.GetTaskByID(ctx, taskId); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
+
+	reports, err := s.db.GetReportsByTaskID(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to load reports", "error", err)
+		return nil, err
+	}
+
+	if minSimilarity <= 0 {
+		minSimilarity = plagiarismThreshold
+	}
+
+	return findSuspiciousGroups(reports, minSimilarity), nil
+}
+
+// similarityGraph - граф подозрительных пар: вершины - студенты, рёбра - пары выше порога.
+type similarityGraph struct {
+	students    []string
+	edges       []clustering.Edge
+	submittedAt map[string]time.Time
+}
+
+func buildSimilarityGraph(reports []domain.PlagiarismReport, minSimilarity float64) *similarityGraph {
+	g := &similarityGraph{submittedAt: make(map[string]time.Time)}
+
+	index := make(map[string]int)
+	vertex := func(studentID string, submittedAt time.Time) int {
+		if current, ok := g.submittedAt[studentID]; !ok || submittedAt.Before(current) {
+			g.submittedAt[studentID] = submittedAt
+		}
+
+		if i, ok := index[studentID]; ok {
+			return i
+		}
+		index[studentID] = len(g.students)
+		g.students = append(g.students, studentID)
+		return index[studentID]
+	}
+
+	for _, r := range reports {
+		if r.Similarity < minSimilarity {
+			continue
+		}
+
+		g.edges = append(g.edges, clustering.Edge{
+			A:      vertex(r.StudentA, r.FileAHandedOverAt),
+			B:      vertex(r.StudentB, r.FileBHandedOverAt),
+			Weight: r.Similarity,
+		})
+	}
+
+	return g
+}
+
+// findSuspiciousGroups строит группы по компонентам связности графа подозрительных пар.
+// Группы упорядочены по убыванию оценки, затем размера.
+func findSuspiciousGroups(reports []domain.PlagiarismReport, minSimilarity float64) []SuspiciousGroup {
+	g := buildSimilarityGraph(reports, minSimilarity)
+
+	communityOf := make(map[int]int)
+	communities := clustering.Communities(len(g.students), g.edges)
+	for c, members := range communities {
+		for _, v := range members {
+			communityOf[v] = c
+		}
+	}
+
+	var groups []SuspiciousGroup
+	for _, component := range clustering.Components(len(g.students), g.edges) {
+		if len(component) < 2 {
+			continue
+		}
+
+		group := SuspiciousGroup{GroupStats: g.stats(component)}
+
+		// компонента делится на сообщества, только если их в ней несколько
+		inComponent := make(map[int][]int)
+		var order []int
+		for _, v := range component {
+			c := communityOf[v]
+			if _, ok := inComponent[c]; !ok {
+				order = append(order, c)
+			}
+			inComponent[c] = append(inComponent[c], v)
+		}
+		if len(order) > 1 {
+			for _, c := range order {
+				if len(inComponent[c]) < 2 {
+					continue
+				}
+				group.Communities = append(group.Communities, g.stats(inComponent[c]))
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Score != groups[j].Score {
+			return groups[i].Score > groups[j].Score
+		}
+		return len(groups[i].Members) > len(groups[j].Members)
+	})
+
+	for i := range groups {
+		groups[i].ID = fmt.Sprintf("group-%d", i+1)
+	}
+
+	return groups
+}
+
+// stats считает показатели группы вершин: оценка - средняя схожесть подозрительных пар внутри группы,
+// плотность - доля таких пар среди всех пар группы, источник - студент, сдавший работу раньше всех.
+func (g *similarityGraph) stats(vertices []int) GroupStats {
+	inGroup := make(map[int]bool, len(vertices))
+	for _, v := range vertices {
+		inGroup[v] = true
+	}
+
+	var stats GroupStats
+	var sum float64
+	var edges int
+	for _, e := range g.edges {
+		if !inGroup[e.A] || !inGroup[e.B] {
+			continue
+		}
+		sum += e.Weight
+		edges++
+		stats.MaxSimilarity = max(stats.MaxSimilarity, e.Weight)
+	}
+
+	if edges > 0 {
+		stats.Score = sum / float64(edges)
+	}
+	if n := len(vertices); n > 1 {
+		stats.Density = float64(edges) / float64(n*(n-1)/2)
+	}
+
+	for _, v := range vertices {
+		student := g.students[v]
+		stats.Members = append(stats.Members, student)
+
+		submittedAt := g.submittedAt[student]
+		if stats.Origin == "" || submittedAt.Before(stats.OriginSubmittedAt) ||
+			(submittedAt.Equal(stats.OriginSubmittedAt) && student < stats.Origin) {
+			stats.Origin = student
+			stats.OriginSubmittedAt = submittedAt
+		}
+	}
+	sort.Strings(stats.Members)
+
+	return stats
+}
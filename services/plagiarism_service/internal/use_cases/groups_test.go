@@ -0,0 +1,101 @@
+package use_cases
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+)
+
+// pair возвращает отчёт по паре работ, сданных в uploadedAt.
+func pair(studentA, studentB string, similarity float64) domain.PlagiarismReport {
+	return domain.PlagiarismReport{
+		StudentA:          studentA,
+		StudentB:          studentB,
+		Similarity:        similarity,
+		FileAHandedOverAt: uploadedAt,
+		FileBHandedOverAt: uploadedAt,
+	}
+}
+
+func TestFindSuspiciousGroups(t *testing.T) {
+	late := pair("s1", "s2", 0.9)
+	late.FileAHandedOverAt = uploadedAt.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		reports     []domain.PlagiarismReport
+		members     [][]string
+		origins     []string
+		communities int
+	}{
+		{
+			name: "empty task",
+		},
+		{
+			name:    "similarity equal to the threshold is suspicious",
+			reports: []domain.PlagiarismReport{pair("s1", "s2", 0.8)},
+			members: [][]string{{"s1", "s2"}},
+			origins: []string{"s1"},
+		},
+		{
+			name:    "pairs below the threshold form no groups",
+			reports: []domain.PlagiarismReport{pair("s1", "s2", 0.79), pair("s3", "s4", 0.5)},
+		},
+		{
+			name:    "chain joins a group, lone students are left out",
+			reports: []domain.PlagiarismReport{pair("s1", "s2", 0.85), pair("s2", "s3", 0.85), pair("s3", "s4", 0.3)},
+			members: [][]string{{"s1", "s2", "s3"}},
+			origins: []string{"s1"},
+		},
+		{
+			name:    "groups ordered by score",
+			reports: []domain.PlagiarismReport{pair("s1", "s2", 0.85), pair("s3", "s4", 0.95)},
+			members: [][]string{{"s3", "s4"}, {"s1", "s2"}},
+			origins: []string{"s3", "s1"},
+		},
+		{
+			name:    "origin is the earliest submission",
+			reports: []domain.PlagiarismReport{late},
+			members: [][]string{{"s1", "s2"}},
+			origins: []string{"s2"},
+		},
+		{
+			name: "weak bridge splits a group into communities",
+			reports: []domain.PlagiarismReport{
+				pair("s1", "s2", 0.95), pair("s2", "s3", 0.95), pair("s1", "s3", 0.95),
+				pair("s4", "s5", 0.95), pair("s5", "s6", 0.95), pair("s4", "s6", 0.95),
+				pair("s3", "s4", 0.8),
+			},
+			members:     [][]string{{"s1", "s2", "s3", "s4", "s5", "s6"}},
+			origins:     []string{"s1"},
+			communities: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := findSuspiciousGroups(tt.reports, 0.8)
+
+			var members [][]string
+			var origins []string
+			for _, g := range groups {
+				members = append(members, g.Members)
+				origins = append(origins, g.Origin)
+			}
+			if !reflect.DeepEqual(members, tt.members) {
+				t.Errorf("members = %v, want %v", members, tt.members)
+			}
+			if !reflect.DeepEqual(origins, tt.origins) {
+				t.Errorf("origins = %v, want %v", origins, tt.origins)
+			}
+			if len(groups) > 0 && len(groups[0].Communities) != tt.communities {
+				t.Errorf("communities = %d, want %d", len(groups[0].Communities), tt.communities)
+			}
+			if len(groups) > 0 && groups[0].ID != "group-1" {
+				t.Errorf("first group id = %q, want group-1", groups[0].ID)
+			}
+		})
+	}
+}
@@ -12,7 +12,38 @@ type DB interface {
 	GetTaskByID(ctx context.Context, taskID string) (*domain.Task, error)
 	DeleteTask(ctx context.Context, taskID string) error
 	DeleteReportsByTaskID(ctx context.Context, taskID string) error
-	SaveReport(ctx context.Context, report *domain.PlagiarismReport) error
-	SaveTask(ctx context.Context, task *domain.Task) error
-	UpdateTaskAnalysisTime(ctx context.Context, taskID string, analysisStartedAt time.Time) error
+	EnsureTask(ctx context.Context, taskID string) error
+	GetAnalyzedFiles(ctx context.Context, taskID string) ([]domain.AnalyzedFile, error)
+	GetReportsByTaskID(ctx context.Context, taskID string) ([]domain.PlagiarismReport, error)
+	GetReportsByTaskIDAbove(ctx context.Context, taskID string, minSimilarity float64) ([]domain.PlagiarismReport, error)
+	GetFragmentsByTaskID(ctx context.Context, taskID string) ([]domain.PairFragment, error)
+	GetReportByPair(ctx context.Context, taskID, studentA, studentB string) (*domain.PlagiarismReport, error)
+	GetFragmentsByPair(ctx context.Context, taskID, studentA, studentB string) ([]domain.PairFragment, error)
+	GetPairFilesByTaskID(ctx context.Context, taskID string) ([]domain.PairFileReport, error)
+	GetPairFilesByPair(ctx context.Context, taskID, studentA, studentB string) ([]domain.PairFileReport, error)
+	SaveReview(ctx context.Context, review *domain.PairReview) error
+	ListReviewsByPair(ctx context.Context, taskID, studentA, studentB string) ([]domain.PairReview, error)
+	ListReviewsByTaskID(ctx context.Context, taskID string) ([]domain.PairReview, error)
+	SaveAppeal(ctx context.Context, appeal *domain.Appeal) error
+	GetAppeal(ctx context.Context, appealID string) (*domain.Appeal, error)
+	ListAppealsByTaskID(ctx context.Context, taskID string, state domain.AppealState) ([]domain.Appeal, error)
+	AddAppealAttachment(ctx context.Context, attachment *domain.AppealAttachment) error
+	ResolveAppeal(ctx context.Context, appeal *domain.Appeal, review *domain.PairReview) error
+	ApplyAnalysis(ctx context.Context, result *domain.AnalysisResult) error
+	TryLockTaskAnalysis(ctx context.Context, taskID string) (func(), error)
+	SaveJob(ctx context.Context, job *domain.AnalysisJob) error
+	EnqueueJob(ctx context.Context, job *domain.AnalysisJob) (*domain.AnalysisJob, bool, error)
+	GetJobByID(ctx context.Context, jobID string) (*domain.AnalysisJob, error)
+	ClaimJob(ctx context.Context, owner string, now, leaseExpiresAt time.Time) (*domain.AnalysisJob, error)
+	BuryExpiredJobs(ctx context.Context, now time.Time) (int64, error)
+	ExtendLease(ctx context.Context, jobID, owner string, leaseExpiresAt time.Time) (bool, error)
+	GetLatestJobByTaskID(ctx context.Context, taskID string) (*domain.AnalysisJob, error)
+	UpdateJobProgress(ctx context.Context, jobID string, progress domain.JobProgress) error
+	SaveJobFinding(ctx context.Context, finding *domain.JobFinding) error
+	DeleteJobFindings(ctx context.Context, jobID string) error
+	ListJobFindings(ctx context.Context, jobID string, afterID int64) ([]domain.JobFinding, error)
+	CompleteJob(ctx context.Context, jobID, owner string, state domain.JobState, errMsg string, finishedAt time.Time) (bool, error)
+	RetryJob(ctx context.Context, jobID, owner, errMsg string, nextRunAt, now time.Time) (domain.JobState, error)
+	ReleaseJob(ctx context.Context, jobID, owner string, nextRunAt time.Time) error
+	FinishJob(ctx context.Context, jobID string, state domain.JobState, errMsg string, finishedAt time.Time) (bool, error)
 }
@@ -0,0 +1,434 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	errJobCancelled = errors.New("analysis job cancelled")
+	errLeaseLost    = errors.New("analysis job lease lost")
+)
+
+// QueueSettings задаёт параметры аренды и повторов заданий анализа.
+type QueueSettings struct {
+	LeaseDuration  time.Duration
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// retryDelay возвращает экспоненциальную задержку перед очередной попыткой.
+func (q QueueSettings) retryDelay(attempt int) time.Duration {
+	delay := q.RetryBaseDelay
+	for i := 1; i < attempt && delay < q.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, q.RetryMaxDelay)
+}
+
+// jobRegistry хранит функции отмены заданий, выполняющихся в этом процессе,
+// чтобы отмена через этот же экземпляр сервиса срабатывала сразу, не дожидаясь продления аренды.
+type jobRegistry struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelCauseFunc
+}
+
+func newJobRegistry() *jobRegistry {
+	return &jobRegistry{cancels: make(map[string]context.CancelCauseFunc)}
+}
+
+func (r *jobRegistry) add(jobID string, cancel context.CancelCauseFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cancels[jobID] = cancel
+}
+
+func (r *jobRegistry) remove(jobID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.cancels, jobID)
+}
+
+func (r *jobRegistry) cancel(jobID string, cause error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cancel, ok := r.cancels[jobID]; ok {
+		cancel(cause)
+	}
+}
+
+// StartAnalysis ставит задание анализа задачи в очередь. Выполнят его воркеры любой из реплик сервиса.
+// Если у задачи уже есть задание в очереди или в работе, новое не создаётся: возвращается
+// идентификатор активного задания и attached = true.
+func (s *PlagiarismService) StartAnalysis(ctx context.Context, taskId string) (jobId string, attached bool, err error) {
+	const op = "Plagiarism_Service.StartAnalysis"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+	)
+
+	if err = s.ensureTask(ctx, taskId, logger); err != nil {
+		return "", false, err
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		logger.Error("failed to generate uuid", "error", err)
+		return "", false, fmt.Errorf("failed to generate uuid: %w", err)
+	}
+
+	now := time.Now()
+	job := &domain.AnalysisJob{
+		ID:          id,
+		TaskID:      taskId,
+		State:       domain.JobStateQueued,
+		CreatedAt:   now,
+		MaxAttempts: s.queue.MaxAttempts,
+		NextRunAt:   now,
+	}
+
+	active, created, err := s.db.EnqueueJob(ctx, job)
+	if err != nil {
+		logger.Error("failed to enqueue job", "error", err)
+		return "", false, err
+	}
+
+	if !created {
+		logger.Info("attached to active analysis job", "job_id", active.ID.String(), "state", string(active.State))
+		return active.ID.String(), true, nil
+	}
+
+	logger.Info("analysis job queued", "job_id", active.ID.String())
+
+	return active.ID.String(), false, nil
+}
+
+func (s *PlagiarismService) GetAnalysisJob(ctx context.Context, jobId string) (*AnalysisJob, error) {
+	const op = "Plagiarism_Service.GetAnalysisJob"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("job_id", jobId),
+	)
+
+	job, err := s.db.GetJobByID(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		logger.Error("failed to load job", "error", err)
+		return nil, err
+	}
+
+	return toUseCaseJob(job), nil
+}
+
+// CancelAnalysisJob отменяет задание в очереди или в работе. Завершённое задание возвращается без изменений.
+// Воркер другой реплики узнаёт об отмене при очередном продлении аренды.
+func (s *PlagiarismService) CancelAnalysisJob(ctx context.Context, jobId string) (*AnalysisJob, error) {
+	const op = "Plagiarism_Service.CancelAnalysisJob"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("job_id", jobId),
+	)
+
+	job, err := s.db.GetJobByID(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		logger.Error("failed to load job", "error", err)
+		return nil, err
+	}
+
+	if job.State.IsFinished() {
+		return toUseCaseJob(job), nil
+	}
+
+	if _, err = s.db.FinishJob(ctx, jobId, domain.JobStateCancelled, "", time.Now()); err != nil {
+		logger.Error("failed to cancel job", "error", err)
+		return nil, err
+	}
+
+	s.jobs.cancel(jobId, errJobCancelled)
+
+	logger.Info("analysis job cancelled")
+
+	return s.GetAnalysisJob(ctx, jobId)
+}
+
+// ProcessNextJob берёт из очереди одно задание и выполняет его, пока воркер держит аренду.
+// Возвращает false, если готовых к запуску заданий нет.
+// Отмена ctx означает остановку воркера: задание возвращается в очередь без учёта попытки.
+func (s *PlagiarismService) ProcessNextJob(ctx context.Context, workerID string) (bool, error) {
+	const op = "Plagiarism_Service.ProcessNextJob"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("worker_id", workerID),
+	)
+
+	now := time.Now()
+
+	buried, err := s.db.BuryExpiredJobs(ctx, now)
+	if err != nil {
+		logger.Error("failed to bury expired jobs", "error", err)
+		return false, err
+	}
+	if buried > 0 {
+		logger.Warn("jobs moved to dead state after repeated lease expiry", "count", buried)
+	}
+
+	job, err := s.db.ClaimJob(ctx, workerID, now, now.Add(s.queue.LeaseDuration))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return false, nil
+		}
+		logger.Error("failed to claim job", "error", err)
+		return false, err
+	}
+
+	// задание одно на задачу, но его аренду мог перехватить другой воркер, пока прежний ещё работает
+	unlock, err := s.db.TryLockTaskAnalysis(ctx, job.TaskID)
+	if err != nil {
+		s.postponeJob(job, workerID, err, logger)
+		return true, nil
+	}
+	defer unlock()
+
+	// находки прошлой попытки будут найдены заново
+	if err = s.db.DeleteJobFindings(ctx, job.ID.String()); err != nil {
+		logger.Warn("failed to delete findings of previous attempts", "job_id", job.ID.String(), "error", err)
+	}
+
+	s.processJob(ctx, job, workerID)
+
+	return true, nil
+}
+
+// postponeJob возвращает в очередь задание, задачу которого сейчас анализирует другой воркер.
+// Попытка не засчитывается.
+func (s *PlagiarismService) postponeJob(job *domain.AnalysisJob, workerID string, lockErr error, logger *slog.Logger) {
+	logger = logger.With(slog.String("job_id", job.ID.String()), slog.String("task_id", job.TaskID))
+
+	if errors.Is(lockErr, repositories.ErrLocked) {
+		logger.Warn("task is being analyzed by another worker, job postponed")
+	} else {
+		logger.Error("failed to lock task for analysis, job postponed", "error", lockErr)
+	}
+
+	err := s.db.ReleaseJob(context.Background(), job.ID.String(), workerID, time.Now().Add(s.queue.RetryBaseDelay))
+	if err != nil {
+		logger.Error("failed to release job", "error", err)
+	}
+}
+
+// processJob выполняет анализ задачи и фиксирует результат в задании.
+func (s *PlagiarismService) processJob(workerCtx context.Context, job *domain.AnalysisJob, workerID string) {
+	const op = "Plagiarism_Service.processJob"
+
+	jobID := job.ID.String()
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", job.TaskID),
+		slog.String("job_id", jobID),
+		slog.Int("attempt", job.Attempts),
+	)
+
+	logger.Info("analysis job claimed")
+
+	ctx, cancel := context.WithCancelCause(workerCtx)
+	defer cancel(nil)
+
+	s.jobs.add(jobID, cancel)
+	defer s.jobs.remove(jobID)
+
+	heartbeatDone := make(chan struct{})
+	go s.keepLease(ctx, cancel, jobID, workerID, logger, heartbeatDone)
+
+	err := s.runJob(ctx, job, logger)
+
+	cancel(nil)
+	<-heartbeatDone
+
+	// статусы пишем вне контекста задания: после отмены он уже недействителен
+	statusCtx := context.Background()
+
+	switch cause := context.Cause(ctx); {
+	case err == nil:
+		s.completeJob(statusCtx, jobID, workerID, domain.JobStateSucceeded, "", logger)
+	case errors.Is(cause, errJobCancelled):
+		logger.Info("analysis job stopped after cancellation")
+	case errors.Is(cause, errLeaseLost):
+		logger.Warn("analysis job stopped: lease lost")
+	case workerCtx.Err() != nil:
+		if err = s.db.ReleaseJob(statusCtx, jobID, workerID, time.Now()); err != nil {
+			logger.Error("failed to release job", "error", err)
+			return
+		}
+		logger.Info("analysis job returned to queue: worker is stopping")
+	case isPermanentJobError(err):
+		s.completeJob(statusCtx, jobID, workerID, domain.JobStateFailed, jobErrorMessage(err), logger)
+	default:
+		s.retryJob(statusCtx, job, workerID, err, logger)
+	}
+}
+
+// runJob выполняет анализ, превращая панику в ошибку задания.
+func (s *PlagiarismService) runJob(ctx context.Context, job *domain.AnalysisJob, logger *slog.Logger) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("PANIC", "recover", r)
+			err = fmt.Errorf("panic during analysis: %v", r)
+		}
+	}()
+
+	return s.analyzeTask(ctx, job.TaskID, s.newJobObserver(job.ID.String(), logger), logger)
+}
+
+// keepLease продлевает аренду задания, пока оно выполняется, и прерывает его,
+// если задание отменили или аренду перехватил другой воркер.
+func (s *PlagiarismService) keepLease(
+	ctx context.Context,
+	cancel context.CancelCauseFunc,
+	jobID, workerID string,
+	logger *slog.Logger,
+	done chan<- struct{},
+) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.queue.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		extended, err := s.db.ExtendLease(ctx, jobID, workerID, time.Now().Add(s.queue.LeaseDuration))
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn("failed to extend job lease", "error", err)
+			}
+			continue
+		}
+		if extended {
+			continue
+		}
+
+		job, err := s.db.GetJobByID(ctx, jobID)
+		if err == nil && job.State == domain.JobStateCancelled {
+			cancel(errJobCancelled)
+		} else {
+			cancel(errLeaseLost)
+		}
+		return
+	}
+}
+
+func (s *PlagiarismService) completeJob(ctx context.Context, jobID, workerID string, state domain.JobState, errMsg string, logger *slog.Logger) {
+	completed, err := s.db.CompleteJob(ctx, jobID, workerID, state, errMsg, time.Now())
+	if err != nil {
+		logger.Error("failed to complete job", "state", string(state), "error", err)
+		return
+	}
+
+	if !completed {
+		logger.Warn("job was taken over or cancelled before completion", "state", string(state))
+		return
+	}
+
+	logger.Info("analysis job finished", "state", string(state))
+}
+
+func (s *PlagiarismService) retryJob(ctx context.Context, job *domain.AnalysisJob, workerID string, jobErr error, logger *slog.Logger) {
+	now := time.Now()
+	nextRunAt := now.Add(s.queue.retryDelay(job.Attempts))
+
+	state, err := s.db.RetryJob(ctx, job.ID.String(), workerID, jobErrorMessage(jobErr), nextRunAt, now)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("job was taken over or cancelled before retry")
+			return
+		}
+		logger.Error("failed to schedule job retry", "error", err)
+		return
+	}
+
+	if state == domain.JobStateDead {
+		logger.Error("analysis job exhausted its attempts", "error", jobErr)
+		return
+	}
+
+	logger.Warn("analysis job failed, retry scheduled", "next_run_at", nextRunAt, "error", jobErr)
+}
+
+// isPermanentJobError сообщает, что повтор задания даст тот же результат:
+// например, файл студента не удаётся разобрать.
+func isPermanentJobError(err error) bool {
+	var analysisErr *AnalysisError
+	return errors.As(err, &analysisErr)
+}
+
+// jobErrorMessage формирует текст ошибки задания, не раскрывая внутренние детали.
+func jobErrorMessage(err error) string {
+	var analysisErr *AnalysisError
+	if errors.As(err, &analysisErr) {
+		return analysisErr.Error()
+	}
+
+	for _, known := range []error{ErrExternalConnectionFailed, ErrFileExtractionFailed, ErrFileDownloadFailed} {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+
+	return "internal error"
+}
+
+func toUseCaseJob(job *domain.AnalysisJob) *AnalysisJob {
+	result := &AnalysisJob{
+		ID:              job.ID.String(),
+		TaskID:          job.TaskID,
+		State:           string(job.State),
+		ProgressDone:    job.ProgressDone,
+		ProgressTotal:   job.ProgressTotal,
+		Error:           job.Error,
+		CreatedAt:       job.CreatedAt,
+		Attempts:        job.Attempts,
+		MaxAttempts:     job.MaxAttempts,
+		FilesExtracted:  job.FilesExtracted,
+		FilesTotal:      job.FilesTotal,
+		SuspiciousPairs: job.SuspiciousPairs,
+	}
+
+	if job.StartedAt != nil {
+		result.StartedAt = *job.StartedAt
+	}
+	if job.FinishedAt != nil {
+		result.FinishedAt = *job.FinishedAt
+	}
+	if job.State == domain.JobStateQueued {
+		result.NextRunAt = job.NextRunAt
+	}
+
+	return result
+}
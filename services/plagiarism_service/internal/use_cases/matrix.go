@@ -0,0 +1,118 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/pkg/clustering"
+)
+
+// GetSimilarityMatrix возвращает полную матрицу попарной схожести работ задачи по последнему анализу.
+// Студенты упорядочены иерархической кластеризацией, чтобы группы похожих работ шли подряд.
+func (s *PlagiarismService) GetSimilarityMatrix(ctx context.Context, taskId string) (*SimilarityMatrix, error) {
+	const op = "Plagiarism_Service.GetSimilarityMatrix"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+	)
+
+	task, err := s.db.GetTaskByID(ctx, taskId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
+
+	reports, err := s.db.GetReportsByTaskID(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to load reports", "error", err)
+		return nil, err
+	}
+
+	analyzedFiles, err := s.db.GetAnalyzedFiles(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to load analyzed files", "error", err)
+		return nil, err
+	}
+
+	students, similarities := buildSimilarityMatrix(reports, analyzedFiles)
+
+	return &SimilarityMatrix{
+		TaskID:       task.ID,
+		StartedAt:    task.AnalysisStartedAt,
+		Students:     students,
+		Similarities: similarities,
+
+		PlagiarismThreshold: plagiarismThreshold,
+	}, nil
+}
+
+// buildSimilarityMatrix собирает симметричную матрицу схожести (на диагонали 1)
+// и переставляет строки и столбцы в порядке кластеризации.
+// В матрицу попадают и студенты без пар, например единственная работа задачи.
+func buildSimilarityMatrix(reports []domain.PlagiarismReport, analyzedFiles []domain.AnalyzedFile) ([]string, [][]float64) {
+	index := make(map[string]int)
+	var students []string
+	add := func(studentID string) {
+		if _, ok := index[studentID]; !ok {
+			index[studentID] = len(students)
+			students = append(students, studentID)
+		}
+	}
+
+	for _, f := range analyzedFiles {
+		add(f.StudentID)
+	}
+	for _, r := range reports {
+		add(r.StudentA)
+		add(r.StudentB)
+	}
+
+	// исходный порядок по имени делает кластеризацию воспроизводимой
+	sort.Strings(students)
+	for i, studentID := range students {
+		index[studentID] = i
+	}
+
+	n := len(students)
+	similarity := make([][]float64, n)
+	for i := range similarity {
+		similarity[i] = make([]float64, n)
+		similarity[i][i] = 1
+	}
+	for _, r := range reports {
+		a, b := index[r.StudentA], index[r.StudentB]
+		similarity[a][b] = r.Similarity
+		similarity[b][a] = r.Similarity
+	}
+
+	distance := make([][]float64, n)
+	for i := range distance {
+		distance[i] = make([]float64, n)
+		for j := range distance[i] {
+			distance[i][j] = 1 - similarity[i][j]
+		}
+	}
+
+	order := clustering.Order(distance)
+
+	orderedStudents := make([]string, n)
+	ordered := make([][]float64, n)
+	for i, oi := range order {
+		orderedStudents[i] = students[oi]
+		ordered[i] = make([]float64, n)
+		for j, oj := range order {
+			ordered[i][j] = similarity[oi][oj]
+		}
+	}
+
+	return orderedStudents, ordered
+}
@@ -0,0 +1,60 @@
+package use_cases
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+)
+
+func TestBuildSimilarityMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		reports  []domain.PlagiarismReport
+		analyzed []domain.AnalyzedFile
+		students []string
+		matrix   [][]float64
+	}{
+		{
+			name:     "empty task",
+			students: []string{},
+			matrix:   [][]float64{},
+		},
+		{
+			name:     "single submission without pairs",
+			analyzed: []domain.AnalyzedFile{{StudentID: "s1"}},
+			students: []string{"s1"},
+			matrix:   [][]float64{{1}},
+		},
+		{
+			name:     "similar submissions become neighbours",
+			reports:  []domain.PlagiarismReport{pair("a", "b", 0.1), pair("a", "c", 0.9), pair("b", "c", 0.2)},
+			analyzed: []domain.AnalyzedFile{{StudentID: "a"}, {StudentID: "b"}, {StudentID: "c"}},
+			students: []string{"a", "c", "b"},
+			matrix: [][]float64{
+				{1, 0.9, 0.1},
+				{0.9, 1, 0.2},
+				{0.1, 0.2, 1},
+			},
+		},
+		{
+			name:     "student missing from analyzed files is still listed",
+			reports:  []domain.PlagiarismReport{pair("b", "a", 0.5)},
+			analyzed: []domain.AnalyzedFile{{StudentID: "a"}},
+			students: []string{"a", "b"},
+			matrix:   [][]float64{{1, 0.5}, {0.5, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			students, matrix := buildSimilarityMatrix(tt.reports, tt.analyzed)
+			if !reflect.DeepEqual(students, tt.students) {
+				t.Errorf("students = %v, want %v", students, tt.students)
+			}
+			if !reflect.DeepEqual(matrix, tt.matrix) {
+				t.Errorf("matrix = %v, want %v", matrix, tt.matrix)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+)
+
+// ListPairReports возвращает пары последнего анализа задачи со схожестью не ниже minSimilarity
+// (nil - порог плагиата), по убыванию схожести. С includeFragments к подозрительным парам добавляются общие фрагменты.
+func (s *PlagiarismService) ListPairReports(ctx context.Context, taskId string, minSimilarity *float64, includeFragments bool) (*TaskPairs, error) {
+	const op = "Plagiarism_Service.ListPairReports"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+	)
+
+	task, err := s.db.GetTaskByID(ctx, taskId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
+
+	threshold := plagiarismThreshold
+	if minSimilarity != nil {
+		threshold = *minSimilarity
+	}
+
+	reports, err := s.db.GetReportsByTaskIDAbove(ctx, taskId, threshold)
+	if err != nil {
+		logger.Error("failed to load reports", "error", err)
+		return nil, err
+	}
+
+	verdicts, err := s.currentVerdicts(ctx, taskId, reports)
+	if err != nil {
+		logger.Error("failed to load reviews", "error", err)
+		return nil, err
+	}
+
+	storedFiles, err := s.db.GetPairFilesByTaskID(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to load pair files", "error", err)
+		return nil, err
+	}
+
+	pairFiles := make(map[[2]string][]PairFileReport)
+	for _, f := range storedFiles {
+		key := [2]string{f.StudentA, f.StudentB}
+		pairFiles[key] = append(pairFiles[key], toUseCasePairFile(f))
+	}
+
+	fragments := make(map[[2]string][]Fragment)
+	if includeFragments {
+		stored, err := s.db.GetFragmentsByTaskID(ctx, taskId)
+		if err != nil {
+			logger.Error("failed to load fragments", "error", err)
+			return nil, err
+		}
+
+		for _, f := range stored {
+			key := [2]string{f.StudentA, f.StudentB}
+			fragments[key] = append(fragments[key], Fragment{
+				AStart:  f.AStart,
+				BStart:  f.BStart,
+				Length:  f.Length,
+				Excerpt: f.Excerpt,
+			})
+		}
+	}
+
+	pairs := make([]PairReport, 0, len(reports))
+	for _, r := range reports {
+		pairs = append(pairs, PairReport{
+			StudentA:          r.StudentA,
+			StudentB:          r.StudentB,
+			Similarity:        r.Similarity,
+			Suspicious:        r.Similarity >= plagiarismThreshold,
+			FileAHandedOverAt: r.FileAHandedOverAt,
+			FileBHandedOverAt: r.FileBHandedOverAt,
+			FileAVersion:      r.FileAVersion,
+			FileBVersion:      r.FileBVersion,
+			Files:             pairFiles[[2]string{r.StudentA, r.StudentB}],
+			Fragments:         fragments[[2]string{r.StudentA, r.StudentB}],
+			Verdict:           verdicts[pairKey{r.StudentA, r.StudentB}],
+		})
+	}
+
+	return &TaskPairs{
+		TaskID:              task.ID,
+		StartedAt:           task.AnalysisStartedAt,
+		PlagiarismThreshold: plagiarismThreshold,
+		NGramSize:           nGramSize,
+		MinFragmentWords:    minFragmentWords,
+		Pairs:               pairs,
+	}, nil
+}
+
+// GetPairEvidence возвращает пару последнего анализа со всеми общими фрагментами.
+// Пары хранятся в каноническом порядке, поэтому при обратном порядке студентов в запросе
+// отчёт и позиции фрагментов разворачиваются.
+func (s *PlagiarismService) GetPairEvidence(ctx context.Context, taskId, studentA, studentB string) (*PairEvidence, error) {
+	const op = "Plagiarism_Service.GetPairEvidence"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+		slog.String("student_a", studentA),
+		slog.String("student_b", studentB),
+	)
+
+	task, err := s.db.GetTaskByID(ctx, taskId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
+
+	swapped := studentA > studentB
+	if swapped {
+		studentA, studentB = studentB, studentA
+	}
+
+	report, err := s.db.GetReportByPair(ctx, taskId, studentA, studentB)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("pair was not compared")
+			return nil, ErrPairNotFound
+		}
+		logger.Error("failed to load report", "error", err)
+		return nil, err
+	}
+
+	stored, err := s.db.GetFragmentsByPair(ctx, taskId, studentA, studentB)
+	if err != nil {
+		logger.Error("failed to load fragments", "error", err)
+		return nil, err
+	}
+
+	storedFiles, err := s.db.GetPairFilesByPair(ctx, taskId, studentA, studentB)
+	if err != nil {
+		logger.Error("failed to load pair files", "error", err)
+		return nil, err
+	}
+
+	reviews, err := s.db.ListReviewsByPair(ctx, taskId, studentA, studentB)
+	if err != nil {
+		logger.Error("failed to load reviews", "error", err)
+		return nil, err
+	}
+
+	pair := PairReport{
+		StudentA:          report.StudentA,
+		StudentB:          report.StudentB,
+		Similarity:        report.Similarity,
+		Suspicious:        report.Similarity >= plagiarismThreshold,
+		FileAHandedOverAt: report.FileAHandedOverAt,
+		FileBHandedOverAt: report.FileBHandedOverAt,
+		FileAVersion:      report.FileAVersion,
+		FileBVersion:      report.FileBVersion,
+		Fragments:         make([]Fragment, 0, len(stored)),
+	}
+	for _, review := range reviewHistory(reviews, []domain.PlagiarismReport{*report}) {
+		if review.Current {
+			pair.Verdict = &review
+			break
+		}
+	}
+	for _, f := range storedFiles {
+		pair.Files = append(pair.Files, toUseCasePairFile(f))
+	}
+	for _, f := range stored {
+		pair.Fragments = append(pair.Fragments, Fragment{
+			AStart:  f.AStart,
+			BStart:  f.BStart,
+			Length:  f.Length,
+			Excerpt: f.Excerpt,
+		})
+	}
+
+	if swapped {
+		pair.StudentA, pair.StudentB = pair.StudentB, pair.StudentA
+		pair.FileAHandedOverAt, pair.FileBHandedOverAt = pair.FileBHandedOverAt, pair.FileAHandedOverAt
+		pair.FileAVersion, pair.FileBVersion = pair.FileBVersion, pair.FileAVersion
+		for i := range pair.Files {
+			f := &pair.Files[i]
+			f.FileAVersion, f.FileBVersion = f.FileBVersion, f.FileAVersion
+		}
+		for i := range pair.Fragments {
+			f := &pair.Fragments[i]
+			f.AStart, f.BStart = f.BStart, f.AStart
+		}
+		sort.Slice(pair.Fragments, func(i, j int) bool { return pair.Fragments[i].AStart < pair.Fragments[j].AStart })
+		if pair.Verdict != nil {
+			orientReview(pair.Verdict, pair.StudentA)
+		}
+	}
+
+	return &PairEvidence{
+		TaskID:              task.ID,
+		StartedAt:           task.AnalysisStartedAt,
+		PlagiarismThreshold: plagiarismThreshold,
+		MinFragmentWords:    minFragmentWords,
+		Pair:                pair,
+	}, nil
+}
+
+func toUseCasePairFile(f domain.PairFileReport) PairFileReport {
+	return PairFileReport{
+		FileName:     f.FileName,
+		Similarity:   f.Similarity,
+		Suspicious:   f.Similarity >= plagiarismThreshold,
+		FileAVersion: f.FileAVersion,
+		FileBVersion: f.FileBVersion,
+	}
+}
@@ -4,26 +4,43 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
 	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
 	"github.com/Nikita-Smirnov-idk/plagiarism-service/pkg/plagiarism_analyzer"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/pkg/text_extractor"
 	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
 	"github.com/google/uuid"
 )
 
+const (
+	nGramSize = 3
+	// plagiarismThreshold - схожесть, начиная с которой пара считается подозрительной
+	plagiarismThreshold = 0.7
+	// общие фрагменты ищутся только у подозрительных пар; короче minFragmentWords слов - случайные совпадения
+	minFragmentWords    = 8
+	maxFragmentsPerPair = 100
+	maxExcerptWords     = 60
+)
+
 type PlagiarismService struct {
 	logger  *slog.Logger
 	db      DB
 	storage storagepb.StorageClient
+	queue   QueueSettings
+	jobs    *jobRegistry
 }
 
-func NewPlagiarismService(logger *slog.Logger, db DB, storage storagepb.StorageClient) *PlagiarismService {
+func NewPlagiarismService(logger *slog.Logger, db DB, storage storagepb.StorageClient, queue QueueSettings) *PlagiarismService {
 	return &PlagiarismService{
 		logger:  logger,
 		db:      db,
 		storage: storage,
+		queue:   queue,
+		jobs:    newJobRegistry(),
 	}
 }
 
@@ -38,46 +55,50 @@ func (s *PlagiarismService) GetPlagiarismReport(ctx context.Context, taskId stri
 	task, err := s.db.GetTaskByID(ctx, taskId)
 	if err != nil {
 		if errors.Is(err, repositories.ErrNotFound) {
-			logger.Info("task not found in DB, creating new task and running analysis")
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
 
-			analysisTime := time.Now()
-			newTask := &domain.Task{
-				ID:                taskId,
-				AnalysisStartedAt: analysisTime,
-			}
-			if err2 := s.db.SaveTask(ctx, newTask); err2 != nil {
-				logger.Error("failed to save task", "error", err2)
-				return nil, err2
-			}
+	storedReports, err := s.db.GetReportsByTaskID(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to load reports", "error", err)
+		return nil, err
+	}
 
-			response, err2 := s.storage.ListTaskFiles(
-				ctx,
-				&storagepb.ListTaskFilesRequest{
-					TaskId: taskId,
-				},
-			)
-			if err2 != nil {
-				logger.Error("failed to contact storage", "error", err2)
-				return nil, ErrExternalConnectionFailed
-			}
+	verdicts, err := s.currentVerdicts(ctx, taskId, storedReports)
+	if err != nil {
+		logger.Error("failed to load reviews", "error", err)
+		return nil, err
+	}
 
-			files := response.Items
-			reports, err2 := s.runAnalysis(ctx, taskId, files, logger)
-			if err2 != nil {
-				return nil, err2
-			}
+	reports := buildMaxReports(storedReports)
+	for i := range reports {
+		reports[i].Verdict = verdicts[canonicalPair(reports[i].Student, reports[i].StudentWithSimilarFile)]
+	}
 
-			return &Task{
-				ID:        taskId,
-				StartedAt: analysisTime,
-				Reports:   reports,
-			}, nil
-		} else {
-			logger.Error("failed to load task", "error", err)
-			return nil, err
-		}
+	return &Task{
+		ID:        task.ID,
+		StartedAt: task.AnalysisStartedAt,
+		Reports:   reports,
+	}, nil
+}
+
+// ensureTask создаёт запись о задаче, если её ещё нет. Время анализа у новой задачи пустое,
+// пока ApplyAnalysis не сохранит результаты первого анализа.
+func (s *PlagiarismService) ensureTask(ctx context.Context, taskId string, logger *slog.Logger) error {
+	if err := s.db.EnsureTask(ctx, taskId); err != nil {
+		logger.Error("failed to save task", "error", err)
+		return err
 	}
 
+	return nil
+}
+
+// analyzeTask пересчитывает отчёты задачи по новым, изменённым и удалённым файлам.
+func (s *PlagiarismService) analyzeTask(ctx context.Context, taskId string, observer analysisObserver, logger *slog.Logger) error {
 	response, err := s.storage.ListTaskFiles(
 		ctx,
 		&storagepb.ListTaskFilesRequest{
@@ -87,48 +108,56 @@ func (s *PlagiarismService) GetPlagiarismReport(ctx context.Context, taskId stri
 
 	if err != nil {
 		logger.Error("failed to contact storage", "error", err)
-		return nil, ErrExternalConnectionFailed
+		return ErrExternalConnectionFailed
 	}
 
-	files := response.Items
+	files := uploadedFiles(response.Items)
 
-	shouldReanalyze := false
-	for _, f := range files {
-		if f.UpdatedAt.AsTime().After(task.AnalysisStartedAt) {
-			shouldReanalyze = true
-			break
-		}
+	analyzedFiles, err := s.db.GetAnalyzedFiles(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to load analyzed files", "error", err)
+		return err
 	}
 
-	if !shouldReanalyze {
-		cachedReports, err2 := s.collectReportsFromCache(ctx, taskId, files, logger)
-		if err2 != nil {
-			return nil, err2
+	plan := planAnalysis(files, analyzedFiles)
+
+	if plan.isEmpty() {
+		logger.Info("files have not changed since the last analysis")
+		observer.progress(domain.JobProgress{})
+
+		// задача без сдач тоже проанализирована: пустой результат только ставит время анализа
+		if len(analyzedFiles) == 0 {
+			if err = s.db.ApplyAnalysis(ctx, &domain.AnalysisResult{TaskID: taskId, AnalysisStartedAt: time.Now()}); err != nil {
+				logger.Error("failed to save analysis result", "error", err)
+				return err
+			}
 		}
+		return nil
+	}
+
+	logger.Info(
+		"running incremental analysis",
+		"changed_files", len(plan.changed),
+		"removed_files", len(plan.removed),
+	)
 
-		return &Task{
-			ID:        task.ID,
-			StartedAt: task.AnalysisStartedAt,
-			Reports:   cachedReports,
-		}, nil
+	result := &domain.AnalysisResult{
+		TaskID:            taskId,
+		AnalysisStartedAt: time.Now(),
+		RemovedStudents:   plan.removed,
 	}
 
-	reports, err := s.runAnalysis(ctx, taskId, files, logger)
-	if err != nil {
-		return nil, err
+	if err = s.compareChangedFiles(ctx, taskId, files, plan, result, observer, logger); err != nil {
+		return err
 	}
 
-	task.AnalysisStartedAt = time.Now()
-	if err = s.db.UpdateTaskAnalysisTime(ctx, task.ID, task.AnalysisStartedAt); err != nil {
-		logger.Error("failed to update task analysis time", "error", err)
-		return nil, err
+	// отчёты заменяются одной транзакцией: читатели видят либо прежний, либо новый набор
+	if err = s.db.ApplyAnalysis(ctx, result); err != nil {
+		logger.Error("failed to save analysis result", "error", err)
+		return err
 	}
 
-	return &Task{
-		ID:        task.ID,
-		StartedAt: task.AnalysisStartedAt,
-		Reports:   reports,
-	}, nil
+	return nil
 }
 
 func toUseCaseReport(r domain.PlagiarismReport, currentStudent string) PlagiarismReport {
@@ -141,147 +170,281 @@ func toUseCaseReport(r domain.PlagiarismReport, currentStudent string) Plagiaris
 		report.Student = r.StudentA
 		report.StudentWithSimilarFile = r.StudentB
 		report.FileHandedOverAt = r.FileAHandedOverAt
+		report.FileVersion = r.FileAVersion
 	} else {
 		report.Student = r.StudentB
 		report.StudentWithSimilarFile = r.StudentA
 		report.FileHandedOverAt = r.FileBHandedOverAt
+		report.FileVersion = r.FileBVersion
 	}
 
 	return report
 }
 
-// collectReportsFromCache собирает сохранённые ранее отчёты по текущей задаче.
-func (s *PlagiarismService) collectReportsFromCache(
+// compareChangedFiles сравнивает попарно только те пары, где хотя бы один файл новый или изменён,
+// и добавляет в result отчёты по ним и версии этих файлов. В БД ничего не пишет.
+// Сдачи сравниваются целиком (все файлы по порядку имён) и пофайлово по одноимённым файлам.
+// Пары с одинаковым хэшем содержимого получают схожесть 1 без сравнения текстов и один фрагмент на весь документ.
+func (s *PlagiarismService) compareChangedFiles(
 	ctx context.Context,
 	taskID string,
 	files []*storagepb.FileInfo,
+	plan analysisPlan,
+	result *domain.AnalysisResult,
+	observer analysisObserver,
 	logger *slog.Logger,
-) ([]PlagiarismReport, error) {
-	return s.buildMaxReportsForTask(ctx, taskID, files, logger)
-}
+) error {
+	checker := plagiarism_analyzer.NewPlagiarismChecker(nGramSize, plagiarismThreshold)
 
-// runAnalysis сравнивает файлы попарно и сохраняет отчёты.
-func (s *PlagiarismService) runAnalysis(
-	ctx context.Context,
-	taskID string,
-	files []*storagepb.FileInfo,
-	logger *slog.Logger,
-) ([]PlagiarismReport, error) {
-	if len(files) < 2 {
-		return []PlagiarismReport{}, nil
+	progress := domain.JobProgress{
+		PairsTotal: plan.pairsToCompare(files),
+		FilesTotal: plan.filesToExtract(files),
 	}
+	observer.progress(progress)
 
-	// подготовим URL-ы для скачивания
-	type fileData struct {
-		studentID string
-		updatedAt time.Time
-		url       string
-	}
+	// каждую сдачу скачиваем не более одного раза
+	texts := make(map[string]*submissionText, len(files))
+	textOf := func(f *storagepb.FileInfo) (*submissionText, error) {
+		if text, ok := texts[f.GetStudentId()]; ok {
+			return text, nil
+		}
 
-	fileInfos := make([]fileData, 0, len(files))
-	for _, f := range files {
-		urlResp, err := s.storage.GenerateDownloadURL(ctx, &storagepb.GenerateDownloadURLRequest{
-			StudentId:  f.GetStudentId(),
-			TaskId:     taskID,
-			FromInside: true,
-		})
-		if err != nil {
-			logger.Error("failed to get download url", "student_id", f.GetStudentId(), "error", err)
-			return nil, ErrExternalConnectionFailed
+		text := &submissionText{files: make(map[string]string)}
+		parts := make([]string, 0, len(submissionFiles(f)))
+		for _, file := range submissionFiles(f) {
+			part, err := s.extractText(ctx, checker, taskID, f.GetStudentId(), file.GetName(), int(file.GetVersion()), logger)
+			if err != nil {
+				return nil, err
+			}
+			text.files[file.GetName()] = part
+			parts = append(parts, part)
 		}
+		text.whole = text_extractor.JoinFiles(parts)
 
-		fileInfos = append(fileInfos, fileData{
-			studentID: f.GetStudentId(),
-			updatedAt: f.GetUpdatedAt().AsTime(),
-			url:       urlResp.GetUrl(),
-		})
+		texts[f.GetStudentId()] = text
+		progress.FilesExtracted++
+		observer.progress(progress)
+		return text, nil
 	}
 
-	if err := s.db.DeleteReportsByTaskID(ctx, taskID); err != nil {
-		logger.Error("failed to delete old reports", "error", err)
-		return nil, err
-	}
+	for i := 0; i < len(files); i++ {
+		for j := i + 1; j < len(files); j++ {
+			fi := files[i]
+			fj := files[j]
 
-	checker := plagiarism_analyzer.NewPlagiarismChecker(3, 0.7)
+			if !plan.needsComparison(fi, fj) {
+				continue
+			}
 
-	for i := 0; i < len(fileInfos); i++ {
-		for j := i + 1; j < len(fileInfos); j++ {
-			fi := fileInfos[i]
-			fj := fileInfos[j]
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-			similarity, err := checker.CompareFiles(fi.url, fj.url)
-			if err != nil {
-				logger.Error("failed to compare files", "student_a", fi.studentID, "student_b", fj.studentID, "error", err)
-				return nil, &AnalysisError{
-					StudentA: fi.studentID,
-					StudentB: fj.studentID,
-					Reason:   "file comparison failed",
-					Err:      err,
-				}
+			// пара хранится в каноническом порядке, чтобы повторный анализ обновлял ту же строку
+			if fi.GetStudentId() > fj.GetStudentId() {
+				fi, fj = fj, fi
 			}
 
-			// сохраняем отчёт
 			reportID, _ := uuid.NewUUID()
 			dbReport := domain.PlagiarismReport{
 				ID:                reportID,
 				TaskId:            taskID,
-				StudentA:          fi.studentID,
-				StudentB:          fj.studentID,
-				Similarity:        similarity,
-				FileAHandedOverAt: fi.updatedAt,
-				FileBHandedOverAt: fj.updatedAt,
+				StudentA:          fi.GetStudentId(),
+				StudentB:          fj.GetStudentId(),
+				FileAHandedOverAt: fi.GetUpdatedAt().AsTime(),
+				FileBHandedOverAt: fj.GetUpdatedAt().AsTime(),
+				FileAVersion:      int(fi.GetVersion()),
+				FileBVersion:      int(fj.GetVersion()),
 			}
 
-			if err = s.db.SaveReport(ctx, &dbReport); err != nil {
-				logger.Error("failed to save report", "error", err)
-				return nil, err
+			// у точной копии тексты не скачиваются и остаются nil
+			var textI, textJ *submissionText
+			exactCopy := identicalContent(fi, fj)
+			if exactCopy {
+				// точная копия: схожесть известна без скачивания, фрагменты не ищем
+				dbReport.Similarity = 1
+				logger.Info("exact duplicate pair", "student_a", fi.GetStudentId(), "student_b", fj.GetStudentId())
+			} else {
+				var err error
+				if textI, err = textOf(fi); err != nil {
+					return err
+				}
+				if textJ, err = textOf(fj); err != nil {
+					return err
+				}
+				dbReport.Similarity = checker.CompareTexts(textI.whole, textJ.whole)
 			}
+
+			result.Reports = append(result.Reports, dbReport)
+			result.PairFiles = append(result.PairFiles, comparePairFiles(checker, &dbReport, fi, fj, textI, textJ)...)
+
+			if checker.IsPlagiarized(dbReport.Similarity) {
+				if exactCopy {
+					// без фрагмента у самой явной пары не было бы доказательств в выгрузках и просмотре;
+					// тексты одинаковы, поэтому хватает текста одной сдачи
+					text, err := textOf(fi)
+					if err != nil {
+						return err
+					}
+					result.Fragments = append(result.Fragments, wholeDocumentFragment(&dbReport, text.whole)...)
+				} else {
+					result.Fragments = append(result.Fragments, pairFragments(checker, &dbReport, textI.whole, textJ.whole)...)
+				}
+
+				progress.SuspiciousPairs++
+				observer.suspiciousPair(dbReport)
+			}
+
+			progress.PairsDone++
+			observer.progress(progress)
+		}
+	}
+
+	analyzedAt := time.Now()
+	for _, f := range files {
+		if !plan.changed[f.GetStudentId()] {
+			continue
 		}
+
+		result.AnalyzedFiles = append(result.AnalyzedFiles, domain.AnalyzedFile{
+			TaskID:        taskID,
+			StudentID:     f.GetStudentId(),
+			FileUpdatedAt: fileVersion(f),
+			AnalyzedAt:    analyzedAt,
+		})
 	}
 
-	// после сохранения всех отчётов в БД возвращаем по одному (с максимальным совпадением) для каждого студента
-	return s.buildMaxReportsForTask(ctx, taskID, files, logger)
+	return nil
 }
 
-// buildMaxReportsForTask выбирает для каждого студента отчёт с максимальным Similarity по указанной задаче.
-func (s *PlagiarismService) buildMaxReportsForTask(
+// wholeDocumentFragment возвращает фрагмент точной копии: весь очищенный текст с начала обеих сдач.
+// У сдачи без слов фрагмента нет.
+func wholeDocumentFragment(report *domain.PlagiarismReport, text string) []domain.PairFragment {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	excerpt := strings.Join(words[:min(len(words), maxExcerptWords)], " ")
+	if len(words) > maxExcerptWords {
+		excerpt += " ..."
+	}
+
+	return []domain.PairFragment{{
+		TaskID:   report.TaskId,
+		StudentA: report.StudentA,
+		StudentB: report.StudentB,
+		Length:   len(words),
+		Excerpt:  excerpt,
+	}}
+}
+
+// pairFragments находит общие фрагменты пары и сохраняет из каждого не больше maxExcerptWords слов текста.
+func pairFragments(checker *plagiarism_analyzer.PlagiarismChecker, report *domain.PlagiarismReport, textA, textB string) []domain.PairFragment {
+	matches := checker.FindFragments(textA, textB, minFragmentWords)
+	if len(matches) > maxFragmentsPerPair {
+		// оставляем самые длинные, затем возвращаем порядок по тексту
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].Length > matches[j].Length })
+		matches = matches[:maxFragmentsPerPair]
+		sort.Slice(matches, func(i, j int) bool { return matches[i].AStart < matches[j].AStart })
+	}
+
+	words := strings.Fields(textA)
+
+	fragments := make([]domain.PairFragment, 0, len(matches))
+	for _, m := range matches {
+		excerpt := strings.Join(words[m.AStart:m.AStart+min(m.Length, maxExcerptWords)], " ")
+		if m.Length > maxExcerptWords {
+			excerpt += " ..."
+		}
+
+		fragments = append(fragments, domain.PairFragment{
+			TaskID:   report.TaskId,
+			StudentA: report.StudentA,
+			StudentB: report.StudentB,
+			AStart:   m.AStart,
+			BStart:   m.BStart,
+			Length:   m.Length,
+			Excerpt:  excerpt,
+		})
+	}
+
+	return fragments
+}
+
+// extractText скачивает через storage-service версию version файла fileName сдачи студента и извлекает из неё текст.
+// Версия берётся из списка файлов задачи, поэтому загруженная во время анализа новая версия не подменит
+// ту, что записывается в отчёт; version 0 (хранилище без версий) - последний загруженный файл.
+func (s *PlagiarismService) extractText(
 	ctx context.Context,
-	taskID string,
-	files []*storagepb.FileInfo,
+	checker *plagiarism_analyzer.PlagiarismChecker,
+	taskID, studentID, fileName string,
+	version int,
 	logger *slog.Logger,
-) ([]PlagiarismReport, error) {
-	result := make([]PlagiarismReport, 0)
-	seen := make(map[string]bool)
+) (string, error) {
+	url, err := s.downloadURL(ctx, taskID, studentID, fileName, version)
+	if err != nil {
+		logger.Error("failed to get download url", "student_id", studentID, "file_name", fileName, "version", version, "error", err)
+		return "", ErrExternalConnectionFailed
+	}
 
-	for _, f := range files {
-		studentID := f.GetStudentId()
-		if seen[studentID] {
-			continue
+	text, err := checker.ExtractText(url)
+	if err != nil {
+		logger.Error("failed to extract text", "student_id", studentID, "file_name", fileName, "error", err)
+		return "", &AnalysisError{
+			StudentA: studentID,
+			Reason:   "text extraction failed",
+			Err:      err,
 		}
-		seen[studentID] = true
+	}
 
-		storedReports, err := s.db.GetReportsByStudentID(ctx, studentID)
-		if err != nil {
-			logger.Error("failed to load reports", "student_id", studentID, "error", err)
-			return nil, err
-		}
+	return text, nil
+}
 
-		var maxRep *domain.PlagiarismReport
-		for i := range storedReports {
-			r := &storedReports[i]
-			if r.TaskId != taskID {
-				continue
+func (s *PlagiarismService) downloadURL(ctx context.Context, taskID, studentID, fileName string, version int) (string, error) {
+	if version == 0 {
+		resp, err := s.storage.GenerateDownloadURL(ctx, &storagepb.GenerateDownloadURLRequest{
+			StudentId:  studentID,
+			TaskId:     taskID,
+			FileName:   fileName,
+			FromInside: true,
+		})
+		return resp.GetUrl(), err
+	}
+
+	resp, err := s.storage.GenerateVersionDownloadURL(ctx, &storagepb.GenerateVersionDownloadURLRequest{
+		StudentId:  studentID,
+		TaskId:     taskID,
+		FileName:   fileName,
+		Version:    int32(version),
+		FromInside: true,
+	})
+	return resp.GetUrl(), err
+}
+
+// buildMaxReports выбирает для каждого студента отчёт с максимальным Similarity.
+func buildMaxReports(reports []domain.PlagiarismReport) []PlagiarismReport {
+	maxReports := make(map[string]domain.PlagiarismReport)
+	students := make([]string, 0)
+
+	for _, r := range reports {
+		for _, studentID := range []string{r.StudentA, r.StudentB} {
+			current, ok := maxReports[studentID]
+			if !ok {
+				students = append(students, studentID)
 			}
-			if maxRep == nil || r.Similarity > maxRep.Similarity {
-				repCopy := *r
-				maxRep = &repCopy
+			if !ok || r.Similarity > current.Similarity {
+				maxReports[studentID] = r
 			}
 		}
+	}
 
-		if maxRep != nil {
-			result = append(result, toUseCaseReport(*maxRep, studentID))
-		}
+	sort.Strings(students)
+
+	result := make([]PlagiarismReport, 0, len(students))
+	for _, studentID := range students {
+		result = append(result, toUseCaseReport(maxReports[studentID], studentID))
 	}
 
-	return result, nil
+	return result
 }
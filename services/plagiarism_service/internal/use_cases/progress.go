@@ -0,0 +1,169 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+)
+
+// progressUpdateInterval ограничивает частоту записи прогресса задания в БД.
+const progressUpdateInterval = time.Second
+
+// analysisObserver получает события хода анализа.
+type analysisObserver interface {
+	progress(p domain.JobProgress)
+	suspiciousPair(report domain.PlagiarismReport)
+}
+
+// jobObserver сохраняет ход анализа в задании, чтобы его видели наблюдатели с любой реплики сервиса.
+type jobObserver struct {
+	db         DB
+	jobID      string
+	logger     *slog.Logger
+	lastUpdate time.Time
+}
+
+func (s *PlagiarismService) newJobObserver(jobID string, logger *slog.Logger) *jobObserver {
+	return &jobObserver{
+		db:     s.db,
+		jobID:  jobID,
+		logger: logger,
+	}
+}
+
+// progress пишет прогресс не чаще progressUpdateInterval, но всегда сохраняет завершение сравнения.
+func (o *jobObserver) progress(p domain.JobProgress) {
+	if p.PairsDone != p.PairsTotal && time.Since(o.lastUpdate) < progressUpdateInterval {
+		return
+	}
+	o.lastUpdate = time.Now()
+
+	if err := o.db.UpdateJobProgress(context.Background(), o.jobID, p); err != nil {
+		o.logger.Warn("failed to update job progress", "error", err)
+	}
+}
+
+func (o *jobObserver) suspiciousPair(report domain.PlagiarismReport) {
+	err := o.db.SaveJobFinding(context.Background(), &domain.JobFinding{
+		JobID:      o.jobID,
+		StudentA:   report.StudentA,
+		StudentB:   report.StudentB,
+		Similarity: report.Similarity,
+		FoundAt:    time.Now(),
+	})
+	if err != nil {
+		o.logger.Warn("failed to save job finding", "error", err)
+	}
+}
+
+// watchPollInterval - как часто WatchAnalysis перечитывает задание из БД.
+// Задание может выполняться на другой реплике, поэтому источник событий - только БД.
+const watchPollInterval = 500 * time.Millisecond
+
+// WatchAnalysis передаёт в send ход задания анализа задачи, пока оно не завершится.
+// Если jobId пуст, наблюдает за последним заданием задачи.
+// Событие отправляется сразу, затем при каждом изменении прогресса или появлении новых подозрительных пар.
+func (s *PlagiarismService) WatchAnalysis(ctx context.Context, taskId, jobId string, send func(*AnalysisEvent) error) error {
+	const op = "Plagiarism_Service.WatchAnalysis"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+		slog.String("job_id", jobId),
+	)
+
+	job, err := s.watchedJob(ctx, taskId, jobId)
+	if err != nil {
+		if !errors.Is(err, ErrJobNotFound) {
+			logger.Error("failed to load job", "error", err)
+		}
+		return err
+	}
+
+	var (
+		last          *AnalysisJob
+		lastFindingID int64
+	)
+	for {
+		findings, err := s.db.ListJobFindings(ctx, job.ID.String(), lastFindingID)
+		if err != nil {
+			logger.Error("failed to load job findings", "error", err)
+			return err
+		}
+
+		current := toUseCaseJob(job)
+		if last == nil || jobChanged(last, current) || len(findings) > 0 {
+			event := &AnalysisEvent{Job: current}
+			for _, finding := range findings {
+				event.NewSuspiciousPairs = append(event.NewSuspiciousPairs, SuspiciousPair{
+					StudentA:   finding.StudentA,
+					StudentB:   finding.StudentB,
+					Similarity: finding.Similarity,
+				})
+				lastFindingID = finding.ID
+			}
+
+			if err = send(event); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		if job.State.IsFinished() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(watchPollInterval):
+		}
+
+		job, err = s.db.GetJobByID(ctx, job.ID.String())
+		if err != nil {
+			logger.Error("failed to reload job", "error", err)
+			return err
+		}
+	}
+}
+
+// watchedJob находит задание, за которым наблюдает WatchAnalysis.
+func (s *PlagiarismService) watchedJob(ctx context.Context, taskId, jobId string) (*domain.AnalysisJob, error) {
+	var (
+		job *domain.AnalysisJob
+		err error
+	)
+	if jobId != "" {
+		job, err = s.db.GetJobByID(ctx, jobId)
+	} else {
+		job, err = s.db.GetLatestJobByTaskID(ctx, taskId)
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+
+	if job.TaskID != taskId {
+		return nil, ErrJobNotFound
+	}
+
+	return job, nil
+}
+
+// jobChanged сообщает, изменилось ли то, что видит наблюдатель задания.
+func jobChanged(prev, cur *AnalysisJob) bool {
+	return prev.State != cur.State ||
+		prev.ProgressDone != cur.ProgressDone ||
+		prev.ProgressTotal != cur.ProgressTotal ||
+		prev.FilesExtracted != cur.FilesExtracted ||
+		prev.FilesTotal != cur.FilesTotal ||
+		prev.SuspiciousPairs != cur.SuspiciousPairs ||
+		prev.Attempts != cur.Attempts ||
+		prev.Error != cur.Error
+}
@@ -0,0 +1,223 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/infrastructure/repositories"
+)
+
+type pairKey [2]string
+
+// canonicalPair упорядочивает студентов так, как пары хранятся в БД.
+func canonicalPair(studentA, studentB string) pairKey {
+	if studentA > studentB {
+		return pairKey{studentB, studentA}
+	}
+	return pairKey{studentA, studentB}
+}
+
+// ReviewPair записывает решение проверяющего по паре последнего анализа. Решение привязывается
+// к версиям файлов из отчёта пары и действует, пока повторный анализ не построит отчёт по новым версиям.
+func (s *PlagiarismService) ReviewPair(
+	ctx context.Context,
+	taskId, studentA, studentB, verdict, comment, reviewerId string,
+) (*PairReview, error) {
+	const op = "Plagiarism_Service.ReviewPair"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+		slog.String("student_a", studentA),
+		slog.String("student_b", studentB),
+		slog.String("reviewer_id", reviewerId),
+	)
+
+	if !domain.ReviewVerdict(verdict).IsValid() {
+		logger.Warn("unknown verdict", "verdict", verdict)
+		return nil, ErrInvalidVerdict
+	}
+
+	if _, err := s.db.GetTaskByID(ctx, taskId); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
+
+	key := canonicalPair(studentA, studentB)
+
+	report, err := s.db.GetReportByPair(ctx, taskId, key[0], key[1])
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("pair was not compared")
+			return nil, ErrPairNotFound
+		}
+		logger.Error("failed to load report", "error", err)
+		return nil, err
+	}
+
+	review := domain.PairReview{
+		TaskID:            taskId,
+		StudentA:          report.StudentA,
+		StudentB:          report.StudentB,
+		Verdict:           domain.ReviewVerdict(verdict),
+		Comment:           comment,
+		ReviewerID:        reviewerId,
+		FileAHandedOverAt: report.FileAHandedOverAt,
+		FileBHandedOverAt: report.FileBHandedOverAt,
+		CreatedAt:         time.Now(),
+	}
+
+	if err = s.db.SaveReview(ctx, &review); err != nil {
+		logger.Error("failed to save review", "error", err)
+		return nil, err
+	}
+
+	logger.Info("pair reviewed", "verdict", verdict)
+
+	result := toUseCaseReview(review, *report)
+	result.Current = true
+	orientReview(&result, studentA)
+
+	return &result, nil
+}
+
+// ListPairReviews возвращает историю решений по паре (в порядке студентов запроса)
+// или, если студенты не заданы, по всем парам задачи. Новые решения идут первыми.
+func (s *PlagiarismService) ListPairReviews(ctx context.Context, taskId, studentA, studentB string) ([]PairReview, error) {
+	const op = "Plagiarism_Service.ListPairReviews"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("task_id", taskId),
+		slog.String("student_a", studentA),
+		slog.String("student_b", studentB),
+	)
+
+	if _, err := s.db.GetTaskByID(ctx, taskId); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Info("task has not been analyzed yet")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to load task", "error", err)
+		return nil, err
+	}
+
+	var (
+		stored  []domain.PairReview
+		reports []domain.PlagiarismReport
+		err     error
+	)
+	if studentA == "" && studentB == "" {
+		if stored, err = s.db.ListReviewsByTaskID(ctx, taskId); err != nil {
+			logger.Error("failed to load reviews", "error", err)
+			return nil, err
+		}
+		if reports, err = s.db.GetReportsByTaskID(ctx, taskId); err != nil {
+			logger.Error("failed to load reports", "error", err)
+			return nil, err
+		}
+	} else {
+		key := canonicalPair(studentA, studentB)
+		if stored, err = s.db.ListReviewsByPair(ctx, taskId, key[0], key[1]); err != nil {
+			logger.Error("failed to load reviews", "error", err)
+			return nil, err
+		}
+
+		// без отчёта (студента убрали из задачи) все решения по паре устарели
+		report, err := s.db.GetReportByPair(ctx, taskId, key[0], key[1])
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			logger.Error("failed to load report", "error", err)
+			return nil, err
+		}
+		if report != nil {
+			reports = append(reports, *report)
+		}
+	}
+
+	reviews := reviewHistory(stored, reports)
+	if studentA != "" {
+		for i := range reviews {
+			orientReview(&reviews[i], studentA)
+		}
+	}
+
+	return reviews, nil
+}
+
+// currentVerdicts возвращает действующие решения по парам задачи.
+func (s *PlagiarismService) currentVerdicts(ctx context.Context, taskID string, reports []domain.PlagiarismReport) (map[pairKey]*PairReview, error) {
+	stored, err := s.db.ListReviewsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	verdicts := make(map[pairKey]*PairReview)
+	for _, review := range reviewHistory(stored, reports) {
+		if review.Current {
+			verdicts[pairKey{review.StudentA, review.StudentB}] = &review
+		}
+	}
+
+	return verdicts, nil
+}
+
+// reviewHistory отмечает устаревшие решения и действующее решение каждой пары.
+// stored должны идти от новых к старым.
+func reviewHistory(stored []domain.PairReview, reports []domain.PlagiarismReport) []PairReview {
+	byPair := make(map[pairKey]domain.PlagiarismReport, len(reports))
+	for _, r := range reports {
+		byPair[pairKey{r.StudentA, r.StudentB}] = r
+	}
+
+	decided := make(map[pairKey]bool)
+
+	result := make([]PairReview, 0, len(stored))
+	for _, review := range stored {
+		key := pairKey{review.StudentA, review.StudentB}
+
+		report, ok := byPair[key]
+		item := toUseCaseReview(review, report)
+		if !ok {
+			item.Outdated = true
+		}
+		if !item.Outdated && !decided[key] {
+			item.Current = true
+			decided[key] = true
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func toUseCaseReview(review domain.PairReview, report domain.PlagiarismReport) PairReview {
+	return PairReview{
+		ID:                review.ID,
+		StudentA:          review.StudentA,
+		StudentB:          review.StudentB,
+		Verdict:           string(review.Verdict),
+		Comment:           review.Comment,
+		ReviewerID:        review.ReviewerID,
+		CreatedAt:         review.CreatedAt,
+		FileAHandedOverAt: review.FileAHandedOverAt,
+		FileBHandedOverAt: review.FileBHandedOverAt,
+		Outdated:          !review.AppliesTo(report),
+	}
+}
+
+// orientReview разворачивает решение так, чтобы StudentA совпадал с первым студентом запроса.
+func orientReview(review *PairReview, studentA string) {
+	if review.StudentA == studentA {
+		return
+	}
+	review.StudentA, review.StudentB = review.StudentB, review.StudentA
+	review.FileAHandedOverAt, review.FileBHandedOverAt = review.FileBHandedOverAt, review.FileAHandedOverAt
+}
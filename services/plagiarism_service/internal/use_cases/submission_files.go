@@ -0,0 +1,71 @@
+package use_cases
+
+import (
+	"sort"
+
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/plagiarism-service/pkg/plagiarism_analyzer"
+	storagepb "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+)
+
+// submissionText - очищенные тексты файлов сдачи и их склейка по порядку имён.
+type submissionText struct {
+	files map[string]string
+	whole string
+}
+
+// submissionFiles возвращает файлы сдачи по порядку имён; основной файл имеет пустое имя и идёт первым.
+// Хранилище без списка файлов отдаёт сдачу одним основным файлом.
+func submissionFiles(f *storagepb.FileInfo) []*storagepb.FileVersion {
+	if len(f.GetFiles()) == 0 {
+		return []*storagepb.FileVersion{{Version: f.GetVersion(), Sha256: f.GetSha256()}}
+	}
+
+	files := append([]*storagepb.FileVersion(nil), f.GetFiles()...)
+	sort.Slice(files, func(i, j int) bool { return files[i].GetName() < files[j].GetName() })
+	return files
+}
+
+// comparePairFiles сравнивает одноимённые файлы пары. Для пары сдач из одного файла пофайловое сравнение
+// совпадает с отчётом пары и не сохраняется. textA и textB равны nil у точной копии: её файлы совпадают.
+func comparePairFiles(
+	checker *plagiarism_analyzer.PlagiarismChecker,
+	report *domain.PlagiarismReport,
+	a, b *storagepb.FileInfo,
+	textA, textB *submissionText,
+) []domain.PairFileReport {
+	filesA, filesB := submissionFiles(a), submissionFiles(b)
+	if len(filesA) < 2 && len(filesB) < 2 {
+		return nil
+	}
+
+	versionsB := make(map[string]int, len(filesB))
+	for _, file := range filesB {
+		versionsB[file.GetName()] = int(file.GetVersion())
+	}
+
+	var result []domain.PairFileReport
+	for _, file := range filesA {
+		versionB, ok := versionsB[file.GetName()]
+		if !ok {
+			continue
+		}
+
+		pairFile := domain.PairFileReport{
+			TaskID:       report.TaskId,
+			StudentA:     report.StudentA,
+			StudentB:     report.StudentB,
+			FileName:     file.GetName(),
+			Similarity:   1,
+			FileAVersion: int(file.GetVersion()),
+			FileBVersion: versionB,
+		}
+		if textA != nil && textB != nil {
+			pairFile.Similarity = checker.CompareTexts(textA.files[file.GetName()], textB.files[file.GetName()])
+		}
+
+		result = append(result, pairFile)
+	}
+
+	return result
+}
@@ -0,0 +1 @@
+DROP TABLE analyzed_files;
@@ -0,0 +1,27 @@
+CREATE TABLE analyzed_files (
+    task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    student_id VARCHAR(50) NOT NULL,
+    file_updated_at TIMESTAMP NOT NULL,
+    analyzed_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (task_id, student_id)
+);
+
+-- пары храним в каноническом порядке (student_a < student_b), чтобы upsert попадал в ту же строку
+UPDATE plagiarism_reports
+SET student_a = student_b,
+    student_b = student_a,
+    file_a_handed_over_at = file_b_handed_over_at,
+    file_b_handed_over_at = file_a_handed_over_at
+WHERE student_a > student_b;
+
+-- уже проанализированные файлы переносим из существующих отчётов
+INSERT INTO analyzed_files (task_id, student_id, file_updated_at, analyzed_at)
+SELECT r.task_id, r.student_id, MAX(r.handed_over_at), t.analysis_started_at
+FROM (
+    SELECT task_id, student_a AS student_id, file_a_handed_over_at AS handed_over_at FROM plagiarism_reports
+    UNION ALL
+    SELECT task_id, student_b AS student_id, file_b_handed_over_at AS handed_over_at FROM plagiarism_reports
+) r
+JOIN tasks t ON t.id = r.task_id
+GROUP BY r.task_id, r.student_id, t.analysis_started_at;
@@ -0,0 +1 @@
+DROP TABLE analysis_jobs;
@@ -0,0 +1,13 @@
+CREATE TABLE analysis_jobs (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    state VARCHAR(20) NOT NULL DEFAULT 'queued',
+    progress_done INTEGER NOT NULL DEFAULT 0,
+    progress_total INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_analysis_jobs_task_id ON analysis_jobs(task_id);
@@ -0,0 +1,8 @@
+DROP INDEX idx_analysis_jobs_queue;
+
+ALTER TABLE analysis_jobs
+    DROP COLUMN attempts,
+    DROP COLUMN max_attempts,
+    DROP COLUMN next_run_at,
+    DROP COLUMN lease_owner,
+    DROP COLUMN lease_expires_at;
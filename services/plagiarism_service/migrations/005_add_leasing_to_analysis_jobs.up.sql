@@ -0,0 +1,11 @@
+ALTER TABLE analysis_jobs
+    ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN max_attempts INTEGER NOT NULL DEFAULT 5,
+    ADD COLUMN next_run_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN lease_owner VARCHAR(100),
+    ADD COLUMN lease_expires_at TIMESTAMP;
+
+-- задания, которые выполнялись в памяти процесса до появления очереди, возвращаем в очередь
+UPDATE analysis_jobs SET state = 'queued' WHERE state = 'running';
+
+CREATE INDEX idx_analysis_jobs_queue ON analysis_jobs(state, next_run_at);
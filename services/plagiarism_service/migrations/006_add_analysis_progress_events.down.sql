@@ -0,0 +1,8 @@
+DROP INDEX idx_analysis_jobs_task_id_created_at;
+
+DROP TABLE analysis_job_findings;
+
+ALTER TABLE analysis_jobs
+    DROP COLUMN files_extracted,
+    DROP COLUMN files_total,
+    DROP COLUMN suspicious_pairs;
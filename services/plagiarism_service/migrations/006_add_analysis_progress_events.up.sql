@@ -0,0 +1,18 @@
+ALTER TABLE analysis_jobs
+    ADD COLUMN files_extracted INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN files_total INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN suspicious_pairs INTEGER NOT NULL DEFAULT 0;
+
+-- подозрительные пары, найденные заданием по ходу анализа (ранние результаты для наблюдателей)
+CREATE TABLE analysis_job_findings (
+    id BIGSERIAL PRIMARY KEY,
+    job_id VARCHAR(36) NOT NULL REFERENCES analysis_jobs(id) ON DELETE CASCADE,
+    student_a VARCHAR(50) NOT NULL,
+    student_b VARCHAR(50) NOT NULL,
+    similarity DOUBLE PRECISION NOT NULL,
+    found_at TIMESTAMP NOT NULL,
+
+    UNIQUE(job_id, student_a, student_b)
+);
+
+CREATE INDEX idx_analysis_jobs_task_id_created_at ON analysis_jobs(task_id, created_at);
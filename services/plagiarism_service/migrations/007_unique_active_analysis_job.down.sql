@@ -0,0 +1 @@
+DROP INDEX idx_analysis_jobs_active_task;
@@ -0,0 +1,17 @@
+-- до появления блокировок одна задача могла получить несколько активных заданий: оставляем самое раннее
+UPDATE analysis_jobs AS j
+SET state = 'cancelled',
+    error = 'superseded by another analysis job of the task',
+    finished_at = CURRENT_TIMESTAMP,
+    lease_owner = NULL,
+    lease_expires_at = NULL
+WHERE j.state IN ('queued', 'running')
+  AND EXISTS (
+      SELECT 1 FROM analysis_jobs AS o
+      WHERE o.task_id = j.task_id
+        AND o.state IN ('queued', 'running')
+        AND (o.created_at, o.id) < (j.created_at, j.id)
+  );
+
+-- у задачи не больше одного активного задания
+CREATE UNIQUE INDEX idx_analysis_jobs_active_task ON analysis_jobs(task_id) WHERE state IN ('queued', 'running');
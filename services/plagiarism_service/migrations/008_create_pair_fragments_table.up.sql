@@ -0,0 +1,16 @@
+CREATE TABLE pair_fragments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id VARCHAR(50) NOT NULL,
+    student_a VARCHAR(50) NOT NULL,
+    student_b VARCHAR(50) NOT NULL,
+    -- позиции в словах очищенного текста (после нормализации и удаления стоп-слов)
+    a_start INTEGER NOT NULL,
+    b_start INTEGER NOT NULL,
+    length INTEGER NOT NULL,
+    excerpt TEXT NOT NULL,
+
+    FOREIGN KEY (task_id, student_a, student_b)
+        REFERENCES plagiarism_reports(task_id, student_a, student_b) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pair_fragments_pair ON pair_fragments(task_id, student_a, student_b);
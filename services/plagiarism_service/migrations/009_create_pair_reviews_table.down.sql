@@ -0,0 +1 @@
+DROP TABLE pair_reviews;
@@ -0,0 +1,17 @@
+-- решения проверяющих по парам; строки только добавляются, поэтому таблица хранит всю историю
+CREATE TABLE pair_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    student_a VARCHAR(50) NOT NULL,
+    student_b VARCHAR(50) NOT NULL,
+    verdict VARCHAR(30) NOT NULL
+        CHECK (verdict IN ('confirmed', 'false_positive', 'allowed_collaboration', 'needs_discussion')),
+    comment TEXT NOT NULL DEFAULT '',
+    reviewer_id VARCHAR(50) NOT NULL,
+    -- версии файлов, которые видел проверяющий: решение действует, пока отчёт пары построен по ним же
+    file_a_handed_over_at TIMESTAMP NOT NULL,
+    file_b_handed_over_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_pair_reviews_pair ON pair_reviews(task_id, student_a, student_b, created_at);
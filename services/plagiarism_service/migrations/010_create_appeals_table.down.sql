@@ -0,0 +1,2 @@
+DROP TABLE appeal_attachments;
+DROP TABLE appeals;
@@ -0,0 +1,36 @@
+-- апелляции студентов на подтверждённый плагиат
+CREATE TABLE appeals (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(50) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    student_a VARCHAR(50) NOT NULL,
+    student_b VARCHAR(50) NOT NULL,
+    -- автор апелляции, один из студентов пары
+    student_id VARCHAR(50) NOT NULL,
+    -- оспариваемое решение confirmed
+    review_id BIGINT NOT NULL REFERENCES pair_reviews(id) ON DELETE CASCADE,
+    state VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (state IN ('open', 'upheld', 'rejected')),
+    explanation TEXT NOT NULL,
+    submitted_at TIMESTAMP NOT NULL,
+    -- срок ответа проверяющего
+    respond_by TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP,
+    resolver_id VARCHAR(50) NOT NULL DEFAULT '',
+    resolution_comment TEXT NOT NULL DEFAULT '',
+    -- новое решение по паре, записанное при удовлетворении апелляции
+    resolution_review_id BIGINT REFERENCES pair_reviews(id),
+
+    UNIQUE(review_id, student_id)
+);
+
+CREATE INDEX idx_appeals_task_id_state ON appeals(task_id, state);
+
+-- приложения к апелляции; сами файлы хранит storage-service
+CREATE TABLE appeal_attachments (
+    appeal_id VARCHAR(36) NOT NULL REFERENCES appeals(id) ON DELETE CASCADE,
+    attachment_id VARCHAR(36) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    added_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (appeal_id, attachment_id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE plagiarism_reports
+    DROP COLUMN file_a_version,
+    DROP COLUMN file_b_version;
@@ -0,0 +1,4 @@
+-- номера версий сдач из storage-service, по которым посчитан отчёт; 0 - отчёт построен до появления версий
+ALTER TABLE plagiarism_reports
+    ADD COLUMN file_a_version INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN file_b_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+-- схожесть одноимённых файлов пары сдач из нескольких файлов; отчёт пары - схожесть сдач целиком
+CREATE TABLE pair_file_reports (
+    task_id VARCHAR(50) NOT NULL,
+    student_a VARCHAR(50) NOT NULL,
+    student_b VARCHAR(50) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    similarity DOUBLE PRECISION NOT NULL,
+    file_a_version INTEGER NOT NULL DEFAULT 0,
+    file_b_version INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (task_id, student_a, student_b, file_name),
+    FOREIGN KEY (task_id, student_a, student_b)
+        REFERENCES plagiarism_reports(task_id, student_a, student_b) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+UPDATE tasks SET analysis_started_at = NOW() WHERE analysis_started_at IS NULL;
+
+ALTER TABLE tasks ALTER COLUMN analysis_started_at SET NOT NULL;
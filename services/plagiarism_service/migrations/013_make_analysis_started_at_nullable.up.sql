@@ -0,0 +1,5 @@
+-- время анализа ставится только при сохранении его результатов; NULL - задача поставлена в очередь, но ещё не анализировалась
+ALTER TABLE tasks ALTER COLUMN analysis_started_at DROP NOT NULL;
+
+UPDATE tasks SET analysis_started_at = NULL
+WHERE NOT EXISTS (SELECT 1 FROM analyzed_files f WHERE f.task_id = tasks.id);
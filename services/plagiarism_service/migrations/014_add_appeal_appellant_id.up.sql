@@ -0,0 +1,4 @@
+-- пользователь, подавший апелляцию: у групповой сдачи student_id - id группы, а подаёт её один из участников
+ALTER TABLE appeals ADD COLUMN appellant_id VARCHAR(50) NOT NULL DEFAULT '';
+
+UPDATE appeals SET appellant_id = student_id;
@@ -0,0 +1,113 @@
+package clustering
+
+import "sort"
+
+// Edge - взвешенное ребро неориентированного графа между вершинами A и B.
+type Edge struct {
+	A      int
+	B      int
+	Weight float64
+}
+
+// maxLabelPropagationRounds ограничивает число проходов распространения меток:
+// на некоторых графах метки могут колебаться бесконечно.
+const maxLabelPropagationRounds = 100
+
+// Components возвращает компоненты связности графа из n вершин (система непересекающихся множеств).
+// Вершины в компоненте и сами компоненты упорядочены по наименьшему номеру вершины.
+func Components(n int, edges []Edge) [][]int {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(x int) int {
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+
+	for _, e := range edges {
+		a, b := find(e.A), find(e.B)
+		if a == b {
+			continue
+		}
+		// корнем остаётся меньшая вершина, чтобы порядок не зависел от порядка рёбер
+		if a < b {
+			parent[b] = a
+		} else {
+			parent[a] = b
+		}
+	}
+
+	return groupBy(n, func(v int) int { return find(v) })
+}
+
+// Communities делит вершины графа на плотные сообщества распространением меток:
+// каждая вершина принимает метку, суммарный вес рёбер к которой у соседей наибольший.
+// Вершины обходятся по порядку, при равенстве весов выбирается меньшая метка, поэтому результат детерминирован.
+func Communities(n int, edges []Edge) [][]int {
+	neighbors := make([][]Edge, n)
+	for _, e := range edges {
+		neighbors[e.A] = append(neighbors[e.A], e)
+		neighbors[e.B] = append(neighbors[e.B], Edge{A: e.B, B: e.A, Weight: e.Weight})
+	}
+
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = i
+	}
+
+	for round := 0; round < maxLabelPropagationRounds; round++ {
+		changed := false
+
+		for v := 0; v < n; v++ {
+			if len(neighbors[v]) == 0 {
+				continue
+			}
+
+			weights := make(map[int]float64)
+			for _, e := range neighbors[v] {
+				weights[labels[e.B]] += e.Weight
+			}
+
+			best := labels[v]
+			for label, weight := range weights {
+				if weight > weights[best] || (weight == weights[best] && label < best) {
+					best = label
+				}
+			}
+
+			if best != labels[v] {
+				labels[v] = best
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	return groupBy(n, func(v int) int { return labels[v] })
+}
+
+// groupBy собирает вершины с одинаковым ключом в группы.
+func groupBy(n int, key func(int) int) [][]int {
+	groups := make(map[int][]int)
+	for v := 0; v < n; v++ {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+
+	result := make([][]int, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i][0] < result[j][0] })
+
+	return result
+}
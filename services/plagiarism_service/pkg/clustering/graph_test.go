@@ -0,0 +1,99 @@
+package clustering
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComponents(t *testing.T) {
+	tests := []struct {
+		name  string
+		n     int
+		edges []Edge
+		want  [][]int
+	}{
+		{
+			name: "empty graph",
+			n:    0,
+			want: [][]int{},
+		},
+		{
+			name: "singletons",
+			n:    3,
+			want: [][]int{{0}, {1}, {2}},
+		},
+		{
+			name:  "chain and singleton",
+			n:     4,
+			edges: []Edge{{A: 3, B: 1, Weight: 0.9}, {A: 1, B: 0, Weight: 0.8}},
+			want:  [][]int{{0, 1, 3}, {2}},
+		},
+		{
+			name:  "edge order does not matter",
+			n:     4,
+			edges: []Edge{{A: 1, B: 0, Weight: 0.8}, {A: 3, B: 1, Weight: 0.9}},
+			want:  [][]int{{0, 1, 3}, {2}},
+		},
+		{
+			name:  "two components",
+			n:     4,
+			edges: []Edge{{A: 2, B: 3, Weight: 1}, {A: 0, B: 1, Weight: 1}},
+			want:  [][]int{{0, 1}, {2, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Components(tt.n, tt.edges); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Components = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommunities(t *testing.T) {
+	// два треугольника с сильными рёбрами, связанные слабым ребром
+	triangles := []Edge{
+		{A: 0, B: 1, Weight: 0.9}, {A: 1, B: 2, Weight: 0.9}, {A: 0, B: 2, Weight: 0.9},
+		{A: 3, B: 4, Weight: 0.9}, {A: 4, B: 5, Weight: 0.9}, {A: 3, B: 5, Weight: 0.9},
+		{A: 2, B: 3, Weight: 0.5},
+	}
+
+	tests := []struct {
+		name  string
+		n     int
+		edges []Edge
+		want  [][]int
+	}{
+		{
+			name: "empty graph",
+			n:    0,
+			want: [][]int{},
+		},
+		{
+			name: "isolated vertices stay alone",
+			n:    2,
+			want: [][]int{{0}, {1}},
+		},
+		{
+			name:  "weak bridge splits communities",
+			n:     6,
+			edges: triangles,
+			want:  [][]int{{0, 1, 2}, {3, 4, 5}},
+		},
+		{
+			name:  "equal weights pick the smaller label",
+			n:     3,
+			edges: []Edge{{A: 0, B: 1, Weight: 0.7}, {A: 1, B: 2, Weight: 0.7}},
+			want:  [][]int{{0, 1, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Communities(tt.n, tt.edges); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Communities = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package clustering
+
+// Node - узел дендрограммы. У листа Left и Right равны nil, Index - номер элемента.
+type Node struct {
+	Index    int
+	Left     *Node
+	Right    *Node
+	Size     int
+	Distance float64
+}
+
+// Leaves возвращает номера элементов в порядке обхода дендрограммы слева направо:
+// элементы одного кластера оказываются рядом.
+func (n *Node) Leaves() []int {
+	if n == nil {
+		return nil
+	}
+	if n.Left == nil && n.Right == nil {
+		return []int{n.Index}
+	}
+
+	return append(n.Left.Leaves(), n.Right.Leaves()...)
+}
+
+// Agglomerative строит дендрограмму иерархической кластеризации со средней связью (UPGMA)
+// по симметричной матрице расстояний. При равных расстояниях объединяются кластеры
+// с меньшими номерами, поэтому результат детерминирован.
+func Agglomerative(distance [][]float64) *Node {
+	n := len(distance)
+	if n == 0 {
+		return nil
+	}
+
+	clusters := make([]*Node, n)
+	for i := range clusters {
+		clusters[i] = &Node{Index: i, Size: 1}
+	}
+
+	// dist[i][j] - среднее расстояние между кластерами i и j; nil в clusters означает поглощённый кластер
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		copy(dist[i], distance[i])
+	}
+
+	for remaining := n; remaining > 1; remaining-- {
+		a, b := -1, -1
+		for i := 0; i < n; i++ {
+			if clusters[i] == nil {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if clusters[j] == nil {
+					continue
+				}
+				if a == -1 || dist[i][j] < dist[a][b] {
+					a, b = i, j
+				}
+			}
+		}
+
+		merged := &Node{
+			Index:    -1,
+			Left:     clusters[a],
+			Right:    clusters[b],
+			Size:     clusters[a].Size + clusters[b].Size,
+			Distance: dist[a][b],
+		}
+
+		sizeA := float64(clusters[a].Size)
+		sizeB := float64(clusters[b].Size)
+		for k := 0; k < n; k++ {
+			if clusters[k] == nil || k == a || k == b {
+				continue
+			}
+			d := (dist[a][k]*sizeA + dist[b][k]*sizeB) / (sizeA + sizeB)
+			dist[a][k] = d
+			dist[k][a] = d
+		}
+
+		clusters[a] = merged
+		clusters[b] = nil
+	}
+
+	for _, c := range clusters {
+		if c != nil {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Order возвращает порядок элементов, при котором похожие элементы стоят рядом.
+func Order(distance [][]float64) []int {
+	return Agglomerative(distance).Leaves()
+}
@@ -0,0 +1,59 @@
+package clustering
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestAgglomerative(t *testing.T) {
+	if root := Agglomerative(nil); root != nil {
+		t.Errorf("Agglomerative of empty matrix = %+v, want nil", root)
+	}
+
+	root := Agglomerative([][]float64{{0}})
+	if root == nil || root.Index != 0 || root.Size != 1 || root.Left != nil || root.Right != nil {
+		t.Errorf("Agglomerative of one element = %+v, want a single leaf", root)
+	}
+
+	// 0 и 2 почти совпадают, 1 далеко от обоих: средняя связь (0.8 + 0.9) / 2
+	root = Agglomerative([][]float64{
+		{0, 0.8, 0.1},
+		{0.8, 0, 0.9},
+		{0.1, 0.9, 0},
+	})
+	if root.Size != 3 || math.Abs(root.Distance-0.85) > 1e-9 {
+		t.Errorf("root size = %d, distance = %v, want 3 and 0.85", root.Size, root.Distance)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		distance [][]float64
+		want     []int
+	}{
+		{
+			name: "empty matrix",
+			want: nil,
+		},
+		{
+			name:     "similar elements become neighbours",
+			distance: [][]float64{{0, 0.9, 0.1, 0.9}, {0.9, 0, 0.9, 0.2}, {0.1, 0.9, 0, 0.9}, {0.9, 0.2, 0.9, 0}},
+			want:     []int{0, 2, 1, 3},
+		},
+		{
+			name:     "ties merge smaller indexes first",
+			distance: [][]float64{{0, 1, 1}, {1, 0, 1}, {1, 1, 0}},
+			want:     []int{0, 1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Order(tt.distance); !slices.Equal(got, tt.want) {
+				t.Errorf("Order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
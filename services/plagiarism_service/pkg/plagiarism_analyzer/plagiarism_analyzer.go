@@ -38,7 +38,27 @@ func (p *PlagiarismChecker) CompareFiles(fileURL1, fileURL2 string) (float64, er
 	return similarity, nil
 }
 
+// ExtractText скачивает файл по URL и возвращает очищенный для сравнения текст
+func (p *PlagiarismChecker) ExtractText(fileURL string) (string, error) {
+	text, err := p.extractor.ExtractFromURL(fileURL)
+	if err != nil {
+		return "", err
+	}
+
+	return p.extractor.CleanText(text), nil
+}
+
+// CompareTexts сравнивает два уже очищенных текста
+func (p *PlagiarismChecker) CompareTexts(cleanText1, cleanText2 string) float64 {
+	return p.analyzer.CompareTexts(cleanText1, cleanText2)
+}
+
 // IsPlagiarized проверяет, является ли схожесть плагиатом
 func (p *PlagiarismChecker) IsPlagiarized(similarity float64) bool {
 	return p.analyzer.IsPlagiarized(similarity)
 }
+
+// FindFragments находит общие фрагменты двух уже очищенных текстов не короче minWords слов
+func (p *PlagiarismChecker) FindFragments(cleanText1, cleanText2 string, minWords int) []text_analyzer.Match {
+	return p.analyzer.FindMatches(cleanText1, cleanText2, minWords)
+}
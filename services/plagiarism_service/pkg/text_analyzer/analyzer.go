@@ -127,6 +127,65 @@ func (a *TextAnalyzer) FindCommonSections(text1, text2 string, minWords int) []s
 	return commonSections
 }
 
+// Match - общий фрагмент двух текстов: Length слов, начиная со слова AStart первого текста и BStart второго.
+type Match struct {
+	AStart int
+	BStart int
+	Length int
+}
+
+// FindMatches находит непересекающиеся общие фрагменты не короче minWords слов.
+// Позиции - номера слов в strings.Fields текста. Кандидаты ищутся по индексу n-грамм второго текста,
+// из нескольких кандидатов берётся самый длинный; каждое слово входит не более чем в один фрагмент.
+func (a *TextAnalyzer) FindMatches(text1, text2 string, minWords int) []Match {
+	words1 := strings.Fields(text1)
+	words2 := strings.Fields(text2)
+
+	if minWords < a.nGramSize {
+		minWords = a.nGramSize
+	}
+	if len(words1) < minWords || len(words2) < minWords {
+		return nil
+	}
+
+	positions := make(map[string][]int)
+	for j := 0; j <= len(words2)-a.nGramSize; j++ {
+		gram := strings.Join(words2[j:j+a.nGramSize], " ")
+		positions[gram] = append(positions[gram], j)
+	}
+
+	used := make([]bool, len(words2))
+
+	var matches []Match
+	for i := 0; i <= len(words1)-a.nGramSize; {
+		gram := strings.Join(words1[i:i+a.nGramSize], " ")
+
+		best := Match{}
+		for _, j := range positions[gram] {
+			k := 0
+			for i+k < len(words1) && j+k < len(words2) && !used[j+k] && words1[i+k] == words2[j+k] {
+				k++
+			}
+			if k > best.Length {
+				best = Match{AStart: i, BStart: j, Length: k}
+			}
+		}
+
+		if best.Length < minWords {
+			i++
+			continue
+		}
+
+		for k := 0; k < best.Length; k++ {
+			used[best.BStart+k] = true
+		}
+		matches = append(matches, best)
+		i += best.Length
+	}
+
+	return matches
+}
+
 // PreprocessText предобрабатывает текст для сравнения
 func (a *TextAnalyzer) PreprocessText(text string) string {
 	text = strings.ToLower(text)
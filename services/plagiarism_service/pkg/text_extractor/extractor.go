@@ -62,47 +62,20 @@ func (e *TextExtractor) ExtractFromURL(fileURL string) (string, error) {
 	return strings.Join(strings.Fields(text), " "), nil
 }
 
-// CleanText очищает текст для сравнения
-func (e *TextExtractor) CleanText(text string) string {
-	if text == "" {
-		return ""
-	}
-
-	text = strings.ToLower(text)
-
-	text = strings.Map(func(r rune) rune {
-		switch {
-		case (r >= 'а' && r <= 'я') || r == 'ё':
-			return r
-		case (r >= 'a' && r <= 'z'):
-			return r
-		case (r >= '0' && r <= '9'):
-			return r
-		case r == ' ' || r == '\n' || r == '\t':
-			return ' '
-		default:
-			return ' '
-		}
-	}, text)
-
-	words := strings.Fields(text)
+// JoinFiles склеивает тексты файлов сдачи, упорядоченных по имени, в текст всей сдачи.
+// Позиции фрагментов пары считаются по этому тексту, поэтому просмотр пар в API Gateway склеивает файлы так же.
+func JoinFiles(texts []string) string {
+	return strings.Join(texts, " ")
+}
 
-	stopWords := map[string]bool{
-		"и": true, "в": true, "не": true, "на": true, "с": true,
-		"по": true, "к": true, "у": true, "о": true, "за": true,
-		"из": true, "от": true, "до": true, "для": true, "это": true,
-		"как": true, "так": true, "но": true, "а": true, "же": true,
-		"что": true, "он": true, "она": true, "они": true, "мы": true,
-		"вы": true, "его": true, "ее": true, "их": true, "все": true,
-		"то": true, "бы": true, "во": true,
-	}
+// CleanText очищает текст для сравнения: слова Tokenize через пробел
+func (e *TextExtractor) CleanText(text string) string {
+	tokens := Tokenize(text)
 
-	filtered := make([]string, 0, len(words))
-	for _, word := range words {
-		if !stopWords[word] && len(word) > 2 {
-			filtered = append(filtered, word)
-		}
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		words = append(words, token.Word)
 	}
 
-	return strings.Join(filtered, " ")
+	return strings.Join(words, " ")
 }
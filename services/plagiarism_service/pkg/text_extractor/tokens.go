@@ -0,0 +1,65 @@
+package text_extractor
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Token - слово очищенного текста и его положение (в байтах) в исходном тексте.
+type Token struct {
+	Word  string
+	Start int
+	End   int
+}
+
+// stopWords - частые слова, которые не учитываются при сравнении текстов.
+var stopWords = map[string]bool{
+	"и": true, "в": true, "не": true, "на": true, "с": true,
+	"по": true, "к": true, "у": true, "о": true, "за": true,
+	"из": true, "от": true, "до": true, "для": true, "это": true,
+	"как": true, "так": true, "но": true, "а": true, "же": true,
+	"что": true, "он": true, "она": true, "они": true, "мы": true,
+	"вы": true, "его": true, "ее": true, "их": true, "все": true,
+	"то": true, "бы": true, "во": true,
+}
+
+// Tokenize разбивает текст на слова для сравнения: нижний регистр, только буквы и цифры,
+// без стоп-слов и слов короче трёх байт. Позиции слов в исходном тексте нужны просмотру пар,
+// поэтому и CleanText, и API Gateway разбивают текст только этой функцией.
+func Tokenize(text string) []Token {
+	var tokens []Token
+
+	start := -1
+	var word strings.Builder
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		w := word.String()
+		if !stopWords[w] && len(w) > 2 {
+			tokens = append(tokens, Token{Word: w, Start: start, End: end})
+		}
+		start = -1
+		word.Reset()
+	}
+
+	for i, r := range text {
+		lower := unicode.ToLower(r)
+		if isWordRune(lower) {
+			if start < 0 {
+				start = i
+			}
+			word.WriteRune(lower)
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return (r >= 'а' && r <= 'я') || r == 'ё' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+}
@@ -24,9 +24,15 @@ const (
 
 // Request for url to upload file
 type GenerateUploadURLRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StudentId     string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
-	TaskId        string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StudentId string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	TaskId    string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// Name of the file within the submission, empty for the main file
+	FileName string `protobuf:"bytes,3,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	// Original name of the uploaded file on the student's machine, used when downloading it
+	OriginalName string `protobuf:"bytes,4,opt,name=OriginalName,proto3" json:"OriginalName,omitempty"`
+	// Content type of the file declared by the client
+	ContentType   string `protobuf:"bytes,5,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -75,10 +81,35 @@ func (x *GenerateUploadURLRequest) GetTaskId() string {
 	return ""
 }
 
+func (x *GenerateUploadURLRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *GenerateUploadURLRequest) GetOriginalName() string {
+	if x != nil {
+		return x.OriginalName
+	}
+	return ""
+}
+
+func (x *GenerateUploadURLRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
 // Response after generating upload link
 type GenerateUploadURLResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=Url,proto3" json:"Url,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=Url,proto3" json:"Url,omitempty"`
+	// Group the student submits for, empty for a personal submission
+	GroupId string `protobuf:"bytes,2,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	// Form fields to send with the file in a multipart POST to Url; the file goes last as "file"
+	Fields        map[string]string `protobuf:"bytes,3,rep,name=Fields,proto3" json:"Fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -120,11 +151,27 @@ func (x *GenerateUploadURLResponse) GetUrl() string {
 	return ""
 }
 
+func (x *GenerateUploadURLResponse) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GenerateUploadURLResponse) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
 // Request for verifying uploaded file
 type VerifyUploadedFileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StudentId     string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
-	TaskId        string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StudentId string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	TaskId    string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// Name of the file within the submission, empty for the main file
+	FileName      string `protobuf:"bytes,3,opt,name=FileName,proto3" json:"FileName,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -173,10 +220,21 @@ func (x *VerifyUploadedFileRequest) GetTaskId() string {
 	return ""
 }
 
+func (x *VerifyUploadedFileRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
 // Response after verifying uploaded file
 type VerifyUploadedFileResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=FileId,proto3" json:"FileId,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=FileId,proto3" json:"FileId,omitempty"`
+	// Version created by this upload
+	Version *FileVersion `protobuf:"bytes,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	// Other submissions with byte-identical content, in any task
+	Duplicates    []*DuplicateFile `protobuf:"bytes,3,rep,name=Duplicates,proto3" json:"Duplicates,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -218,30 +276,46 @@ func (x *VerifyUploadedFileResponse) GetFileId() string {
 	return ""
 }
 
-// Request for url to download file
-type GenerateDownloadURLRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StudentId     string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
-	TaskId        string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
-	FromInside    bool                   `protobuf:"varint,3,opt,name=FromInside,proto3" json:"FromInside,omitempty"`
+func (x *VerifyUploadedFileResponse) GetVersion() *FileVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *VerifyUploadedFileResponse) GetDuplicates() []*DuplicateFile {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+// First message carries the metadata, the following ones carry the file content in order
+type UploadFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadFileRequest_Metadata
+	//	*UploadFileRequest_Chunk
+	Payload       isUploadFileRequest_Payload `protobuf_oneof:"Payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateDownloadURLRequest) Reset() {
-	*x = GenerateDownloadURLRequest{}
+func (x *UploadFileRequest) Reset() {
+	*x = UploadFileRequest{}
 	mi := &file_storage_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateDownloadURLRequest) String() string {
+func (x *UploadFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateDownloadURLRequest) ProtoMessage() {}
+func (*UploadFileRequest) ProtoMessage() {}
 
-func (x *GenerateDownloadURLRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_storage_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -253,54 +327,80 @@ func (x *GenerateDownloadURLRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateDownloadURLRequest.ProtoReflect.Descriptor instead.
-func (*GenerateDownloadURLRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
+func (*UploadFileRequest) Descriptor() ([]byte, []int) {
 	return file_storage_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GenerateDownloadURLRequest) GetStudentId() string {
+func (x *UploadFileRequest) GetPayload() isUploadFileRequest_Payload {
 	if x != nil {
-		return x.StudentId
+		return x.Payload
 	}
-	return ""
+	return nil
 }
 
-func (x *GenerateDownloadURLRequest) GetTaskId() string {
+func (x *UploadFileRequest) GetMetadata() *UploadFileMetadata {
 	if x != nil {
-		return x.TaskId
+		if x, ok := x.Payload.(*UploadFileRequest_Metadata); ok {
+			return x.Metadata
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *GenerateDownloadURLRequest) GetFromInside() bool {
+func (x *UploadFileRequest) GetChunk() []byte {
 	if x != nil {
-		return x.FromInside
+		if x, ok := x.Payload.(*UploadFileRequest_Chunk); ok {
+			return x.Chunk
+		}
 	}
-	return false
+	return nil
 }
 
-// Response after generating download link
-type GenerateDownloadURLResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=Url,proto3" json:"Url,omitempty"`
+type isUploadFileRequest_Payload interface {
+	isUploadFileRequest_Payload()
+}
+
+type UploadFileRequest_Metadata struct {
+	Metadata *UploadFileMetadata `protobuf:"bytes,1,opt,name=Metadata,proto3,oneof"`
+}
+
+type UploadFileRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=Chunk,proto3,oneof"`
+}
+
+func (*UploadFileRequest_Metadata) isUploadFileRequest_Payload() {}
+
+func (*UploadFileRequest_Chunk) isUploadFileRequest_Payload() {}
+
+type UploadFileMetadata struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StudentId string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	TaskId    string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// Name of the file within the submission, empty for the main file
+	FileName string `protobuf:"bytes,3,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	// Original name of the uploaded file on the student's machine, used when downloading it
+	OriginalName string `protobuf:"bytes,4,opt,name=OriginalName,proto3" json:"OriginalName,omitempty"`
+	// Content type of the file declared by the client
+	ContentType   string `protobuf:"bytes,5,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateDownloadURLResponse) Reset() {
-	*x = GenerateDownloadURLResponse{}
+func (x *UploadFileMetadata) Reset() {
+	*x = UploadFileMetadata{}
 	mi := &file_storage_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateDownloadURLResponse) String() string {
+func (x *UploadFileMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateDownloadURLResponse) ProtoMessage() {}
+func (*UploadFileMetadata) ProtoMessage() {}
 
-func (x *GenerateDownloadURLResponse) ProtoReflect() protoreflect.Message {
+func (x *UploadFileMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_storage_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -312,40 +412,73 @@ func (x *GenerateDownloadURLResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateDownloadURLResponse.ProtoReflect.Descriptor instead.
-func (*GenerateDownloadURLResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadFileMetadata.ProtoReflect.Descriptor instead.
+func (*UploadFileMetadata) Descriptor() ([]byte, []int) {
 	return file_storage_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GenerateDownloadURLResponse) GetUrl() string {
+func (x *UploadFileMetadata) GetStudentId() string {
 	if x != nil {
-		return x.Url
+		return x.StudentId
 	}
 	return ""
 }
 
-// Request for students ids
-type ListTaskFilesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+func (x *UploadFileMetadata) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UploadFileMetadata) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadFileMetadata) GetOriginalName() string {
+	if x != nil {
+		return x.OriginalName
+	}
+	return ""
+}
+
+func (x *UploadFileMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type UploadFileResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FileId string                 `protobuf:"bytes,1,opt,name=FileId,proto3" json:"FileId,omitempty"`
+	// Version created by this upload, with its size and SHA-256
+	Version *FileVersion `protobuf:"bytes,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	// Other submissions with byte-identical content, in any task
+	Duplicates []*DuplicateFile `protobuf:"bytes,3,rep,name=Duplicates,proto3" json:"Duplicates,omitempty"`
+	// Group the student submits with, empty for a personal submission
+	GroupId       string `protobuf:"bytes,4,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTaskFilesRequest) Reset() {
-	*x = ListTaskFilesRequest{}
+func (x *UploadFileResponse) Reset() {
+	*x = UploadFileResponse{}
 	mi := &file_storage_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTaskFilesRequest) String() string {
+func (x *UploadFileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTaskFilesRequest) ProtoMessage() {}
+func (*UploadFileResponse) ProtoMessage() {}
 
-func (x *ListTaskFilesRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_storage_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -357,40 +490,66 @@ func (x *ListTaskFilesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTaskFilesRequest.ProtoReflect.Descriptor instead.
-func (*ListTaskFilesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
 	return file_storage_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListTaskFilesRequest) GetTaskId() string {
+func (x *UploadFileResponse) GetFileId() string {
 	if x != nil {
-		return x.TaskId
+		return x.FileId
 	}
 	return ""
 }
 
-// Response for getting students ids
-type ListTaskFilesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*FileInfo            `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
+func (x *UploadFileResponse) GetVersion() *FileVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *UploadFileResponse) GetDuplicates() []*DuplicateFile {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+func (x *UploadFileResponse) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+// Submission of another student whose version has the same SHA-256
+type DuplicateFile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Student or group id
+	StudentId     string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=Version,proto3" json:"Version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	FileName      string                 `protobuf:"bytes,5,opt,name=FileName,proto3" json:"FileName,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTaskFilesResponse) Reset() {
-	*x = ListTaskFilesResponse{}
+func (x *DuplicateFile) Reset() {
+	*x = DuplicateFile{}
 	mi := &file_storage_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTaskFilesResponse) String() string {
+func (x *DuplicateFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTaskFilesResponse) ProtoMessage() {}
+func (*DuplicateFile) ProtoMessage() {}
 
-func (x *ListTaskFilesResponse) ProtoReflect() protoreflect.Message {
+func (x *DuplicateFile) ProtoReflect() protoreflect.Message {
 	mi := &file_storage_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -402,42 +561,72 @@ func (x *ListTaskFilesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTaskFilesResponse.ProtoReflect.Descriptor instead.
-func (*ListTaskFilesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DuplicateFile.ProtoReflect.Descriptor instead.
+func (*DuplicateFile) Descriptor() ([]byte, []int) {
 	return file_storage_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListTaskFilesResponse) GetItems() []*FileInfo {
+func (x *DuplicateFile) GetStudentId() string {
 	if x != nil {
-		return x.Items
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *DuplicateFile) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *DuplicateFile) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DuplicateFile) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-// File info
-type FileInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StudentId     string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=Status,proto3" json:"Status,omitempty"`
+func (x *DuplicateFile) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+// Request for url to download file
+type GenerateDownloadURLRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	StudentId  string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	TaskId     string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	FromInside bool                   `protobuf:"varint,3,opt,name=FromInside,proto3" json:"FromInside,omitempty"`
+	// Name of the file within the submission, empty for the main file
+	FileName      string `protobuf:"bytes,4,opt,name=FileName,proto3" json:"FileName,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileInfo) Reset() {
-	*x = FileInfo{}
+func (x *GenerateDownloadURLRequest) Reset() {
+	*x = GenerateDownloadURLRequest{}
 	mi := &file_storage_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileInfo) String() string {
+func (x *GenerateDownloadURLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileInfo) ProtoMessage() {}
+func (*GenerateDownloadURLRequest) ProtoMessage() {}
 
-func (x *FileInfo) ProtoReflect() protoreflect.Message {
+func (x *GenerateDownloadURLRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_storage_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -449,72 +638,3299 @@ func (x *FileInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
-func (*FileInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use GenerateDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GenerateDownloadURLRequest) Descriptor() ([]byte, []int) {
 	return file_storage_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *FileInfo) GetStudentId() string {
+func (x *GenerateDownloadURLRequest) GetStudentId() string {
 	if x != nil {
 		return x.StudentId
 	}
 	return ""
 }
 
-func (x *FileInfo) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *GenerateDownloadURLRequest) GetTaskId() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.TaskId
 	}
-	return nil
+	return ""
 }
 
-func (x *FileInfo) GetStatus() string {
+func (x *GenerateDownloadURLRequest) GetFromInside() bool {
 	if x != nil {
-		return x.Status
+		return x.FromInside
 	}
-	return ""
+	return false
 }
 
-var File_storage_proto protoreflect.FileDescriptor
+func (x *GenerateDownloadURLRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
 
-const file_storage_proto_rawDesc = "" +
-	"\n" +
-	"\rstorage.proto\x12\astorage\x1a\x1fgoogle/protobuf/timestamp.proto\"P\n" +
-	"\x18GenerateUploadURLRequest\x12\x1c\n" +
-	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
-	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\"-\n" +
-	"\x19GenerateUploadURLResponse\x12\x10\n" +
-	"\x03Url\x18\x01 \x01(\tR\x03Url\"Q\n" +
-	"\x19VerifyUploadedFileRequest\x12\x1c\n" +
-	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
-	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\"4\n" +
-	"\x1aVerifyUploadedFileResponse\x12\x16\n" +
-	"\x06FileId\x18\x01 \x01(\tR\x06FileId\"r\n" +
-	"\x1aGenerateDownloadURLRequest\x12\x1c\n" +
-	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
-	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x1e\n" +
-	"\n" +
-	"FromInside\x18\x03 \x01(\bR\n" +
-	"FromInside\"/\n" +
-	"\x1bGenerateDownloadURLResponse\x12\x10\n" +
-	"\x03Url\x18\x01 \x01(\tR\x03Url\".\n" +
-	"\x14ListTaskFilesRequest\x12\x16\n" +
-	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"@\n" +
-	"\x15ListTaskFilesResponse\x12'\n" +
-	"\x05Items\x18\x01 \x03(\v2\x11.storage.FileInfoR\x05Items\"z\n" +
-	"\bFileInfo\x12\x1c\n" +
-	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x128\n" +
-	"\tUpdatedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x16\n" +
-	"\x06Status\x18\x03 \x01(\tR\x06Status2\xfe\x02\n" +
-	"\aStorage\x12\\\n" +
-	"\x11GenerateUploadURL\x12!.storage.GenerateUploadURLRequest\x1a\".storage.GenerateUploadURLResponse\"\x00\x12_\n" +
-	"\x12VerifyUploadedFile\x12\".storage.VerifyUploadedFileRequest\x1a#.storage.VerifyUploadedFileResponse\"\x00\x12b\n" +
-	"\x13GenerateDownloadURL\x12#.storage.GenerateDownloadURLRequest\x1a$.storage.GenerateDownloadURLResponse\"\x00\x12P\n" +
-	"\rListTaskFiles\x12\x1d.storage.ListTaskFilesRequest\x1a\x1e.storage.ListTaskFilesResponse\"\x00BJZHgithub.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go;storagepbb\x06proto3"
+// Response after generating download link
+type GenerateDownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=Url,proto3" json:"Url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-var (
-	file_storage_proto_rawDescOnce sync.Once
-	file_storage_proto_rawDescData []byte
+func (x *GenerateDownloadURLResponse) Reset() {
+	*x = GenerateDownloadURLResponse{}
+	mi := &file_storage_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateDownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateDownloadURLResponse) ProtoMessage() {}
+
+func (x *GenerateDownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateDownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*GenerateDownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GenerateDownloadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Request for students ids
+type ListTaskFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskFilesRequest) Reset() {
+	*x = ListTaskFilesRequest{}
+	mi := &file_storage_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskFilesRequest) ProtoMessage() {}
+
+func (x *ListTaskFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskFilesRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListTaskFilesRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Response for getting students ids
+type ListTaskFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*FileInfo            `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskFilesResponse) Reset() {
+	*x = ListTaskFilesResponse{}
+	mi := &file_storage_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskFilesResponse) ProtoMessage() {}
+
+func (x *ListTaskFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskFilesResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTaskFilesResponse) GetItems() []*FileInfo {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// File info
+type FileInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StudentId string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	Status    string                 `protobuf:"bytes,3,opt,name=Status,proto3" json:"Status,omitempty"`
+	// Members of the group for a group submission; StudentId is then the group id
+	MemberIds []string `protobuf:"bytes,4,rep,name=MemberIds,proto3" json:"MemberIds,omitempty"`
+	// Member who uploaded a group submission
+	UploadedBy string `protobuf:"bytes,5,opt,name=UploadedBy,proto3" json:"UploadedBy,omitempty"`
+	// Latest verified version of the main file of the submission
+	Version int32 `protobuf:"varint,6,opt,name=Version,proto3" json:"Version,omitempty"`
+	// Size and hex SHA-256 of the latest version of the main file
+	Size   int64  `protobuf:"varint,7,opt,name=Size,proto3" json:"Size,omitempty"`
+	Sha256 string `protobuf:"bytes,8,opt,name=Sha256,proto3" json:"Sha256,omitempty"`
+	// Another student submitted byte-identical content (of any file) in this or another task
+	ExactDuplicate bool `protobuf:"varint,9,opt,name=ExactDuplicate,proto3" json:"ExactDuplicate,omitempty"`
+	// Latest version of every file of the submission, by name; the main file has an empty name
+	Files []*FileVersion `protobuf:"bytes,10,rep,name=Files,proto3" json:"Files,omitempty"`
+	// Metadata of the latest version of the main file, see FileVersion
+	OriginalName string `protobuf:"bytes,11,opt,name=OriginalName,proto3" json:"OriginalName,omitempty"`
+	ContentType  string `protobuf:"bytes,12,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	MimeType     string `protobuf:"bytes,13,opt,name=MimeType,proto3" json:"MimeType,omitempty"`
+	Etag         string `protobuf:"bytes,14,opt,name=Etag,proto3" json:"Etag,omitempty"`
+	// Latest version of at least one file was uploaded after the deadline
+	Late bool `protobuf:"varint,15,opt,name=Late,proto3" json:"Late,omitempty"`
+	// Checks of the latest upload of any file of the submission, absent before the first check
+	Validation    *ValidationReport `protobuf:"bytes,16,opt,name=Validation,proto3" json:"Validation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	mi := &file_storage_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FileInfo) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *FileInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *FileInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FileInfo) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *FileInfo) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *FileInfo) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfo) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *FileInfo) GetExactDuplicate() bool {
+	if x != nil {
+		return x.ExactDuplicate
+	}
+	return false
+}
+
+func (x *FileInfo) GetFiles() []*FileVersion {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *FileInfo) GetOriginalName() string {
+	if x != nil {
+		return x.OriginalName
+	}
+	return ""
+}
+
+func (x *FileInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileInfo) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *FileInfo) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *FileInfo) GetLate() bool {
+	if x != nil {
+		return x.Late
+	}
+	return false
+}
+
+func (x *FileInfo) GetValidation() *ValidationReport {
+	if x != nil {
+		return x.Validation
+	}
+	return nil
+}
+
+// Request for versions of a submission
+type ListFileVersionsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StudentId string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	TaskId    string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	// Only versions of this file; empty for versions of all files
+	FileName      string `protobuf:"bytes,3,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsRequest) Reset() {
+	*x = ListFileVersionsRequest{}
+	mi := &file_storage_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsRequest) ProtoMessage() {}
+
+func (x *ListFileVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListFileVersionsRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *ListFileVersionsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ListFileVersionsRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+// Response for getting versions of a submission
+type ListFileVersionsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Versions []*FileVersion         `protobuf:"bytes,1,rep,name=Versions,proto3" json:"Versions,omitempty"`
+	// Status of the submission: uploading, uploaded or rejected
+	Status string `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	// Checks of the latest upload, absent before the first check or if it was for another file
+	Validation    *ValidationReport `protobuf:"bytes,3,opt,name=Validation,proto3" json:"Validation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsResponse) Reset() {
+	*x = ListFileVersionsResponse{}
+	mi := &file_storage_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsResponse) ProtoMessage() {}
+
+func (x *ListFileVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListFileVersionsResponse) GetVersions() []*FileVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *ListFileVersionsResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListFileVersionsResponse) GetValidation() *ValidationReport {
+	if x != nil {
+		return x.Validation
+	}
+	return nil
+}
+
+// Result of the checks of an upload, in the order they ran; checks stop at the first failed one
+type ValidationReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the checked file within the submission, empty for the main file
+	FileName string `protobuf:"bytes,1,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	// Reason of the failed check as "validator: reason", empty if the upload was accepted
+	RejectionReason string                 `protobuf:"bytes,2,opt,name=RejectionReason,proto3" json:"RejectionReason,omitempty"`
+	Steps           []*ValidationStep      `protobuf:"bytes,3,rep,name=Steps,proto3" json:"Steps,omitempty"`
+	CheckedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=CheckedAt,proto3" json:"CheckedAt,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ValidationReport) Reset() {
+	*x = ValidationReport{}
+	mi := &file_storage_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationReport) ProtoMessage() {}
+
+func (x *ValidationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationReport.ProtoReflect.Descriptor instead.
+func (*ValidationReport) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ValidationReport) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ValidationReport) GetRejectionReason() string {
+	if x != nil {
+		return x.RejectionReason
+	}
+	return ""
+}
+
+func (x *ValidationReport) GetSteps() []*ValidationStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *ValidationReport) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+type ValidationStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the check: size, mime, archive, encrypted or clamav
+	Validator     string `protobuf:"bytes,1,opt,name=Validator,proto3" json:"Validator,omitempty"`
+	Passed        bool   `protobuf:"varint,2,opt,name=Passed,proto3" json:"Passed,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationStep) Reset() {
+	*x = ValidationStep{}
+	mi := &file_storage_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationStep) ProtoMessage() {}
+
+func (x *ValidationStep) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationStep.ProtoReflect.Descriptor instead.
+func (*ValidationStep) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ValidationStep) GetValidator() string {
+	if x != nil {
+		return x.Validator
+	}
+	return ""
+}
+
+func (x *ValidationStep) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *ValidationStep) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Request for url to download a version of a submission
+type GenerateVersionDownloadURLRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	StudentId  string                 `protobuf:"bytes,1,opt,name=StudentId,proto3" json:"StudentId,omitempty"`
+	TaskId     string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	Version    int32                  `protobuf:"varint,3,opt,name=Version,proto3" json:"Version,omitempty"`
+	FromInside bool                   `protobuf:"varint,4,opt,name=FromInside,proto3" json:"FromInside,omitempty"`
+	// Name of the file within the submission, empty for the main file
+	FileName      string `protobuf:"bytes,5,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateVersionDownloadURLRequest) Reset() {
+	*x = GenerateVersionDownloadURLRequest{}
+	mi := &file_storage_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateVersionDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateVersionDownloadURLRequest) ProtoMessage() {}
+
+func (x *GenerateVersionDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateVersionDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GenerateVersionDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GenerateVersionDownloadURLRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *GenerateVersionDownloadURLRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GenerateVersionDownloadURLRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GenerateVersionDownloadURLRequest) GetFromInside() bool {
+	if x != nil {
+		return x.FromInside
+	}
+	return false
+}
+
+func (x *GenerateVersionDownloadURLRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+// Response after generating version download link
+type GenerateVersionDownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       *FileVersion           `protobuf:"bytes,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=Url,proto3" json:"Url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateVersionDownloadURLResponse) Reset() {
+	*x = GenerateVersionDownloadURLResponse{}
+	mi := &file_storage_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateVersionDownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateVersionDownloadURLResponse) ProtoMessage() {}
+
+func (x *GenerateVersionDownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateVersionDownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*GenerateVersionDownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GenerateVersionDownloadURLResponse) GetVersion() *FileVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *GenerateVersionDownloadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Immutable verified upload of a submission
+type FileVersion struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Version int32                  `protobuf:"varint,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	Size    int64                  `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
+	// Hex SHA-256 of the file, empty for versions uploaded before versioning
+	Sha256     string                 `protobuf:"bytes,3,opt,name=Sha256,proto3" json:"Sha256,omitempty"`
+	UploadedBy string                 `protobuf:"bytes,4,opt,name=UploadedBy,proto3" json:"UploadedBy,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	// Name of the file within the submission, empty for the main file
+	Name string `protobuf:"bytes,6,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Another student submitted byte-identical content; set only in FileInfo.Files
+	ExactDuplicate bool `protobuf:"varint,7,opt,name=ExactDuplicate,proto3" json:"ExactDuplicate,omitempty"`
+	// Original name and content type declared when requesting the upload url, empty if not declared
+	OriginalName string `protobuf:"bytes,8,opt,name=OriginalName,proto3" json:"OriginalName,omitempty"`
+	ContentType  string `protobuf:"bytes,9,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	// Content type sniffed from the file content and ETag of the object, set on verification
+	MimeType string `protobuf:"bytes,10,opt,name=MimeType,proto3" json:"MimeType,omitempty"`
+	Etag     string `protobuf:"bytes,11,opt,name=Etag,proto3" json:"Etag,omitempty"`
+	// Uploaded after the deadline of a task with the mark_late policy
+	Late bool `protobuf:"varint,12,opt,name=Late,proto3" json:"Late,omitempty"`
+	// Other submissions with byte-identical content; set only in FileInfo.Files for exact duplicates
+	Duplicates    []*DuplicateFile `protobuf:"bytes,13,rep,name=Duplicates,proto3" json:"Duplicates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileVersion) Reset() {
+	*x = FileVersion{}
+	mi := &file_storage_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileVersion) ProtoMessage() {}
+
+func (x *FileVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileVersion.ProtoReflect.Descriptor instead.
+func (*FileVersion) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FileVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *FileVersion) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileVersion) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *FileVersion) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *FileVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FileVersion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileVersion) GetExactDuplicate() bool {
+	if x != nil {
+		return x.ExactDuplicate
+	}
+	return false
+}
+
+func (x *FileVersion) GetOriginalName() string {
+	if x != nil {
+		return x.OriginalName
+	}
+	return ""
+}
+
+func (x *FileVersion) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileVersion) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *FileVersion) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *FileVersion) GetLate() bool {
+	if x != nil {
+		return x.Late
+	}
+	return false
+}
+
+func (x *FileVersion) GetDuplicates() []*DuplicateFile {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+// Request for url to upload attachment
+type GenerateAttachmentUploadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=OwnerId,proto3" json:"OwnerId,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateAttachmentUploadURLRequest) Reset() {
+	*x = GenerateAttachmentUploadURLRequest{}
+	mi := &file_storage_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateAttachmentUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateAttachmentUploadURLRequest) ProtoMessage() {}
+
+func (x *GenerateAttachmentUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateAttachmentUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*GenerateAttachmentUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GenerateAttachmentUploadURLRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GenerateAttachmentUploadURLRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *GenerateAttachmentUploadURLRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+// Response after registering attachment
+type GenerateAttachmentUploadURLResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId string                 `protobuf:"bytes,1,opt,name=AttachmentId,proto3" json:"AttachmentId,omitempty"`
+	Url          string                 `protobuf:"bytes,2,opt,name=Url,proto3" json:"Url,omitempty"`
+	// Form fields to send with the file in a multipart POST to Url; the file goes last as "file"
+	Fields        map[string]string `protobuf:"bytes,3,rep,name=Fields,proto3" json:"Fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateAttachmentUploadURLResponse) Reset() {
+	*x = GenerateAttachmentUploadURLResponse{}
+	mi := &file_storage_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateAttachmentUploadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateAttachmentUploadURLResponse) ProtoMessage() {}
+
+func (x *GenerateAttachmentUploadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateAttachmentUploadURLResponse.ProtoReflect.Descriptor instead.
+func (*GenerateAttachmentUploadURLResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GenerateAttachmentUploadURLResponse) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *GenerateAttachmentUploadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GenerateAttachmentUploadURLResponse) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Request for verifying uploaded attachment
+type VerifyAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=AttachmentId,proto3" json:"AttachmentId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAttachmentRequest) Reset() {
+	*x = VerifyAttachmentRequest{}
+	mi := &file_storage_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAttachmentRequest) ProtoMessage() {}
+
+func (x *VerifyAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*VerifyAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerifyAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+// Response after verifying uploaded attachment
+type VerifyAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *AttachmentInfo        `protobuf:"bytes,1,opt,name=Attachment,proto3" json:"Attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAttachmentResponse) Reset() {
+	*x = VerifyAttachmentResponse{}
+	mi := &file_storage_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAttachmentResponse) ProtoMessage() {}
+
+func (x *VerifyAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*VerifyAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VerifyAttachmentResponse) GetAttachment() *AttachmentInfo {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+// Request for url to download attachment
+type GenerateAttachmentDownloadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=AttachmentId,proto3" json:"AttachmentId,omitempty"`
+	FromInside    bool                   `protobuf:"varint,2,opt,name=FromInside,proto3" json:"FromInside,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateAttachmentDownloadURLRequest) Reset() {
+	*x = GenerateAttachmentDownloadURLRequest{}
+	mi := &file_storage_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateAttachmentDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateAttachmentDownloadURLRequest) ProtoMessage() {}
+
+func (x *GenerateAttachmentDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateAttachmentDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GenerateAttachmentDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GenerateAttachmentDownloadURLRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *GenerateAttachmentDownloadURLRequest) GetFromInside() bool {
+	if x != nil {
+		return x.FromInside
+	}
+	return false
+}
+
+// Response after generating attachment download link
+type GenerateAttachmentDownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *AttachmentInfo        `protobuf:"bytes,1,opt,name=Attachment,proto3" json:"Attachment,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=Url,proto3" json:"Url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateAttachmentDownloadURLResponse) Reset() {
+	*x = GenerateAttachmentDownloadURLResponse{}
+	mi := &file_storage_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateAttachmentDownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateAttachmentDownloadURLResponse) ProtoMessage() {}
+
+func (x *GenerateAttachmentDownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateAttachmentDownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*GenerateAttachmentDownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GenerateAttachmentDownloadURLResponse) GetAttachment() *AttachmentInfo {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *GenerateAttachmentDownloadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Attachment info
+type AttachmentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=AttachmentId,proto3" json:"AttachmentId,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,3,opt,name=OwnerId,proto3" json:"OwnerId,omitempty"`
+	FileName      string                 `protobuf:"bytes,4,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentInfo) Reset() {
+	*x = AttachmentInfo{}
+	mi := &file_storage_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentInfo) ProtoMessage() {}
+
+func (x *AttachmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentInfo.ProtoReflect.Descriptor instead.
+func (*AttachmentInfo) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AttachmentInfo) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *AttachmentInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Request for creating a group
+type CreateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	MemberIds     []string               `protobuf:"bytes,3,rep,name=MemberIds,proto3" json:"MemberIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	mi := &file_storage_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateGroupRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+// Response after creating a group
+type CreateGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *GroupInfo             `protobuf:"bytes,1,opt,name=Group,proto3" json:"Group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupResponse) Reset() {
+	*x = CreateGroupResponse{}
+	mi := &file_storage_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupResponse) ProtoMessage() {}
+
+func (x *CreateGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateGroupResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateGroupResponse) GetGroup() *GroupInfo {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// Request for replacing members of a group
+type UpdateGroupMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	MemberIds     []string               `protobuf:"bytes,3,rep,name=MemberIds,proto3" json:"MemberIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGroupMembersRequest) Reset() {
+	*x = UpdateGroupMembersRequest{}
+	mi := &file_storage_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGroupMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGroupMembersRequest) ProtoMessage() {}
+
+func (x *UpdateGroupMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGroupMembersRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGroupMembersRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateGroupMembersRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UpdateGroupMembersRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *UpdateGroupMembersRequest) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+// Response after replacing members of a group
+type UpdateGroupMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *GroupInfo             `protobuf:"bytes,1,opt,name=Group,proto3" json:"Group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGroupMembersResponse) Reset() {
+	*x = UpdateGroupMembersResponse{}
+	mi := &file_storage_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGroupMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGroupMembersResponse) ProtoMessage() {}
+
+func (x *UpdateGroupMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGroupMembersResponse.ProtoReflect.Descriptor instead.
+func (*UpdateGroupMembersResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateGroupMembersResponse) GetGroup() *GroupInfo {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// Request for deleting a group
+type DeleteGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGroupRequest) Reset() {
+	*x = DeleteGroupRequest{}
+	mi := &file_storage_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupRequest) ProtoMessage() {}
+
+func (x *DeleteGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteGroupRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *DeleteGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+// Response after deleting a group
+type DeleteGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGroupResponse) Reset() {
+	*x = DeleteGroupResponse{}
+	mi := &file_storage_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupResponse) ProtoMessage() {}
+
+func (x *DeleteGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGroupResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{32}
+}
+
+// Request for groups of a task
+type ListTaskGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskGroupsRequest) Reset() {
+	*x = ListTaskGroupsRequest{}
+	mi := &file_storage_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskGroupsRequest) ProtoMessage() {}
+
+func (x *ListTaskGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListTaskGroupsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Response for getting groups of a task
+type ListTaskGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*GroupInfo           `protobuf:"bytes,1,rep,name=Groups,proto3" json:"Groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskGroupsResponse) Reset() {
+	*x = ListTaskGroupsResponse{}
+	mi := &file_storage_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskGroupsResponse) ProtoMessage() {}
+
+func (x *ListTaskGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListTaskGroupsResponse) GetGroups() []*GroupInfo {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// Group info
+type GroupInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	GroupId   string                 `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	TaskId    string                 `protobuf:"bytes,2,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	MemberIds []string               `protobuf:"bytes,3,rep,name=MemberIds,proto3" json:"MemberIds,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	// The group has uploaded its submission
+	Submitted     bool `protobuf:"varint,5,opt,name=Submitted,proto3" json:"Submitted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupInfo) Reset() {
+	*x = GroupInfo{}
+	mi := &file_storage_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInfo) ProtoMessage() {}
+
+func (x *GroupInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInfo.ProtoReflect.Descriptor instead.
+func (*GroupInfo) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GroupInfo) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GroupInfo) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GroupInfo) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *GroupInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GroupInfo) GetSubmitted() bool {
+	if x != nil {
+		return x.Submitted
+	}
+	return false
+}
+
+// Request for creating a task
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	Settings      *TaskSettings          `protobuf:"bytes,2,opt,name=Settings,proto3" json:"Settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_storage_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetSettings() *TaskSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Response after creating a task
+type CreateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *TaskInfo              `protobuf:"bytes,1,opt,name=Task,proto3" json:"Task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskResponse) Reset() {
+	*x = CreateTaskResponse{}
+	mi := &file_storage_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskResponse) ProtoMessage() {}
+
+func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateTaskResponse) GetTask() *TaskInfo {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// Request for replacing settings of a task
+type UpdateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	Settings      *TaskSettings          `protobuf:"bytes,2,opt,name=Settings,proto3" json:"Settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_storage_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetSettings() *TaskSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Response after updating a task
+type UpdateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *TaskInfo              `protobuf:"bytes,1,opt,name=Task,proto3" json:"Task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskResponse) Reset() {
+	*x = UpdateTaskResponse{}
+	mi := &file_storage_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskResponse) ProtoMessage() {}
+
+func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateTaskResponse) GetTask() *TaskInfo {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// Request for a task
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_storage_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Response for getting a task
+type GetTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *TaskInfo              `protobuf:"bytes,1,opt,name=Task,proto3" json:"Task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_storage_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetTaskResponse) GetTask() *TaskInfo {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// Request for list of tasks
+type ListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only tasks of this course; empty for all tasks
+	CourseId      string `protobuf:"bytes,1,opt,name=CourseId,proto3" json:"CourseId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_storage_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListTasksRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+// Response for getting list of tasks
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskInfo            `protobuf:"bytes,1,rep,name=Tasks,proto3" json:"Tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_storage_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListTasksResponse) GetTasks() []*TaskInfo {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// Request for deleting a task
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_storage_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeleteTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Response after deleting a task
+type DeleteTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_storage_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{45}
+}
+
+// Editable settings of a task
+type TaskSettings struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Title    string                 `protobuf:"bytes,1,opt,name=Title,proto3" json:"Title,omitempty"`
+	CourseId string                 `protobuf:"bytes,2,opt,name=CourseId,proto3" json:"CourseId,omitempty"`
+	// Submission window; unset means no limit on that side
+	OpensAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=OpensAt,proto3" json:"OpensAt,omitempty"`
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=Deadline,proto3" json:"Deadline,omitempty"`
+	// reject (default) or mark_late
+	LatePolicy string `protobuf:"bytes,5,opt,name=LatePolicy,proto3" json:"LatePolicy,omitempty"`
+	// Allowed file extensions without dot; empty allows any
+	AllowedTypes []string `protobuf:"bytes,6,rep,name=AllowedTypes,proto3" json:"AllowedTypes,omitempty"`
+	// Max file size in bytes; 0 means the service default
+	MaxFileSize int64 `protobuf:"varint,7,opt,name=MaxFileSize,proto3" json:"MaxFileSize,omitempty"`
+	// Allowed content types detected from the file ("application/pdf") or their groups ("text/"); empty allows any
+	AllowedMimeTypes []string `protobuf:"bytes,8,rep,name=AllowedMimeTypes,proto3" json:"AllowedMimeTypes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TaskSettings) Reset() {
+	*x = TaskSettings{}
+	mi := &file_storage_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSettings) ProtoMessage() {}
+
+func (x *TaskSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSettings.ProtoReflect.Descriptor instead.
+func (*TaskSettings) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TaskSettings) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskSettings) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *TaskSettings) GetOpensAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpensAt
+	}
+	return nil
+}
+
+func (x *TaskSettings) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *TaskSettings) GetLatePolicy() string {
+	if x != nil {
+		return x.LatePolicy
+	}
+	return ""
+}
+
+func (x *TaskSettings) GetAllowedTypes() []string {
+	if x != nil {
+		return x.AllowedTypes
+	}
+	return nil
+}
+
+func (x *TaskSettings) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *TaskSettings) GetAllowedMimeTypes() []string {
+	if x != nil {
+		return x.AllowedMimeTypes
+	}
+	return nil
+}
+
+// Task info
+type TaskInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	Settings      *TaskSettings          `protobuf:"bytes,2,opt,name=Settings,proto3" json:"Settings,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskInfo) Reset() {
+	*x = TaskInfo{}
+	mi := &file_storage_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskInfo) ProtoMessage() {}
+
+func (x *TaskInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskInfo.ProtoReflect.Descriptor instead.
+func (*TaskInfo) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TaskInfo) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetSettings() *TaskSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *TaskInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TaskInfo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Request for creating a course
+type CreateCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=CourseId,proto3" json:"CourseId,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Members       []*CourseMember        `protobuf:"bytes,3,rep,name=Members,proto3" json:"Members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourseRequest) Reset() {
+	*x = CreateCourseRequest{}
+	mi := &file_storage_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourseRequest) ProtoMessage() {}
+
+func (x *CreateCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourseRequest.ProtoReflect.Descriptor instead.
+func (*CreateCourseRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateCourseRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CreateCourseRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateCourseRequest) GetMembers() []*CourseMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Response after creating a course
+type CreateCourseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Course        *CourseInfo            `protobuf:"bytes,1,opt,name=Course,proto3" json:"Course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourseResponse) Reset() {
+	*x = CreateCourseResponse{}
+	mi := &file_storage_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourseResponse) ProtoMessage() {}
+
+func (x *CreateCourseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourseResponse.ProtoReflect.Descriptor instead.
+func (*CreateCourseResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateCourseResponse) GetCourse() *CourseInfo {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+// Request for a course
+type GetCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=CourseId,proto3" json:"CourseId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourseRequest) Reset() {
+	*x = GetCourseRequest{}
+	mi := &file_storage_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourseRequest) ProtoMessage() {}
+
+func (x *GetCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourseRequest.ProtoReflect.Descriptor instead.
+func (*GetCourseRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetCourseRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+// Response for getting a course
+type GetCourseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Course        *CourseInfo            `protobuf:"bytes,1,opt,name=Course,proto3" json:"Course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourseResponse) Reset() {
+	*x = GetCourseResponse{}
+	mi := &file_storage_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourseResponse) ProtoMessage() {}
+
+func (x *GetCourseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourseResponse.ProtoReflect.Descriptor instead.
+func (*GetCourseResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetCourseResponse) GetCourse() *CourseInfo {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+// Request for importing a roster of a course
+type ImportCourseRosterRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=CourseId,proto3" json:"CourseId,omitempty"`
+	Members  []*CourseMember        `protobuf:"bytes,2,rep,name=Members,proto3" json:"Members,omitempty"`
+	// Remove members missing from the roster
+	Replace       bool `protobuf:"varint,3,opt,name=Replace,proto3" json:"Replace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCourseRosterRequest) Reset() {
+	*x = ImportCourseRosterRequest{}
+	mi := &file_storage_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCourseRosterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCourseRosterRequest) ProtoMessage() {}
+
+func (x *ImportCourseRosterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCourseRosterRequest.ProtoReflect.Descriptor instead.
+func (*ImportCourseRosterRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ImportCourseRosterRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ImportCourseRosterRequest) GetMembers() []*CourseMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *ImportCourseRosterRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+// Response after importing a roster
+type ImportCourseRosterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Course        *CourseInfo            `protobuf:"bytes,1,opt,name=Course,proto3" json:"Course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCourseRosterResponse) Reset() {
+	*x = ImportCourseRosterResponse{}
+	mi := &file_storage_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCourseRosterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCourseRosterResponse) ProtoMessage() {}
+
+func (x *ImportCourseRosterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCourseRosterResponse.ProtoReflect.Descriptor instead.
+func (*ImportCourseRosterResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ImportCourseRosterResponse) GetCourse() *CourseInfo {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+// Request for removing a member from a course
+type RemoveCourseMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=CourseId,proto3" json:"CourseId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCourseMemberRequest) Reset() {
+	*x = RemoveCourseMemberRequest{}
+	mi := &file_storage_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCourseMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCourseMemberRequest) ProtoMessage() {}
+
+func (x *RemoveCourseMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCourseMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCourseMemberRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RemoveCourseMemberRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *RemoveCourseMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Response after removing a member from a course
+type RemoveCourseMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCourseMemberResponse) Reset() {
+	*x = RemoveCourseMemberResponse{}
+	mi := &file_storage_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCourseMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCourseMemberResponse) ProtoMessage() {}
+
+func (x *RemoveCourseMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCourseMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveCourseMemberResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{55}
+}
+
+// Request for students who have not submitted a task
+type ListMissingSubmissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=TaskId,proto3" json:"TaskId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissingSubmissionsRequest) Reset() {
+	*x = ListMissingSubmissionsRequest{}
+	mi := &file_storage_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissingSubmissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissingSubmissionsRequest) ProtoMessage() {}
+
+func (x *ListMissingSubmissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissingSubmissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMissingSubmissionsRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListMissingSubmissionsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// Response for getting students who have not submitted a task
+type ListMissingSubmissionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty for a task without a course
+	StudentIds    []string `protobuf:"bytes,1,rep,name=StudentIds,proto3" json:"StudentIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissingSubmissionsResponse) Reset() {
+	*x = ListMissingSubmissionsResponse{}
+	mi := &file_storage_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissingSubmissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissingSubmissionsResponse) ProtoMessage() {}
+
+func (x *ListMissingSubmissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissingSubmissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMissingSubmissionsResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListMissingSubmissionsResponse) GetStudentIds() []string {
+	if x != nil {
+		return x.StudentIds
+	}
+	return nil
+}
+
+// Member of a course
+type CourseMember struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	// student or teacher
+	Role          string `protobuf:"bytes,2,opt,name=Role,proto3" json:"Role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourseMember) Reset() {
+	*x = CourseMember{}
+	mi := &file_storage_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourseMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourseMember) ProtoMessage() {}
+
+func (x *CourseMember) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourseMember.ProtoReflect.Descriptor instead.
+func (*CourseMember) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CourseMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CourseMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// Course info
+type CourseInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=CourseId,proto3" json:"CourseId,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Members       []*CourseMember        `protobuf:"bytes,3,rep,name=Members,proto3" json:"Members,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourseInfo) Reset() {
+	*x = CourseInfo{}
+	mi := &file_storage_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourseInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourseInfo) ProtoMessage() {}
+
+func (x *CourseInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourseInfo.ProtoReflect.Descriptor instead.
+func (*CourseInfo) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CourseInfo) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CourseInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CourseInfo) GetMembers() []*CourseMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *CourseInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_storage_proto protoreflect.FileDescriptor
+
+const file_storage_proto_rawDesc = "" +
+	"\n" +
+	"\rstorage.proto\x12\astorage\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb2\x01\n" +
+	"\x18GenerateUploadURLRequest\x12\x1c\n" +
+	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x1a\n" +
+	"\bFileName\x18\x03 \x01(\tR\bFileName\x12\"\n" +
+	"\fOriginalName\x18\x04 \x01(\tR\fOriginalName\x12 \n" +
+	"\vContentType\x18\x05 \x01(\tR\vContentType\"\xca\x01\n" +
+	"\x19GenerateUploadURLResponse\x12\x10\n" +
+	"\x03Url\x18\x01 \x01(\tR\x03Url\x12\x18\n" +
+	"\aGroupId\x18\x02 \x01(\tR\aGroupId\x12F\n" +
+	"\x06Fields\x18\x03 \x03(\v2..storage.GenerateUploadURLResponse.FieldsEntryR\x06Fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"m\n" +
+	"\x19VerifyUploadedFileRequest\x12\x1c\n" +
+	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x1a\n" +
+	"\bFileName\x18\x03 \x01(\tR\bFileName\"\x9c\x01\n" +
+	"\x1aVerifyUploadedFileResponse\x12\x16\n" +
+	"\x06FileId\x18\x01 \x01(\tR\x06FileId\x12.\n" +
+	"\aVersion\x18\x02 \x01(\v2\x14.storage.FileVersionR\aVersion\x126\n" +
+	"\n" +
+	"Duplicates\x18\x03 \x03(\v2\x16.storage.DuplicateFileR\n" +
+	"Duplicates\"q\n" +
+	"\x11UploadFileRequest\x129\n" +
+	"\bMetadata\x18\x01 \x01(\v2\x1b.storage.UploadFileMetadataH\x00R\bMetadata\x12\x16\n" +
+	"\x05Chunk\x18\x02 \x01(\fH\x00R\x05ChunkB\t\n" +
+	"\aPayload\"\xac\x01\n" +
+	"\x12UploadFileMetadata\x12\x1c\n" +
+	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x1a\n" +
+	"\bFileName\x18\x03 \x01(\tR\bFileName\x12\"\n" +
+	"\fOriginalName\x18\x04 \x01(\tR\fOriginalName\x12 \n" +
+	"\vContentType\x18\x05 \x01(\tR\vContentType\"\xae\x01\n" +
+	"\x12UploadFileResponse\x12\x16\n" +
+	"\x06FileId\x18\x01 \x01(\tR\x06FileId\x12.\n" +
+	"\aVersion\x18\x02 \x01(\v2\x14.storage.FileVersionR\aVersion\x126\n" +
+	"\n" +
+	"Duplicates\x18\x03 \x03(\v2\x16.storage.DuplicateFileR\n" +
+	"Duplicates\x12\x18\n" +
+	"\aGroupId\x18\x04 \x01(\tR\aGroupId\"\xb5\x01\n" +
+	"\rDuplicateFile\x12\x1c\n" +
+	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x18\n" +
+	"\aVersion\x18\x03 \x01(\x05R\aVersion\x128\n" +
+	"\tCreatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x12\x1a\n" +
+	"\bFileName\x18\x05 \x01(\tR\bFileName\"\x8e\x01\n" +
+	"\x1aGenerateDownloadURLRequest\x12\x1c\n" +
+	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x1e\n" +
+	"\n" +
+	"FromInside\x18\x03 \x01(\bR\n" +
+	"FromInside\x12\x1a\n" +
+	"\bFileName\x18\x04 \x01(\tR\bFileName\"/\n" +
+	"\x1bGenerateDownloadURLResponse\x12\x10\n" +
+	"\x03Url\x18\x01 \x01(\tR\x03Url\".\n" +
+	"\x14ListTaskFilesRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"@\n" +
+	"\x15ListTaskFilesResponse\x12'\n" +
+	"\x05Items\x18\x01 \x03(\v2\x11.storage.FileInfoR\x05Items\"\x97\x04\n" +
+	"\bFileInfo\x12\x1c\n" +
+	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x128\n" +
+	"\tUpdatedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x16\n" +
+	"\x06Status\x18\x03 \x01(\tR\x06Status\x12\x1c\n" +
+	"\tMemberIds\x18\x04 \x03(\tR\tMemberIds\x12\x1e\n" +
+	"\n" +
+	"UploadedBy\x18\x05 \x01(\tR\n" +
+	"UploadedBy\x12\x18\n" +
+	"\aVersion\x18\x06 \x01(\x05R\aVersion\x12\x12\n" +
+	"\x04Size\x18\a \x01(\x03R\x04Size\x12\x16\n" +
+	"\x06Sha256\x18\b \x01(\tR\x06Sha256\x12&\n" +
+	"\x0eExactDuplicate\x18\t \x01(\bR\x0eExactDuplicate\x12*\n" +
+	"\x05Files\x18\n" +
+	" \x03(\v2\x14.storage.FileVersionR\x05Files\x12\"\n" +
+	"\fOriginalName\x18\v \x01(\tR\fOriginalName\x12 \n" +
+	"\vContentType\x18\f \x01(\tR\vContentType\x12\x1a\n" +
+	"\bMimeType\x18\r \x01(\tR\bMimeType\x12\x12\n" +
+	"\x04Etag\x18\x0e \x01(\tR\x04Etag\x12\x12\n" +
+	"\x04Late\x18\x0f \x01(\bR\x04Late\x129\n" +
+	"\n" +
+	"Validation\x18\x10 \x01(\v2\x19.storage.ValidationReportR\n" +
+	"Validation\"k\n" +
+	"\x17ListFileVersionsRequest\x12\x1c\n" +
+	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x1a\n" +
+	"\bFileName\x18\x03 \x01(\tR\bFileName\"\x9f\x01\n" +
+	"\x18ListFileVersionsResponse\x120\n" +
+	"\bVersions\x18\x01 \x03(\v2\x14.storage.FileVersionR\bVersions\x12\x16\n" +
+	"\x06Status\x18\x02 \x01(\tR\x06Status\x129\n" +
+	"\n" +
+	"Validation\x18\x03 \x01(\v2\x19.storage.ValidationReportR\n" +
+	"Validation\"\xc1\x01\n" +
+	"\x10ValidationReport\x12\x1a\n" +
+	"\bFileName\x18\x01 \x01(\tR\bFileName\x12(\n" +
+	"\x0fRejectionReason\x18\x02 \x01(\tR\x0fRejectionReason\x12-\n" +
+	"\x05Steps\x18\x03 \x03(\v2\x17.storage.ValidationStepR\x05Steps\x128\n" +
+	"\tCheckedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tCheckedAt\"^\n" +
+	"\x0eValidationStep\x12\x1c\n" +
+	"\tValidator\x18\x01 \x01(\tR\tValidator\x12\x16\n" +
+	"\x06Passed\x18\x02 \x01(\bR\x06Passed\x12\x16\n" +
+	"\x06Reason\x18\x03 \x01(\tR\x06Reason\"\xaf\x01\n" +
+	"!GenerateVersionDownloadURLRequest\x12\x1c\n" +
+	"\tStudentId\x18\x01 \x01(\tR\tStudentId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x18\n" +
+	"\aVersion\x18\x03 \x01(\x05R\aVersion\x12\x1e\n" +
+	"\n" +
+	"FromInside\x18\x04 \x01(\bR\n" +
+	"FromInside\x12\x1a\n" +
+	"\bFileName\x18\x05 \x01(\tR\bFileName\"f\n" +
+	"\"GenerateVersionDownloadURLResponse\x12.\n" +
+	"\aVersion\x18\x01 \x01(\v2\x14.storage.FileVersionR\aVersion\x12\x10\n" +
+	"\x03Url\x18\x02 \x01(\tR\x03Url\"\xab\x03\n" +
+	"\vFileVersion\x12\x18\n" +
+	"\aVersion\x18\x01 \x01(\x05R\aVersion\x12\x12\n" +
+	"\x04Size\x18\x02 \x01(\x03R\x04Size\x12\x16\n" +
+	"\x06Sha256\x18\x03 \x01(\tR\x06Sha256\x12\x1e\n" +
+	"\n" +
+	"UploadedBy\x18\x04 \x01(\tR\n" +
+	"UploadedBy\x128\n" +
+	"\tCreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x12\x12\n" +
+	"\x04Name\x18\x06 \x01(\tR\x04Name\x12&\n" +
+	"\x0eExactDuplicate\x18\a \x01(\bR\x0eExactDuplicate\x12\"\n" +
+	"\fOriginalName\x18\b \x01(\tR\fOriginalName\x12 \n" +
+	"\vContentType\x18\t \x01(\tR\vContentType\x12\x1a\n" +
+	"\bMimeType\x18\n" +
+	" \x01(\tR\bMimeType\x12\x12\n" +
+	"\x04Etag\x18\v \x01(\tR\x04Etag\x12\x12\n" +
+	"\x04Late\x18\f \x01(\bR\x04Late\x126\n" +
+	"\n" +
+	"Duplicates\x18\r \x03(\v2\x16.storage.DuplicateFileR\n" +
+	"Duplicates\"r\n" +
+	"\"GenerateAttachmentUploadURLRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x18\n" +
+	"\aOwnerId\x18\x02 \x01(\tR\aOwnerId\x12\x1a\n" +
+	"\bFileName\x18\x03 \x01(\tR\bFileName\"\xe8\x01\n" +
+	"#GenerateAttachmentUploadURLResponse\x12\"\n" +
+	"\fAttachmentId\x18\x01 \x01(\tR\fAttachmentId\x12\x10\n" +
+	"\x03Url\x18\x02 \x01(\tR\x03Url\x12P\n" +
+	"\x06Fields\x18\x03 \x03(\v28.storage.GenerateAttachmentUploadURLResponse.FieldsEntryR\x06Fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"=\n" +
+	"\x17VerifyAttachmentRequest\x12\"\n" +
+	"\fAttachmentId\x18\x01 \x01(\tR\fAttachmentId\"S\n" +
+	"\x18VerifyAttachmentResponse\x127\n" +
+	"\n" +
+	"Attachment\x18\x01 \x01(\v2\x17.storage.AttachmentInfoR\n" +
+	"Attachment\"j\n" +
+	"$GenerateAttachmentDownloadURLRequest\x12\"\n" +
+	"\fAttachmentId\x18\x01 \x01(\tR\fAttachmentId\x12\x1e\n" +
+	"\n" +
+	"FromInside\x18\x02 \x01(\bR\n" +
+	"FromInside\"r\n" +
+	"%GenerateAttachmentDownloadURLResponse\x127\n" +
+	"\n" +
+	"Attachment\x18\x01 \x01(\v2\x17.storage.AttachmentInfoR\n" +
+	"Attachment\x12\x10\n" +
+	"\x03Url\x18\x02 \x01(\tR\x03Url\"\xd4\x01\n" +
+	"\x0eAttachmentInfo\x12\"\n" +
+	"\fAttachmentId\x18\x01 \x01(\tR\fAttachmentId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x18\n" +
+	"\aOwnerId\x18\x03 \x01(\tR\aOwnerId\x12\x1a\n" +
+	"\bFileName\x18\x04 \x01(\tR\bFileName\x128\n" +
+	"\tUpdatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x16\n" +
+	"\x06Status\x18\x06 \x01(\tR\x06Status\"d\n" +
+	"\x12CreateGroupRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x18\n" +
+	"\aGroupId\x18\x02 \x01(\tR\aGroupId\x12\x1c\n" +
+	"\tMemberIds\x18\x03 \x03(\tR\tMemberIds\"?\n" +
+	"\x13CreateGroupResponse\x12(\n" +
+	"\x05Group\x18\x01 \x01(\v2\x12.storage.GroupInfoR\x05Group\"k\n" +
+	"\x19UpdateGroupMembersRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x18\n" +
+	"\aGroupId\x18\x02 \x01(\tR\aGroupId\x12\x1c\n" +
+	"\tMemberIds\x18\x03 \x03(\tR\tMemberIds\"F\n" +
+	"\x1aUpdateGroupMembersResponse\x12(\n" +
+	"\x05Group\x18\x01 \x01(\v2\x12.storage.GroupInfoR\x05Group\"F\n" +
+	"\x12DeleteGroupRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x12\x18\n" +
+	"\aGroupId\x18\x02 \x01(\tR\aGroupId\"\x15\n" +
+	"\x13DeleteGroupResponse\"/\n" +
+	"\x15ListTaskGroupsRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"D\n" +
+	"\x16ListTaskGroupsResponse\x12*\n" +
+	"\x06Groups\x18\x01 \x03(\v2\x12.storage.GroupInfoR\x06Groups\"\xb3\x01\n" +
+	"\tGroupInfo\x12\x18\n" +
+	"\aGroupId\x18\x01 \x01(\tR\aGroupId\x12\x16\n" +
+	"\x06TaskId\x18\x02 \x01(\tR\x06TaskId\x12\x1c\n" +
+	"\tMemberIds\x18\x03 \x03(\tR\tMemberIds\x128\n" +
+	"\tCreatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x12\x1c\n" +
+	"\tSubmitted\x18\x05 \x01(\bR\tSubmitted\"^\n" +
+	"\x11CreateTaskRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x121\n" +
+	"\bSettings\x18\x02 \x01(\v2\x15.storage.TaskSettingsR\bSettings\";\n" +
+	"\x12CreateTaskResponse\x12%\n" +
+	"\x04Task\x18\x01 \x01(\v2\x11.storage.TaskInfoR\x04Task\"^\n" +
+	"\x11UpdateTaskRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x121\n" +
+	"\bSettings\x18\x02 \x01(\v2\x15.storage.TaskSettingsR\bSettings\";\n" +
+	"\x12UpdateTaskResponse\x12%\n" +
+	"\x04Task\x18\x01 \x01(\v2\x11.storage.TaskInfoR\x04Task\"(\n" +
+	"\x0eGetTaskRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"8\n" +
+	"\x0fGetTaskResponse\x12%\n" +
+	"\x04Task\x18\x01 \x01(\v2\x11.storage.TaskInfoR\x04Task\".\n" +
+	"\x10ListTasksRequest\x12\x1a\n" +
+	"\bCourseId\x18\x01 \x01(\tR\bCourseId\"<\n" +
+	"\x11ListTasksResponse\x12'\n" +
+	"\x05Tasks\x18\x01 \x03(\v2\x11.storage.TaskInfoR\x05Tasks\"+\n" +
+	"\x11DeleteTaskRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"\x14\n" +
+	"\x12DeleteTaskResponse\"\xc0\x02\n" +
+	"\fTaskSettings\x12\x14\n" +
+	"\x05Title\x18\x01 \x01(\tR\x05Title\x12\x1a\n" +
+	"\bCourseId\x18\x02 \x01(\tR\bCourseId\x124\n" +
+	"\aOpensAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aOpensAt\x126\n" +
+	"\bDeadline\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bDeadline\x12\x1e\n" +
+	"\n" +
+	"LatePolicy\x18\x05 \x01(\tR\n" +
+	"LatePolicy\x12\"\n" +
+	"\fAllowedTypes\x18\x06 \x03(\tR\fAllowedTypes\x12 \n" +
+	"\vMaxFileSize\x18\a \x01(\x03R\vMaxFileSize\x12*\n" +
+	"\x10AllowedMimeTypes\x18\b \x03(\tR\x10AllowedMimeTypes\"\xc9\x01\n" +
+	"\bTaskInfo\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\x121\n" +
+	"\bSettings\x18\x02 \x01(\v2\x15.storage.TaskSettingsR\bSettings\x128\n" +
+	"\tCreatedAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
+	"\tUpdatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\"x\n" +
+	"\x13CreateCourseRequest\x12\x1a\n" +
+	"\bCourseId\x18\x01 \x01(\tR\bCourseId\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12/\n" +
+	"\aMembers\x18\x03 \x03(\v2\x15.storage.CourseMemberR\aMembers\"C\n" +
+	"\x14CreateCourseResponse\x12+\n" +
+	"\x06Course\x18\x01 \x01(\v2\x13.storage.CourseInfoR\x06Course\".\n" +
+	"\x10GetCourseRequest\x12\x1a\n" +
+	"\bCourseId\x18\x01 \x01(\tR\bCourseId\"@\n" +
+	"\x11GetCourseResponse\x12+\n" +
+	"\x06Course\x18\x01 \x01(\v2\x13.storage.CourseInfoR\x06Course\"\x82\x01\n" +
+	"\x19ImportCourseRosterRequest\x12\x1a\n" +
+	"\bCourseId\x18\x01 \x01(\tR\bCourseId\x12/\n" +
+	"\aMembers\x18\x02 \x03(\v2\x15.storage.CourseMemberR\aMembers\x12\x18\n" +
+	"\aReplace\x18\x03 \x01(\bR\aReplace\"I\n" +
+	"\x1aImportCourseRosterResponse\x12+\n" +
+	"\x06Course\x18\x01 \x01(\v2\x13.storage.CourseInfoR\x06Course\"O\n" +
+	"\x19RemoveCourseMemberRequest\x12\x1a\n" +
+	"\bCourseId\x18\x01 \x01(\tR\bCourseId\x12\x16\n" +
+	"\x06UserId\x18\x02 \x01(\tR\x06UserId\"\x1c\n" +
+	"\x1aRemoveCourseMemberResponse\"7\n" +
+	"\x1dListMissingSubmissionsRequest\x12\x16\n" +
+	"\x06TaskId\x18\x01 \x01(\tR\x06TaskId\"@\n" +
+	"\x1eListMissingSubmissionsResponse\x12\x1e\n" +
+	"\n" +
+	"StudentIds\x18\x01 \x03(\tR\n" +
+	"StudentIds\":\n" +
+	"\fCourseMember\x12\x16\n" +
+	"\x06UserId\x18\x01 \x01(\tR\x06UserId\x12\x12\n" +
+	"\x04Role\x18\x02 \x01(\tR\x04Role\"\xa9\x01\n" +
+	"\n" +
+	"CourseInfo\x12\x1a\n" +
+	"\bCourseId\x18\x01 \x01(\tR\bCourseId\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12/\n" +
+	"\aMembers\x18\x03 \x03(\v2\x15.storage.CourseMemberR\aMembers\x128\n" +
+	"\tCreatedAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt2\xea\x10\n" +
+	"\aStorage\x12\\\n" +
+	"\x11GenerateUploadURL\x12!.storage.GenerateUploadURLRequest\x1a\".storage.GenerateUploadURLResponse\"\x00\x12_\n" +
+	"\x12VerifyUploadedFile\x12\".storage.VerifyUploadedFileRequest\x1a#.storage.VerifyUploadedFileResponse\"\x00\x12I\n" +
+	"\n" +
+	"UploadFile\x12\x1a.storage.UploadFileRequest\x1a\x1b.storage.UploadFileResponse\"\x00(\x01\x12b\n" +
+	"\x13GenerateDownloadURL\x12#.storage.GenerateDownloadURLRequest\x1a$.storage.GenerateDownloadURLResponse\"\x00\x12P\n" +
+	"\rListTaskFiles\x12\x1d.storage.ListTaskFilesRequest\x1a\x1e.storage.ListTaskFilesResponse\"\x00\x12Y\n" +
+	"\x10ListFileVersions\x12 .storage.ListFileVersionsRequest\x1a!.storage.ListFileVersionsResponse\"\x00\x12w\n" +
+	"\x1aGenerateVersionDownloadURL\x12*.storage.GenerateVersionDownloadURLRequest\x1a+.storage.GenerateVersionDownloadURLResponse\"\x00\x12z\n" +
+	"\x1bGenerateAttachmentUploadURL\x12+.storage.GenerateAttachmentUploadURLRequest\x1a,.storage.GenerateAttachmentUploadURLResponse\"\x00\x12Y\n" +
+	"\x10VerifyAttachment\x12 .storage.VerifyAttachmentRequest\x1a!.storage.VerifyAttachmentResponse\"\x00\x12\x80\x01\n" +
+	"\x1dGenerateAttachmentDownloadURL\x12-.storage.GenerateAttachmentDownloadURLRequest\x1a..storage.GenerateAttachmentDownloadURLResponse\"\x00\x12J\n" +
+	"\vCreateGroup\x12\x1b.storage.CreateGroupRequest\x1a\x1c.storage.CreateGroupResponse\"\x00\x12_\n" +
+	"\x12UpdateGroupMembers\x12\".storage.UpdateGroupMembersRequest\x1a#.storage.UpdateGroupMembersResponse\"\x00\x12J\n" +
+	"\vDeleteGroup\x12\x1b.storage.DeleteGroupRequest\x1a\x1c.storage.DeleteGroupResponse\"\x00\x12S\n" +
+	"\x0eListTaskGroups\x12\x1e.storage.ListTaskGroupsRequest\x1a\x1f.storage.ListTaskGroupsResponse\"\x00\x12G\n" +
+	"\n" +
+	"CreateTask\x12\x1a.storage.CreateTaskRequest\x1a\x1b.storage.CreateTaskResponse\"\x00\x12G\n" +
+	"\n" +
+	"UpdateTask\x12\x1a.storage.UpdateTaskRequest\x1a\x1b.storage.UpdateTaskResponse\"\x00\x12>\n" +
+	"\aGetTask\x12\x17.storage.GetTaskRequest\x1a\x18.storage.GetTaskResponse\"\x00\x12D\n" +
+	"\tListTasks\x12\x19.storage.ListTasksRequest\x1a\x1a.storage.ListTasksResponse\"\x00\x12G\n" +
+	"\n" +
+	"DeleteTask\x12\x1a.storage.DeleteTaskRequest\x1a\x1b.storage.DeleteTaskResponse\"\x00\x12M\n" +
+	"\fCreateCourse\x12\x1c.storage.CreateCourseRequest\x1a\x1d.storage.CreateCourseResponse\"\x00\x12D\n" +
+	"\tGetCourse\x12\x19.storage.GetCourseRequest\x1a\x1a.storage.GetCourseResponse\"\x00\x12_\n" +
+	"\x12ImportCourseRoster\x12\".storage.ImportCourseRosterRequest\x1a#.storage.ImportCourseRosterResponse\"\x00\x12_\n" +
+	"\x12RemoveCourseMember\x12\".storage.RemoveCourseMemberRequest\x1a#.storage.RemoveCourseMemberResponse\"\x00\x12k\n" +
+	"\x16ListMissingSubmissions\x12&.storage.ListMissingSubmissionsRequest\x1a'.storage.ListMissingSubmissionsResponse\"\x00BJZHgithub.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go;storagepbb\x06proto3"
+
+var (
+	file_storage_proto_rawDescOnce sync.Once
+	file_storage_proto_rawDescData []byte
 )
 
 func file_storage_proto_rawDescGZIP() []byte {
@@ -524,35 +3940,170 @@ func file_storage_proto_rawDescGZIP() []byte {
 	return file_storage_proto_rawDescData
 }
 
-var file_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
 var file_storage_proto_goTypes = []any{
-	(*GenerateUploadURLRequest)(nil),    // 0: storage.GenerateUploadURLRequest
-	(*GenerateUploadURLResponse)(nil),   // 1: storage.GenerateUploadURLResponse
-	(*VerifyUploadedFileRequest)(nil),   // 2: storage.VerifyUploadedFileRequest
-	(*VerifyUploadedFileResponse)(nil),  // 3: storage.VerifyUploadedFileResponse
-	(*GenerateDownloadURLRequest)(nil),  // 4: storage.GenerateDownloadURLRequest
-	(*GenerateDownloadURLResponse)(nil), // 5: storage.GenerateDownloadURLResponse
-	(*ListTaskFilesRequest)(nil),        // 6: storage.ListTaskFilesRequest
-	(*ListTaskFilesResponse)(nil),       // 7: storage.ListTaskFilesResponse
-	(*FileInfo)(nil),                    // 8: storage.FileInfo
-	(*timestamppb.Timestamp)(nil),       // 9: google.protobuf.Timestamp
+	(*GenerateUploadURLRequest)(nil),              // 0: storage.GenerateUploadURLRequest
+	(*GenerateUploadURLResponse)(nil),             // 1: storage.GenerateUploadURLResponse
+	(*VerifyUploadedFileRequest)(nil),             // 2: storage.VerifyUploadedFileRequest
+	(*VerifyUploadedFileResponse)(nil),            // 3: storage.VerifyUploadedFileResponse
+	(*UploadFileRequest)(nil),                     // 4: storage.UploadFileRequest
+	(*UploadFileMetadata)(nil),                    // 5: storage.UploadFileMetadata
+	(*UploadFileResponse)(nil),                    // 6: storage.UploadFileResponse
+	(*DuplicateFile)(nil),                         // 7: storage.DuplicateFile
+	(*GenerateDownloadURLRequest)(nil),            // 8: storage.GenerateDownloadURLRequest
+	(*GenerateDownloadURLResponse)(nil),           // 9: storage.GenerateDownloadURLResponse
+	(*ListTaskFilesRequest)(nil),                  // 10: storage.ListTaskFilesRequest
+	(*ListTaskFilesResponse)(nil),                 // 11: storage.ListTaskFilesResponse
+	(*FileInfo)(nil),                              // 12: storage.FileInfo
+	(*ListFileVersionsRequest)(nil),               // 13: storage.ListFileVersionsRequest
+	(*ListFileVersionsResponse)(nil),              // 14: storage.ListFileVersionsResponse
+	(*ValidationReport)(nil),                      // 15: storage.ValidationReport
+	(*ValidationStep)(nil),                        // 16: storage.ValidationStep
+	(*GenerateVersionDownloadURLRequest)(nil),     // 17: storage.GenerateVersionDownloadURLRequest
+	(*GenerateVersionDownloadURLResponse)(nil),    // 18: storage.GenerateVersionDownloadURLResponse
+	(*FileVersion)(nil),                           // 19: storage.FileVersion
+	(*GenerateAttachmentUploadURLRequest)(nil),    // 20: storage.GenerateAttachmentUploadURLRequest
+	(*GenerateAttachmentUploadURLResponse)(nil),   // 21: storage.GenerateAttachmentUploadURLResponse
+	(*VerifyAttachmentRequest)(nil),               // 22: storage.VerifyAttachmentRequest
+	(*VerifyAttachmentResponse)(nil),              // 23: storage.VerifyAttachmentResponse
+	(*GenerateAttachmentDownloadURLRequest)(nil),  // 24: storage.GenerateAttachmentDownloadURLRequest
+	(*GenerateAttachmentDownloadURLResponse)(nil), // 25: storage.GenerateAttachmentDownloadURLResponse
+	(*AttachmentInfo)(nil),                        // 26: storage.AttachmentInfo
+	(*CreateGroupRequest)(nil),                    // 27: storage.CreateGroupRequest
+	(*CreateGroupResponse)(nil),                   // 28: storage.CreateGroupResponse
+	(*UpdateGroupMembersRequest)(nil),             // 29: storage.UpdateGroupMembersRequest
+	(*UpdateGroupMembersResponse)(nil),            // 30: storage.UpdateGroupMembersResponse
+	(*DeleteGroupRequest)(nil),                    // 31: storage.DeleteGroupRequest
+	(*DeleteGroupResponse)(nil),                   // 32: storage.DeleteGroupResponse
+	(*ListTaskGroupsRequest)(nil),                 // 33: storage.ListTaskGroupsRequest
+	(*ListTaskGroupsResponse)(nil),                // 34: storage.ListTaskGroupsResponse
+	(*GroupInfo)(nil),                             // 35: storage.GroupInfo
+	(*CreateTaskRequest)(nil),                     // 36: storage.CreateTaskRequest
+	(*CreateTaskResponse)(nil),                    // 37: storage.CreateTaskResponse
+	(*UpdateTaskRequest)(nil),                     // 38: storage.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),                    // 39: storage.UpdateTaskResponse
+	(*GetTaskRequest)(nil),                        // 40: storage.GetTaskRequest
+	(*GetTaskResponse)(nil),                       // 41: storage.GetTaskResponse
+	(*ListTasksRequest)(nil),                      // 42: storage.ListTasksRequest
+	(*ListTasksResponse)(nil),                     // 43: storage.ListTasksResponse
+	(*DeleteTaskRequest)(nil),                     // 44: storage.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),                    // 45: storage.DeleteTaskResponse
+	(*TaskSettings)(nil),                          // 46: storage.TaskSettings
+	(*TaskInfo)(nil),                              // 47: storage.TaskInfo
+	(*CreateCourseRequest)(nil),                   // 48: storage.CreateCourseRequest
+	(*CreateCourseResponse)(nil),                  // 49: storage.CreateCourseResponse
+	(*GetCourseRequest)(nil),                      // 50: storage.GetCourseRequest
+	(*GetCourseResponse)(nil),                     // 51: storage.GetCourseResponse
+	(*ImportCourseRosterRequest)(nil),             // 52: storage.ImportCourseRosterRequest
+	(*ImportCourseRosterResponse)(nil),            // 53: storage.ImportCourseRosterResponse
+	(*RemoveCourseMemberRequest)(nil),             // 54: storage.RemoveCourseMemberRequest
+	(*RemoveCourseMemberResponse)(nil),            // 55: storage.RemoveCourseMemberResponse
+	(*ListMissingSubmissionsRequest)(nil),         // 56: storage.ListMissingSubmissionsRequest
+	(*ListMissingSubmissionsResponse)(nil),        // 57: storage.ListMissingSubmissionsResponse
+	(*CourseMember)(nil),                          // 58: storage.CourseMember
+	(*CourseInfo)(nil),                            // 59: storage.CourseInfo
+	nil,                                           // 60: storage.GenerateUploadURLResponse.FieldsEntry
+	nil,                                           // 61: storage.GenerateAttachmentUploadURLResponse.FieldsEntry
+	(*timestamppb.Timestamp)(nil),                 // 62: google.protobuf.Timestamp
 }
 var file_storage_proto_depIdxs = []int32{
-	8, // 0: storage.ListTaskFilesResponse.Items:type_name -> storage.FileInfo
-	9, // 1: storage.FileInfo.UpdatedAt:type_name -> google.protobuf.Timestamp
-	0, // 2: storage.Storage.GenerateUploadURL:input_type -> storage.GenerateUploadURLRequest
-	2, // 3: storage.Storage.VerifyUploadedFile:input_type -> storage.VerifyUploadedFileRequest
-	4, // 4: storage.Storage.GenerateDownloadURL:input_type -> storage.GenerateDownloadURLRequest
-	6, // 5: storage.Storage.ListTaskFiles:input_type -> storage.ListTaskFilesRequest
-	1, // 6: storage.Storage.GenerateUploadURL:output_type -> storage.GenerateUploadURLResponse
-	3, // 7: storage.Storage.VerifyUploadedFile:output_type -> storage.VerifyUploadedFileResponse
-	5, // 8: storage.Storage.GenerateDownloadURL:output_type -> storage.GenerateDownloadURLResponse
-	7, // 9: storage.Storage.ListTaskFiles:output_type -> storage.ListTaskFilesResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	60, // 0: storage.GenerateUploadURLResponse.Fields:type_name -> storage.GenerateUploadURLResponse.FieldsEntry
+	19, // 1: storage.VerifyUploadedFileResponse.Version:type_name -> storage.FileVersion
+	7,  // 2: storage.VerifyUploadedFileResponse.Duplicates:type_name -> storage.DuplicateFile
+	5,  // 3: storage.UploadFileRequest.Metadata:type_name -> storage.UploadFileMetadata
+	19, // 4: storage.UploadFileResponse.Version:type_name -> storage.FileVersion
+	7,  // 5: storage.UploadFileResponse.Duplicates:type_name -> storage.DuplicateFile
+	62, // 6: storage.DuplicateFile.CreatedAt:type_name -> google.protobuf.Timestamp
+	12, // 7: storage.ListTaskFilesResponse.Items:type_name -> storage.FileInfo
+	62, // 8: storage.FileInfo.UpdatedAt:type_name -> google.protobuf.Timestamp
+	19, // 9: storage.FileInfo.Files:type_name -> storage.FileVersion
+	15, // 10: storage.FileInfo.Validation:type_name -> storage.ValidationReport
+	19, // 11: storage.ListFileVersionsResponse.Versions:type_name -> storage.FileVersion
+	15, // 12: storage.ListFileVersionsResponse.Validation:type_name -> storage.ValidationReport
+	16, // 13: storage.ValidationReport.Steps:type_name -> storage.ValidationStep
+	62, // 14: storage.ValidationReport.CheckedAt:type_name -> google.protobuf.Timestamp
+	19, // 15: storage.GenerateVersionDownloadURLResponse.Version:type_name -> storage.FileVersion
+	62, // 16: storage.FileVersion.CreatedAt:type_name -> google.protobuf.Timestamp
+	7,  // 17: storage.FileVersion.Duplicates:type_name -> storage.DuplicateFile
+	61, // 18: storage.GenerateAttachmentUploadURLResponse.Fields:type_name -> storage.GenerateAttachmentUploadURLResponse.FieldsEntry
+	26, // 19: storage.VerifyAttachmentResponse.Attachment:type_name -> storage.AttachmentInfo
+	26, // 20: storage.GenerateAttachmentDownloadURLResponse.Attachment:type_name -> storage.AttachmentInfo
+	62, // 21: storage.AttachmentInfo.UpdatedAt:type_name -> google.protobuf.Timestamp
+	35, // 22: storage.CreateGroupResponse.Group:type_name -> storage.GroupInfo
+	35, // 23: storage.UpdateGroupMembersResponse.Group:type_name -> storage.GroupInfo
+	35, // 24: storage.ListTaskGroupsResponse.Groups:type_name -> storage.GroupInfo
+	62, // 25: storage.GroupInfo.CreatedAt:type_name -> google.protobuf.Timestamp
+	46, // 26: storage.CreateTaskRequest.Settings:type_name -> storage.TaskSettings
+	47, // 27: storage.CreateTaskResponse.Task:type_name -> storage.TaskInfo
+	46, // 28: storage.UpdateTaskRequest.Settings:type_name -> storage.TaskSettings
+	47, // 29: storage.UpdateTaskResponse.Task:type_name -> storage.TaskInfo
+	47, // 30: storage.GetTaskResponse.Task:type_name -> storage.TaskInfo
+	47, // 31: storage.ListTasksResponse.Tasks:type_name -> storage.TaskInfo
+	62, // 32: storage.TaskSettings.OpensAt:type_name -> google.protobuf.Timestamp
+	62, // 33: storage.TaskSettings.Deadline:type_name -> google.protobuf.Timestamp
+	46, // 34: storage.TaskInfo.Settings:type_name -> storage.TaskSettings
+	62, // 35: storage.TaskInfo.CreatedAt:type_name -> google.protobuf.Timestamp
+	62, // 36: storage.TaskInfo.UpdatedAt:type_name -> google.protobuf.Timestamp
+	58, // 37: storage.CreateCourseRequest.Members:type_name -> storage.CourseMember
+	59, // 38: storage.CreateCourseResponse.Course:type_name -> storage.CourseInfo
+	59, // 39: storage.GetCourseResponse.Course:type_name -> storage.CourseInfo
+	58, // 40: storage.ImportCourseRosterRequest.Members:type_name -> storage.CourseMember
+	59, // 41: storage.ImportCourseRosterResponse.Course:type_name -> storage.CourseInfo
+	58, // 42: storage.CourseInfo.Members:type_name -> storage.CourseMember
+	62, // 43: storage.CourseInfo.CreatedAt:type_name -> google.protobuf.Timestamp
+	0,  // 44: storage.Storage.GenerateUploadURL:input_type -> storage.GenerateUploadURLRequest
+	2,  // 45: storage.Storage.VerifyUploadedFile:input_type -> storage.VerifyUploadedFileRequest
+	4,  // 46: storage.Storage.UploadFile:input_type -> storage.UploadFileRequest
+	8,  // 47: storage.Storage.GenerateDownloadURL:input_type -> storage.GenerateDownloadURLRequest
+	10, // 48: storage.Storage.ListTaskFiles:input_type -> storage.ListTaskFilesRequest
+	13, // 49: storage.Storage.ListFileVersions:input_type -> storage.ListFileVersionsRequest
+	17, // 50: storage.Storage.GenerateVersionDownloadURL:input_type -> storage.GenerateVersionDownloadURLRequest
+	20, // 51: storage.Storage.GenerateAttachmentUploadURL:input_type -> storage.GenerateAttachmentUploadURLRequest
+	22, // 52: storage.Storage.VerifyAttachment:input_type -> storage.VerifyAttachmentRequest
+	24, // 53: storage.Storage.GenerateAttachmentDownloadURL:input_type -> storage.GenerateAttachmentDownloadURLRequest
+	27, // 54: storage.Storage.CreateGroup:input_type -> storage.CreateGroupRequest
+	29, // 55: storage.Storage.UpdateGroupMembers:input_type -> storage.UpdateGroupMembersRequest
+	31, // 56: storage.Storage.DeleteGroup:input_type -> storage.DeleteGroupRequest
+	33, // 57: storage.Storage.ListTaskGroups:input_type -> storage.ListTaskGroupsRequest
+	36, // 58: storage.Storage.CreateTask:input_type -> storage.CreateTaskRequest
+	38, // 59: storage.Storage.UpdateTask:input_type -> storage.UpdateTaskRequest
+	40, // 60: storage.Storage.GetTask:input_type -> storage.GetTaskRequest
+	42, // 61: storage.Storage.ListTasks:input_type -> storage.ListTasksRequest
+	44, // 62: storage.Storage.DeleteTask:input_type -> storage.DeleteTaskRequest
+	48, // 63: storage.Storage.CreateCourse:input_type -> storage.CreateCourseRequest
+	50, // 64: storage.Storage.GetCourse:input_type -> storage.GetCourseRequest
+	52, // 65: storage.Storage.ImportCourseRoster:input_type -> storage.ImportCourseRosterRequest
+	54, // 66: storage.Storage.RemoveCourseMember:input_type -> storage.RemoveCourseMemberRequest
+	56, // 67: storage.Storage.ListMissingSubmissions:input_type -> storage.ListMissingSubmissionsRequest
+	1,  // 68: storage.Storage.GenerateUploadURL:output_type -> storage.GenerateUploadURLResponse
+	3,  // 69: storage.Storage.VerifyUploadedFile:output_type -> storage.VerifyUploadedFileResponse
+	6,  // 70: storage.Storage.UploadFile:output_type -> storage.UploadFileResponse
+	9,  // 71: storage.Storage.GenerateDownloadURL:output_type -> storage.GenerateDownloadURLResponse
+	11, // 72: storage.Storage.ListTaskFiles:output_type -> storage.ListTaskFilesResponse
+	14, // 73: storage.Storage.ListFileVersions:output_type -> storage.ListFileVersionsResponse
+	18, // 74: storage.Storage.GenerateVersionDownloadURL:output_type -> storage.GenerateVersionDownloadURLResponse
+	21, // 75: storage.Storage.GenerateAttachmentUploadURL:output_type -> storage.GenerateAttachmentUploadURLResponse
+	23, // 76: storage.Storage.VerifyAttachment:output_type -> storage.VerifyAttachmentResponse
+	25, // 77: storage.Storage.GenerateAttachmentDownloadURL:output_type -> storage.GenerateAttachmentDownloadURLResponse
+	28, // 78: storage.Storage.CreateGroup:output_type -> storage.CreateGroupResponse
+	30, // 79: storage.Storage.UpdateGroupMembers:output_type -> storage.UpdateGroupMembersResponse
+	32, // 80: storage.Storage.DeleteGroup:output_type -> storage.DeleteGroupResponse
+	34, // 81: storage.Storage.ListTaskGroups:output_type -> storage.ListTaskGroupsResponse
+	37, // 82: storage.Storage.CreateTask:output_type -> storage.CreateTaskResponse
+	39, // 83: storage.Storage.UpdateTask:output_type -> storage.UpdateTaskResponse
+	41, // 84: storage.Storage.GetTask:output_type -> storage.GetTaskResponse
+	43, // 85: storage.Storage.ListTasks:output_type -> storage.ListTasksResponse
+	45, // 86: storage.Storage.DeleteTask:output_type -> storage.DeleteTaskResponse
+	49, // 87: storage.Storage.CreateCourse:output_type -> storage.CreateCourseResponse
+	51, // 88: storage.Storage.GetCourse:output_type -> storage.GetCourseResponse
+	53, // 89: storage.Storage.ImportCourseRoster:output_type -> storage.ImportCourseRosterResponse
+	55, // 90: storage.Storage.RemoveCourseMember:output_type -> storage.RemoveCourseMemberResponse
+	57, // 91: storage.Storage.ListMissingSubmissions:output_type -> storage.ListMissingSubmissionsResponse
+	68, // [68:92] is the sub-list for method output_type
+	44, // [44:68] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_storage_proto_init() }
@@ -560,13 +4111,17 @@ func file_storage_proto_init() {
 	if File_storage_proto != nil {
 		return
 	}
+	file_storage_proto_msgTypes[4].OneofWrappers = []any{
+		(*UploadFileRequest_Metadata)(nil),
+		(*UploadFileRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_storage_proto_rawDesc), len(file_storage_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   62,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
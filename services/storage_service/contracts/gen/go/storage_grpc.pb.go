@@ -19,10 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Storage_GenerateUploadURL_FullMethodName   = "/storage.Storage/GenerateUploadURL"
-	Storage_VerifyUploadedFile_FullMethodName  = "/storage.Storage/VerifyUploadedFile"
-	Storage_GenerateDownloadURL_FullMethodName = "/storage.Storage/GenerateDownloadURL"
-	Storage_ListTaskFiles_FullMethodName       = "/storage.Storage/ListTaskFiles"
+	Storage_GenerateUploadURL_FullMethodName             = "/storage.Storage/GenerateUploadURL"
+	Storage_VerifyUploadedFile_FullMethodName            = "/storage.Storage/VerifyUploadedFile"
+	Storage_UploadFile_FullMethodName                    = "/storage.Storage/UploadFile"
+	Storage_GenerateDownloadURL_FullMethodName           = "/storage.Storage/GenerateDownloadURL"
+	Storage_ListTaskFiles_FullMethodName                 = "/storage.Storage/ListTaskFiles"
+	Storage_ListFileVersions_FullMethodName              = "/storage.Storage/ListFileVersions"
+	Storage_GenerateVersionDownloadURL_FullMethodName    = "/storage.Storage/GenerateVersionDownloadURL"
+	Storage_GenerateAttachmentUploadURL_FullMethodName   = "/storage.Storage/GenerateAttachmentUploadURL"
+	Storage_VerifyAttachment_FullMethodName              = "/storage.Storage/VerifyAttachment"
+	Storage_GenerateAttachmentDownloadURL_FullMethodName = "/storage.Storage/GenerateAttachmentDownloadURL"
+	Storage_CreateGroup_FullMethodName                   = "/storage.Storage/CreateGroup"
+	Storage_UpdateGroupMembers_FullMethodName            = "/storage.Storage/UpdateGroupMembers"
+	Storage_DeleteGroup_FullMethodName                   = "/storage.Storage/DeleteGroup"
+	Storage_ListTaskGroups_FullMethodName                = "/storage.Storage/ListTaskGroups"
+	Storage_CreateTask_FullMethodName                    = "/storage.Storage/CreateTask"
+	Storage_UpdateTask_FullMethodName                    = "/storage.Storage/UpdateTask"
+	Storage_GetTask_FullMethodName                       = "/storage.Storage/GetTask"
+	Storage_ListTasks_FullMethodName                     = "/storage.Storage/ListTasks"
+	Storage_DeleteTask_FullMethodName                    = "/storage.Storage/DeleteTask"
+	Storage_CreateCourse_FullMethodName                  = "/storage.Storage/CreateCourse"
+	Storage_GetCourse_FullMethodName                     = "/storage.Storage/GetCourse"
+	Storage_ImportCourseRoster_FullMethodName            = "/storage.Storage/ImportCourseRoster"
+	Storage_RemoveCourseMember_FullMethodName            = "/storage.Storage/RemoveCourseMember"
+	Storage_ListMissingSubmissions_FullMethodName        = "/storage.Storage/ListMissingSubmissions"
 )
 
 // StorageClient is the client API for Storage service.
@@ -35,10 +55,50 @@ type StorageClient interface {
 	GenerateUploadURL(ctx context.Context, in *GenerateUploadURLRequest, opts ...grpc.CallOption) (*GenerateUploadURLResponse, error)
 	// Verify uploaded file RPC
 	VerifyUploadedFile(ctx context.Context, in *VerifyUploadedFileRequest, opts ...grpc.CallOption) (*VerifyUploadedFileResponse, error)
+	// Upload file through the service and verify it in one call RPC
+	UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse], error)
 	// Get download url RPC
 	GenerateDownloadURL(ctx context.Context, in *GenerateDownloadURLRequest, opts ...grpc.CallOption) (*GenerateDownloadURLResponse, error)
 	// Get list of info about files by task id
 	ListTaskFiles(ctx context.Context, in *ListTaskFilesRequest, opts ...grpc.CallOption) (*ListTaskFilesResponse, error)
+	// Get all verified versions of files of a student's submission, oldest first
+	ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*ListFileVersionsResponse, error)
+	// Get download url of a specific version of a file of a submission
+	GenerateVersionDownloadURL(ctx context.Context, in *GenerateVersionDownloadURLRequest, opts ...grpc.CallOption) (*GenerateVersionDownloadURLResponse, error)
+	// Register an auxiliary file (e.g. an appeal attachment) and get url to upload it
+	GenerateAttachmentUploadURL(ctx context.Context, in *GenerateAttachmentUploadURLRequest, opts ...grpc.CallOption) (*GenerateAttachmentUploadURLResponse, error)
+	// Verify uploaded attachment RPC
+	VerifyAttachment(ctx context.Context, in *VerifyAttachmentRequest, opts ...grpc.CallOption) (*VerifyAttachmentResponse, error)
+	// Get download url of an uploaded attachment
+	GenerateAttachmentDownloadURL(ctx context.Context, in *GenerateAttachmentDownloadURLRequest, opts ...grpc.CallOption) (*GenerateAttachmentDownloadURLResponse, error)
+	// Create a group of students submitting one work for a task
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	// Replace members of a group
+	UpdateGroupMembers(ctx context.Context, in *UpdateGroupMembersRequest, opts ...grpc.CallOption) (*UpdateGroupMembersResponse, error)
+	// Delete a group that has no submission
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	// Get list of groups by task id
+	ListTaskGroups(ctx context.Context, in *ListTaskGroupsRequest, opts ...grpc.CallOption) (*ListTaskGroupsResponse, error)
+	// Create a task with submission window and file restrictions
+	CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*CreateTaskResponse, error)
+	// Replace settings of a task
+	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error)
+	// Get a task by id
+	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error)
+	// Get list of tasks, optionally of one course
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	// Delete settings of a task; its submissions are kept
+	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error)
+	// Create a course, optionally with its roster
+	CreateCourse(ctx context.Context, in *CreateCourseRequest, opts ...grpc.CallOption) (*CreateCourseResponse, error)
+	// Get a course with its members
+	GetCourse(ctx context.Context, in *GetCourseRequest, opts ...grpc.CallOption) (*GetCourseResponse, error)
+	// Add members to a course or replace its roster
+	ImportCourseRoster(ctx context.Context, in *ImportCourseRosterRequest, opts ...grpc.CallOption) (*ImportCourseRosterResponse, error)
+	// Remove a member from a course
+	RemoveCourseMember(ctx context.Context, in *RemoveCourseMemberRequest, opts ...grpc.CallOption) (*RemoveCourseMemberResponse, error)
+	// Get enrolled students of the task's course who have not submitted anything
+	ListMissingSubmissions(ctx context.Context, in *ListMissingSubmissionsRequest, opts ...grpc.CallOption) (*ListMissingSubmissionsResponse, error)
 }
 
 type storageClient struct {
@@ -69,6 +129,19 @@ func (c *storageClient) VerifyUploadedFile(ctx context.Context, in *VerifyUpload
 	return out, nil
 }
 
+func (c *storageClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Storage_ServiceDesc.Streams[0], Storage_UploadFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadFileRequest, UploadFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Storage_UploadFileClient = grpc.ClientStreamingClient[UploadFileRequest, UploadFileResponse]
+
 func (c *storageClient) GenerateDownloadURL(ctx context.Context, in *GenerateDownloadURLRequest, opts ...grpc.CallOption) (*GenerateDownloadURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GenerateDownloadURLResponse)
@@ -89,6 +162,196 @@ func (c *storageClient) ListTaskFiles(ctx context.Context, in *ListTaskFilesRequ
 	return out, nil
 }
 
+func (c *storageClient) ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*ListFileVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFileVersionsResponse)
+	err := c.cc.Invoke(ctx, Storage_ListFileVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) GenerateVersionDownloadURL(ctx context.Context, in *GenerateVersionDownloadURLRequest, opts ...grpc.CallOption) (*GenerateVersionDownloadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateVersionDownloadURLResponse)
+	err := c.cc.Invoke(ctx, Storage_GenerateVersionDownloadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) GenerateAttachmentUploadURL(ctx context.Context, in *GenerateAttachmentUploadURLRequest, opts ...grpc.CallOption) (*GenerateAttachmentUploadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateAttachmentUploadURLResponse)
+	err := c.cc.Invoke(ctx, Storage_GenerateAttachmentUploadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) VerifyAttachment(ctx context.Context, in *VerifyAttachmentRequest, opts ...grpc.CallOption) (*VerifyAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyAttachmentResponse)
+	err := c.cc.Invoke(ctx, Storage_VerifyAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) GenerateAttachmentDownloadURL(ctx context.Context, in *GenerateAttachmentDownloadURLRequest, opts ...grpc.CallOption) (*GenerateAttachmentDownloadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateAttachmentDownloadURLResponse)
+	err := c.cc.Invoke(ctx, Storage_GenerateAttachmentDownloadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, Storage_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) UpdateGroupMembers(ctx context.Context, in *UpdateGroupMembersRequest, opts ...grpc.CallOption) (*UpdateGroupMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateGroupMembersResponse)
+	err := c.cc.Invoke(ctx, Storage_UpdateGroupMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteGroupResponse)
+	err := c.cc.Invoke(ctx, Storage_DeleteGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) ListTaskGroups(ctx context.Context, in *ListTaskGroupsRequest, opts ...grpc.CallOption) (*ListTaskGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskGroupsResponse)
+	err := c.cc.Invoke(ctx, Storage_ListTaskGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*CreateTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTaskResponse)
+	err := c.cc.Invoke(ctx, Storage_CreateTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTaskResponse)
+	err := c.cc.Invoke(ctx, Storage_UpdateTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskResponse)
+	err := c.cc.Invoke(ctx, Storage_GetTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, Storage_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTaskResponse)
+	err := c.cc.Invoke(ctx, Storage_DeleteTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) CreateCourse(ctx context.Context, in *CreateCourseRequest, opts ...grpc.CallOption) (*CreateCourseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCourseResponse)
+	err := c.cc.Invoke(ctx, Storage_CreateCourse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) GetCourse(ctx context.Context, in *GetCourseRequest, opts ...grpc.CallOption) (*GetCourseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCourseResponse)
+	err := c.cc.Invoke(ctx, Storage_GetCourse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) ImportCourseRoster(ctx context.Context, in *ImportCourseRosterRequest, opts ...grpc.CallOption) (*ImportCourseRosterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportCourseRosterResponse)
+	err := c.cc.Invoke(ctx, Storage_ImportCourseRoster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) RemoveCourseMember(ctx context.Context, in *RemoveCourseMemberRequest, opts ...grpc.CallOption) (*RemoveCourseMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveCourseMemberResponse)
+	err := c.cc.Invoke(ctx, Storage_RemoveCourseMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) ListMissingSubmissions(ctx context.Context, in *ListMissingSubmissionsRequest, opts ...grpc.CallOption) (*ListMissingSubmissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMissingSubmissionsResponse)
+	err := c.cc.Invoke(ctx, Storage_ListMissingSubmissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageServer is the server API for Storage service.
 // All implementations must embed UnimplementedStorageServer
 // for forward compatibility.
@@ -99,10 +362,50 @@ type StorageServer interface {
 	GenerateUploadURL(context.Context, *GenerateUploadURLRequest) (*GenerateUploadURLResponse, error)
 	// Verify uploaded file RPC
 	VerifyUploadedFile(context.Context, *VerifyUploadedFileRequest) (*VerifyUploadedFileResponse, error)
+	// Upload file through the service and verify it in one call RPC
+	UploadFile(grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]) error
 	// Get download url RPC
 	GenerateDownloadURL(context.Context, *GenerateDownloadURLRequest) (*GenerateDownloadURLResponse, error)
 	// Get list of info about files by task id
 	ListTaskFiles(context.Context, *ListTaskFilesRequest) (*ListTaskFilesResponse, error)
+	// Get all verified versions of files of a student's submission, oldest first
+	ListFileVersions(context.Context, *ListFileVersionsRequest) (*ListFileVersionsResponse, error)
+	// Get download url of a specific version of a file of a submission
+	GenerateVersionDownloadURL(context.Context, *GenerateVersionDownloadURLRequest) (*GenerateVersionDownloadURLResponse, error)
+	// Register an auxiliary file (e.g. an appeal attachment) and get url to upload it
+	GenerateAttachmentUploadURL(context.Context, *GenerateAttachmentUploadURLRequest) (*GenerateAttachmentUploadURLResponse, error)
+	// Verify uploaded attachment RPC
+	VerifyAttachment(context.Context, *VerifyAttachmentRequest) (*VerifyAttachmentResponse, error)
+	// Get download url of an uploaded attachment
+	GenerateAttachmentDownloadURL(context.Context, *GenerateAttachmentDownloadURLRequest) (*GenerateAttachmentDownloadURLResponse, error)
+	// Create a group of students submitting one work for a task
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	// Replace members of a group
+	UpdateGroupMembers(context.Context, *UpdateGroupMembersRequest) (*UpdateGroupMembersResponse, error)
+	// Delete a group that has no submission
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	// Get list of groups by task id
+	ListTaskGroups(context.Context, *ListTaskGroupsRequest) (*ListTaskGroupsResponse, error)
+	// Create a task with submission window and file restrictions
+	CreateTask(context.Context, *CreateTaskRequest) (*CreateTaskResponse, error)
+	// Replace settings of a task
+	UpdateTask(context.Context, *UpdateTaskRequest) (*UpdateTaskResponse, error)
+	// Get a task by id
+	GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
+	// Get list of tasks, optionally of one course
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	// Delete settings of a task; its submissions are kept
+	DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error)
+	// Create a course, optionally with its roster
+	CreateCourse(context.Context, *CreateCourseRequest) (*CreateCourseResponse, error)
+	// Get a course with its members
+	GetCourse(context.Context, *GetCourseRequest) (*GetCourseResponse, error)
+	// Add members to a course or replace its roster
+	ImportCourseRoster(context.Context, *ImportCourseRosterRequest) (*ImportCourseRosterResponse, error)
+	// Remove a member from a course
+	RemoveCourseMember(context.Context, *RemoveCourseMemberRequest) (*RemoveCourseMemberResponse, error)
+	// Get enrolled students of the task's course who have not submitted anything
+	ListMissingSubmissions(context.Context, *ListMissingSubmissionsRequest) (*ListMissingSubmissionsResponse, error)
 	mustEmbedUnimplementedStorageServer()
 }
 
@@ -119,12 +422,72 @@ func (UnimplementedStorageServer) GenerateUploadURL(context.Context, *GenerateUp
 func (UnimplementedStorageServer) VerifyUploadedFile(context.Context, *VerifyUploadedFileRequest) (*VerifyUploadedFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyUploadedFile not implemented")
 }
+func (UnimplementedStorageServer) UploadFile(grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
+}
 func (UnimplementedStorageServer) GenerateDownloadURL(context.Context, *GenerateDownloadURLRequest) (*GenerateDownloadURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateDownloadURL not implemented")
 }
 func (UnimplementedStorageServer) ListTaskFiles(context.Context, *ListTaskFilesRequest) (*ListTaskFilesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTaskFiles not implemented")
 }
+func (UnimplementedStorageServer) ListFileVersions(context.Context, *ListFileVersionsRequest) (*ListFileVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFileVersions not implemented")
+}
+func (UnimplementedStorageServer) GenerateVersionDownloadURL(context.Context, *GenerateVersionDownloadURLRequest) (*GenerateVersionDownloadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateVersionDownloadURL not implemented")
+}
+func (UnimplementedStorageServer) GenerateAttachmentUploadURL(context.Context, *GenerateAttachmentUploadURLRequest) (*GenerateAttachmentUploadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateAttachmentUploadURL not implemented")
+}
+func (UnimplementedStorageServer) VerifyAttachment(context.Context, *VerifyAttachmentRequest) (*VerifyAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAttachment not implemented")
+}
+func (UnimplementedStorageServer) GenerateAttachmentDownloadURL(context.Context, *GenerateAttachmentDownloadURLRequest) (*GenerateAttachmentDownloadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateAttachmentDownloadURL not implemented")
+}
+func (UnimplementedStorageServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedStorageServer) UpdateGroupMembers(context.Context, *UpdateGroupMembersRequest) (*UpdateGroupMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroupMembers not implemented")
+}
+func (UnimplementedStorageServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedStorageServer) ListTaskGroups(context.Context, *ListTaskGroupsRequest) (*ListTaskGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskGroups not implemented")
+}
+func (UnimplementedStorageServer) CreateTask(context.Context, *CreateTaskRequest) (*CreateTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTask not implemented")
+}
+func (UnimplementedStorageServer) UpdateTask(context.Context, *UpdateTaskRequest) (*UpdateTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
+}
+func (UnimplementedStorageServer) GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+}
+func (UnimplementedStorageServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedStorageServer) DeleteTask(context.Context, *DeleteTaskRequest) (*DeleteTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
+}
+func (UnimplementedStorageServer) CreateCourse(context.Context, *CreateCourseRequest) (*CreateCourseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCourse not implemented")
+}
+func (UnimplementedStorageServer) GetCourse(context.Context, *GetCourseRequest) (*GetCourseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCourse not implemented")
+}
+func (UnimplementedStorageServer) ImportCourseRoster(context.Context, *ImportCourseRosterRequest) (*ImportCourseRosterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportCourseRoster not implemented")
+}
+func (UnimplementedStorageServer) RemoveCourseMember(context.Context, *RemoveCourseMemberRequest) (*RemoveCourseMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCourseMember not implemented")
+}
+func (UnimplementedStorageServer) ListMissingSubmissions(context.Context, *ListMissingSubmissionsRequest) (*ListMissingSubmissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMissingSubmissions not implemented")
+}
 func (UnimplementedStorageServer) mustEmbedUnimplementedStorageServer() {}
 func (UnimplementedStorageServer) testEmbeddedByValue()                 {}
 
@@ -182,6 +545,13 @@ func _Storage_VerifyUploadedFile_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Storage_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StorageServer).UploadFile(&grpc.GenericServerStream[UploadFileRequest, UploadFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Storage_UploadFileServer = grpc.ClientStreamingServer[UploadFileRequest, UploadFileResponse]
+
 func _Storage_GenerateDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateDownloadURLRequest)
 	if err := dec(in); err != nil {
@@ -218,6 +588,348 @@ func _Storage_ListTaskFiles_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Storage_ListFileVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFileVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).ListFileVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_ListFileVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).ListFileVersions(ctx, req.(*ListFileVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_GenerateVersionDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateVersionDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).GenerateVersionDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_GenerateVersionDownloadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).GenerateVersionDownloadURL(ctx, req.(*GenerateVersionDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_GenerateAttachmentUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateAttachmentUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).GenerateAttachmentUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_GenerateAttachmentUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).GenerateAttachmentUploadURL(ctx, req.(*GenerateAttachmentUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_VerifyAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).VerifyAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_VerifyAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).VerifyAttachment(ctx, req.(*VerifyAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_GenerateAttachmentDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateAttachmentDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).GenerateAttachmentDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_GenerateAttachmentDownloadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).GenerateAttachmentDownloadURL(ctx, req.(*GenerateAttachmentDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_UpdateGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).UpdateGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_UpdateGroupMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).UpdateGroupMembers(ctx, req.(*UpdateGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_DeleteGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_ListTaskGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).ListTaskGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_ListTaskGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).ListTaskGroups(ctx, req.(*ListTaskGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_CreateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).CreateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_CreateTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).CreateTask(ctx, req.(*CreateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).UpdateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_UpdateTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).UpdateTask(ctx, req.(*UpdateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).GetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_GetTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).GetTask(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).DeleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_DeleteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).DeleteTask(ctx, req.(*DeleteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_CreateCourse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).CreateCourse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_CreateCourse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).CreateCourse(ctx, req.(*CreateCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_GetCourse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).GetCourse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_GetCourse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).GetCourse(ctx, req.(*GetCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_ImportCourseRoster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportCourseRosterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).ImportCourseRoster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_ImportCourseRoster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).ImportCourseRoster(ctx, req.(*ImportCourseRosterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_RemoveCourseMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCourseMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).RemoveCourseMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_RemoveCourseMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).RemoveCourseMember(ctx, req.(*RemoveCourseMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_ListMissingSubmissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMissingSubmissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).ListMissingSubmissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Storage_ListMissingSubmissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).ListMissingSubmissions(ctx, req.(*ListMissingSubmissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Storage_ServiceDesc is the grpc.ServiceDesc for Storage service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,7 +953,89 @@ var Storage_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTaskFiles",
 			Handler:    _Storage_ListTaskFiles_Handler,
 		},
+		{
+			MethodName: "ListFileVersions",
+			Handler:    _Storage_ListFileVersions_Handler,
+		},
+		{
+			MethodName: "GenerateVersionDownloadURL",
+			Handler:    _Storage_GenerateVersionDownloadURL_Handler,
+		},
+		{
+			MethodName: "GenerateAttachmentUploadURL",
+			Handler:    _Storage_GenerateAttachmentUploadURL_Handler,
+		},
+		{
+			MethodName: "VerifyAttachment",
+			Handler:    _Storage_VerifyAttachment_Handler,
+		},
+		{
+			MethodName: "GenerateAttachmentDownloadURL",
+			Handler:    _Storage_GenerateAttachmentDownloadURL_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _Storage_CreateGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroupMembers",
+			Handler:    _Storage_UpdateGroupMembers_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _Storage_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "ListTaskGroups",
+			Handler:    _Storage_ListTaskGroups_Handler,
+		},
+		{
+			MethodName: "CreateTask",
+			Handler:    _Storage_CreateTask_Handler,
+		},
+		{
+			MethodName: "UpdateTask",
+			Handler:    _Storage_UpdateTask_Handler,
+		},
+		{
+			MethodName: "GetTask",
+			Handler:    _Storage_GetTask_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _Storage_ListTasks_Handler,
+		},
+		{
+			MethodName: "DeleteTask",
+			Handler:    _Storage_DeleteTask_Handler,
+		},
+		{
+			MethodName: "CreateCourse",
+			Handler:    _Storage_CreateCourse_Handler,
+		},
+		{
+			MethodName: "GetCourse",
+			Handler:    _Storage_GetCourse_Handler,
+		},
+		{
+			MethodName: "ImportCourseRoster",
+			Handler:    _Storage_ImportCourseRoster_Handler,
+		},
+		{
+			MethodName: "RemoveCourseMember",
+			Handler:    _Storage_RemoveCourseMember_Handler,
+		},
+		{
+			MethodName: "ListMissingSubmissions",
+			Handler:    _Storage_ListMissingSubmissions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadFile",
+			Handler:       _Storage_UploadFile_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "storage.proto",
 }
@@ -8,8 +8,11 @@ import (
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/app/config"
 	postgresRepo "github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories/postgres"
 	s3Repo "github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories/s3"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/validators"
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/transport/grpc"
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/use_cases"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/mtls"
+	"google.golang.org/grpc/credentials"
 )
 
 type App struct {
@@ -49,11 +52,38 @@ func New(ctx context.Context, log *slog.Logger, cfg *config.Config) (*App, error
 	dbRepository := postgresRepo.NewFileRepository(dbPool)
 	s3Repository := s3Repo.NewRepo(s3Storage, log)
 
+	// Инициализация проверок загрузок
+	fileValidator, err := validators.New(cfg.Validation.Steps, validators.Options{
+		ClamAVAddr:      cfg.Validation.ClamAVAddr,
+		ClamAVTimeout:   cfg.Validation.ClamAVTimeout,
+		MaxArchiveFiles: cfg.Validation.MaxArchiveFiles,
+		MaxArchiveRatio: cfg.Validation.MaxArchiveRatio,
+		MaxUnpackedSize: cfg.Validation.MaxUnpackedSize,
+	}, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize validators: %w", err)
+	}
+	log.Info("upload validation initialized", "steps", cfg.Validation.Steps)
+
 	// Создание use case сервиса
-	fileService := use_cases.NewFileService(s3Repository, dbRepository, log)
+	fileService := use_cases.NewFileService(s3Repository, dbRepository, fileValidator, cfg.AllowUnregisteredTasks, log)
+
+	// Инициализация mTLS
+	var creds credentials.TransportCredentials
+	acl, err := mtls.ParseACL(cfg.TLS.AllowedClients)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse allowed clients: %w", err)
+	}
+	if cfg.TLS.Enabled() {
+		creds, err = mtls.ServerCredentials(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize tls: %w", err)
+		}
+		log.Info("mtls enabled")
+	}
 
 	// Инициализация gRPC сервера
-	grpcApp := grpc.New(log, cfg.GRPC.Port, fileService)
+	grpcApp := grpc.New(log, cfg.GRPC.Port, fileService, creds, acl, cfg.TLS.IdentityClients)
 
 	return &App{
 		GRPCSrv: grpcApp,
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"slices"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -11,6 +12,13 @@ type Config struct {
 	GRPC GRPCConfig     `env-prefix:"GRPC_"`
 	DB   PostgresConfig `env-prefix:"POSTGRES_"`
 	S3   S3Config       `env-prefix:"S3_"`
+	TLS  TLSConfig      `env-prefix:"TLS_"`
+
+	Validation ValidationConfig `env-prefix:"VALIDATION_"`
+
+	// AllowUnregisteredTasks разрешает загрузки в задачи, не заведённые через CreateTask, без сроков и проверки курса.
+	// Нужен только для старых сдач со свободными id задач.
+	AllowUnregisteredTasks bool `env:"ALLOW_UNREGISTERED_TASKS" env-default:"false"`
 }
 
 type PostgresConfig struct {
@@ -35,6 +43,34 @@ type S3Config struct {
 	ExpirationTime int32  `env:"EXPIRATION_TIME" env-default:"5"`
 }
 
+// TLSConfig - mTLS gRPC-сервера: сертификат сервиса, его ключ и CA, которым подписаны сертификаты клиентов.
+// Пустые пути - соединения без TLS. AllowedClients ограничивает вызовы по имени клиента из сертификата:
+// "api-gateway=*;plagiarism-service=ListTaskFiles,GenerateDownloadURL"; пусто - любому клиенту с верным сертификатом всё.
+type TLSConfig struct {
+	CertFile       string `env:"CERT_FILE"`
+	KeyFile        string `env:"KEY_FILE"`
+	CAFile         string `env:"CA_FILE"`
+	AllowedClients string `env:"ALLOWED_CLIENTS"`
+	// IdentityClients - клиенты, которым сервис верит пользователя из metadata вызова (пакет identity)
+	IdentityClients []string `env:"IDENTITY_CLIENTS" env-default:"api-gateway" env-separator:","`
+}
+
+// ValidationConfig - шаги проверки загруженных файлов по порядку: size, mime, archive, encrypted, clamav.
+// Шаг size выполняется всегда.
+// Шаг clamav отправляет файл на сканирование в clamd по ClamAVAddr ("host:3310" или "unix:/путь/к/сокету").
+type ValidationConfig struct {
+	Steps           []string      `env:"STEPS" env-default:"size,mime,archive,encrypted" env-separator:","`
+	ClamAVAddr      string        `env:"CLAMAV_ADDR"`
+	ClamAVTimeout   time.Duration `env:"CLAMAV_TIMEOUT" env-default:"60s"`
+	MaxArchiveFiles int           `env:"MAX_ARCHIVE_FILES" env-default:"10000"`
+	MaxArchiveRatio int64         `env:"MAX_ARCHIVE_RATIO" env-default:"100"`
+	MaxUnpackedSize int64         `env:"MAX_UNPACKED_SIZE" env-default:"1000000000"`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
 func MustLoad() *Config {
 	var cfg Config
 
@@ -42,5 +78,18 @@ func MustLoad() *Config {
 		panic("failed to read config: " + err.Error())
 	}
 
+	if cfg.TLS.Enabled() && (cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "" || cfg.TLS.CAFile == "") {
+		panic("TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE must be set together")
+	}
+
+	// без mTLS имя клиента неизвестно, и список разрешённых клиентов не применялся бы
+	if cfg.TLS.AllowedClients != "" && !cfg.TLS.Enabled() {
+		panic("TLS_ALLOWED_CLIENTS requires TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE")
+	}
+
+	if slices.Contains(cfg.Validation.Steps, "clamav") && cfg.Validation.ClamAVAddr == "" {
+		panic("VALIDATION_CLAMAV_ADDR must be set to use the clamav step")
+	}
+
 	return &cfg
 }
@@ -1,6 +1,12 @@
 package domain
 
 import (
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,9 +17,43 @@ type FileInfo struct {
 
 	StudentID string `json:"student_id" db:"student_id"`
 	TaskID    string `json:"task_id" db:"task_id"`
+	// GroupID - группа, чья это сдача; пустой у личной сдачи. У сдачи группы StudentID - загрузивший её участник
+	GroupID string `json:"group_id" db:"group_id"`
 
 	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
 	Status    FileStatus `json:"status" db:"status"`
+
+	// LatestVersion - номер последней подтверждённой версии основного файла, 0 если версий нет
+	LatestVersion int `json:"latest_version" db:"-"`
+	// Size и SHA256 - размер и хэш последней версии основного файла
+	Size   int64  `json:"size" db:"-"`
+	SHA256 string `json:"sha256" db:"-"`
+	// ExactDuplicate - такое же содержимое сдавал другой студент (в этой или другой задаче)
+	ExactDuplicate bool `json:"exact_duplicate" db:"-"`
+	// OriginalName, ContentType, MimeType и ETag - метаданные последней версии основного файла (см. FileVersion)
+	OriginalName string `json:"original_name" db:"-"`
+	ContentType  string `json:"content_type" db:"-"`
+	MimeType     string `json:"mime_type" db:"-"`
+	ETag         string `json:"etag" db:"-"`
+
+	// Validation - результат проверки последней загрузки любого файла сдачи, nil - загрузки ещё не проверялись
+	Validation *ValidationReport `json:"validation" db:"validation"`
+}
+
+// VersionKey - ключ объекта версии файла сдачи в бакете; name пустое у основного файла.
+func (f *FileInfo) VersionKey(name string, version int) string {
+	if name == "" {
+		return fmt.Sprintf("%s/%s/v%d", f.TaskID, f.ID.String(), version)
+	}
+	return fmt.Sprintf("%s/%s/%s/v%d", f.TaskID, f.ID.String(), name, version)
+}
+
+// AuthorID - автор сдачи: группа или студент.
+func (f *FileInfo) AuthorID() string {
+	if f.GroupID != "" {
+		return f.GroupID
+	}
+	return f.StudentID
 }
 
 type FileStatus string
@@ -21,6 +61,8 @@ type FileStatus string
 const (
 	FileStatusUploading FileStatus = "uploading"
 	FileStatusUploaded  FileStatus = "uploaded"
+	// FileStatusRejected - единственная загрузка сдачи не прошла проверку; после принятой версии статус не меняется
+	FileStatusRejected FileStatus = "rejected"
 )
 
 func NewFileInfo(id uuid.UUID, studentId, taskId string, updatedAt time.Time, status FileStatus) *FileInfo {
@@ -32,3 +74,263 @@ func NewFileInfo(id uuid.UUID, studentId, taskId string, updatedAt time.Time, st
 		Status:    status,
 	}
 }
+
+// FileVersion - подтверждённая загрузка файла сдачи. Версии не перезаписываются.
+type FileVersion struct {
+	FileID uuid.UUID `json:"file_id" db:"file_id"`
+	// Name - имя файла в сдаче, пустое у основного файла; версии нумеруются отдельно для каждого имени
+	Name    string `json:"name" db:"name"`
+	Version int    `json:"version" db:"version"`
+
+	ObjectKey  string `json:"object_key" db:"object_key"`
+	Size       int64  `json:"size" db:"size"`
+	SHA256     string `json:"sha256" db:"sha256"`
+	UploadedBy string `json:"uploaded_by" db:"uploaded_by"`
+
+	// OriginalName и ContentType - исходное имя и тип файла, заявленные при запросе ссылки на загрузку;
+	// MimeType - тип, определённый по содержимому, ETag - ETag объекта в бакете
+	OriginalName string `json:"original_name" db:"original_name"`
+	ContentType  string `json:"content_type" db:"content_type"`
+	MimeType     string `json:"mime_type" db:"mime_type"`
+	ETag         string `json:"etag" db:"etag"`
+
+	// Late - версия загружена после дедлайна задачи с политикой LatePolicyMarkLate
+	Late bool `json:"late" db:"late"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// ExactDuplicate - такое же содержимое сдавал другой студент; заполняется только для последних версий
+	ExactDuplicate bool `json:"exact_duplicate" db:"-"`
+}
+
+// DownloadName - имя, под которым версию отдают при скачивании: исходное имя файла, иначе его имя в сдаче.
+// Пустое у основного файла без исходного имени.
+func (v *FileVersion) DownloadName() string {
+	if v.OriginalName != "" {
+		return v.OriginalName
+	}
+	return v.Name
+}
+
+// PendingUpload - заявленные имя и тип загрузки, ссылка на которую выдана, но ещё не проверена.
+type PendingUpload struct {
+	FileID uuid.UUID `json:"file_id" db:"file_id"`
+	Name   string    `json:"name" db:"name"`
+
+	OriginalName string `json:"original_name" db:"original_name"`
+	ContentType  string `json:"content_type" db:"content_type"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ObjectDigest - сведения о загруженном объекте, полученные при его чтении.
+type ObjectDigest struct {
+	Size     int64
+	SHA256   string
+	MimeType string
+	ETag     string
+}
+
+// Duplicate - чужая сдача, одна из версий которой совпадает с проверяемым файлом байт в байт.
+type Duplicate struct {
+	// AuthorID - студент или группа
+	AuthorID string `json:"author_id" db:"author_id"`
+	TaskID   string `json:"task_id" db:"task_id"`
+	FileName string `json:"file_name" db:"file_name"`
+	Version  int    `json:"version" db:"version"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Task - задача со сроками сдачи и ограничениями на файлы.
+type Task struct {
+	ID       string `json:"id" db:"id"`
+	Title    string `json:"title" db:"title"`
+	CourseID string `json:"course_id" db:"course_id"`
+
+	// OpensAt и Deadline - границы приёма сдач, nil - без ограничения
+	OpensAt    *time.Time `json:"opens_at" db:"opens_at"`
+	Deadline   *time.Time `json:"deadline" db:"deadline"`
+	LatePolicy LatePolicy `json:"late_policy" db:"late_policy"`
+	// AllowedTypes - допустимые расширения файлов в нижнем регистре без точки; пустой - любые
+	AllowedTypes []string `json:"allowed_types" db:"allowed_types"`
+	// MaxFileSize - предельный размер файла в байтах; 0 - DefaultMaxFileSize
+	MaxFileSize int64 `json:"max_file_size" db:"max_file_size"`
+	// AllowedMimeTypes - допустимые типы файлов по содержимому ("application/pdf") или их группы ("text/"); пустой - любые
+	AllowedMimeTypes []string `json:"allowed_mime_types" db:"allowed_mime_types"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultMaxFileSize - предельный размер загружаемого файла, если задача не задаёт свой.
+const DefaultMaxFileSize int64 = 500_000_000
+
+// LatePolicy - что делать с загрузкой после дедлайна.
+type LatePolicy string
+
+const (
+	LatePolicyReject   LatePolicy = "reject"
+	LatePolicyMarkLate LatePolicy = "mark_late"
+)
+
+// AllowsFile проверяет расширение файла по списку допустимых типов задачи.
+func (t *Task) AllowsFile(name string) bool {
+	if len(t.AllowedTypes) == 0 {
+		return true
+	}
+
+	ext := strings.TrimPrefix(strings.ToLower(path.Ext(name)), ".")
+	return ext != "" && slices.Contains(t.AllowedTypes, ext)
+}
+
+// FileSizeLimit возвращает предельный размер файла задачи. Без задачи действует DefaultMaxFileSize.
+func (t *Task) FileSizeLimit() int64 {
+	if t == nil || t.MaxFileSize <= 0 {
+		return DefaultMaxFileSize
+	}
+	return t.MaxFileSize
+}
+
+// zipDocumentPrefixes - начала типов документов OOXML и ODF: по содержимому такие документы - zip-архивы.
+var zipDocumentPrefixes = []string{
+	"application/vnd.openxmlformats-officedocument.",
+	"application/vnd.oasis.opendocument.",
+}
+
+// AllowsMimeType проверяет тип файла (с параметрами или без) по списку допустимых типов задачи.
+// Разрешённый application/zip разрешает и документы OOXML и ODF.
+func (t *Task) AllowsMimeType(mimeType string) bool {
+	if t == nil || len(t.AllowedMimeTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range t.AllowedMimeTypes {
+		if allowed == mediaType || strings.HasSuffix(allowed, "/") && strings.HasPrefix(mediaType, allowed) {
+			return true
+		}
+		if allowed == "application/zip" && isZipDocument(mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+func isZipDocument(mediaType string) bool {
+	for _, prefix := range zipDocumentPrefixes {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadedObject - загруженный файл сдачи, который проверяется перед сохранением версии.
+type UploadedObject struct {
+	// Task - задача сдачи, nil - задача не заведена
+	Task *Task
+	// Name - исходное имя файла, а без него - имя в сдаче
+	Name        string
+	ContentType string
+	Digest      ObjectDigest
+	// Content - содержимое файла длиной Digest.Size
+	Content io.ReaderAt
+}
+
+// ValidationStep - результат одного шага проверки загрузки.
+type ValidationStep struct {
+	Validator string `json:"validator"`
+	Passed    bool   `json:"passed"`
+	// Reason - почему шаг отклонил файл, пустая у пройденного шага
+	Reason string `json:"reason,omitempty"`
+}
+
+// ValidationReport - результат проверки загрузки файла сдачи по шагам в порядке их выполнения.
+// Проверка останавливается на первом отклонившем файл шаге.
+type ValidationReport struct {
+	FileName  string           `json:"file_name"`
+	Steps     []ValidationStep `json:"steps"`
+	CheckedAt time.Time        `json:"checked_at"`
+}
+
+// RejectionReason возвращает причину отказа первого не пройденного шага; пустая - файл принят.
+func (r *ValidationReport) RejectionReason() string {
+	if r == nil {
+		return ""
+	}
+	for _, step := range r.Steps {
+		if !step.Passed {
+			return step.Validator + ": " + step.Reason
+		}
+	}
+	return ""
+}
+
+// UploadConstraints - условия, которые хранилище проверяет при загрузке по ссылке.
+type UploadConstraints struct {
+	MinSize int64
+	MaxSize int64
+	// ContentType - обязательный Content-Type загрузки; пустой - любой
+	ContentType string
+}
+
+// UploadForm - ссылка и поля формы для загрузки файла POST-запросом multipart/form-data прямо в хранилище.
+// Поля отправляются как есть, файл - последним полем file.
+type UploadForm struct {
+	URL    string
+	Fields map[string]string
+}
+
+// Course - курс со списком участников.
+type Course struct {
+	ID    string `json:"id" db:"id"`
+	Title string `json:"title" db:"title"`
+
+	Members   []CourseMember `json:"members" db:"-"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// CourseMember - студент или преподаватель курса.
+type CourseMember struct {
+	UserID string     `json:"user_id" db:"user_id"`
+	Role   CourseRole `json:"role" db:"role"`
+}
+
+type CourseRole string
+
+const (
+	CourseRoleStudent CourseRole = "student"
+	CourseRoleTeacher CourseRole = "teacher"
+)
+
+// Group - группа студентов, сдающая одну работу на задачу.
+type Group struct {
+	ID     string `json:"id" db:"id"`
+	TaskID string `json:"task_id" db:"task_id"`
+
+	MemberIDs []string  `json:"member_ids" db:"-"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Attachment - вспомогательный файл задачи, не участвующий в проверке на плагиат.
+type Attachment struct {
+	ID uuid.UUID `json:"id" db:"id"`
+
+	TaskID   string `json:"task_id" db:"task_id"`
+	OwnerID  string `json:"owner_id" db:"owner_id"`
+	FileName string `json:"file_name" db:"file_name"`
+
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	Status    FileStatus `json:"status" db:"status"`
+}
+
+// Key - ключ объекта вложения в бакете.
+func (a *Attachment) Key() string {
+	return "attachments/" + a.TaskID + "/" + a.ID.String()
+}
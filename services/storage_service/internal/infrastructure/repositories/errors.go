@@ -4,4 +4,6 @@ import "errors"
 
 var (
 	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists - нарушено ограничение уникальности
+	ErrAlreadyExists = errors.New("already exists")
 )
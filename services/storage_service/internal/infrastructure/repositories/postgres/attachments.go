@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *FileRepo) SaveAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	query := `
+        INSERT INTO attachments (id, task_id, owner_id, file_name, created_at, updated_at, status)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+
+	_, err := r.pool.Exec(ctx, query,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.OwnerID,
+		attachment.FileName,
+		attachment.CreatedAt,
+		attachment.UpdatedAt,
+		string(attachment.Status),
+	)
+
+	return err
+}
+
+func (r *FileRepo) GetAttachment(ctx context.Context, id string) (*domain.Attachment, error) {
+	query := `
+		SELECT id, task_id, owner_id, file_name, created_at, updated_at, status
+		FROM attachments
+		WHERE id = $1
+	`
+
+	var attachment domain.Attachment
+	var status string
+
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.OwnerID,
+		&attachment.FileName,
+		&attachment.CreatedAt,
+		&attachment.UpdatedAt,
+		&status,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	attachment.Status = domain.FileStatus(status)
+
+	return &attachment, nil
+}
+
+func (r *FileRepo) UpdateAttachmentStatus(ctx context.Context, id string, status domain.FileStatus) error {
+	query := `
+		UPDATE attachments
+		SET status = $2,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, string(status))
+	if err != nil {
+		return fmt.Errorf("failed to update attachment status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("attachment with id %s not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SaveCourse создаёт курс с участниками; если курс с таким id уже есть, возвращает ErrAlreadyExists.
+func (r *FileRepo) SaveCourse(ctx context.Context, course *domain.Course) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		query := `
+			INSERT INTO courses (id, title, created_at)
+			VALUES ($1, $2, $3)
+		`
+
+		if _, err := tx.Exec(ctx, query, course.ID, course.Title, course.CreatedAt); err != nil {
+			return err
+		}
+
+		return upsertCourseMembers(ctx, tx, course.ID, course.Members)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrAlreadyExists
+	}
+	return err
+}
+
+// ImportCourseMembers добавляет участников курса или меняет их роль; при replace прежний список
+// участников заменяется целиком. Если курса нет, возвращает ErrNotFound.
+func (r *FileRepo) ImportCourseMembers(ctx context.Context, courseID string, members []domain.CourseMember, replace bool) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		query := `
+			SELECT 1
+			FROM courses
+			WHERE id = $1
+			FOR UPDATE
+		`
+
+		var exists int
+		if err := tx.QueryRow(ctx, query, courseID).Scan(&exists); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repositories.ErrNotFound
+			}
+			return err
+		}
+
+		if replace {
+			query = `
+				DELETE FROM course_members
+				WHERE course_id = $1
+			`
+
+			if _, err := tx.Exec(ctx, query, courseID); err != nil {
+				return err
+			}
+		}
+
+		return upsertCourseMembers(ctx, tx, courseID, members)
+	})
+}
+
+func (r *FileRepo) RemoveCourseMember(ctx context.Context, courseID, userID string) error {
+	query := `
+		DELETE FROM course_members
+		WHERE course_id = $1 AND user_id = $2
+	`
+
+	result, err := r.pool.Exec(ctx, query, courseID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove course member: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetCourse возвращает курс с участниками, упорядоченными по роли и id.
+func (r *FileRepo) GetCourse(ctx context.Context, id string) (*domain.Course, error) {
+	query := `
+		SELECT id, title, created_at
+		FROM courses
+		WHERE id = $1
+	`
+
+	var course domain.Course
+	err := r.pool.QueryRow(ctx, query, id).Scan(&course.ID, &course.Title, &course.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	query = `
+		SELECT user_id, role
+		FROM course_members
+		WHERE course_id = $1
+		ORDER BY role, user_id
+	`
+
+	rows, err := r.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query course members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member domain.CourseMember
+		var role string
+		if err := rows.Scan(&member.UserID, &role); err != nil {
+			return nil, fmt.Errorf("failed to scan course member: %w", err)
+		}
+		member.Role = domain.CourseRole(role)
+		course.Members = append(course.Members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &course, nil
+}
+
+// GetCourseRole возвращает роль пользователя в курсе; ErrNotFound, если он не участник.
+func (r *FileRepo) GetCourseRole(ctx context.Context, courseID, userID string) (domain.CourseRole, error) {
+	query := `
+		SELECT role
+		FROM course_members
+		WHERE course_id = $1 AND user_id = $2
+	`
+
+	var role string
+	if err := r.pool.QueryRow(ctx, query, courseID, userID).Scan(&role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", repositories.ErrNotFound
+		}
+		return "", err
+	}
+
+	return domain.CourseRole(role), nil
+}
+
+func upsertCourseMembers(ctx context.Context, tx pgx.Tx, courseID string, members []domain.CourseMember) error {
+	query := `
+		INSERT INTO course_members (course_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (course_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role
+	`
+
+	for _, member := range members {
+		if _, err := tx.Exec(ctx, query, courseID, member.UserID, string(member.Role)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SaveGroup создаёт группу с участниками. Если группа уже есть или участник состоит
+// в другой группе задачи, возвращает ErrAlreadyExists.
+func (r *FileRepo) SaveGroup(ctx context.Context, group *domain.Group) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		query := `
+			INSERT INTO task_groups (id, task_id, created_at)
+			VALUES ($1, $2, $3)
+		`
+
+		if _, err := tx.Exec(ctx, query, group.ID, group.TaskID, group.CreatedAt); err != nil {
+			return err
+		}
+
+		return insertGroupMembers(ctx, tx, group)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrAlreadyExists
+	}
+	return err
+}
+
+// ReplaceGroupMembers заменяет состав группы. Если участник состоит в другой группе задачи,
+// возвращает ErrAlreadyExists.
+func (r *FileRepo) ReplaceGroupMembers(ctx context.Context, group *domain.Group) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		query := `
+			DELETE FROM task_group_members
+			WHERE task_id = $1 AND group_id = $2
+		`
+
+		if _, err := tx.Exec(ctx, query, group.TaskID, group.ID); err != nil {
+			return err
+		}
+
+		return insertGroupMembers(ctx, tx, group)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrAlreadyExists
+	}
+	return err
+}
+
+func (r *FileRepo) DeleteGroup(ctx context.Context, taskID, groupID string) error {
+	query := `
+		DELETE FROM task_groups
+		WHERE task_id = $1 AND id = $2
+	`
+
+	result, err := r.pool.Exec(ctx, query, taskID, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *FileRepo) GetGroup(ctx context.Context, taskID, groupID string) (*domain.Group, error) {
+	query := `
+		SELECT g.id, g.task_id, g.created_at, COALESCE(array_agg(m.student_id ORDER BY m.student_id) FILTER (WHERE m.student_id IS NOT NULL), '{}')
+		FROM task_groups g
+		LEFT JOIN task_group_members m ON m.task_id = g.task_id AND m.group_id = g.id
+		WHERE g.task_id = $1 AND g.id = $2
+		GROUP BY g.id, g.task_id, g.created_at
+	`
+
+	return r.scanGroup(ctx, query, taskID, groupID)
+}
+
+// GetGroupByMember возвращает группу задачи, в которой состоит студент.
+func (r *FileRepo) GetGroupByMember(ctx context.Context, taskID, studentID string) (*domain.Group, error) {
+	query := `
+		SELECT g.id, g.task_id, g.created_at, array_agg(m.student_id ORDER BY m.student_id)
+		FROM task_groups g
+		JOIN task_group_members m ON m.task_id = g.task_id AND m.group_id = g.id
+		WHERE g.task_id = $1
+		  AND g.id = (SELECT group_id FROM task_group_members WHERE task_id = $1 AND student_id = $2)
+		GROUP BY g.id, g.task_id, g.created_at
+	`
+
+	return r.scanGroup(ctx, query, taskID, studentID)
+}
+
+func (r *FileRepo) ListTaskGroups(ctx context.Context, taskID string) ([]domain.Group, error) {
+	query := `
+		SELECT g.id, g.task_id, g.created_at, COALESCE(array_agg(m.student_id ORDER BY m.student_id) FILTER (WHERE m.student_id IS NOT NULL), '{}')
+		FROM task_groups g
+		LEFT JOIN task_group_members m ON m.task_id = g.task_id AND m.group_id = g.id
+		WHERE g.task_id = $1
+		GROUP BY g.id, g.task_id, g.created_at
+		ORDER BY g.id
+	`
+
+	rows, err := r.pool.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query groups by task id: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []domain.Group
+	for rows.Next() {
+		var group domain.Group
+		if err := rows.Scan(&group.ID, &group.TaskID, &group.CreatedAt, &group.MemberIDs); err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return groups, nil
+}
+
+func (r *FileRepo) scanGroup(ctx context.Context, query string, args ...interface{}) (*domain.Group, error) {
+	var group domain.Group
+
+	err := r.pool.QueryRow(ctx, query, args...).Scan(
+		&group.ID,
+		&group.TaskID,
+		&group.CreatedAt,
+		&group.MemberIDs,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func insertGroupMembers(ctx context.Context, tx pgx.Tx, group *domain.Group) error {
+	query := `
+		INSERT INTO task_group_members (task_id, group_id, student_id)
+		VALUES ($1, $2, $3)
+	`
+
+	for _, studentID := range group.MemberIDs {
+		if _, err := tx.Exec(ctx, query, group.TaskID, group.ID, studentID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -8,9 +8,12 @@ import (
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const uniqueViolation = "23505"
+
 type FileRepo struct {
 	pool *pgxpool.Pool
 }
@@ -21,8 +24,8 @@ func NewFileRepository(pool *pgxpool.Pool) *FileRepo {
 
 func (r *FileRepo) Save(ctx context.Context, file *domain.FileInfo) error {
 	query := `
-        INSERT INTO files (id, student_id, task_id, updated_at, status)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO files (id, student_id, task_id, updated_at, status, group_id)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
     `
 
 	_, err := r.pool.Exec(ctx, query,
@@ -31,20 +34,44 @@ func (r *FileRepo) Save(ctx context.Context, file *domain.FileInfo) error {
 		file.TaskID,
 		file.UpdatedAt,
 		string(file.Status),
+		file.GroupID,
 	)
 
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrAlreadyExists
+	}
 	return err
 }
 
+// fileColumns - колонки сдачи вместе с последней версией её основного файла (из latestVersionJoin).
+// Последняя колонка - признак точного дубликата: такое же содержимое есть в любой версии чужой сдачи любой задачи.
+const fileColumns = `
+	files.id, files.student_id, files.task_id, files.updated_at, files.status, COALESCE(files.group_id, ''),
+	COALESCE(lv.version, 0), COALESCE(lv.size, 0), COALESCE(lv.sha256, ''),
+	COALESCE(lv.original_name, ''), COALESCE(lv.content_type, ''), COALESCE(lv.mime_type, ''), COALESCE(lv.etag, ''),
+	EXISTS (
+		SELECT 1
+		FROM file_versions d
+		JOIN files df ON df.id = d.file_id
+		WHERE lv.sha256 <> '' AND d.sha256 = lv.sha256
+		  AND d.file_id <> files.id AND df.student_id <> files.student_id
+	),
+	files.validation`
+
+const latestVersionJoin = `
+	LEFT JOIN LATERAL (
+		SELECT v.version, v.size, v.sha256, v.original_name, v.content_type, v.mime_type, v.etag
+		FROM file_versions v
+		WHERE v.file_id = files.id AND v.name = ''
+		ORDER BY v.version DESC
+		LIMIT 1
+	) lv ON TRUE`
+
 func (r *FileRepo) ListTaskFiles(ctx context.Context, taskID string) ([]domain.FileInfo, error) {
 	query := `
-        SELECT 
-            id,
-            student_id,
-            task_id,
-            updated_at,
-            status
-        FROM files 
+        SELECT ` + fileColumns + `
+        FROM files ` + latestVersionJoin + `
         WHERE task_id = $1
         ORDER BY updated_at DESC, student_id
     `
@@ -66,6 +93,16 @@ func (r *FileRepo) ListTaskFiles(ctx context.Context, taskID string) ([]domain.F
 			&file.TaskID,
 			&file.UpdatedAt,
 			&status,
+			&file.GroupID,
+			&file.LatestVersion,
+			&file.Size,
+			&file.SHA256,
+			&file.OriginalName,
+			&file.ContentType,
+			&file.MimeType,
+			&file.ETag,
+			&file.ExactDuplicate,
+			&file.Validation,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan file: %w", err)
@@ -84,14 +121,25 @@ func (r *FileRepo) ListTaskFiles(ctx context.Context, taskID string) ([]domain.F
 
 func (r *FileRepo) GetByStudentAndTask(ctx context.Context, studentID, taskID string) (*domain.FileInfo, error) {
 	query := `
-		SELECT *
-		FROM files 
-		WHERE student_id = $1 AND task_id = $2
+		SELECT ` + fileColumns + `
+		FROM files ` + latestVersionJoin + `
+		WHERE student_id = $1 AND task_id = $2 AND group_id IS NULL
 	`
 
 	return r.scanFile(ctx, query, studentID, taskID)
 }
 
+// GetByGroupAndTask возвращает сдачу группы.
+func (r *FileRepo) GetByGroupAndTask(ctx context.Context, groupID, taskID string) (*domain.FileInfo, error) {
+	query := `
+		SELECT ` + fileColumns + `
+		FROM files ` + latestVersionJoin + `
+		WHERE group_id = $1 AND task_id = $2
+	`
+
+	return r.scanFile(ctx, query, groupID, taskID)
+}
+
 func (r *FileRepo) DeleteFile(ctx context.Context, id string) error {
 	query := `
 		DELETE FROM files 
@@ -130,6 +178,34 @@ func (r *FileRepo) UpdateStatus(ctx context.Context, id string, status domain.Fi
 	return nil
 }
 
+// SaveValidation записывает результат проверки последней загрузки сдачи. Отклонённая загрузка
+// переводит в статус rejected только сдачу без принятых версий.
+func (r *FileRepo) SaveValidation(ctx context.Context, id string, report *domain.ValidationReport) error {
+	query := `
+		UPDATE files
+		SET validation = $2,
+		    status = CASE WHEN $3 AND status <> $4 THEN $5 ELSE status END
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		id,
+		report,
+		report.RejectionReason() != "",
+		string(domain.FileStatusUploaded),
+		string(domain.FileStatusRejected),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save validation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("file with id %s not found", id)
+	}
+
+	return nil
+}
+
 func (r *FileRepo) scanFile(ctx context.Context, query string, args ...interface{}) (*domain.FileInfo, error) {
 	var file domain.FileInfo
 	var status string
@@ -140,6 +216,16 @@ func (r *FileRepo) scanFile(ctx context.Context, query string, args ...interface
 		&file.TaskID,
 		&file.UpdatedAt,
 		&status,
+		&file.GroupID,
+		&file.LatestVersion,
+		&file.Size,
+		&file.SHA256,
+		&file.OriginalName,
+		&file.ContentType,
+		&file.MimeType,
+		&file.ETag,
+		&file.ExactDuplicate,
+		&file.Validation,
 	)
 
 	if err != nil {
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const taskColumns = `id, title, course_id, opens_at, deadline, late_policy, allowed_types, max_file_size, allowed_mime_types, created_at, updated_at`
+
+// SaveTask создаёт задачу; если задача с таким id уже есть, возвращает ErrAlreadyExists.
+func (r *FileRepo) SaveTask(ctx context.Context, task *domain.Task) error {
+	query := `
+		INSERT INTO tasks (` + taskColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		task.ID,
+		task.Title,
+		task.CourseID,
+		task.OpensAt,
+		task.Deadline,
+		string(task.LatePolicy),
+		task.AllowedTypes,
+		task.MaxFileSize,
+		task.AllowedMimeTypes,
+		task.CreatedAt,
+		task.UpdatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save task: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTask заменяет настройки задачи, кроме created_at.
+func (r *FileRepo) UpdateTask(ctx context.Context, task *domain.Task) error {
+	query := `
+		UPDATE tasks
+		SET title = $2,
+		    course_id = $3,
+		    opens_at = $4,
+		    deadline = $5,
+		    late_policy = $6,
+		    allowed_types = $7,
+		    max_file_size = $8,
+		    allowed_mime_types = $9,
+		    updated_at = $10
+		WHERE id = $1
+		RETURNING created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		task.ID,
+		task.Title,
+		task.CourseID,
+		task.OpensAt,
+		task.Deadline,
+		string(task.LatePolicy),
+		task.AllowedTypes,
+		task.MaxFileSize,
+		task.AllowedMimeTypes,
+		task.UpdatedAt,
+	).Scan(&task.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrNotFound
+		}
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return nil
+}
+
+func (r *FileRepo) GetTask(ctx context.Context, id string) (*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id = $1
+	`
+
+	task, err := scanTask(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// ListTasks возвращает задачи курса по дедлайну (задачи без дедлайна - в конце); пустой courseID - все задачи.
+func (r *FileRepo) ListTasks(ctx context.Context, courseID string) ([]domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE $1 = '' OR course_id = $1
+		ORDER BY deadline NULLS LAST, id
+	`
+
+	rows, err := r.pool.Query(ctx, query, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, *task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (r *FileRepo) DeleteTask(ctx context.Context, id string) error {
+	query := `
+		DELETE FROM tasks
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
+
+func scanTask(row pgx.Row) (*domain.Task, error) {
+	var task domain.Task
+	var latePolicy string
+
+	err := row.Scan(
+		&task.ID,
+		&task.Title,
+		&task.CourseID,
+		&task.OpensAt,
+		&task.Deadline,
+		&latePolicy,
+		&task.AllowedTypes,
+		&task.MaxFileSize,
+		&task.AllowedMimeTypes,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	task.LatePolicy = domain.LatePolicy(latePolicy)
+
+	return &task, nil
+}
@@ -0,0 +1,338 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AddFileVersion одной транзакцией сохраняет новую версию файла, удаляет заявку на её загрузку и отмечает сдачу загруженной:
+// updated_at сдачи совпадает со временем последней версии любого её файла. Если версия с таким номером уже есть
+// (параллельная проверка той же загрузки), возвращает ErrAlreadyExists.
+func (r *FileRepo) AddFileVersion(ctx context.Context, version *domain.FileVersion) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		query := `
+			INSERT INTO file_versions (
+				file_id, name, version, object_key, size, sha256, uploaded_by,
+				original_name, content_type, mime_type, etag, late, created_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		`
+
+		_, err := tx.Exec(ctx, query,
+			version.FileID,
+			version.Name,
+			version.Version,
+			version.ObjectKey,
+			version.Size,
+			version.SHA256,
+			version.UploadedBy,
+			version.OriginalName,
+			version.ContentType,
+			version.MimeType,
+			version.ETag,
+			version.Late,
+			version.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		query = `
+			DELETE FROM pending_uploads
+			WHERE file_id = $1 AND name = $2
+		`
+
+		if _, err = tx.Exec(ctx, query, version.FileID, version.Name); err != nil {
+			return err
+		}
+
+		query = `
+			UPDATE files 
+			SET status = $2, 
+			    updated_at = $3
+			WHERE id = $1
+		`
+
+		result, err := tx.Exec(ctx, query, version.FileID, string(domain.FileStatusUploaded), version.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("file with id %s not found", version.FileID)
+		}
+
+		return nil
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrAlreadyExists
+	}
+	return err
+}
+
+func (r *FileRepo) GetFileVersion(ctx context.Context, fileID, name string, version int) (*domain.FileVersion, error) {
+	query := `
+		SELECT file_id, name, version, object_key, size, sha256, uploaded_by,
+		       original_name, content_type, mime_type, etag, late, created_at
+		FROM file_versions
+		WHERE file_id = $1 AND name = $2 AND version = $3
+	`
+
+	var fileVersion domain.FileVersion
+	err := r.pool.QueryRow(ctx, query, fileID, name, version).Scan(
+		&fileVersion.FileID,
+		&fileVersion.Name,
+		&fileVersion.Version,
+		&fileVersion.ObjectKey,
+		&fileVersion.Size,
+		&fileVersion.SHA256,
+		&fileVersion.UploadedBy,
+		&fileVersion.OriginalName,
+		&fileVersion.ContentType,
+		&fileVersion.MimeType,
+		&fileVersion.ETag,
+		&fileVersion.Late,
+		&fileVersion.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &fileVersion, nil
+}
+
+// SavePendingUpload сохраняет заявленные имя и тип очередной загрузки файла, заменяя прежнюю заявку.
+func (r *FileRepo) SavePendingUpload(ctx context.Context, upload *domain.PendingUpload) error {
+	query := `
+		INSERT INTO pending_uploads (file_id, name, original_name, content_type, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (file_id, name) DO UPDATE
+		SET original_name = EXCLUDED.original_name,
+		    content_type = EXCLUDED.content_type,
+		    created_at = EXCLUDED.created_at
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		upload.FileID,
+		upload.Name,
+		upload.OriginalName,
+		upload.ContentType,
+		upload.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save pending upload: %w", err)
+	}
+
+	return nil
+}
+
+func (r *FileRepo) GetPendingUpload(ctx context.Context, fileID, name string) (*domain.PendingUpload, error) {
+	query := `
+		SELECT file_id, name, original_name, content_type, created_at
+		FROM pending_uploads
+		WHERE file_id = $1 AND name = $2
+	`
+
+	var upload domain.PendingUpload
+	err := r.pool.QueryRow(ctx, query, fileID, name).Scan(
+		&upload.FileID,
+		&upload.Name,
+		&upload.OriginalName,
+		&upload.ContentType,
+		&upload.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+// LatestVersionNumber возвращает номер последней версии файла сдачи, 0 если версий нет.
+func (r *FileRepo) LatestVersionNumber(ctx context.Context, fileID, name string) (int, error) {
+	query := `
+		SELECT COALESCE(MAX(version), 0)
+		FROM file_versions
+		WHERE file_id = $1 AND name = $2
+	`
+
+	var version int
+	if err := r.pool.QueryRow(ctx, query, fileID, name).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to query latest version: %w", err)
+	}
+
+	return version, nil
+}
+
+// ListFileVersions возвращает версии всех файлов сдачи в порядке загрузки.
+func (r *FileRepo) ListFileVersions(ctx context.Context, fileID string) ([]domain.FileVersion, error) {
+	query := `
+		SELECT file_id, name, version, object_key, size, sha256, uploaded_by,
+		       original_name, content_type, mime_type, etag, late, created_at
+		FROM file_versions
+		WHERE file_id = $1
+		ORDER BY created_at, name, version
+	`
+
+	rows, err := r.pool.Query(ctx, query, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query file versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []domain.FileVersion
+	for rows.Next() {
+		var fileVersion domain.FileVersion
+
+		err := rows.Scan(
+			&fileVersion.FileID,
+			&fileVersion.Name,
+			&fileVersion.Version,
+			&fileVersion.ObjectKey,
+			&fileVersion.Size,
+			&fileVersion.SHA256,
+			&fileVersion.UploadedBy,
+			&fileVersion.OriginalName,
+			&fileVersion.ContentType,
+			&fileVersion.MimeType,
+			&fileVersion.ETag,
+			&fileVersion.Late,
+			&fileVersion.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan file version: %w", err)
+		}
+
+		versions = append(versions, fileVersion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return versions, nil
+}
+
+// ListLatestVersions возвращает последнюю версию каждого файла данных сдач, упорядоченно по сдаче и имени файла,
+// с признаком точного дубликата (такое же содержимое есть в любой версии чужой сдачи любой задачи).
+func (r *FileRepo) ListLatestVersions(ctx context.Context, fileIDs []string) ([]domain.FileVersion, error) {
+	query := `
+		SELECT lv.file_id, lv.name, lv.version, lv.object_key, lv.size, lv.sha256, lv.uploaded_by,
+		       lv.original_name, lv.content_type, lv.mime_type, lv.etag, lv.late, lv.created_at,
+		       EXISTS (
+		           SELECT 1
+		           FROM file_versions d
+		           JOIN files df ON df.id = d.file_id
+		           WHERE lv.sha256 <> '' AND d.sha256 = lv.sha256
+		             AND d.file_id <> lv.file_id AND df.student_id <> f.student_id
+		       )
+		FROM (
+			SELECT DISTINCT ON (file_id, name) *
+			FROM file_versions
+			WHERE file_id = ANY($1)
+			ORDER BY file_id, name, version DESC
+		) lv
+		JOIN files f ON f.id = lv.file_id
+		ORDER BY lv.file_id, lv.name
+	`
+
+	rows, err := r.pool.Query(ctx, query, fileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []domain.FileVersion
+	for rows.Next() {
+		var fileVersion domain.FileVersion
+
+		err := rows.Scan(
+			&fileVersion.FileID,
+			&fileVersion.Name,
+			&fileVersion.Version,
+			&fileVersion.ObjectKey,
+			&fileVersion.Size,
+			&fileVersion.SHA256,
+			&fileVersion.UploadedBy,
+			&fileVersion.OriginalName,
+			&fileVersion.ContentType,
+			&fileVersion.MimeType,
+			&fileVersion.ETag,
+			&fileVersion.Late,
+			&fileVersion.CreatedAt,
+			&fileVersion.ExactDuplicate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan file version: %w", err)
+		}
+
+		versions = append(versions, fileVersion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return versions, nil
+}
+
+// FindDuplicates ищет чужие сдачи (не этого файла и не этого студента), у которых есть версия с тем же хэшем.
+// Для каждой сдачи берётся последняя совпавшая версия; результат упорядочен по времени загрузки.
+func (r *FileRepo) FindDuplicates(ctx context.Context, fileID, studentID, sha256 string) ([]domain.Duplicate, error) {
+	query := `
+		SELECT author_id, task_id, name, version, created_at
+		FROM (
+			SELECT DISTINCT ON (f.id)
+			       COALESCE(f.group_id, f.student_id) AS author_id, f.task_id, v.name, v.version, v.created_at
+			FROM file_versions v
+			JOIN files f ON f.id = v.file_id
+			WHERE v.sha256 = $3 AND v.file_id <> $1 AND f.student_id <> $2
+			ORDER BY f.id, v.created_at DESC
+		) d
+		ORDER BY created_at, task_id, author_id
+	`
+
+	rows, err := r.pool.Query(ctx, query, fileID, studentID, sha256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query duplicates: %w", err)
+	}
+	defer rows.Close()
+
+	var duplicates []domain.Duplicate
+	for rows.Next() {
+		var duplicate domain.Duplicate
+
+		err := rows.Scan(
+			&duplicate.AuthorID,
+			&duplicate.TaskID,
+			&duplicate.FileName,
+			&duplicate.Version,
+			&duplicate.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate: %w", err)
+		}
+
+		duplicates = append(duplicates, duplicate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return duplicates, nil
+}
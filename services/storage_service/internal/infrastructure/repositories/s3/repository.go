@@ -1,16 +1,27 @@
 package s3
 
 import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
-	"math"
+	"mime"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
 type Repo struct {
@@ -25,46 +36,102 @@ func NewRepo(storage *Storage, logger *slog.Logger) *Repo {
 	}
 }
 
-func (s *Repo) GenerateUploadURL(key string) (string, error) {
-	const op = "S3.REPO.GenerateUploadURL"
+// GenerateUploadForm возвращает ссылку и подписанную политику (SigV4) для загрузки объекта key
+// POST-запросом. Хранилище само отклоняет загрузку, которая не укладывается в размер
+// или не совпадает по Content-Type с constraints.
+func (s *Repo) GenerateUploadForm(key string, constraints domain.UploadConstraints) (*domain.UploadForm, error) {
+	const op = "S3.REPO.GenerateUploadForm"
 
 	logger := s.logger.With(
 		slog.String("op", op),
 		slog.String("file key", key),
 	)
 
-	req, _ := s.Storage.externalClient.PutObjectRequest(&s3.PutObjectInput{
-		Bucket: aws.String(s.Storage.bucket),
-		Key:    aws.String(key),
-	})
-
-	conditions := []string{
-		fmt.Sprintf(`["content-length-range", 1, %d]`, int64(5*math.Pow(10, 8))),
+	creds, err := s.Storage.externalClient.Config.Credentials.Get()
+	if err != nil {
+		logger.Error("failed to get credentials", "error", err)
+		return nil, err
 	}
 
-	policy := fmt.Sprintf(`{
-        "expiration": "%s",
-        "conditions": [%s]
-    }`,
-		time.Now().Add(s.Storage.expirationTime).Format("2006-01-02T15:04:05Z"),
-		strings.Join(conditions, ","),
-	)
+	now := time.Now().UTC()
+	date := now.Format("20060102")
+	credential := strings.Join([]string{creds.AccessKeyID, date, s.Storage.region, "s3", "aws4_request"}, "/")
 
-	encodedPolicy := base64.StdEncoding.EncodeToString([]byte(policy))
+	fields := map[string]string{
+		"key":              key,
+		"x-amz-algorithm":  "AWS4-HMAC-SHA256",
+		"x-amz-credential": credential,
+		"x-amz-date":       now.Format("20060102T150405Z"),
+	}
+	if creds.SessionToken != "" {
+		fields["x-amz-security-token"] = creds.SessionToken
+	}
 
-	if req.HTTPRequest.Form == nil {
-		req.HTTPRequest.Form = make(url.Values)
+	conditions := []any{
+		map[string]string{"bucket": s.Storage.bucket},
+		[]any{"content-length-range", constraints.MinSize, constraints.MaxSize},
+	}
+	if constraints.ContentType != "" {
+		fields["Content-Type"] = constraints.ContentType
+		conditions = append(conditions, []any{"eq", "$Content-Type", constraints.ContentType})
+	} else {
+		conditions = append(conditions, []any{"starts-with", "$Content-Type", ""})
+	}
+	for name, value := range fields {
+		if name != "Content-Type" {
+			conditions = append(conditions, map[string]string{name: value})
+		}
 	}
 
-	req.HTTPRequest.Form.Add("Policy", encodedPolicy)
+	policy, err := json.Marshal(map[string]any{
+		"expiration": now.Add(s.Storage.expirationTime).Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	})
+	if err != nil {
+		logger.Error("failed to build policy", "error", err)
+		return nil, err
+	}
 
-	presignedUrl, err := req.Presign(s.Storage.expirationTime)
+	encodedPolicy := base64.StdEncoding.EncodeToString(policy)
+	fields["policy"] = encodedPolicy
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.Storage.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(signingKey, encodedPolicy))
+
+	return &domain.UploadForm{
+		URL:    strings.TrimRight(s.Storage.externalClient.Endpoint, "/") + "/" + url.PathEscape(s.Storage.bucket),
+		Fields: fields,
+	}, nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// DeleteObject удаляет объект key, например загрузку, не прошедшую проверку.
+func (s *Repo) DeleteObject(key string) error {
+	const op = "S3.REPO.DeleteObject"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("file key", key),
+	)
+
+	_, err := s.Storage.internalClient.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.Storage.bucket),
+		Key:    aws.String(key),
+	})
 	if err != nil {
-		logger.Error("failed to generate presigned url", "error", err)
-		return "", err
+		logger.Error("failed to delete file", "error", err)
+		return fmt.Errorf("failed to delete file: %w", err)
 	}
 
-	return presignedUrl, nil
+	return nil
 }
 
 func (s *Repo) VerifyUploadedFile(key string) error {
@@ -88,7 +155,112 @@ func (s *Repo) VerifyUploadedFile(key string) error {
 	return nil
 }
 
-func (s *Repo) GenerateDownloadURL(key string, fromInside bool) (string, error) {
+// sniffLen - сколько первых байт объекта нужно, чтобы определить его тип по содержимому.
+const sniffLen = 512
+
+// ObjectDigest читает загруженный объект и возвращает его размер, SHA-256 в hex,
+// тип по содержимому и ETag. Прочитанное содержимое копируется в sink, если он задан.
+func (s *Repo) ObjectDigest(key string, sink io.Writer) (*domain.ObjectDigest, error) {
+	const op = "S3.REPO.ObjectDigest"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("file key", key),
+	)
+
+	output, err := s.Storage.internalClient.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.Storage.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		logger.Error("failed to find file", "error", err)
+		return nil, fmt.Errorf("failed to find file: %w", err)
+	}
+	defer output.Body.Close()
+
+	body := bufio.NewReaderSize(output.Body, sniffLen)
+	head, err := body.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		logger.Error("failed to read file", "error", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	mimeType := http.DetectContentType(head)
+
+	hash := sha256.New()
+	var dst io.Writer = hash
+	if sink != nil {
+		dst = io.MultiWriter(hash, sink)
+	}
+	size, err := io.Copy(dst, body)
+	if err != nil {
+		logger.Error("failed to read file", "error", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return &domain.ObjectDigest{
+		Size:     size,
+		SHA256:   hex.EncodeToString(hash.Sum(nil)),
+		MimeType: mimeType,
+		ETag:     strings.Trim(aws.StringValue(output.ETag), `"`),
+	}, nil
+}
+
+// PutObject записывает body в объект key, по пути считая то же, что ObjectDigest,
+// чтобы загруженный через сервис файл не приходилось читать из хранилища повторно.
+func (s *Repo) PutObject(ctx context.Context, key, contentType string, body io.Reader) (*domain.ObjectDigest, error) {
+	const op = "S3.REPO.PutObject"
+
+	logger := s.logger.With(
+		slog.String("op", op),
+		slog.String("file key", key),
+	)
+
+	reader := bufio.NewReaderSize(body, sniffLen)
+	head, err := reader.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		logger.Error("failed to read file", "error", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	mimeType := http.DetectContentType(head)
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+
+	input := &s3manager.UploadInput{
+		Bucket: aws.String(s.Storage.bucket),
+		Key:    aws.String(key),
+		Body:   io.TeeReader(reader, io.MultiWriter(hash, counter)),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
+	output, err := s3manager.NewUploaderWithClient(s.Storage.internalClient).UploadWithContext(ctx, input)
+	if err != nil {
+		logger.Error("failed to upload file", "error", err)
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return &domain.ObjectDigest{
+		Size:     counter.n,
+		SHA256:   hex.EncodeToString(hash.Sum(nil)),
+		MimeType: mimeType,
+		ETag:     strings.Trim(aws.StringValue(output.ETag), `"`),
+	}, nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// GenerateDownloadURL возвращает ссылку на скачивание объекта. Непустое fileName попадает
+// в Content-Disposition ответа, чтобы файл сохранялся под этим именем, а не под ключом объекта.
+func (s *Repo) GenerateDownloadURL(key, fileName string, fromInside bool) (string, error) {
 	const op = "S3.REPO.GenerateDownloadURL"
 
 	logger := s.logger.With(
@@ -104,10 +276,17 @@ func (s *Repo) GenerateDownloadURL(key string, fromInside bool) (string, error)
 		client = s.Storage.externalClient
 	}
 
-	req, _ := client.GetObjectRequest(&s3.GetObjectInput{
+	input := &s3.GetObjectInput{
 		Bucket: aws.String(s.Storage.bucket),
 		Key:    aws.String(key),
-	})
+	}
+	if fileName != "" {
+		input.ResponseContentDisposition = aws.String(mime.FormatMediaType("attachment", map[string]string{
+			"filename": fileName,
+		}))
+	}
+
+	req, _ := client.GetObjectRequest(input)
 
 	presignedUrl, err := req.Presign(s.Storage.expirationTime)
 	if err != nil {
@@ -0,0 +1,138 @@
+package validators
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+// archiveStep ищет zip-бомбы: слишком много файлов в архиве, слишком большой распакованный
+// размер или слишком большая степень сжатия. Размеры берутся из фактической распаковки,
+// а не из заголовков, которым нельзя доверять. Документы OOXML (docx, xlsx, pptx) и ODF (odt, ods) -
+// тоже zip, но XML в них обычно сжимается сильнее предела, поэтому степень сжатия у них не проверяется:
+// от бомбы их защищают пределы на число файлов и распакованный размер.
+type archiveStep struct {
+	maxFiles    int
+	maxRatio    int64
+	maxUnpacked int64
+}
+
+func (archiveStep) Name() string { return "archive" }
+
+func (s archiveStep) Check(ctx context.Context, object *domain.UploadedObject) (string, error) {
+	switch object.Digest.MimeType {
+	case "application/zip", "application/x-zip-compressed":
+		return s.checkZip(ctx, object)
+	case "application/x-gzip", "application/gzip":
+		return s.checkGzip(object)
+	}
+	return "", nil
+}
+
+func (s archiveStep) checkZip(ctx context.Context, object *domain.UploadedObject) (string, error) {
+	archive, err := zip.NewReader(object.Content, object.Digest.Size)
+	if err != nil {
+		return "archive is corrupted", nil
+	}
+
+	if s.maxFiles > 0 && len(archive.File) > s.maxFiles {
+		return fmt.Sprintf("archive contains more than %d files", s.maxFiles), nil
+	}
+
+	document := isOfficeDocument(archive)
+
+	var unpacked int64
+	for _, file := range archive.File {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		// зашифрованные записи распаковать нельзя, их отклоняет шаг encrypted
+		if file.Flags&0x1 != 0 {
+			continue
+		}
+
+		entry, err := file.Open()
+		if err != nil {
+			return "archive is corrupted", nil
+		}
+		n, err := io.Copy(io.Discard, io.LimitReader(entry, s.remaining(unpacked)))
+		entry.Close()
+		unpacked += n
+		if err != nil {
+			return "archive is corrupted", nil
+		}
+
+		if reason := s.checkUnpacked(unpacked, object.Digest.Size, document); reason != "" {
+			return reason, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (s archiveStep) checkGzip(object *domain.UploadedObject) (string, error) {
+	stream, err := gzip.NewReader(io.NewSectionReader(object.Content, 0, object.Digest.Size))
+	if err != nil {
+		return "archive is corrupted", nil
+	}
+	defer stream.Close()
+
+	unpacked, err := io.Copy(io.Discard, io.LimitReader(stream, s.remaining(0)))
+	if err != nil {
+		return "archive is corrupted", nil
+	}
+
+	return s.checkUnpacked(unpacked, object.Digest.Size, false), nil
+}
+
+// remaining - сколько ещё байт можно распаковать, чтобы заметить превышение предела.
+func (s archiveStep) remaining(unpacked int64) int64 {
+	limit := s.maxUnpacked
+	if limit <= 0 {
+		limit = domain.DefaultMaxFileSize * 2
+	}
+	return max(limit-unpacked+1, 0)
+}
+
+// checkUnpacked сверяет распакованный размер с пределами; степень сжатия не проверяется у документов.
+func (s archiveStep) checkUnpacked(unpacked, packed int64, document bool) string {
+	if s.maxUnpacked > 0 && unpacked > s.maxUnpacked {
+		return fmt.Sprintf("archive unpacks to more than %d bytes", s.maxUnpacked)
+	}
+	if !document && s.maxRatio > 0 && packed > 0 && unpacked/packed > s.maxRatio {
+		return fmt.Sprintf("archive compression ratio exceeds %d", s.maxRatio)
+	}
+	return ""
+}
+
+// odfMimePrefix - начало содержимого записи mimetype в документах ODF.
+var odfMimePrefix = []byte("application/vnd.oasis.opendocument.")
+
+// isOfficeDocument сообщает, что zip - документ OOXML (есть запись [Content_Types].xml)
+// или ODF (запись mimetype с типом OpenDocument).
+func isOfficeDocument(archive *zip.Reader) bool {
+	for _, file := range archive.File {
+		switch file.Name {
+		case "[Content_Types].xml":
+			return true
+		case "mimetype":
+			entry, err := file.Open()
+			if err != nil {
+				return false
+			}
+			head := make([]byte, len(odfMimePrefix))
+			_, err = io.ReadFull(entry, head)
+			entry.Close()
+			return err == nil && bytes.Equal(head, odfMimePrefix)
+		}
+	}
+	return false
+}
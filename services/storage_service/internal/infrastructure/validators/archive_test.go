@@ -0,0 +1,166 @@
+package validators
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+func newObject(mimeType string, content []byte) *domain.UploadedObject {
+	return &domain.UploadedObject{
+		Name:    "file",
+		Digest:  domain.ObjectDigest{Size: int64(len(content)), MimeType: mimeType},
+		Content: bytes.NewReader(content),
+	}
+}
+
+type zipEntry struct {
+	name  string
+	data  []byte
+	flags uint16
+}
+
+func makeZip(t *testing.T, entries ...zipEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, e := range entries {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: e.name, Method: zip.Deflate, Flags: e.flags})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(e.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func makeGzip(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestArchiveStep(t *testing.T) {
+	zeros := make([]byte, 4<<20)
+	// случайные данные почти не сжимаются, и предел степени сжатия на них не срабатывает
+	noise := make([]byte, 4<<20)
+	rand.NewChaCha8([32]byte{}).Read(noise)
+	step := archiveStep{maxFiles: 3, maxRatio: 100, maxUnpacked: 8 << 20}
+
+	many := make([]zipEntry, 4)
+	for i := range many {
+		many[i] = zipEntry{name: strings.Repeat("f", i+1), data: []byte("text")}
+	}
+
+	tests := []struct {
+		name     string
+		mimeType string
+		content  []byte
+		reason   string
+	}{
+		{
+			name:     "plain zip",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "main.go", data: []byte("package main\n")}),
+		},
+		{
+			name:     "zip ratio bomb",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "zeros", data: zeros}),
+			reason:   "archive compression ratio exceeds 100",
+		},
+		{
+			name:     "zip unpacked size bomb",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "a", data: noise}, zipEntry{name: "b", data: noise}, zipEntry{name: "c", data: noise}),
+			reason:   "archive unpacks to more than 8388608 bytes",
+		},
+		{
+			name:     "too many files",
+			mimeType: "application/zip",
+			content:  makeZip(t, many...),
+			reason:   "archive contains more than 3 files",
+		},
+		{
+			name:     "corrupted zip",
+			mimeType: "application/zip",
+			content:  []byte("PK\x03\x04 not a zip"),
+			reason:   "archive is corrupted",
+		},
+		{
+			name:     "docx skips ratio",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "[Content_Types].xml", data: []byte("<Types/>")}, zipEntry{name: "word/document.xml", data: zeros}),
+		},
+		{
+			name:     "odt skips ratio",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "mimetype", data: []byte("application/vnd.oasis.opendocument.text")}, zipEntry{name: "content.xml", data: zeros}),
+		},
+		{
+			name:     "docx keeps unpacked size",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "[Content_Types].xml", data: []byte("<Types/>")}, zipEntry{name: "a", data: zeros}, zipEntry{name: "b", data: zeros}),
+			reason:   "archive unpacks to more than 8388608 bytes",
+		},
+		{
+			name:     "foreign mimetype entry",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "mimetype", data: []byte("application/epub+zip")}, zipEntry{name: "zeros", data: zeros}),
+			reason:   "archive compression ratio exceeds 100",
+		},
+		{
+			name:     "encrypted entry is left to encrypted step",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "secret", data: zeros, flags: 0x1}),
+		},
+		{
+			name:     "plain gzip",
+			mimeType: "application/x-gzip",
+			content:  makeGzip(t, []byte("some text that is not compressed much")),
+		},
+		{
+			name:     "gzip bomb",
+			mimeType: "application/x-gzip",
+			content:  makeGzip(t, zeros),
+			reason:   "archive compression ratio exceeds 100",
+		},
+		{
+			name:     "not an archive",
+			mimeType: "text/plain; charset=utf-8",
+			content:  []byte("hello"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := step.Check(context.Background(), newObject(tt.mimeType, tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reason != tt.reason {
+				t.Errorf("reason = %q, want %q", reason, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package validators
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+// sizeStep отклоняет пустые файлы и файлы больше предела задачи.
+type sizeStep struct{}
+
+func (sizeStep) Name() string { return "size" }
+
+func (sizeStep) Check(_ context.Context, object *domain.UploadedObject) (string, error) {
+	if object.Digest.Size < 1 {
+		return "file is empty", nil
+	}
+	if limit := object.Task.FileSizeLimit(); object.Digest.Size > limit {
+		return fmt.Sprintf("file is larger than %d bytes", limit), nil
+	}
+	return "", nil
+}
+
+// mimeStep сверяет тип файла по содержимому со списком допустимых типов задачи. Документы OOXML и ODF
+// по первым байтам - zip, поэтому у них сверяется тип документа из самого архива - тот же, что клиент
+// заявляет при получении ссылки.
+type mimeStep struct{}
+
+func (mimeStep) Name() string { return "mime" }
+
+func (mimeStep) Check(_ context.Context, object *domain.UploadedObject) (string, error) {
+	mimeType := cmp.Or(documentType(object), object.Digest.MimeType)
+	if !object.Task.AllowsMimeType(mimeType) {
+		return fmt.Sprintf("content type %s is not allowed for the task", mimeType), nil
+	}
+	return "", nil
+}
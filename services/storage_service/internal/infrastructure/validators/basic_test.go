@@ -0,0 +1,92 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+const docxType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// docxContentTypes - [Content_Types].xml документа Word с главной частью word/document.xml.
+var docxContentTypes = []byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+</Types>`)
+
+func TestMimeStep(t *testing.T) {
+	docx := makeZip(t,
+		zipEntry{name: "[Content_Types].xml", data: docxContentTypes},
+		zipEntry{name: "word/document.xml", data: []byte("<w:document/>")},
+	)
+	odt := makeZip(t,
+		zipEntry{name: "mimetype", data: []byte("application/vnd.oasis.opendocument.text")},
+		zipEntry{name: "content.xml", data: []byte("<office:document-content/>")},
+	)
+	plainZip := makeZip(t, zipEntry{name: "main.go", data: []byte("package main\n")})
+
+	tests := []struct {
+		name     string
+		allowed  []string
+		mimeType string
+		content  []byte
+		reason   string
+	}{
+		{
+			name:     "docx allowed by its type",
+			allowed:  []string{docxType},
+			mimeType: "application/zip",
+			content:  docx,
+		},
+		{
+			name:     "docx allowed by zip",
+			allowed:  []string{"application/zip"},
+			mimeType: "application/zip",
+			content:  docx,
+		},
+		{
+			name:     "docx not allowed",
+			allowed:  []string{"application/pdf"},
+			mimeType: "application/zip",
+			content:  docx,
+			reason:   "content type " + docxType + " is not allowed for the task",
+		},
+		{
+			name:     "odt allowed by its type",
+			allowed:  []string{"application/vnd.oasis.opendocument.text"},
+			mimeType: "application/zip",
+			content:  odt,
+		},
+		{
+			name:     "plain zip is not a docx",
+			allowed:  []string{docxType},
+			mimeType: "application/zip",
+			content:  plainZip,
+			reason:   "content type application/zip is not allowed for the task",
+		},
+		{
+			name:     "text with parameters",
+			allowed:  []string{"text/"},
+			mimeType: "text/plain; charset=utf-8",
+			content:  []byte("hello"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object := newObject(tt.mimeType, tt.content)
+			object.Task = &domain.Task{AllowedMimeTypes: tt.allowed}
+
+			reason, err := mimeStep{}.Check(context.Background(), object)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reason != tt.reason {
+				t.Errorf("reason = %q, want %q", reason, tt.reason)
+			}
+		})
+	}
+}
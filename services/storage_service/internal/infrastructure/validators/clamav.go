@@ -0,0 +1,94 @@
+package validators
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+// clamAVChunkSize - размер куска в команде INSTREAM, меньше StreamMaxLength по умолчанию у clamd.
+const clamAVChunkSize = 64 << 10
+
+// clamAVStep отправляет файл на проверку в clamd по протоколу INSTREAM.
+type clamAVStep struct {
+	addr    string
+	timeout time.Duration
+}
+
+func (clamAVStep) Name() string { return "clamav" }
+
+func (s clamAVStep) Check(ctx context.Context, object *domain.UploadedObject) (string, error) {
+	network, addr := "tcp", s.addr
+	if path, ok := strings.CutPrefix(s.addr, "unix:"); ok {
+		network, addr = "unix", path
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return "", err
+	}
+
+	content := io.NewSectionReader(object.Content, 0, object.Digest.Size)
+	chunk := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, readErr := io.ReadFull(content, chunk[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(chunk, uint32(n))
+			if _, err := conn.Write(chunk[:4+n]); err != nil {
+				return "", err
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return "", readErr
+		}
+	}
+
+	// кусок нулевой длины завершает поток
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return "", err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return "", err
+	}
+	reply = strings.TrimRight(reply, "\x00\n")
+
+	switch {
+	case reply == "stream: OK":
+		return "", nil
+	case strings.HasSuffix(reply, " FOUND"):
+		signature := strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND")
+		return "malware detected: " + signature, nil
+	}
+
+	return "", fmt.Errorf("unexpected clamd reply %q", reply)
+}
@@ -0,0 +1,108 @@
+package validators
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeClamd принимает одно соединение, читает поток INSTREAM и отвечает reply.
+// Полученное содержимое отправляется в канал.
+func fakeClamd(t *testing.T, reply string) (string, <-chan []byte) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		command, err := r.ReadString(0)
+		if err != nil || command != "zINSTREAM\x00" {
+			close(received)
+			return
+		}
+
+		var content bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				close(received)
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&content, r, int64(size)); err != nil {
+				close(received)
+				return
+			}
+		}
+		received <- content.Bytes()
+
+		io.WriteString(conn, reply+"\x00")
+	}()
+
+	return ln.Addr().String(), received
+}
+
+func TestClamAVStep(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), clamAVChunkSize/5)
+
+	tests := []struct {
+		name    string
+		reply   string
+		reason  string
+		wantErr bool
+	}{
+		{name: "clean", reply: "stream: OK"},
+		{name: "infected", reply: "stream: Eicar-Test-Signature FOUND", reason: "malware detected: Eicar-Test-Signature"},
+		{name: "error reply", reply: "INSTREAM size limit exceeded. ERROR", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, received := fakeClamd(t, tt.reply)
+			step := clamAVStep{addr: addr, timeout: 5 * time.Second}
+
+			reason, err := step.Check(context.Background(), newObject("application/octet-stream", content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if reason != tt.reason {
+				t.Errorf("reason = %q, want %q", reason, tt.reason)
+			}
+			if got := <-received; !bytes.Equal(got, content) {
+				t.Errorf("clamd received %d bytes, want %d", len(got), len(content))
+			}
+		})
+	}
+}
+
+func TestClamAVStepUnavailable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	step := clamAVStep{addr: addr, timeout: time.Second}
+	if _, err := step.Check(context.Background(), newObject("text/plain", []byte("hello"))); err == nil {
+		t.Fatal("expected error for unreachable clamd")
+	}
+}
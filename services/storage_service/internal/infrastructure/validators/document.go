@@ -0,0 +1,78 @@
+package validators
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+// ooxmlMainSuffix - окончание типа главной части документа OOXML в [Content_Types].xml; без него остаётся
+// тип самого документа: "...wordprocessingml.document.main+xml" у docx.
+const ooxmlMainSuffix = ".main+xml"
+
+// maxDocumentEntrySize - предел чтения записей [Content_Types].xml и mimetype.
+const maxDocumentEntrySize = 1 << 20
+
+// ooxmlContentTypes - записи Override из [Content_Types].xml.
+type ooxmlContentTypes struct {
+	Overrides []struct {
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Override"`
+}
+
+// documentType возвращает тип документа OOXML (docx, xlsx, pptx) или ODF (odt, ods), который по первым байтам
+// неотличим от zip: у OOXML - по главной части из [Content_Types].xml, у ODF - из записи mimetype.
+// Для остальных файлов, в том числе обычных zip, возвращает пустую строку.
+func documentType(object *domain.UploadedObject) string {
+	switch object.Digest.MimeType {
+	case "application/zip", "application/x-zip-compressed":
+	default:
+		return ""
+	}
+
+	archive, err := zip.NewReader(object.Content, object.Digest.Size)
+	if err != nil {
+		return ""
+	}
+
+	for _, file := range archive.File {
+		switch file.Name {
+		case "[Content_Types].xml":
+			data, err := readEntry(file)
+			if err != nil {
+				return ""
+			}
+			var types ooxmlContentTypes
+			if err := xml.Unmarshal(data, &types); err != nil {
+				return ""
+			}
+			for _, override := range types.Overrides {
+				if mimeType, ok := strings.CutSuffix(override.ContentType, ooxmlMainSuffix); ok {
+					return mimeType
+				}
+			}
+			return ""
+		case "mimetype":
+			data, err := readEntry(file)
+			if err != nil || !bytes.HasPrefix(data, odfMimePrefix) {
+				return ""
+			}
+			return strings.TrimSpace(string(data))
+		}
+	}
+	return ""
+}
+
+func readEntry(file *zip.File) ([]byte, error) {
+	entry, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer entry.Close()
+
+	return io.ReadAll(io.LimitReader(entry, maxDocumentEntrySize))
+}
@@ -0,0 +1,104 @@
+package validators
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+// oleMagic - сигнатура составного файла OLE, в котором Office хранит зашифрованные документы.
+var oleMagic = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// oleEncryptionInfo - имя потока EncryptionInfo в каталоге OLE (UTF-16LE).
+var oleEncryptionInfo = utf16le("EncryptionInfo")
+
+// encryptedStep отклоняет документы под паролем: зашифрованные PDF, zip с зашифрованными
+// записями и документы Office, сохранённые с паролем. Такие файлы нельзя проверить на плагиат.
+type encryptedStep struct{}
+
+func (encryptedStep) Name() string { return "encrypted" }
+
+func (encryptedStep) Check(ctx context.Context, object *domain.UploadedObject) (string, error) {
+	switch object.Digest.MimeType {
+	case "application/pdf":
+		found, err := containsToken(ctx, object, []byte("/Encrypt"))
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return "pdf is password-protected", nil
+		}
+	case "application/zip", "application/x-zip-compressed":
+		archive, err := zip.NewReader(object.Content, object.Digest.Size)
+		if err != nil {
+			// повреждённый архив отклоняет шаг archive, здесь проверять нечего
+			return "", nil
+		}
+		for _, file := range archive.File {
+			if file.Flags&0x1 != 0 {
+				return "archive is password-protected", nil
+			}
+		}
+	default:
+		head := make([]byte, len(oleMagic))
+		if _, err := object.Content.ReadAt(head, 0); err != nil {
+			return "", nil
+		}
+		if !bytes.Equal(head, oleMagic) {
+			return "", nil
+		}
+		found, err := containsToken(ctx, object, oleEncryptionInfo)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return "document is password-protected", nil
+		}
+	}
+
+	return "", nil
+}
+
+// containsToken ищет token в содержимом файла, читая его кусками с перекрытием.
+func containsToken(ctx context.Context, object *domain.UploadedObject, token []byte) (bool, error) {
+	const chunkSize = 1 << 20
+
+	buf := make([]byte, chunkSize+len(token))
+	var offset, kept int64
+	for offset < object.Digest.Size {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		n, err := object.Content.ReadAt(buf[kept:], offset)
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		if n == 0 {
+			break
+		}
+
+		window := buf[:kept+int64(n)]
+		if bytes.Contains(window, token) {
+			return true, nil
+		}
+		offset += int64(n)
+
+		// хвост окна переносится в начало, чтобы не пропустить токен на границе кусков
+		kept = min(int64(len(token)-1), int64(len(window)))
+		copy(buf, window[int64(len(window))-kept:])
+	}
+
+	return false, nil
+}
+
+func utf16le(s string) []byte {
+	out := make([]byte, 0, len(s)*2)
+	for _, r := range s {
+		out = append(out, byte(r), 0)
+	}
+	return out
+}
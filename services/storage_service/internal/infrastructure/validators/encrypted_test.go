@@ -0,0 +1,81 @@
+package validators
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestEncryptedStep(t *testing.T) {
+	// токен на границе кусков, которыми читает containsToken
+	straddling := bytes.Repeat([]byte{' '}, 1<<20-3)
+	straddling = append([]byte("%PDF-1.7\n"), straddling...)
+	straddling = append(straddling, []byte("/Encrypt 5 0 R\n%%EOF")...)
+
+	ole := append(append([]byte{}, oleMagic...), make([]byte, 512)...)
+	encryptedOle := append(append([]byte{}, ole...), utf16le("EncryptionInfo")...)
+
+	tests := []struct {
+		name     string
+		mimeType string
+		content  []byte
+		reason   string
+	}{
+		{
+			name:     "plain pdf",
+			mimeType: "application/pdf",
+			content:  []byte("%PDF-1.7\n1 0 obj << /Type /Catalog >> endobj\n%%EOF"),
+		},
+		{
+			name:     "encrypted pdf",
+			mimeType: "application/pdf",
+			content:  []byte("%PDF-1.7\ntrailer << /Root 1 0 R /Encrypt 5 0 R >>\n%%EOF"),
+			reason:   "pdf is password-protected",
+		},
+		{
+			name:     "encrypted pdf token on chunk boundary",
+			mimeType: "application/pdf",
+			content:  straddling,
+			reason:   "pdf is password-protected",
+		},
+		{
+			name:     "plain zip",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "main.go", data: []byte("package main\n")}),
+		},
+		{
+			name:     "encrypted zip",
+			mimeType: "application/zip",
+			content:  makeZip(t, zipEntry{name: "main.go", data: []byte("package main\n")}, zipEntry{name: "secret", data: []byte("x"), flags: 0x1}),
+			reason:   "archive is password-protected",
+		},
+		{
+			name:     "plain ole",
+			mimeType: "application/octet-stream",
+			content:  ole,
+		},
+		{
+			name:     "encrypted ole",
+			mimeType: "application/octet-stream",
+			content:  encryptedOle,
+			reason:   "document is password-protected",
+		},
+		{
+			name:     "text mentioning EncryptionInfo",
+			mimeType: "text/plain; charset=utf-8",
+			content:  utf16le("EncryptionInfo"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := encryptedStep{}.Check(context.Background(), newObject(tt.mimeType, tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reason != tt.reason {
+				t.Errorf("reason = %q, want %q", reason, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+// Step - шаг проверки загруженного файла. Check возвращает причину отказа (пустая - файл прошёл шаг)
+// или ошибку, если проверить файл не удалось.
+type Step interface {
+	Name() string
+	Check(ctx context.Context, object *domain.UploadedObject) (string, error)
+}
+
+// Options - настройки шагов проверки.
+type Options struct {
+	// ClamAVAddr - адрес clamd: "host:port" или "unix:/путь/к/сокету"
+	ClamAVAddr    string
+	ClamAVTimeout time.Duration
+	// MaxArchiveFiles, MaxArchiveRatio и MaxUnpackedSize - пределы, после которых архив считается zip-бомбой
+	MaxArchiveFiles int
+	MaxArchiveRatio int64
+	MaxUnpackedSize int64
+}
+
+// Pipeline прогоняет файл через шаги по порядку и останавливается на первом отказе.
+type Pipeline struct {
+	logger *slog.Logger
+	steps  []Step
+}
+
+// New собирает цепочку из шагов с именами names: size, mime, archive, encrypted, clamav.
+// Шаг size выполняется всегда, даже если его нет в names: файл сверх предела задачи
+// читается не полностью, и остальные шаги не должны принять его обрезанным.
+func New(names []string, opts Options, logger *slog.Logger) (*Pipeline, error) {
+	p := &Pipeline{logger: logger}
+	hasSize := false
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		var step Step
+		switch name {
+		case "":
+			continue
+		case "size":
+			step = sizeStep{}
+			hasSize = true
+		case "mime":
+			step = mimeStep{}
+		case "archive":
+			step = archiveStep{maxFiles: opts.MaxArchiveFiles, maxRatio: opts.MaxArchiveRatio, maxUnpacked: opts.MaxUnpackedSize}
+		case "encrypted":
+			step = encryptedStep{}
+		case "clamav":
+			if opts.ClamAVAddr == "" {
+				return nil, fmt.Errorf("clamav address is required")
+			}
+			step = clamAVStep{addr: opts.ClamAVAddr, timeout: opts.ClamAVTimeout}
+		default:
+			return nil, fmt.Errorf("unknown validation step %q", name)
+		}
+		p.steps = append(p.steps, step)
+	}
+
+	if !hasSize {
+		p.steps = append([]Step{sizeStep{}}, p.steps...)
+	}
+
+	return p, nil
+}
+
+// Validate проверяет файл и возвращает результаты выполненных шагов.
+func (p *Pipeline) Validate(ctx context.Context, object *domain.UploadedObject) (*domain.ValidationReport, error) {
+	const op = "Validators.Pipeline.Validate"
+
+	logger := p.logger.With(
+		slog.String("op", op),
+		slog.String("name", object.Name),
+	)
+
+	report := &domain.ValidationReport{
+		Steps: make([]domain.ValidationStep, 0, len(p.steps)),
+	}
+
+	for _, step := range p.steps {
+		reason, err := step.Check(ctx, object)
+		if err != nil {
+			logger.Error("validation step failed", "step", step.Name(), "error", err)
+			return nil, fmt.Errorf("%s: %w", step.Name(), err)
+		}
+
+		report.Steps = append(report.Steps, domain.ValidationStep{
+			Validator: step.Name(),
+			Passed:    reason == "",
+			Reason:    reason,
+		})
+		if reason != "" {
+			logger.Warn("file rejected", "step", step.Name(), "reason", reason)
+			break
+		}
+	}
+
+	report.CheckedAt = time.Now()
+	return report, nil
+}
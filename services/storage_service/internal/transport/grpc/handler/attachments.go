@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/use_cases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) GenerateAttachmentUploadURL(ctx context.Context, req *gen.GenerateAttachmentUploadURLRequest) (*gen.GenerateAttachmentUploadURLResponse, error) {
+	const op = "Handler.GenerateAttachmentUploadURL"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("OwnerId", req.GetOwnerId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateIdWrapped(req.GetOwnerId(), "owner", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateFileName(req.GetFileName(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentId, form, err := h.service.GenerateAttachmentUploadURL(ctx, req.GetTaskId(), req.GetOwnerId(), req.GetFileName())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrFailedToGenerateURL) {
+			logger.Error("failed to generate url", "error", err)
+			return nil, status.Error(codes.Internal, "failed to generate url")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.GenerateAttachmentUploadURLResponse{
+		AttachmentId: attachmentId,
+		Url:          form.URL,
+		Fields:       form.Fields,
+	}, nil
+}
+
+func (h *Handler) VerifyAttachment(ctx context.Context, req *gen.VerifyAttachmentRequest) (*gen.VerifyAttachmentResponse, error) {
+	const op = "Handler.VerifyAttachment"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("AttachmentId", req.GetAttachmentId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateAttachmentId(req.GetAttachmentId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := h.service.VerifyAttachment(ctx, req.GetAttachmentId())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrAttachmentNotFound) {
+			logger.Error("attachment not found", "error", err)
+			return nil, status.Error(codes.NotFound, "attachment not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.VerifyAttachmentResponse{
+		Attachment: toProtoAttachment(attachment),
+	}, nil
+}
+
+func (h *Handler) GenerateAttachmentDownloadURL(ctx context.Context, req *gen.GenerateAttachmentDownloadURLRequest) (*gen.GenerateAttachmentDownloadURLResponse, error) {
+	const op = "Handler.GenerateAttachmentDownloadURL"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("AttachmentId", req.GetAttachmentId()),
+		slog.Bool("FromInside", req.GetFromInside()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateAttachmentId(req.GetAttachmentId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, url, err := h.service.GenerateAttachmentDownloadURL(ctx, req.GetAttachmentId(), req.GetFromInside())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrAttachmentNotFound) {
+			logger.Error("attachment not found", "error", err)
+			return nil, status.Error(codes.NotFound, "attachment not found")
+		}
+		if errors.Is(err, use_cases.ErrFailedToGenerateURL) {
+			logger.Error("failed to generate url", "error", err)
+			return nil, status.Error(codes.Internal, "failed to generate url")
+		}
+		if errors.Is(err, use_cases.ErrFileYetNotUploaded) {
+			logger.Error("attachment has not been uploaded yet", "error", err)
+			return nil, status.Error(codes.FailedPrecondition, "attachment has not been uploaded yet")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.GenerateAttachmentDownloadURLResponse{
+		Attachment: toProtoAttachment(attachment),
+		Url:        url,
+	}, nil
+}
+
+func toProtoAttachment(attachment *use_cases.SafeAttachmentInfo) *gen.AttachmentInfo {
+	var updatedAt *timestamppb.Timestamp
+	if !attachment.UpdatedAt.IsZero() {
+		updatedAt = timestamppb.New(attachment.UpdatedAt)
+	}
+
+	return &gen.AttachmentInfo{
+		AttachmentId: attachment.AttachmentId,
+		TaskId:       attachment.TaskId,
+		OwnerId:      attachment.OwnerId,
+		FileName:     attachment.FileName,
+		UpdatedAt:    updatedAt,
+		Status:       attachment.Status,
+	}
+}
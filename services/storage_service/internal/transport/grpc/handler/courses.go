@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/use_cases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) CreateCourse(ctx context.Context, req *gen.CreateCourseRequest) (*gen.CreateCourseResponse, error) {
+	const op = "Handler.CreateCourse"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("CourseId", req.GetCourseId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateCourse(req.GetCourseId(), req.GetTitle(), req.GetMembers(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := h.service.CreateCourse(ctx, req.GetCourseId(), req.GetTitle(), toUseCaseCourseMembers(req.GetMembers()))
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrCourseAlreadyExists) {
+			logger.Warn("course already exists", "error", err)
+			return nil, status.Error(codes.AlreadyExists, "course already exists")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.CreateCourseResponse{
+		Course: toProtoCourse(course),
+	}, nil
+}
+
+func (h *Handler) GetCourse(ctx context.Context, req *gen.GetCourseRequest) (*gen.GetCourseResponse, error) {
+	const op = "Handler.GetCourse"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("CourseId", req.GetCourseId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateIdWrapped(req.GetCourseId(), "course", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := h.service.GetCourse(ctx, req.GetCourseId())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrCourseNotFound) {
+			logger.Warn("course not found", "error", err)
+			return nil, status.Error(codes.NotFound, "course not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.GetCourseResponse{
+		Course: toProtoCourse(course),
+	}, nil
+}
+
+func (h *Handler) ImportCourseRoster(ctx context.Context, req *gen.ImportCourseRosterRequest) (*gen.ImportCourseRosterResponse, error) {
+	const op = "Handler.ImportCourseRoster"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("CourseId", req.GetCourseId()),
+		slog.Bool("Replace", req.GetReplace()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateIdWrapped(req.GetCourseId(), "course", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ValidateCourseMembers(req.GetMembers(), h.logger); err != nil {
+		return nil, err
+	}
+
+	course, err := h.service.ImportCourseRoster(ctx, req.GetCourseId(), toUseCaseCourseMembers(req.GetMembers()), req.GetReplace())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrCourseNotFound) {
+			logger.Warn("course not found", "error", err)
+			return nil, status.Error(codes.NotFound, "course not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.ImportCourseRosterResponse{
+		Course: toProtoCourse(course),
+	}, nil
+}
+
+func (h *Handler) RemoveCourseMember(ctx context.Context, req *gen.RemoveCourseMemberRequest) (*gen.RemoveCourseMemberResponse, error) {
+	const op = "Handler.RemoveCourseMember"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("CourseId", req.GetCourseId()),
+		slog.String("UserId", req.GetUserId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateIdWrapped(req.GetCourseId(), "course", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ValidateIdWrapped(req.GetUserId(), "user", logger); err != nil {
+		return nil, err
+	}
+
+	err = h.service.RemoveCourseMember(ctx, req.GetCourseId(), req.GetUserId())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrCourseMemberNotFound) {
+			logger.Warn("course member not found", "error", err)
+			return nil, status.Error(codes.NotFound, "course member not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.RemoveCourseMemberResponse{}, nil
+}
+
+func (h *Handler) ListMissingSubmissions(ctx context.Context, req *gen.ListMissingSubmissionsRequest) (*gen.ListMissingSubmissionsResponse, error) {
+	const op = "Handler.ListMissingSubmissions"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	studentIds, err := h.service.ListMissingSubmissions(ctx, req.GetTaskId())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task not found", "error", err)
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		if errors.Is(err, use_cases.ErrCourseNotFound) {
+			logger.Warn("course not found", "error", err)
+			return nil, status.Error(codes.NotFound, "course not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.ListMissingSubmissionsResponse{
+		StudentIds: studentIds,
+	}, nil
+}
+
+func toUseCaseCourseMembers(members []*gen.CourseMember) []use_cases.SafeCourseMember {
+	result := make([]use_cases.SafeCourseMember, 0, len(members))
+	for _, member := range members {
+		result = append(result, use_cases.SafeCourseMember{
+			UserId: member.GetUserId(),
+			Role:   member.GetRole(),
+		})
+	}
+	return result
+}
+
+func toProtoCourse(course *use_cases.SafeCourseInfo) *gen.CourseInfo {
+	members := make([]*gen.CourseMember, 0, len(course.Members))
+	for _, member := range course.Members {
+		members = append(members, &gen.CourseMember{
+			UserId: member.UserId,
+			Role:   member.Role,
+		})
+	}
+
+	return &gen.CourseInfo{
+		CourseId:  course.CourseId,
+		Title:     course.Title,
+		Members:   members,
+		CreatedAt: timestamppb.New(course.CreatedAt),
+	}
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/use_cases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) CreateGroup(ctx context.Context, req *gen.CreateGroupRequest) (*gen.CreateGroupResponse, error) {
+	const op = "Handler.CreateGroup"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("GroupId", req.GetGroupId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateGroup(req.GetTaskId(), req.GetGroupId(), req.GetMemberIds(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := h.service.CreateGroup(ctx, req.GetTaskId(), req.GetGroupId(), req.GetMemberIds())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrGroupConflict) {
+			logger.Warn("group id or member is already taken", "error", err)
+			return nil, status.Error(codes.AlreadyExists, "group id or member is already taken in the task")
+		}
+		if errors.Is(err, use_cases.ErrNotEnrolled) {
+			logger.Warn("member is not enrolled", "error", err)
+			return nil, status.Error(codes.PermissionDenied, "member is not enrolled in the course of the task")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.CreateGroupResponse{
+		Group: toProtoGroup(group),
+	}, nil
+}
+
+func (h *Handler) UpdateGroupMembers(ctx context.Context, req *gen.UpdateGroupMembersRequest) (*gen.UpdateGroupMembersResponse, error) {
+	const op = "Handler.UpdateGroupMembers"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("GroupId", req.GetGroupId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateGroup(req.GetTaskId(), req.GetGroupId(), req.GetMemberIds(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := h.service.UpdateGroupMembers(ctx, req.GetTaskId(), req.GetGroupId(), req.GetMemberIds())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrGroupNotFound) {
+			logger.Warn("group not found", "error", err)
+			return nil, status.Error(codes.NotFound, "group not found")
+		}
+		if errors.Is(err, use_cases.ErrGroupConflict) {
+			logger.Warn("member is already taken", "error", err)
+			return nil, status.Error(codes.AlreadyExists, "member is already in another group or is a group id")
+		}
+		if errors.Is(err, use_cases.ErrNotEnrolled) {
+			logger.Warn("member is not enrolled", "error", err)
+			return nil, status.Error(codes.PermissionDenied, "member is not enrolled in the course of the task")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.UpdateGroupMembersResponse{
+		Group: toProtoGroup(group),
+	}, nil
+}
+
+func (h *Handler) DeleteGroup(ctx context.Context, req *gen.DeleteGroupRequest) (*gen.DeleteGroupResponse, error) {
+	const op = "Handler.DeleteGroup"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.String("GroupId", req.GetGroupId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateIdWrapped(req.GetGroupId(), "group", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.service.DeleteGroup(ctx, req.GetTaskId(), req.GetGroupId())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrGroupNotFound) {
+			logger.Warn("group not found", "error", err)
+			return nil, status.Error(codes.NotFound, "group not found")
+		}
+		if errors.Is(err, use_cases.ErrGroupHasSubmission) {
+			logger.Warn("group has a submission", "error", err)
+			return nil, status.Error(codes.FailedPrecondition, "group has a submission")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.DeleteGroupResponse{}, nil
+}
+
+func (h *Handler) ListTaskGroups(ctx context.Context, req *gen.ListTaskGroupsRequest) (*gen.ListTaskGroupsResponse, error) {
+	const op = "Handler.ListTaskGroups"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := h.service.ListTaskGroups(ctx, req.GetTaskId())
+
+	if err != nil {
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	result := make([]*gen.GroupInfo, 0, len(groups))
+	for i := range groups {
+		result = append(result, toProtoGroup(&groups[i]))
+	}
+
+	return &gen.ListTaskGroupsResponse{
+		Groups: result,
+	}, nil
+}
+
+func toProtoGroup(group *use_cases.SafeGroupInfo) *gen.GroupInfo {
+	return &gen.GroupInfo{
+		GroupId:   group.GroupId,
+		TaskId:    group.TaskId,
+		MemberIds: group.MemberIds,
+		CreatedAt: timestamppb.New(group.CreatedAt),
+		Submitted: group.Submitted,
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
 
 	gen "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
@@ -14,10 +15,30 @@ import (
 )
 
 type Service interface {
-	GenerateUploadURL(ctx context.Context, studentId, taskId string) (string, error)
-	VerifyUploadedFile(ctx context.Context, studentId, taskId string) (string, error)
-	GenerateDownloadURL(ctx context.Context, studentId, taskId string, fromInside bool) (string, error)
+	GenerateUploadURL(ctx context.Context, studentId, taskId, fileName, originalName, contentType string) (*use_cases.SafeUploadForm, string, error)
+	VerifyUploadedFile(ctx context.Context, studentId, taskId, fileName string) (string, *use_cases.SafeFileVersion, error)
+	UploadFile(ctx context.Context, studentId, taskId, fileName, originalName, contentType string, body io.Reader) (string, string, *use_cases.SafeFileVersion, error)
+	GenerateDownloadURL(ctx context.Context, studentId, taskId, fileName string, fromInside bool) (string, error)
 	ListTaskFiles(ctx context.Context, taskID string) ([]use_cases.SafeFileInfo, error)
+	ListFileVersions(ctx context.Context, studentId, taskId, fileName string) (*use_cases.SafeFileHistory, error)
+	GenerateVersionDownloadURL(ctx context.Context, studentId, taskId, fileName string, version int, fromInside bool) (*use_cases.SafeFileVersion, string, error)
+	GenerateAttachmentUploadURL(ctx context.Context, taskId, ownerId, fileName string) (string, *use_cases.SafeUploadForm, error)
+	VerifyAttachment(ctx context.Context, attachmentId string) (*use_cases.SafeAttachmentInfo, error)
+	GenerateAttachmentDownloadURL(ctx context.Context, attachmentId string, fromInside bool) (*use_cases.SafeAttachmentInfo, string, error)
+	CreateGroup(ctx context.Context, taskId, groupId string, memberIds []string) (*use_cases.SafeGroupInfo, error)
+	UpdateGroupMembers(ctx context.Context, taskId, groupId string, memberIds []string) (*use_cases.SafeGroupInfo, error)
+	DeleteGroup(ctx context.Context, taskId, groupId string) error
+	ListTaskGroups(ctx context.Context, taskId string) ([]use_cases.SafeGroupInfo, error)
+	CreateTask(ctx context.Context, taskId string, settings use_cases.TaskSettings) (*use_cases.SafeTaskInfo, error)
+	UpdateTask(ctx context.Context, taskId string, settings use_cases.TaskSettings) (*use_cases.SafeTaskInfo, error)
+	GetTask(ctx context.Context, taskId string) (*use_cases.SafeTaskInfo, error)
+	ListTasks(ctx context.Context, courseId string) ([]use_cases.SafeTaskInfo, error)
+	DeleteTask(ctx context.Context, taskId string) error
+	CreateCourse(ctx context.Context, courseId, title string, members []use_cases.SafeCourseMember) (*use_cases.SafeCourseInfo, error)
+	GetCourse(ctx context.Context, courseId string) (*use_cases.SafeCourseInfo, error)
+	ImportCourseRoster(ctx context.Context, courseId string, members []use_cases.SafeCourseMember, replace bool) (*use_cases.SafeCourseInfo, error)
+	RemoveCourseMember(ctx context.Context, courseId, userId string) error
+	ListMissingSubmissions(ctx context.Context, taskId string) ([]string, error)
 }
 
 type Handler struct {
@@ -53,20 +74,44 @@ func (h *Handler) GenerateUploadURL(ctx context.Context, req *gen.GenerateUpload
 		return nil, err
 	}
 
-	url, err := h.service.GenerateUploadURL(ctx, req.GetStudentId(), req.GetTaskId())
+	if err := ValidateSubmissionFileName(req.GetFileName(), h.logger); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateUploadMetadata(req.GetOriginalName(), req.GetContentType(), h.logger); err != nil {
+		return nil, err
+	}
+
+	form, groupId, err := h.service.GenerateUploadURL(
+		ctx,
+		req.GetStudentId(),
+		req.GetTaskId(),
+		req.GetFileName(),
+		req.GetOriginalName(),
+		req.GetContentType(),
+	)
 
 	if err != nil {
 		if errors.Is(err, use_cases.ErrFailedToGenerateURL) {
 			logger.Error("failed to generate url", "error", err)
 			return nil, status.Error(codes.Internal, "failed to generate url")
 		}
+		if errors.Is(err, use_cases.ErrTooManyFiles) {
+			logger.Warn("too many files in submission", "error", err)
+			return nil, status.Error(codes.InvalidArgument, "too many files in submission")
+		}
+		if statusErr := uploadRestrictionError(err, logger); statusErr != nil {
+			return nil, statusErr
+		}
 
 		logger.Error("internal error", "error", err)
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
 	return &gen.GenerateUploadURLResponse{
-		Url: url,
+		Url:     form.URL,
+		GroupId: groupId,
+		Fields:  form.Fields,
 	}, nil
 }
 func (h *Handler) VerifyUploadedFile(ctx context.Context, req *gen.VerifyUploadedFileRequest) (*gen.VerifyUploadedFileResponse, error) {
@@ -92,20 +137,33 @@ func (h *Handler) VerifyUploadedFile(ctx context.Context, req *gen.VerifyUploade
 		return nil, err
 	}
 
-	fileId, err := h.service.VerifyUploadedFile(ctx, req.GetStudentId(), req.GetTaskId())
+	if err := ValidateSubmissionFileName(req.GetFileName(), h.logger); err != nil {
+		return nil, err
+	}
+
+	fileId, version, err := h.service.VerifyUploadedFile(ctx, req.GetStudentId(), req.GetTaskId(), req.GetFileName())
 
 	if err != nil {
 		if errors.Is(err, use_cases.ErrFileNotFound) {
 			logger.Error("file not found", "error", err)
 			return nil, status.Error(codes.NotFound, "file not found")
 		}
+		if errors.Is(err, use_cases.ErrVersionAlreadyVerified) {
+			logger.Warn("version has already been verified", "error", err)
+			return nil, status.Error(codes.AlreadyExists, "upload has already been verified")
+		}
+		if statusErr := uploadRestrictionError(err, logger); statusErr != nil {
+			return nil, statusErr
+		}
 
 		logger.Error("internal error", "error", err)
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
 	return &gen.VerifyUploadedFileResponse{
-		FileId: fileId,
+		FileId:     fileId,
+		Version:    toProtoFileVersion(version),
+		Duplicates: toProtoDuplicates(version.Duplicates),
 	}, nil
 }
 func (h *Handler) GenerateDownloadURL(ctx context.Context, req *gen.GenerateDownloadURLRequest) (*gen.GenerateDownloadURLResponse, error) {
@@ -132,7 +190,11 @@ func (h *Handler) GenerateDownloadURL(ctx context.Context, req *gen.GenerateDown
 		return nil, err
 	}
 
-	url, err := h.service.GenerateDownloadURL(ctx, req.GetStudentId(), req.GetTaskId(), req.GetFromInside())
+	if err := ValidateSubmissionFileName(req.GetFileName(), h.logger); err != nil {
+		return nil, err
+	}
+
+	url, err := h.service.GenerateDownloadURL(ctx, req.GetStudentId(), req.GetTaskId(), req.GetFileName(), req.GetFromInside())
 
 	if err != nil {
 		if errors.Is(err, use_cases.ErrFileNotFound) {
@@ -194,10 +256,31 @@ func (h *Handler) ListTaskFiles(ctx context.Context, req *gen.ListTaskFilesReque
 			updatedAt = timestamppb.New(file.UpdatedAt)
 		}
 
+		files := make([]*gen.FileVersion, 0, len(file.Files))
+		for i := range file.Files {
+			version := toProtoFileVersion(&file.Files[i])
+			version.Duplicates = toProtoDuplicates(file.Files[i].Duplicates)
+			files = append(files, version)
+		}
+
 		result = append(result, &gen.FileInfo{
-			StudentId: file.StudentId,
-			UpdatedAt: updatedAt,
-			Status:    file.Status,
+			StudentId:  file.StudentId,
+			UpdatedAt:  updatedAt,
+			Status:     file.Status,
+			MemberIds:  file.MemberIds,
+			UploadedBy: file.UploadedBy,
+			Version:    int32(file.Version),
+			Size:       file.Size,
+			Sha256:     file.SHA256,
+
+			ExactDuplicate: file.ExactDuplicate,
+			Files:          files,
+			OriginalName:   file.OriginalName,
+			ContentType:    file.ContentType,
+			MimeType:       file.MimeType,
+			Etag:           file.ETag,
+			Late:           file.Late,
+			Validation:     toProtoValidationReport(file.Validation),
 		})
 	}
 
@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	gen "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/use_cases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) CreateTask(ctx context.Context, req *gen.CreateTaskRequest) (*gen.CreateTaskResponse, error) {
+	const op = "Handler.CreateTask"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTask(req.GetTaskId(), req.GetSettings(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := h.service.CreateTask(ctx, req.GetTaskId(), toUseCaseTaskSettings(req.GetSettings()))
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskAlreadyExists) {
+			logger.Warn("task already exists", "error", err)
+			return nil, status.Error(codes.AlreadyExists, "task already exists")
+		}
+		if errors.Is(err, use_cases.ErrCourseNotFound) {
+			logger.Warn("course not found", "error", err)
+			return nil, status.Error(codes.NotFound, "course not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.CreateTaskResponse{
+		Task: toProtoTask(task),
+	}, nil
+}
+
+func (h *Handler) UpdateTask(ctx context.Context, req *gen.UpdateTaskRequest) (*gen.UpdateTaskResponse, error) {
+	const op = "Handler.UpdateTask"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTask(req.GetTaskId(), req.GetSettings(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := h.service.UpdateTask(ctx, req.GetTaskId(), toUseCaseTaskSettings(req.GetSettings()))
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task not found", "error", err)
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		if errors.Is(err, use_cases.ErrCourseNotFound) {
+			logger.Warn("course not found", "error", err)
+			return nil, status.Error(codes.NotFound, "course not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.UpdateTaskResponse{
+		Task: toProtoTask(task),
+	}, nil
+}
+
+func (h *Handler) GetTask(ctx context.Context, req *gen.GetTaskRequest) (*gen.GetTaskResponse, error) {
+	const op = "Handler.GetTask"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := h.service.GetTask(ctx, req.GetTaskId())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task not found", "error", err)
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.GetTaskResponse{
+		Task: toProtoTask(task),
+	}, nil
+}
+
+func (h *Handler) ListTasks(ctx context.Context, req *gen.ListTasksRequest) (*gen.ListTasksResponse, error) {
+	const op = "Handler.ListTasks"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("CourseId", req.GetCourseId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	if req.GetCourseId() != "" {
+		if err := ValidateIdWrapped(req.GetCourseId(), "course", logger); err != nil {
+			return nil, err
+		}
+	}
+
+	tasks, err := h.service.ListTasks(ctx, req.GetCourseId())
+
+	if err != nil {
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	result := make([]*gen.TaskInfo, 0, len(tasks))
+	for i := range tasks {
+		result = append(result, toProtoTask(&tasks[i]))
+	}
+
+	return &gen.ListTasksResponse{
+		Tasks: result,
+	}, nil
+}
+
+func (h *Handler) DeleteTask(ctx context.Context, req *gen.DeleteTaskRequest) (*gen.DeleteTaskResponse, error) {
+	const op = "Handler.DeleteTask"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskId(req.GetTaskId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.service.DeleteTask(ctx, req.GetTaskId())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrTaskNotFound) {
+			logger.Warn("task not found", "error", err)
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.DeleteTaskResponse{}, nil
+}
+
+// uploadRestrictionError переводит отказ по незаведённой задаче, срокам, типу файла, проверке загрузки или списку курса задачи
+// в статус gRPC; для прочих ошибок - nil.
+func uploadRestrictionError(err error, logger *slog.Logger) error {
+	switch {
+	case errors.Is(err, use_cases.ErrTaskNotFound):
+		logger.Warn("task not found", "error", err)
+		return status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, use_cases.ErrTaskNotOpen):
+		logger.Warn("task is not open yet", "error", err)
+		return status.Error(codes.FailedPrecondition, "task is not open for submissions yet")
+	case errors.Is(err, use_cases.ErrDeadlinePassed):
+		logger.Warn("deadline has passed", "error", err)
+		return status.Error(codes.FailedPrecondition, "task deadline has passed")
+	case errors.Is(err, use_cases.ErrFileTypeNotAllowed):
+		logger.Warn("file type is not allowed", "error", err)
+		return status.Error(codes.InvalidArgument, "file type is not allowed for the task")
+	case errors.Is(err, use_cases.ErrFileRejected):
+		logger.Warn("file rejected", "error", err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, use_cases.ErrValidationUnavailable):
+		logger.Error("file validation is unavailable", "error", err)
+		return status.Error(codes.Unavailable, "file validation is unavailable, try again later")
+	case errors.Is(err, use_cases.ErrNotEnrolled):
+		logger.Warn("student is not enrolled", "error", err)
+		return status.Error(codes.PermissionDenied, "student is not enrolled in the course of the task")
+	}
+
+	return nil
+}
+
+func toUseCaseTaskSettings(settings *gen.TaskSettings) use_cases.TaskSettings {
+	return use_cases.TaskSettings{
+		Title:        settings.GetTitle(),
+		CourseId:     settings.GetCourseId(),
+		OpensAt:      fromProtoTime(settings.GetOpensAt()),
+		Deadline:     fromProtoTime(settings.GetDeadline()),
+		LatePolicy:   settings.GetLatePolicy(),
+		AllowedTypes: settings.GetAllowedTypes(),
+
+		MaxFileSize:      settings.GetMaxFileSize(),
+		AllowedMimeTypes: settings.GetAllowedMimeTypes(),
+	}
+}
+
+func toProtoTask(task *use_cases.SafeTaskInfo) *gen.TaskInfo {
+	return &gen.TaskInfo{
+		TaskId: task.TaskId,
+		Settings: &gen.TaskSettings{
+			Title:        task.Title,
+			CourseId:     task.CourseId,
+			OpensAt:      toProtoTime(task.OpensAt),
+			Deadline:     toProtoTime(task.Deadline),
+			LatePolicy:   task.LatePolicy,
+			AllowedTypes: task.AllowedTypes,
+
+			MaxFileSize:      task.MaxFileSize,
+			AllowedMimeTypes: task.AllowedMimeTypes,
+		},
+		CreatedAt: timestamppb.New(task.CreatedAt),
+		UpdatedAt: timestamppb.New(task.UpdatedAt),
+	}
+}
+
+func fromProtoTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func toProtoTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/use_cases"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) UploadFile(stream grpc.ClientStreamingServer[gen.UploadFileRequest, gen.UploadFileResponse]) error {
+	const op = "Handler.UploadFile"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	first, err := stream.Recv()
+	if err != nil {
+		logger.Error("failed to receive metadata", "error", err)
+		return status.Error(codes.InvalidArgument, "metadata is required")
+	}
+
+	meta := first.GetMetadata()
+	if meta == nil {
+		logger.Error("first message is not metadata")
+		return status.Error(codes.InvalidArgument, "first message must carry metadata")
+	}
+
+	logger = logger.With(
+		slog.String("StudentId", meta.GetStudentId()),
+		slog.String("TaskId", meta.GetTaskId()),
+	)
+
+	err = ValidateTaskAndStudentIds(meta.GetTaskId(), meta.GetStudentId(), h.logger)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateSubmissionFileName(meta.GetFileName(), h.logger); err != nil {
+		return err
+	}
+
+	if err := ValidateUploadMetadata(meta.GetOriginalName(), meta.GetContentType(), h.logger); err != nil {
+		return err
+	}
+
+	// первый кусок читается до обращения к сервису: пустой файл не должен заводить сдачу
+	body := &chunkReader{stream: stream, limit: domain.DefaultMaxFileSize}
+	if err := body.fill(); err != nil {
+		if errors.Is(err, io.EOF) {
+			logger.Warn("file is empty")
+			return status.Error(codes.InvalidArgument, "file is empty")
+		}
+		logger.Error("failed to receive file", "error", err)
+		return status.Error(codes.InvalidArgument, "failed to receive file")
+	}
+
+	fileId, groupId, version, err := h.service.UploadFile(
+		stream.Context(),
+		meta.GetStudentId(),
+		meta.GetTaskId(),
+		meta.GetFileName(),
+		meta.GetOriginalName(),
+		meta.GetContentType(),
+		body,
+	)
+
+	if err != nil {
+		// ошибка потока клиента приходит из хранилища обёрнутой SDK, поэтому причина берётся из chunkReader
+		if body.tooLarge {
+			logger.Warn("file is too large", "received", body.received)
+			return status.Error(codes.InvalidArgument, "file is too large")
+		}
+		if body.err != nil {
+			logger.Error("failed to receive file", "error", body.err)
+			return status.Error(codes.InvalidArgument, "failed to receive file")
+		}
+		if errors.Is(err, use_cases.ErrTooManyFiles) {
+			logger.Warn("too many files in submission", "error", err)
+			return status.Error(codes.InvalidArgument, "too many files in submission")
+		}
+		if errors.Is(err, use_cases.ErrVersionAlreadyVerified) {
+			logger.Warn("version has already been uploaded", "error", err)
+			return status.Error(codes.AlreadyExists, "another upload of the file is in progress")
+		}
+		if statusErr := uploadRestrictionError(err, logger); statusErr != nil {
+			return statusErr
+		}
+
+		logger.Error("internal error", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	return stream.SendAndClose(&gen.UploadFileResponse{
+		FileId:     fileId,
+		Version:    toProtoFileVersion(version),
+		Duplicates: toProtoDuplicates(version.Duplicates),
+		GroupId:    groupId,
+	})
+}
+
+// chunkReader читает содержимое файла из кусков потока UploadFile и обрывает его после limit байт.
+type chunkReader struct {
+	stream   grpc.ClientStreamingServer[gen.UploadFileRequest, gen.UploadFileResponse]
+	limit    int64
+	received int64
+	buf      []byte
+	tooLarge bool
+	err      error
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.buf) == 0 {
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// fill получает следующий непустой кусок. Конец потока возвращается как io.EOF.
+func (r *chunkReader) fill() error {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		if err != nil {
+			r.err = err
+			return err
+		}
+		if req.GetMetadata() != nil {
+			r.err = errors.New("metadata sent twice")
+			return r.err
+		}
+
+		r.buf = req.GetChunk()
+		r.received += int64(len(r.buf))
+		if r.received > r.limit {
+			r.tooLarge = true
+			r.buf = nil
+			return errors.New("file is too large")
+		}
+	}
+	return nil
+}
@@ -3,12 +3,26 @@ package handler
 import (
 	"errors"
 	"log/slog"
+	"mime"
+	"strings"
+	"unicode"
 	"unicode/utf8"
 
+	gen "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	maxGroupMembers = 20
+	// maxAllowedTypes - сколько расширений файлов или типов по содержимому можно разрешить задаче
+	maxAllowedTypes = 20
+	// maxCourseMembers - сколько участников можно передать в одном списке курса
+	maxCourseMembers = 2000
+)
+
 func ValidateTaskAndStudentIds(taskId, studentId string, log *slog.Logger) error {
 	const op = "Handler.Validation.ValidateTaskAndStudentIds"
 
@@ -45,6 +59,49 @@ func ValidateTaskId(taskId string, log *slog.Logger) error {
 	return nil
 }
 
+// ValidateGroup проверяет id группы и список участников: непустой, без повторов и не длиннее maxGroupMembers.
+func ValidateGroup(taskId, groupId string, memberIds []string, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidateGroup"
+
+	logger := log.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+		slog.String("group id", groupId),
+	)
+
+	if err := ValidateIdWrapped(taskId, "task", logger); err != nil {
+		return err
+	}
+
+	if err := ValidateIdWrapped(groupId, "group", logger); err != nil {
+		return err
+	}
+
+	if len(memberIds) == 0 {
+		logger.Warn("group members required")
+		return status.Error(codes.InvalidArgument, "group members required")
+	}
+
+	if len(memberIds) > maxGroupMembers {
+		logger.Warn("too many group members")
+		return status.Error(codes.InvalidArgument, "too many group members")
+	}
+
+	seen := make(map[string]bool, len(memberIds))
+	for _, memberId := range memberIds {
+		if err := ValidateIdWrapped(memberId, "student", logger); err != nil {
+			return err
+		}
+		if seen[memberId] {
+			logger.Warn("duplicate group member", "member id", memberId)
+			return status.Error(codes.InvalidArgument, "duplicate group member")
+		}
+		seen[memberId] = true
+	}
+
+	return nil
+}
+
 func ValidateIdWrapped(id, nameOfId string, logger *slog.Logger) error {
 	err := ValidateId(id)
 	if err != nil {
@@ -82,3 +139,238 @@ func ValidateId(id string) error {
 	}
 	return nil
 }
+
+// ValidateFileName проверяет имя вложения: оно попадает в заголовки ответа при скачивании.
+func ValidateFileName(fileName string, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidateFileName"
+
+	logger := log.With(
+		slog.String("op", op),
+	)
+
+	if fileName == "" {
+		logger.Warn("file name required")
+		return status.Error(codes.InvalidArgument, "file name required")
+	}
+
+	if utf8.RuneCountInString(fileName) > 255 {
+		logger.Warn("file name is too long")
+		return status.Error(codes.InvalidArgument, "file name is too long")
+	}
+
+	if strings.ContainsAny(fileName, "/\\\"") || strings.IndexFunc(fileName, unicode.IsControl) >= 0 {
+		logger.Warn("file name contains forbidden characters")
+		return status.Error(codes.InvalidArgument, "file name contains forbidden characters")
+	}
+
+	return nil
+}
+
+// ValidateSubmissionFileName проверяет имя файла сдачи: пустое - основной файл, иначе те же правила, что у вложения.
+func ValidateSubmissionFileName(fileName string, log *slog.Logger) error {
+	if fileName == "" {
+		return nil
+	}
+
+	return ValidateFileName(fileName, log)
+}
+
+// ValidateUploadMetadata проверяет заявленные при загрузке исходное имя и тип файла; оба необязательны.
+// Исходное имя отдаётся при скачивании, поэтому к нему те же правила, что к имени вложения.
+func ValidateUploadMetadata(originalName, contentType string, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidateUploadMetadata"
+
+	logger := log.With(
+		slog.String("op", op),
+	)
+
+	if err := ValidateSubmissionFileName(originalName, log); err != nil {
+		return err
+	}
+
+	if contentType == "" {
+		return nil
+	}
+
+	if len(contentType) > 255 {
+		logger.Warn("content type is too long")
+		return status.Error(codes.InvalidArgument, "content type is too long")
+	}
+
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		logger.Warn("invalid content type", "error", err)
+		return status.Error(codes.InvalidArgument, "invalid content type")
+	}
+
+	return nil
+}
+
+// ValidateTask проверяет настройки задачи: название обязательно, дедлайн не раньше открытия,
+// политика опоздания - reject или mark_late, типы файлов - короткие расширения из букв и цифр.
+func ValidateTask(taskId string, settings *gen.TaskSettings, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidateTask"
+
+	logger := log.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+	)
+
+	if err := ValidateIdWrapped(taskId, "task", logger); err != nil {
+		return err
+	}
+
+	if settings.GetTitle() == "" {
+		logger.Warn("task title required")
+		return status.Error(codes.InvalidArgument, "task title required")
+	}
+
+	if utf8.RuneCountInString(settings.GetTitle()) > 255 {
+		logger.Warn("task title is too long")
+		return status.Error(codes.InvalidArgument, "task title is too long")
+	}
+
+	if settings.GetCourseId() != "" {
+		if err := ValidateIdWrapped(settings.GetCourseId(), "course", logger); err != nil {
+			return err
+		}
+	}
+
+	if settings.GetOpensAt() != nil && settings.GetDeadline() != nil &&
+		settings.GetDeadline().AsTime().Before(settings.GetOpensAt().AsTime()) {
+		logger.Warn("deadline is before opening")
+		return status.Error(codes.InvalidArgument, "deadline must not be before opens_at")
+	}
+
+	switch domain.LatePolicy(settings.GetLatePolicy()) {
+	case "", domain.LatePolicyReject, domain.LatePolicyMarkLate:
+	default:
+		logger.Warn("invalid late policy", "late policy", settings.GetLatePolicy())
+		return status.Error(codes.InvalidArgument, "late policy must be reject or mark_late")
+	}
+
+	if len(settings.GetAllowedTypes()) > maxAllowedTypes {
+		logger.Warn("too many allowed types")
+		return status.Error(codes.InvalidArgument, "too many allowed types")
+	}
+
+	for _, fileType := range settings.GetAllowedTypes() {
+		fileType = strings.TrimPrefix(fileType, ".")
+		if fileType == "" || len(fileType) > 20 || strings.IndexFunc(fileType, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) >= 0 {
+			logger.Warn("invalid allowed type", "type", fileType)
+			return status.Error(codes.InvalidArgument, "allowed types must be file extensions")
+		}
+	}
+
+	if settings.GetMaxFileSize() < 0 || settings.GetMaxFileSize() > domain.DefaultMaxFileSize {
+		logger.Warn("invalid max file size", "max file size", settings.GetMaxFileSize())
+		return status.Error(codes.InvalidArgument, "max file size must be between 0 and 500000000 bytes")
+	}
+
+	if len(settings.GetAllowedMimeTypes()) > maxAllowedTypes {
+		logger.Warn("too many allowed mime types")
+		return status.Error(codes.InvalidArgument, "too many allowed mime types")
+	}
+
+	for _, mimeType := range settings.GetAllowedMimeTypes() {
+		if !validMimeTypePattern(mimeType) {
+			logger.Warn("invalid allowed mime type", "mime type", mimeType)
+			return status.Error(codes.InvalidArgument, "allowed mime types must look like type/subtype or type/")
+		}
+	}
+
+	return nil
+}
+
+// ValidateCourse проверяет id и название курса и его начальный список участников.
+func ValidateCourse(courseId, title string, members []*gen.CourseMember, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidateCourse"
+
+	logger := log.With(
+		slog.String("op", op),
+		slog.String("course id", courseId),
+	)
+
+	if err := ValidateIdWrapped(courseId, "course", logger); err != nil {
+		return err
+	}
+
+	if title == "" {
+		logger.Warn("course title required")
+		return status.Error(codes.InvalidArgument, "course title required")
+	}
+
+	if utf8.RuneCountInString(title) > 255 {
+		logger.Warn("course title is too long")
+		return status.Error(codes.InvalidArgument, "course title is too long")
+	}
+
+	return ValidateCourseMembers(members, log)
+}
+
+// ValidateCourseMembers проверяет список участников курса: роли student или teacher, без повторов
+// и не длиннее maxCourseMembers.
+func ValidateCourseMembers(members []*gen.CourseMember, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidateCourseMembers"
+
+	logger := log.With(
+		slog.String("op", op),
+	)
+
+	if len(members) > maxCourseMembers {
+		logger.Warn("too many course members")
+		return status.Error(codes.InvalidArgument, "too many course members")
+	}
+
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		if err := ValidateIdWrapped(member.GetUserId(), "user", logger); err != nil {
+			return err
+		}
+
+		switch domain.CourseRole(member.GetRole()) {
+		case domain.CourseRoleStudent, domain.CourseRoleTeacher:
+		default:
+			logger.Warn("invalid course role", "user id", member.GetUserId(), "role", member.GetRole())
+			return status.Error(codes.InvalidArgument, "course role must be student or teacher")
+		}
+
+		if seen[member.GetUserId()] {
+			logger.Warn("duplicate course member", "user id", member.GetUserId())
+			return status.Error(codes.InvalidArgument, "duplicate course member")
+		}
+		seen[member.GetUserId()] = true
+	}
+
+	return nil
+}
+
+func ValidateAttachmentId(attachmentId string, log *slog.Logger) error {
+	const op = "Handler.Validation.ValidateAttachmentId"
+
+	logger := log.With(
+		slog.String("op", op),
+		slog.String("id", attachmentId),
+	)
+
+	if _, err := uuid.Parse(attachmentId); err != nil {
+		logger.Warn("invalid attachment id")
+		return status.Error(codes.InvalidArgument, "invalid attachment id")
+	}
+
+	return nil
+}
+
+// validMimeTypePattern проверяет элемент списка допустимых типов по содержимому: "type/subtype" или группа "type/".
+func validMimeTypePattern(pattern string) bool {
+	if len(pattern) > 100 || strings.Contains(pattern, "*") {
+		return false
+	}
+	if group, ok := strings.CutSuffix(pattern, "/"); ok {
+		return group != "" && !strings.Contains(group, "/") && validMimeTypePattern(group+"/x")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(pattern)
+	return err == nil && len(params) == 0 && strings.Count(mediaType, "/") == 1
+}
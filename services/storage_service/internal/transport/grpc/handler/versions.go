@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	gen "github.com/Nikita-Smirnov-idk/storage-service/contracts/gen/go"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/use_cases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *Handler) ListFileVersions(ctx context.Context, req *gen.ListFileVersionsRequest) (*gen.ListFileVersionsResponse, error) {
+	const op = "Handler.ListFileVersions"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("StudentId", req.GetStudentId()),
+		slog.String("TaskId", req.GetTaskId()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskAndStudentIds(req.GetTaskId(), req.GetStudentId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateSubmissionFileName(req.GetFileName(), h.logger); err != nil {
+		return nil, err
+	}
+
+	history, err := h.service.ListFileVersions(ctx, req.GetStudentId(), req.GetTaskId(), req.GetFileName())
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrFileNotFound) {
+			logger.Error("file not found", "error", err)
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	result := make([]*gen.FileVersion, 0, len(history.Versions))
+	for i := range history.Versions {
+		result = append(result, toProtoFileVersion(&history.Versions[i]))
+	}
+
+	return &gen.ListFileVersionsResponse{
+		Versions:   result,
+		Status:     history.Status,
+		Validation: toProtoValidationReport(history.Validation),
+	}, nil
+}
+
+func (h *Handler) GenerateVersionDownloadURL(ctx context.Context, req *gen.GenerateVersionDownloadURLRequest) (*gen.GenerateVersionDownloadURLResponse, error) {
+	const op = "Handler.GenerateVersionDownloadURL"
+
+	logger := h.logger.With(
+		slog.String("op", op),
+		slog.String("StudentId", req.GetStudentId()),
+		slog.String("TaskId", req.GetTaskId()),
+		slog.Int("Version", int(req.GetVersion())),
+		slog.Bool("FromInside", req.GetFromInside()),
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(
+				"PANIC",
+				"recover", r,
+			)
+		}
+	}()
+
+	err := ValidateTaskAndStudentIds(req.GetTaskId(), req.GetStudentId(), h.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateSubmissionFileName(req.GetFileName(), h.logger); err != nil {
+		return nil, err
+	}
+
+	if req.GetVersion() < 1 {
+		logger.Warn("invalid version")
+		return nil, status.Error(codes.InvalidArgument, "version must be positive")
+	}
+
+	version, url, err := h.service.GenerateVersionDownloadURL(
+		ctx,
+		req.GetStudentId(),
+		req.GetTaskId(),
+		req.GetFileName(),
+		int(req.GetVersion()),
+		req.GetFromInside(),
+	)
+
+	if err != nil {
+		if errors.Is(err, use_cases.ErrFileNotFound) {
+			logger.Error("file not found", "error", err)
+			return nil, status.Error(codes.NotFound, "file not found")
+		}
+		if errors.Is(err, use_cases.ErrVersionNotFound) {
+			logger.Warn("version not found", "error", err)
+			return nil, status.Error(codes.NotFound, "version not found")
+		}
+		if errors.Is(err, use_cases.ErrFailedToGenerateURL) {
+			logger.Error("failed to generate url", "error", err)
+			return nil, status.Error(codes.Internal, "failed to generate url")
+		}
+
+		logger.Error("internal error", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &gen.GenerateVersionDownloadURLResponse{
+		Version: toProtoFileVersion(version),
+		Url:     url,
+	}, nil
+}
+
+func toProtoFileVersion(version *use_cases.SafeFileVersion) *gen.FileVersion {
+	return &gen.FileVersion{
+		Version:    int32(version.Version),
+		Size:       version.Size,
+		Sha256:     version.SHA256,
+		UploadedBy: version.UploadedBy,
+		CreatedAt:  timestamppb.New(version.CreatedAt),
+		Name:       version.FileName,
+
+		ExactDuplicate: version.ExactDuplicate,
+		OriginalName:   version.OriginalName,
+		ContentType:    version.ContentType,
+		MimeType:       version.MimeType,
+		Etag:           version.ETag,
+		Late:           version.Late,
+	}
+}
+
+func toProtoValidationReport(report *use_cases.SafeValidationReport) *gen.ValidationReport {
+	if report == nil {
+		return nil
+	}
+
+	steps := make([]*gen.ValidationStep, 0, len(report.Steps))
+	for _, step := range report.Steps {
+		steps = append(steps, &gen.ValidationStep{
+			Validator: step.Validator,
+			Passed:    step.Passed,
+			Reason:    step.Reason,
+		})
+	}
+
+	return &gen.ValidationReport{
+		FileName:        report.FileName,
+		RejectionReason: report.RejectionReason,
+		Steps:           steps,
+		CheckedAt:       timestamppb.New(report.CheckedAt),
+	}
+}
+
+func toProtoDuplicates(duplicates []use_cases.SafeDuplicate) []*gen.DuplicateFile {
+	result := make([]*gen.DuplicateFile, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		result = append(result, &gen.DuplicateFile{
+			StudentId: duplicate.StudentId,
+			TaskId:    duplicate.TaskId,
+			FileName:  duplicate.FileName,
+			Version:   int32(duplicate.Version),
+			CreatedAt: timestamppb.New(duplicate.CreatedAt),
+		})
+	}
+	return result
+}
@@ -6,7 +6,10 @@ import (
 	"net"
 
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/transport/grpc/handler"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/identity"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/mtls"
 	googleGRPC "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type Server struct {
@@ -15,8 +18,27 @@ type Server struct {
 	port       int
 }
 
-func New(logger *slog.Logger, port int, service handler.Service) *Server {
-	gRPCServer := googleGRPC.NewServer()
+// New создаёт gRPC-сервер. С creds сервер требует сертификат клиента и проверяет каждый вызов по acl,
+// а пользователя, от имени которого делают вызов клиенты identityClients, кладёт в контекст вызова (пакет identity).
+// Без creds клиент не проверен, поэтому metadata с пользователем игнорируется.
+func New(logger *slog.Logger, port int, service handler.Service, creds credentials.TransportCredentials, acl mtls.ACL, identityClients []string) *Server {
+	var opts []googleGRPC.ServerOption
+	if creds != nil {
+		// пользователь из metadata - только после проверки клиента
+		opts = append(opts,
+			googleGRPC.Creds(creds),
+			googleGRPC.ChainUnaryInterceptor(
+				acl.UnaryServerInterceptor(logger),
+				identity.UnaryServerInterceptor(identityClients),
+			),
+			googleGRPC.ChainStreamInterceptor(
+				acl.StreamServerInterceptor(logger),
+				identity.StreamServerInterceptor(identityClients),
+			),
+		)
+	}
+
+	gRPCServer := googleGRPC.NewServer(opts...)
 
 	handler.Register(gRPCServer, service, logger)
 
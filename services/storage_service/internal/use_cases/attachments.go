@@ -0,0 +1,142 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/identity"
+	"github.com/google/uuid"
+)
+
+// GenerateAttachmentUploadURL регистрирует новое вложение и возвращает его id и форму для загрузки.
+// В отличие от работы студента, вложений у владельца может быть сколько угодно.
+func (f *FileService) GenerateAttachmentUploadURL(ctx context.Context, taskId, ownerId, fileName string) (string, *SafeUploadForm, error) {
+	const op = "Storage_Service.GenerateAttachmentUploadURL"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+	)
+
+	attachmentId, err := uuid.NewUUID()
+	if err != nil {
+		logger.Error("failed to generate uuid", "error", err)
+		return "", nil, fmt.Errorf("failed to generate uuid: %w", err)
+	}
+
+	now := time.Now()
+	attachment := &domain.Attachment{
+		ID:        attachmentId,
+		TaskID:    taskId,
+		OwnerID:   identity.ActorID(ctx, ownerId),
+		FileName:  fileName,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Status:    domain.FileStatusUploading,
+	}
+
+	if err = f.DB.SaveAttachment(ctx, attachment); err != nil {
+		logger.Error("failed to save data to database", "error", err)
+		return "", nil, fmt.Errorf("failed to save data to database: %w", err)
+	}
+
+	logger.Info("Attachment Info", "attachment id", attachment.ID.String())
+
+	form, err := f.S3.GenerateUploadForm(attachment.Key(), domain.UploadConstraints{
+		MinSize: 1,
+		MaxSize: domain.DefaultMaxFileSize,
+	})
+	if err != nil {
+		logger.Error("failed to generate url", "error", err)
+		return "", nil, ErrFailedToGenerateURL
+	}
+
+	return attachment.ID.String(), &SafeUploadForm{URL: form.URL, Fields: form.Fields}, nil
+}
+
+// VerifyAttachment проверяет, что вложение загружено в бакет, и отмечает его загруженным.
+func (f *FileService) VerifyAttachment(ctx context.Context, attachmentId string) (*SafeAttachmentInfo, error) {
+	const op = "Storage_Service.VerifyAttachment"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("attachment id", attachmentId),
+	)
+
+	attachment, err := f.getAttachment(ctx, attachmentId, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = f.S3.VerifyUploadedFile(attachment.Key()); err != nil {
+		logger.Error("failed to verify uploaded attachment", "error", err)
+		return nil, fmt.Errorf("failed to verify uploaded attachment: %w", err)
+	}
+
+	if err = f.DB.UpdateAttachmentStatus(ctx, attachmentId, domain.FileStatusUploaded); err != nil {
+		logger.Error("failed to update status", "error", err)
+		return nil, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	attachment.Status = domain.FileStatusUploaded
+	attachment.UpdatedAt = time.Now()
+
+	return toSafeAttachmentInfo(attachment), nil
+}
+
+// GenerateAttachmentDownloadURL возвращает сведения о загруженном вложении и ссылку на его скачивание.
+func (f *FileService) GenerateAttachmentDownloadURL(ctx context.Context, attachmentId string, fromInside bool) (*SafeAttachmentInfo, string, error) {
+	const op = "Storage_Service.GenerateAttachmentDownloadURL"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("attachment id", attachmentId),
+	)
+
+	attachment, err := f.getAttachment(ctx, attachmentId, logger)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if attachment.Status != domain.FileStatusUploaded {
+		logger.Error("attachment has not been uploaded yet")
+		return nil, "", ErrFileYetNotUploaded
+	}
+
+	urlToDownload, err := f.S3.GenerateDownloadURL(attachment.Key(), attachment.FileName, fromInside)
+	if err != nil {
+		logger.Error("failed to generate url", "error", err)
+		return nil, "", ErrFailedToGenerateURL
+	}
+
+	return toSafeAttachmentInfo(attachment), urlToDownload, nil
+}
+
+func (f *FileService) getAttachment(ctx context.Context, attachmentId string, logger *slog.Logger) (*domain.Attachment, error) {
+	attachment, err := f.DB.GetAttachment(ctx, attachmentId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("attachment not found")
+			return nil, ErrAttachmentNotFound
+		}
+		logger.Error("failed to find attachment", "error", err)
+		return nil, fmt.Errorf("failed to find attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func toSafeAttachmentInfo(attachment *domain.Attachment) *SafeAttachmentInfo {
+	return &SafeAttachmentInfo{
+		AttachmentId: attachment.ID.String(),
+		TaskId:       attachment.TaskID,
+		OwnerId:      attachment.OwnerID,
+		FileName:     attachment.FileName,
+		UpdatedAt:    attachment.UpdatedAt,
+		Status:       string(attachment.Status),
+	}
+}
@@ -0,0 +1,251 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+)
+
+// CreateCourse создаёт курс, сразу со списком участников или пустой.
+func (f *FileService) CreateCourse(ctx context.Context, courseId, title string, members []SafeCourseMember) (*SafeCourseInfo, error) {
+	const op = "Storage_Service.CreateCourse"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("course id", courseId),
+	)
+
+	course := &domain.Course{
+		ID:        courseId,
+		Title:     title,
+		Members:   toDomainCourseMembers(members),
+		CreatedAt: time.Now(),
+	}
+
+	if err := f.DB.SaveCourse(ctx, course); err != nil {
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			logger.Warn("course already exists")
+			return nil, ErrCourseAlreadyExists
+		}
+		logger.Error("failed to save course", "error", err)
+		return nil, fmt.Errorf("failed to save course: %w", err)
+	}
+
+	return f.GetCourse(ctx, courseId)
+}
+
+func (f *FileService) GetCourse(ctx context.Context, courseId string) (*SafeCourseInfo, error) {
+	const op = "Storage_Service.GetCourse"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("course id", courseId),
+	)
+
+	course, err := f.getCourse(ctx, courseId, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSafeCourseInfo(course), nil
+}
+
+// ImportCourseRoster добавляет участников курса (из списка группы) или меняет их роль;
+// при replace участники, которых нет в списке, исключаются из курса.
+func (f *FileService) ImportCourseRoster(ctx context.Context, courseId string, members []SafeCourseMember, replace bool) (*SafeCourseInfo, error) {
+	const op = "Storage_Service.ImportCourseRoster"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("course id", courseId),
+		slog.Bool("replace", replace),
+	)
+
+	err := f.DB.ImportCourseMembers(ctx, courseId, toDomainCourseMembers(members), replace)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("course not found")
+			return nil, ErrCourseNotFound
+		}
+		logger.Error("failed to import roster", "error", err)
+		return nil, fmt.Errorf("failed to import roster: %w", err)
+	}
+
+	logger.Info("roster imported", "members", len(members))
+
+	return f.GetCourse(ctx, courseId)
+}
+
+// RemoveCourseMember исключает участника из курса; его прежние сдачи остаются.
+func (f *FileService) RemoveCourseMember(ctx context.Context, courseId, userId string) error {
+	const op = "Storage_Service.RemoveCourseMember"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("course id", courseId),
+		slog.String("user id", userId),
+	)
+
+	if err := f.DB.RemoveCourseMember(ctx, courseId, userId); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("course member not found")
+			return ErrCourseMemberNotFound
+		}
+		logger.Error("failed to remove course member", "error", err)
+		return fmt.Errorf("failed to remove course member: %w", err)
+	}
+
+	return nil
+}
+
+// ListMissingSubmissions возвращает студентов курса задачи, которые ничего не сдали ни сами, ни в группе.
+// У задачи без курса список пуст.
+func (f *FileService) ListMissingSubmissions(ctx context.Context, taskId string) ([]string, error) {
+	const op = "Storage_Service.ListMissingSubmissions"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+	)
+
+	task, err := f.DB.GetTask(ctx, taskId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("task not found")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to find task", "error", err)
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	if task.CourseID == "" {
+		return nil, nil
+	}
+
+	course, err := f.getCourse(ctx, task.CourseID, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := f.DB.ListTaskFiles(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to find files by task id", "error", err)
+		return nil, fmt.Errorf("failed to find files by task id: %w", err)
+	}
+
+	groups, err := f.DB.ListTaskGroups(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to find groups by task id", "error", err)
+		return nil, fmt.Errorf("failed to find groups by task id: %w", err)
+	}
+
+	members := make(map[string][]string, len(groups))
+	for _, group := range groups {
+		members[group.ID] = group.MemberIDs
+	}
+
+	submitted := make(map[string]bool)
+	for _, file := range files {
+		if file.Status != domain.FileStatusUploaded {
+			continue
+		}
+		submitted[file.StudentID] = true
+		for _, memberId := range members[file.GroupID] {
+			submitted[memberId] = true
+		}
+	}
+
+	var missing []string
+	for _, member := range course.Members {
+		if member.Role == domain.CourseRoleStudent && !submitted[member.UserID] {
+			missing = append(missing, member.UserID)
+		}
+	}
+	slices.Sort(missing)
+
+	return missing, nil
+}
+
+// checkEnrollment проверяет, что все studentIds - студенты курса задачи. Задачи без курса
+// (и незаведённые задачи, task == nil) принимают сдачи от кого угодно.
+func (f *FileService) checkEnrollment(ctx context.Context, task *domain.Task, logger *slog.Logger, studentIds ...string) error {
+	if task == nil || task.CourseID == "" {
+		return nil
+	}
+
+	for _, studentId := range studentIds {
+		role, err := f.DB.GetCourseRole(ctx, task.CourseID, studentId)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			logger.Error("failed to find course member", "error", err)
+			return fmt.Errorf("failed to find course member: %w", err)
+		}
+		if role != domain.CourseRoleStudent {
+			logger.Warn("student is not enrolled in the course", "student id", studentId, "course id", task.CourseID)
+			return ErrNotEnrolled
+		}
+	}
+
+	return nil
+}
+
+// findTask возвращает заведённую задачу или nil, если задачи нет.
+func (f *FileService) findTask(ctx context.Context, taskId string, logger *slog.Logger) (*domain.Task, error) {
+	task, err := f.DB.GetTask(ctx, taskId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, nil
+		}
+		logger.Error("failed to find task", "error", err)
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (f *FileService) getCourse(ctx context.Context, courseId string, logger *slog.Logger) (*domain.Course, error) {
+	course, err := f.DB.GetCourse(ctx, courseId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("course not found")
+			return nil, ErrCourseNotFound
+		}
+		logger.Error("failed to find course", "error", err)
+		return nil, fmt.Errorf("failed to find course: %w", err)
+	}
+
+	return course, nil
+}
+
+func toDomainCourseMembers(members []SafeCourseMember) []domain.CourseMember {
+	result := make([]domain.CourseMember, 0, len(members))
+	for _, member := range members {
+		result = append(result, domain.CourseMember{
+			UserID: member.UserId,
+			Role:   domain.CourseRole(member.Role),
+		})
+	}
+	return result
+}
+
+func toSafeCourseInfo(course *domain.Course) *SafeCourseInfo {
+	members := make([]SafeCourseMember, 0, len(course.Members))
+	for _, member := range course.Members {
+		members = append(members, SafeCourseMember{
+			UserId: member.UserID,
+			Role:   string(member.Role),
+		})
+	}
+
+	return &SafeCourseInfo{
+		CourseId:  course.ID,
+		Title:     course.Title,
+		Members:   members,
+		CreatedAt: course.CreatedAt,
+	}
+}
@@ -4,9 +4,157 @@ import (
 	"time"
 )
 
+// SafeFileInfo - сдача задачи. У сдачи группы StudentId - id группы, MemberIds - её участники,
+// UploadedBy - пользователь, загрузивший файл: участник группы или преподаватель, загрузивший работу за студента.
 type SafeFileInfo struct {
+	StudentId  string   `json:"student_id"`
+	MemberIds  []string `json:"member_ids"`
+	UploadedBy string   `json:"uploaded_by"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+	Status    string    `json:"status"`
+	// Version - последняя подтверждённая версия основного файла сдачи, Size и SHA256 - её размер и хэш
+	Version int    `json:"version"`
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+	// ExactDuplicate - такое же содержимое сдавал другой студент в этой или другой задаче
+	ExactDuplicate bool `json:"exact_duplicate"`
+	// OriginalName, ContentType, MimeType и ETag - метаданные последней версии основного файла
+	OriginalName string `json:"original_name"`
+	ContentType  string `json:"content_type"`
+	MimeType     string `json:"mime_type"`
+	ETag         string `json:"etag"`
+	// Late - последняя версия хотя бы одного файла сдачи загружена после дедлайна
+	Late bool `json:"late"`
+	// Files - последние версии всех файлов сдачи (включая основной) по имени
+	Files []SafeFileVersion `json:"files"`
+	// Validation - проверка последней загрузки любого файла сдачи, nil - загрузки ещё не проверялись
+	Validation *SafeValidationReport `json:"validation"`
+}
+
+// SafeFileHistory - история сдачи: её статус, проверенные версии и проверка последней загрузки.
+type SafeFileHistory struct {
+	Status   string            `json:"status"`
+	Versions []SafeFileVersion `json:"versions"`
+	// Validation - nil, если загрузки не проверялись или последняя проверка была у другого файла
+	Validation *SafeValidationReport `json:"validation"`
+}
+
+// SafeValidationReport - результат проверки загрузки по шагам. RejectionReason - причина отказа
+// в виде "шаг: причина", пустая у принятой загрузки.
+type SafeValidationReport struct {
+	FileName        string               `json:"file_name"`
+	RejectionReason string               `json:"rejection_reason"`
+	Steps           []SafeValidationStep `json:"steps"`
+
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type SafeValidationStep struct {
+	Validator string `json:"validator"`
+	Passed    bool   `json:"passed"`
+	Reason    string `json:"reason"`
+}
+
+// SafeUploadForm - ссылка и поля формы для загрузки файла POST-запросом прямо в хранилище.
+type SafeUploadForm struct {
+	URL    string            `json:"url"`
+	Fields map[string]string `json:"fields"`
+}
+
+type SafeFileVersion struct {
+	// FileName - имя файла в сдаче, пустое у основного файла
+	FileName   string `json:"file_name"`
+	Version    int    `json:"version"`
+	Size       int64  `json:"size"`
+	SHA256     string `json:"sha256"`
+	UploadedBy string `json:"uploaded_by"`
+	// OriginalName и ContentType - заявленные при загрузке, MimeType - определённый по содержимому
+	OriginalName string `json:"original_name"`
+	ContentType  string `json:"content_type"`
+	MimeType     string `json:"mime_type"`
+	ETag         string `json:"etag"`
+	// Late - версия загружена после дедлайна задачи
+	Late bool `json:"late"`
+
+	CreatedAt time.Time `json:"created_at"`
+	// ExactDuplicate заполняется только в списке файлов сдачи, Duplicates - чужие сдачи
+	// с тем же содержимым - при проверке загрузки и у помеченных дубликатами файлов в списке
+	ExactDuplicate bool            `json:"exact_duplicate"`
+	Duplicates     []SafeDuplicate `json:"duplicates,omitempty"`
+}
+
+type SafeDuplicate struct {
 	StudentId string `json:"student_id"`
+	TaskId    string `json:"task_id"`
+	FileName  string `json:"file_name"`
+	Version   int    `json:"version"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SafeAttachmentInfo struct {
+	AttachmentId string `json:"attachment_id"`
+	TaskId       string `json:"task_id"`
+	OwnerId      string `json:"owner_id"`
+	FileName     string `json:"file_name"`
 
 	UpdatedAt time.Time `json:"updated_at"`
 	Status    string    `json:"status"`
 }
+
+type SafeGroupInfo struct {
+	GroupId   string   `json:"group_id"`
+	TaskId    string   `json:"task_id"`
+	MemberIds []string `json:"member_ids"`
+
+	CreatedAt time.Time `json:"created_at"`
+	Submitted bool      `json:"submitted"`
+}
+
+// TaskSettings - изменяемые настройки задачи.
+type TaskSettings struct {
+	Title    string
+	CourseId string
+	// OpensAt и Deadline - границы приёма сдач, nil - без ограничения
+	OpensAt  *time.Time
+	Deadline *time.Time
+	// LatePolicy - reject или mark_late, пустая - reject
+	LatePolicy   string
+	AllowedTypes []string
+	// MaxFileSize - предельный размер файла в байтах, 0 - предел сервиса по умолчанию
+	MaxFileSize int64
+	// AllowedMimeTypes - допустимые типы по содержимому или их группы ("text/"), пустой - любые
+	AllowedMimeTypes []string
+}
+
+type SafeTaskInfo struct {
+	TaskId   string `json:"task_id"`
+	Title    string `json:"title"`
+	CourseId string `json:"course_id"`
+
+	OpensAt      *time.Time `json:"opens_at"`
+	Deadline     *time.Time `json:"deadline"`
+	LatePolicy   string     `json:"late_policy"`
+	AllowedTypes []string   `json:"allowed_types"`
+
+	MaxFileSize      int64    `json:"max_file_size"`
+	AllowedMimeTypes []string `json:"allowed_mime_types"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SafeCourseInfo struct {
+	CourseId string             `json:"course_id"`
+	Title    string             `json:"title"`
+	Members  []SafeCourseMember `json:"members"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SafeCourseMember struct {
+	UserId string `json:"user_id"`
+	// Role - student или teacher
+	Role string `json:"role"`
+}
@@ -3,7 +3,28 @@ package use_cases
 import "errors"
 
 var (
-	ErrFailedToGenerateURL = errors.New("failed to generate url")
-	ErrFileNotFound        = errors.New("file not found")
-	ErrFileYetNotUploaded  = errors.New("file has not been uploaded yet")
+	ErrFailedToGenerateURL  = errors.New("failed to generate url")
+	ErrFileNotFound         = errors.New("file not found")
+	ErrFileYetNotUploaded   = errors.New("file has not been uploaded yet")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrGroupNotFound        = errors.New("group not found")
+	ErrGroupConflict        = errors.New("group id or member is already taken in the task")
+	ErrGroupHasSubmission   = errors.New("group has a submission")
+	ErrVersionNotFound      = errors.New("file version not found")
+	ErrTooManyFiles         = errors.New("too many files in submission")
+	ErrTaskNotFound         = errors.New("task not found")
+	ErrTaskAlreadyExists    = errors.New("task already exists")
+	ErrTaskNotOpen          = errors.New("task is not open for submissions yet")
+	ErrDeadlinePassed       = errors.New("task deadline has passed")
+	ErrFileTypeNotAllowed   = errors.New("file type is not allowed for the task")
+	ErrCourseNotFound       = errors.New("course not found")
+	ErrCourseAlreadyExists  = errors.New("course already exists")
+	ErrCourseMemberNotFound = errors.New("course member not found")
+	ErrNotEnrolled          = errors.New("student is not enrolled in the course of the task")
+	// ErrVersionAlreadyVerified - ту же загрузку параллельно подтвердил другой запрос
+	ErrVersionAlreadyVerified = errors.New("file version has already been verified")
+	// ErrFileRejected - загруженный файл не прошёл проверку, причина добавляется к ошибке
+	ErrFileRejected = errors.New("file rejected")
+	// ErrValidationUnavailable - проверку не удалось выполнить, например, недоступен антивирус
+	ErrValidationUnavailable = errors.New("file validation is unavailable")
 )
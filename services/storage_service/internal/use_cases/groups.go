@@ -0,0 +1,305 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+)
+
+// CreateGroup создаёт группу задачи. Id группы и студентов задачи не должны совпадать:
+// plagiarism-service обращается к сдаче группы по её id так же, как к сдаче студента.
+func (f *FileService) CreateGroup(ctx context.Context, taskId, groupId string, memberIds []string) (*SafeGroupInfo, error) {
+	const op = "Storage_Service.CreateGroup"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+		slog.String("group id", groupId),
+	)
+
+	if err := f.checkGroupIds(ctx, taskId, groupId, memberIds, logger); err != nil {
+		return nil, err
+	}
+
+	if err := f.checkMembersEnrolled(ctx, taskId, memberIds, logger); err != nil {
+		return nil, err
+	}
+
+	group := &domain.Group{
+		ID:        groupId,
+		TaskID:    taskId,
+		MemberIDs: memberIds,
+		CreatedAt: time.Now(),
+	}
+
+	if err := f.DB.SaveGroup(ctx, group); err != nil {
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			logger.Warn("group or member already exists")
+			return nil, ErrGroupConflict
+		}
+		logger.Error("failed to save group", "error", err)
+		return nil, fmt.Errorf("failed to save group: %w", err)
+	}
+
+	return toSafeGroupInfo(group, false), nil
+}
+
+// UpdateGroupMembers заменяет состав группы. Сдача остаётся за группой и доступна новому составу.
+func (f *FileService) UpdateGroupMembers(ctx context.Context, taskId, groupId string, memberIds []string) (*SafeGroupInfo, error) {
+	const op = "Storage_Service.UpdateGroupMembers"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+		slog.String("group id", groupId),
+	)
+
+	group, err := f.getGroup(ctx, taskId, groupId, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = f.checkGroupIds(ctx, taskId, "", memberIds, logger); err != nil {
+		return nil, err
+	}
+
+	if err = f.checkMembersEnrolled(ctx, taskId, memberIds, logger); err != nil {
+		return nil, err
+	}
+
+	group.MemberIDs = memberIds
+	if err = f.DB.ReplaceGroupMembers(ctx, group); err != nil {
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			logger.Warn("member is in another group")
+			return nil, ErrGroupConflict
+		}
+		logger.Error("failed to update members", "error", err)
+		return nil, fmt.Errorf("failed to update members: %w", err)
+	}
+
+	submitted, err := f.groupSubmitted(ctx, group, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSafeGroupInfo(group, submitted), nil
+}
+
+// DeleteGroup удаляет группу без сдачи; группу со сдачей удалить нельзя, иначе файл останется без автора.
+func (f *FileService) DeleteGroup(ctx context.Context, taskId, groupId string) error {
+	const op = "Storage_Service.DeleteGroup"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+		slog.String("group id", groupId),
+	)
+
+	group, err := f.getGroup(ctx, taskId, groupId, logger)
+	if err != nil {
+		return err
+	}
+
+	submitted, err := f.groupSubmitted(ctx, group, logger)
+	if err != nil {
+		return err
+	}
+	if submitted {
+		logger.Warn("group has a submission")
+		return ErrGroupHasSubmission
+	}
+
+	if err = f.DB.DeleteGroup(ctx, taskId, groupId); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("group not found")
+			return ErrGroupNotFound
+		}
+		logger.Error("failed to delete group", "error", err)
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	return nil
+}
+
+func (f *FileService) ListTaskGroups(ctx context.Context, taskId string) ([]SafeGroupInfo, error) {
+	const op = "Storage_Service.ListTaskGroups"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+	)
+
+	groups, err := f.DB.ListTaskGroups(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to find groups by task id", "error", err)
+		return nil, fmt.Errorf("failed to find groups by task id: %w", err)
+	}
+
+	files, err := f.DB.ListTaskFiles(ctx, taskId)
+	if err != nil {
+		logger.Error("failed to find files by task id", "error", err)
+		return nil, fmt.Errorf("failed to find files by task id: %w", err)
+	}
+
+	submitted := make(map[string]bool)
+	for _, file := range files {
+		if file.GroupID != "" {
+			submitted[file.GroupID] = true
+		}
+	}
+
+	result := make([]SafeGroupInfo, 0, len(groups))
+	for i := range groups {
+		result = append(result, *toSafeGroupInfo(&groups[i], submitted[groups[i].ID]))
+	}
+
+	return result, nil
+}
+
+// findSubmission находит сдачу, к которой относится studentId: сдачу его группы, сдачу группы
+// с таким id или личную. Вторая возвращаемая группа - nil у личной сдачи.
+func (f *FileService) findSubmission(ctx context.Context, studentId, taskId string) (*domain.FileInfo, *domain.Group, error) {
+	group, err := f.DB.GetGroupByMember(ctx, taskId, studentId)
+	if errors.Is(err, repositories.ErrNotFound) {
+		group, err = f.DB.GetGroup(ctx, taskId, studentId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			group, err = nil, nil
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if group == nil {
+		fileInfo, err := f.DB.GetByStudentAndTask(ctx, studentId, taskId)
+		return fileInfo, nil, err
+	}
+
+	fileInfo, err := f.DB.GetByGroupAndTask(ctx, group.ID, taskId)
+	return fileInfo, group, err
+}
+
+// checkGroupIds проверяет, что id группы не занят студентом задачи или курса, а участники - не id групп.
+// Пустой groupId не проверяется.
+func (f *FileService) checkGroupIds(ctx context.Context, taskId, groupId string, memberIds []string, logger *slog.Logger) error {
+	if groupId != "" {
+		_, err := f.DB.GetByStudentAndTask(ctx, groupId, taskId)
+		if err == nil {
+			logger.Warn("group id is used by a student")
+			return ErrGroupConflict
+		}
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logger.Error("failed to find file", "error", err)
+			return fmt.Errorf("failed to find file: %w", err)
+		}
+
+		_, err = f.DB.GetGroupByMember(ctx, taskId, groupId)
+		if err == nil {
+			logger.Warn("group id is used by a student")
+			return ErrGroupConflict
+		}
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logger.Error("failed to find group", "error", err)
+			return fmt.Errorf("failed to find group: %w", err)
+		}
+
+		if err := f.checkNotEnrolled(ctx, taskId, groupId, logger); err != nil {
+			return err
+		}
+	}
+
+	for _, memberId := range memberIds {
+		if memberId == groupId {
+			logger.Warn("member id equals group id", "member id", memberId)
+			return ErrGroupConflict
+		}
+
+		_, err := f.DB.GetGroup(ctx, taskId, memberId)
+		if err == nil {
+			logger.Warn("member id is used by a group", "member id", memberId)
+			return ErrGroupConflict
+		}
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logger.Error("failed to find group", "error", err)
+			return fmt.Errorf("failed to find group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkNotEnrolled проверяет, что groupId не совпадает с id участника курса задачи. Иначе студент курса,
+// который ещё ничего не сдал, попал бы в сдачу группы: findSubmission ищет группу по id раньше личной сдачи.
+func (f *FileService) checkNotEnrolled(ctx context.Context, taskId, groupId string, logger *slog.Logger) error {
+	task, err := f.findTask(ctx, taskId, logger)
+	if err != nil {
+		return err
+	}
+	if task == nil || task.CourseID == "" {
+		return nil
+	}
+
+	_, err = f.DB.GetCourseRole(ctx, task.CourseID, groupId)
+	if err == nil {
+		logger.Warn("group id is used by a course member", "course id", task.CourseID)
+		return ErrGroupConflict
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		logger.Error("failed to find course member", "error", err)
+		return fmt.Errorf("failed to find course member: %w", err)
+	}
+
+	return nil
+}
+
+// checkMembersEnrolled проверяет, что участники группы - студенты курса задачи.
+func (f *FileService) checkMembersEnrolled(ctx context.Context, taskId string, memberIds []string, logger *slog.Logger) error {
+	task, err := f.findTask(ctx, taskId, logger)
+	if err != nil {
+		return err
+	}
+
+	return f.checkEnrollment(ctx, task, logger, memberIds...)
+}
+
+func (f *FileService) getGroup(ctx context.Context, taskId, groupId string, logger *slog.Logger) (*domain.Group, error) {
+	group, err := f.DB.GetGroup(ctx, taskId, groupId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("group not found")
+			return nil, ErrGroupNotFound
+		}
+		logger.Error("failed to find group", "error", err)
+		return nil, fmt.Errorf("failed to find group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (f *FileService) groupSubmitted(ctx context.Context, group *domain.Group, logger *slog.Logger) (bool, error) {
+	_, err := f.DB.GetByGroupAndTask(ctx, group.ID, group.TaskID)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, repositories.ErrNotFound) {
+		return false, nil
+	}
+
+	logger.Error("failed to find file", "error", err)
+	return false, fmt.Errorf("failed to find file: %w", err)
+}
+
+func toSafeGroupInfo(group *domain.Group, submitted bool) *SafeGroupInfo {
+	return &SafeGroupInfo{
+		GroupId:   group.ID,
+		TaskId:    group.TaskID,
+		MemberIds: group.MemberIDs,
+		CreatedAt: group.CreatedAt,
+		Submitted: submitted,
+	}
+}
@@ -2,20 +2,57 @@ package use_cases
 
 import (
 	"context"
+	"io"
 
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
 )
 
 type S3Repository interface {
-	GenerateUploadURL(key string) (string, error)
+	GenerateUploadForm(key string, constraints domain.UploadConstraints) (*domain.UploadForm, error)
 	VerifyUploadedFile(key string) error
-	GenerateDownloadURL(key string, fromInside bool) (string, error)
+	ObjectDigest(key string, sink io.Writer) (*domain.ObjectDigest, error)
+	PutObject(ctx context.Context, key, contentType string, body io.Reader) (*domain.ObjectDigest, error)
+	DeleteObject(key string) error
+	GenerateDownloadURL(key, fileName string, fromInside bool) (string, error)
 }
 
 type DBRepository interface {
 	Save(ctx context.Context, file *domain.FileInfo) error
 	GetByStudentAndTask(ctx context.Context, studentID, taskID string) (*domain.FileInfo, error)
+	GetByGroupAndTask(ctx context.Context, groupID, taskID string) (*domain.FileInfo, error)
 	DeleteFile(ctx context.Context, id string) error
 	UpdateStatus(ctx context.Context, id string, status domain.FileStatus) error
+	SaveValidation(ctx context.Context, id string, report *domain.ValidationReport) error
 	ListTaskFiles(ctx context.Context, taskID string) ([]domain.FileInfo, error)
+	AddFileVersion(ctx context.Context, version *domain.FileVersion) error
+	GetFileVersion(ctx context.Context, fileID, name string, version int) (*domain.FileVersion, error)
+	SavePendingUpload(ctx context.Context, upload *domain.PendingUpload) error
+	GetPendingUpload(ctx context.Context, fileID, name string) (*domain.PendingUpload, error)
+	LatestVersionNumber(ctx context.Context, fileID, name string) (int, error)
+	ListFileVersions(ctx context.Context, fileID string) ([]domain.FileVersion, error)
+	ListLatestVersions(ctx context.Context, fileIDs []string) ([]domain.FileVersion, error)
+	FindDuplicates(ctx context.Context, fileID, studentID, sha256 string) ([]domain.Duplicate, error)
+	SaveAttachment(ctx context.Context, attachment *domain.Attachment) error
+	GetAttachment(ctx context.Context, id string) (*domain.Attachment, error)
+	UpdateAttachmentStatus(ctx context.Context, id string, status domain.FileStatus) error
+	SaveTask(ctx context.Context, task *domain.Task) error
+	UpdateTask(ctx context.Context, task *domain.Task) error
+	GetTask(ctx context.Context, id string) (*domain.Task, error)
+	ListTasks(ctx context.Context, courseID string) ([]domain.Task, error)
+	DeleteTask(ctx context.Context, id string) error
+	SaveCourse(ctx context.Context, course *domain.Course) error
+	ImportCourseMembers(ctx context.Context, courseID string, members []domain.CourseMember, replace bool) error
+	RemoveCourseMember(ctx context.Context, courseID, userID string) error
+	GetCourse(ctx context.Context, id string) (*domain.Course, error)
+	GetCourseRole(ctx context.Context, courseID, userID string) (domain.CourseRole, error)
+	SaveGroup(ctx context.Context, group *domain.Group) error
+	ReplaceGroupMembers(ctx context.Context, group *domain.Group) error
+	DeleteGroup(ctx context.Context, taskID, groupID string) error
+	GetGroup(ctx context.Context, taskID, groupID string) (*domain.Group, error)
+	GetGroupByMember(ctx context.Context, taskID, studentID string) (*domain.Group, error)
+	ListTaskGroups(ctx context.Context, taskID string) ([]domain.Group, error)
+}
+
+type FileValidator interface {
+	Validate(ctx context.Context, object *domain.UploadedObject) (*domain.ValidationReport, error)
 }
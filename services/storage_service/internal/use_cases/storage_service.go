@@ -1,39 +1,271 @@
 package use_cases
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"time"
 
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
 	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/identity"
 	"github.com/google/uuid"
 )
 
+// maxSubmissionFiles - сколько разных файлов может быть в одной сдаче.
+const maxSubmissionFiles = 20
+
 type FileService struct {
-	logger *slog.Logger
-	S3     S3Repository
-	DB     DBRepository
+	logger    *slog.Logger
+	S3        S3Repository
+	DB        DBRepository
+	Validator FileValidator
+	// allowUnregisteredTasks - принимать загрузки в задачи, которые не заведены
+	allowUnregisteredTasks bool
 }
 
-func NewFileService(s3 S3Repository, db DBRepository, logger *slog.Logger) *FileService {
+func NewFileService(s3 S3Repository, db DBRepository, validator FileValidator, allowUnregisteredTasks bool, logger *slog.Logger) *FileService {
 	return &FileService{
-		S3:     s3,
-		DB:     db,
-		logger: logger,
+		S3:                     s3,
+		DB:                     db,
+		Validator:              validator,
+		allowUnregisteredTasks: allowUnregisteredTasks,
+		logger:                 logger,
 	}
 }
 
-func (f *FileService) GenerateUploadURL(ctx context.Context, studentId, taskId string) (string, error) {
+// GenerateUploadURL возвращает форму для загрузки файла fileName сдачи студента POST-запросом (пустое имя - основной файл)
+// и id группы, если он сдаёт работу в группе. Любой участник группы загружает одну общую сдачу.
+// Исходное имя и заявленный тип файла запоминаются до проверки загрузки и переходят в её версию.
+// Заведённая задача принимает загрузки только в свои сроки, только файлы допустимых типов
+// (по расширению исходного имени, а без него - имени в сдаче) и, если у неё есть курс, только от его студентов.
+// Размер файла и заявленный тип хранилище проверяет само по политике формы.
+func (f *FileService) GenerateUploadURL(ctx context.Context, studentId, taskId, fileName, originalName, contentType string) (*SafeUploadForm, string, error) {
 	const op = "Storage_Service.GenerateUploadURL"
 
 	logger := f.logger.With(
 		slog.String("op", op),
 	)
 
-	fileInfo, err := f.DB.GetByStudentAndTask(ctx, studentId, taskId)
+	target, err := f.prepareUpload(ctx, studentId, taskId, fileName, originalName, contentType, time.Now(), logger)
+	if err != nil {
+		return nil, "", err
+	}
+	fileInfo := target.fileInfo
+
+	err = f.DB.SavePendingUpload(ctx, &domain.PendingUpload{
+		FileID:       fileInfo.ID,
+		Name:         fileName,
+		OriginalName: originalName,
+		ContentType:  contentType,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		logger.Error("failed to save pending upload", "error", err)
+		return nil, "", fmt.Errorf("failed to save pending upload: %w", err)
+	}
+
+	// каждая загрузка идёт в объект следующей версии, прежние версии не перезаписываются
+	form, err := f.S3.GenerateUploadForm(fileInfo.VersionKey(fileName, target.latest+1), domain.UploadConstraints{
+		MinSize:     1,
+		MaxSize:     target.task.FileSizeLimit(),
+		ContentType: contentType,
+	})
+
+	if err != nil {
+		logger.Error("failed to generate url", "error", err)
+		return nil, "", ErrFailedToGenerateURL
+	}
+
+	return &SafeUploadForm{URL: form.URL, Fields: form.Fields}, fileInfo.GroupID, nil
+}
+
+// VerifyUploadedFile проверяет загрузку файла fileName по последней выданной ссылке и сохраняет её как новую версию.
+// Загрузку после дедлайна задачи отклоняет или помечает опоздавшей политика задачи.
+func (f *FileService) VerifyUploadedFile(ctx context.Context, studentId, taskId, fileName string) (string, *SafeFileVersion, error) {
+	const op = "Storage_Service.VerifyUploadedFile"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+	)
+
+	fileInfo, _, err := f.findSubmission(ctx, studentId, taskId)
+	if err != nil {
+		logger.Error("failed to find file", "error", err)
+		return "", nil, ErrFileNotFound
+	}
+
+	logger.Info("File Info", "file id", fileInfo.ID.String())
+
+	latest, err := f.DB.LatestVersionNumber(ctx, fileInfo.ID.String(), fileName)
+	if err != nil {
+		logger.Error("failed to find latest version", "error", err)
+		return "", nil, fmt.Errorf("failed to find latest version: %w", err)
+	}
+
+	version := &domain.FileVersion{
+		FileID:     fileInfo.ID,
+		Name:       fileName,
+		Version:    latest + 1,
+		ObjectKey:  fileInfo.VersionKey(fileName, latest+1),
+		UploadedBy: identity.ActorID(ctx, studentId),
+		CreatedAt:  time.Now(),
+	}
+
+	// сроки проверяются по времени подтверждения: загрузка считается сданной, когда её проверили
+	task, late, err := f.checkUploadWindow(ctx, taskId, version.CreatedAt, logger)
+	if err != nil {
+		return "", nil, err
+	}
+	version.Late = late
+
+	if err = f.checkEnrollment(ctx, task, logger, studentId); err != nil {
+		return "", nil, err
+	}
+
+	// без заявки (ссылка выдана до появления метаданных) версия сохраняется без исходного имени и типа
+	pending, err := f.DB.GetPendingUpload(ctx, fileInfo.ID.String(), fileName)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		logger.Error("failed to find pending upload", "error", err)
+		return "", nil, fmt.Errorf("failed to find pending upload: %w", err)
+	}
+	if pending != nil {
+		version.OriginalName = pending.OriginalName
+		version.ContentType = pending.ContentType
+	}
+
+	content, err := newSpoolFile()
+	if err != nil {
+		logger.Error("failed to spool uploaded file", "error", err)
+		return "", nil, err
+	}
+	defer content.Close()
+
+	digest, err := f.S3.ObjectDigest(version.ObjectKey, content)
+
+	if err != nil {
+		logger.Error("failed to verify uploaded file", "error", err)
+		return "", nil, fmt.Errorf("failed to verify uploaded file: %w", err)
+	}
+
+	report, err := f.validateUpload(ctx, fileInfo, task, version, digest, content, logger)
+	if err != nil {
+		return "", nil, err
+	}
+
+	version.Size = digest.Size
+	version.SHA256 = digest.SHA256
+	version.MimeType = digest.MimeType
+	version.ETag = digest.ETag
+
+	result, err := f.saveVersion(ctx, fileInfo, version, logger)
+	if err != nil {
+		return "", nil, err
+	}
+	f.recordValidation(ctx, fileInfo, report, logger)
+
+	return fileInfo.ID.String(), result, nil
+}
+
+// UploadFile принимает содержимое файла fileName сдачи студента, сохраняет его в хранилище и сразу
+// записывает новой версией, как GenerateUploadURL и VerifyUploadedFile вместе. Возвращает id сдачи и
+// id группы, если он сдаёт работу в группе. Сроки проверяются по началу загрузки, чтобы медленная
+// передача файла перед дедлайном не делала его опоздавшим.
+func (f *FileService) UploadFile(ctx context.Context, studentId, taskId, fileName, originalName, contentType string, body io.Reader) (string, string, *SafeFileVersion, error) {
+	const op = "Storage_Service.UploadFile"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+	)
+
+	target, err := f.prepareUpload(ctx, studentId, taskId, fileName, originalName, contentType, time.Now(), logger)
+	if err != nil {
+		return "", "", nil, err
+	}
+	fileInfo := target.fileInfo
+
+	version := &domain.FileVersion{
+		FileID:       fileInfo.ID,
+		Name:         fileName,
+		Version:      target.latest + 1,
+		ObjectKey:    fileInfo.VersionKey(fileName, target.latest+1),
+		OriginalName: originalName,
+		ContentType:  contentType,
+		UploadedBy:   identity.ActorID(ctx, studentId),
+		Late:         target.late,
+	}
+
+	content, err := newSpoolFile()
+	if err != nil {
+		logger.Error("failed to spool uploaded file", "error", err)
+		return "", "", nil, err
+	}
+	defer content.Close()
+
+	// лишнего сверх предела не читается: размера limit+1 достаточно, чтобы шаг size отклонил файл
+	limit := target.task.FileSizeLimit()
+	digest, err := f.S3.PutObject(ctx, version.ObjectKey, contentType, io.TeeReader(io.LimitReader(body, limit+1), content))
+	if err != nil {
+		logger.Error("failed to upload file", "error", err)
+		return "", "", nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	report, err := f.validateUpload(ctx, fileInfo, target.task, version, digest, content, logger)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	version.Size = digest.Size
+	version.SHA256 = digest.SHA256
+	version.MimeType = digest.MimeType
+	version.ETag = digest.ETag
+	version.CreatedAt = time.Now()
+
+	result, err := f.saveVersion(ctx, fileInfo, version, logger)
+	if err != nil {
+		return "", "", nil, err
+	}
+	f.recordValidation(ctx, fileInfo, report, logger)
+
+	return fileInfo.ID.String(), fileInfo.GroupID, result, nil
+}
+
+// uploadTarget - куда пойдёт очередная загрузка файла сдачи.
+type uploadTarget struct {
+	// task - задача сдачи, nil - задача не заведена
+	task     *domain.Task
+	fileInfo *domain.FileInfo
+	// latest - номер последней версии файла, 0 - файл ещё не загружался
+	latest int
+	late   bool
+}
+
+// prepareUpload проверяет, что студент может загрузить файл fileName в момент at, находит или заводит его сдачу
+// и возвращает её вместе с задачей, номером последней версии файла и признаком опоздания.
+func (f *FileService) prepareUpload(ctx context.Context, studentId, taskId, fileName, originalName, contentType string, at time.Time, logger *slog.Logger) (*uploadTarget, error) {
+	task, late, err := f.checkUploadWindow(ctx, taskId, at, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = f.checkEnrollment(ctx, task, logger, studentId); err != nil {
+		return nil, err
+	}
+
+	if task != nil && !task.AllowsFile(cmp.Or(originalName, fileName)) {
+		logger.Warn("file type is not allowed", "original name", originalName, "file name", fileName)
+		return nil, ErrFileTypeNotAllowed
+	}
+
+	if contentType != "" && !task.AllowsMimeType(contentType) {
+		logger.Warn("content type is not allowed", "content type", contentType)
+		return nil, ErrFileTypeNotAllowed
+	}
+
+	fileInfo, group, err := f.findSubmission(ctx, studentId, taskId)
 
 	if err != nil {
 		if errors.Is(err, repositories.ErrNotFound) {
@@ -43,73 +275,86 @@ func (f *FileService) GenerateUploadURL(ctx context.Context, studentId, taskId s
 
 			if uuidErr != nil {
 				logger.Error("failed to generate uuid", "error", uuidErr)
-				return "", fmt.Errorf("failed to generate uuid: %w", uuidErr)
+				return nil, fmt.Errorf("failed to generate uuid: %w", uuidErr)
 			}
 
 			fileInfo = domain.NewFileInfo(fileId, studentId, taskId, updatedAt, status)
+			if group != nil {
+				fileInfo.GroupID = group.ID
+			}
 
 			saveErr := f.DB.Save(ctx, fileInfo)
 			if saveErr != nil {
 				logger.Error("failed to save data to database", "error", saveErr)
-				return "", fmt.Errorf("failed to save data to database: %w", saveErr)
+				return nil, fmt.Errorf("failed to save data to database: %w", saveErr)
 			}
 		} else {
 			logger.Error("failed to find file", "error", err)
-			return "", fmt.Errorf("failed to find file: %w", err)
+			return nil, fmt.Errorf("failed to find file: %w", err)
 		}
 	}
 
-	logger.Info("File Info", "file id", fileInfo.ID.String())
-
-	urlToUpload, err := f.S3.GenerateUploadURL(fileInfo.TaskID + "/" + fileInfo.ID.String())
+	logger.Info("File Info", "file id", fileInfo.ID.String(), "group id", fileInfo.GroupID)
 
+	latest, err := f.DB.LatestVersionNumber(ctx, fileInfo.ID.String(), fileName)
 	if err != nil {
-		logger.Error("failed to generate url", "error", err)
-		return "", ErrFailedToGenerateURL
+		logger.Error("failed to find latest version", "error", err)
+		return nil, fmt.Errorf("failed to find latest version: %w", err)
 	}
 
-	return urlToUpload, nil
-}
-
-func (f *FileService) VerifyUploadedFile(ctx context.Context, studentId, taskId string) (string, error) {
-	const op = "Storage_Service.VerifyUploadedFile"
+	if latest == 0 && fileName != "" {
+		files, err := f.DB.ListLatestVersions(ctx, []string{fileInfo.ID.String()})
+		if err != nil {
+			logger.Error("failed to find submission files", "error", err)
+			return nil, fmt.Errorf("failed to find submission files: %w", err)
+		}
+		if len(files) >= maxSubmissionFiles {
+			logger.Warn("too many files in submission", "files", len(files))
+			return nil, ErrTooManyFiles
+		}
+	}
 
-	logger := f.logger.With(
-		slog.String("op", op),
-	)
+	return &uploadTarget{task: task, fileInfo: fileInfo, latest: latest, late: late}, nil
+}
 
-	fileInfo, err := f.DB.GetByStudentAndTask(ctx, studentId, taskId)
+// saveVersion сохраняет проверенную версию файла сдачи и ищет загрузки с тем же содержимым.
+func (f *FileService) saveVersion(ctx context.Context, fileInfo *domain.FileInfo, version *domain.FileVersion, logger *slog.Logger) (*SafeFileVersion, error) {
+	err := f.DB.AddFileVersion(ctx, version)
 	if err != nil {
-		logger.Error("failed to find file", "error", err)
-		return "", ErrFileNotFound
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			logger.Warn("version has already been verified", "version", version.Version)
+			return nil, ErrVersionAlreadyVerified
+		}
+		logger.Error("failed to save version", "error", err)
+		return nil, fmt.Errorf("failed to save version: %w", err)
 	}
 
-	logger.Info("File Info", "file id", fileInfo.ID.String())
+	logger.Info("new version saved", "version", version.Version, "size", version.Size, "mime type", version.MimeType)
 
-	err = f.S3.VerifyUploadedFile(fileInfo.TaskID + "/" + fileInfo.ID.String())
+	result := toSafeFileVersion(version)
 
+	// дубликаты только помечаются: сама загрузка уже принята, ошибка поиска её не отменяет
+	duplicates, err := f.DB.FindDuplicates(ctx, fileInfo.ID.String(), fileInfo.StudentID, version.SHA256)
 	if err != nil {
-		logger.Error("failed to verify uploaded file", "error", err)
-		return "", fmt.Errorf("failed to verify uploaded file: %w", err)
+		logger.Error("failed to find duplicates", "error", err)
 	}
-
-	err = f.DB.UpdateStatus(ctx, fileInfo.ID.String(), domain.FileStatusUploaded)
-	if err != nil {
-		logger.Error("failed to update status", "error", err)
-		return "", fmt.Errorf("failed to update status: %w", err)
+	result.Duplicates = toSafeDuplicates(duplicates)
+	if len(result.Duplicates) > 0 {
+		logger.Warn("exact duplicate uploaded", "version", version.Version, "duplicates", len(result.Duplicates))
 	}
 
-	return fileInfo.ID.String(), nil
+	return result, nil
 }
 
-func (f *FileService) GenerateDownloadURL(ctx context.Context, studentId, taskId string, fromInside bool) (string, error) {
+// GenerateDownloadURL возвращает ссылку на последнюю версию файла fileName сдачи (пустое имя - основной файл).
+func (f *FileService) GenerateDownloadURL(ctx context.Context, studentId, taskId, fileName string, fromInside bool) (string, error) {
 	const op = "Storage_Service.GenerateDownloadURL"
 
 	logger := f.logger.With(
 		slog.String("op", op),
 	)
 
-	fileInfo, err := f.DB.GetByStudentAndTask(ctx, studentId, taskId)
+	fileInfo, _, err := f.findSubmission(ctx, studentId, taskId)
 	if err != nil {
 		logger.Error("failed to find file", "error", err)
 		return "", ErrFileNotFound
@@ -120,9 +365,21 @@ func (f *FileService) GenerateDownloadURL(ctx context.Context, studentId, taskId
 		return "", ErrFileYetNotUploaded
 	}
 
-	logger.Info("File Info", "file id", fileInfo.ID.String())
+	latest, err := f.DB.LatestVersionNumber(ctx, fileInfo.ID.String(), fileName)
+	if err != nil {
+		logger.Error("failed to find latest version", "error", err)
+		return "", fmt.Errorf("failed to find latest version: %w", err)
+	}
 
-	urlToDownload, err := f.S3.GenerateDownloadURL(fileInfo.TaskID+"/"+fileInfo.ID.String(), fromInside)
+	logger.Info("File Info", "file id", fileInfo.ID.String(), "file name", fileName, "version", latest)
+
+	version, err := f.DB.GetFileVersion(ctx, fileInfo.ID.String(), fileName, latest)
+	if err != nil {
+		logger.Error("failed to find latest version", "error", err)
+		return "", ErrFileYetNotUploaded
+	}
+
+	urlToDownload, err := f.S3.GenerateDownloadURL(version.ObjectKey, version.DownloadName(), fromInside)
 
 	if err != nil {
 		logger.Error("failed to generate url", "error", err)
@@ -132,6 +389,8 @@ func (f *FileService) GenerateDownloadURL(ctx context.Context, studentId, taskId
 	return urlToDownload, nil
 }
 
+// ListTaskFiles возвращает сдачи задачи по авторам: сдача группы идёт одной записью с id группы,
+// а личные файлы участников групп не возвращаются - за них отвечает сдача группы.
 func (f *FileService) ListTaskFiles(ctx context.Context, taskID string) ([]SafeFileInfo, error) {
 	const op = "Storage_Service.ListTaskFiles"
 
@@ -145,15 +404,83 @@ func (f *FileService) ListTaskFiles(ctx context.Context, taskID string) ([]SafeF
 		return nil, fmt.Errorf("failed to find files by task id: %w", err)
 	}
 
+	groups, err := f.DB.ListTaskGroups(ctx, taskID)
+	if err != nil {
+		logger.Error("failed to find groups by task id", "error", err)
+		return nil, fmt.Errorf("failed to find groups by task id: %w", err)
+	}
+
+	members := make(map[string][]string, len(groups))
+	inGroup := make(map[string]bool)
+	for _, group := range groups {
+		members[group.ID] = group.MemberIDs
+		for _, studentID := range group.MemberIDs {
+			inGroup[studentID] = true
+		}
+	}
+
+	fileIDs := make([]string, 0, len(files))
+	uploaders := make(map[uuid.UUID]string, len(files))
+	for _, file := range files {
+		fileIDs = append(fileIDs, file.ID.String())
+		uploaders[file.ID] = file.StudentID
+	}
+
+	latestVersions, err := f.DB.ListLatestVersions(ctx, fileIDs)
+	if err != nil {
+		logger.Error("failed to find submission files", "error", err)
+		return nil, fmt.Errorf("failed to find submission files: %w", err)
+	}
+
+	submissionFiles := make(map[uuid.UUID][]SafeFileVersion, len(files))
+	for i := range latestVersions {
+		version := &latestVersions[i]
+		safeVersion := toSafeFileVersion(version)
+
+		// чужие сдачи с тем же содержимым ищутся только для файлов, уже помеченных дубликатами
+		if version.ExactDuplicate {
+			duplicates, err := f.DB.FindDuplicates(ctx, version.FileID.String(), uploaders[version.FileID], version.SHA256)
+			if err != nil {
+				logger.Error("failed to find duplicates", "error", err)
+				return nil, fmt.Errorf("failed to find duplicates: %w", err)
+			}
+			safeVersion.Duplicates = toSafeDuplicates(duplicates)
+		}
+
+		submissionFiles[version.FileID] = append(submissionFiles[version.FileID], *safeVersion)
+	}
+
 	var result []SafeFileInfo
 
 	for _, file := range files {
-		var item SafeFileInfo
+		if file.GroupID == "" && inGroup[file.StudentID] {
+			continue
+		}
 
-		item = SafeFileInfo{
-			StudentId: file.StudentID,
+		item := SafeFileInfo{
+			StudentId: file.AuthorID(),
 			UpdatedAt: file.UpdatedAt,
 			Status:    string(file.Status),
+			Version:   file.LatestVersion,
+			Size:      file.Size,
+			SHA256:    file.SHA256,
+
+			OriginalName: file.OriginalName,
+			ContentType:  file.ContentType,
+			MimeType:     file.MimeType,
+			ETag:         file.ETag,
+
+			ExactDuplicate: file.ExactDuplicate,
+			Files:          submissionFiles[file.ID],
+			Validation:     toSafeValidationReport(file.Validation),
+		}
+		for _, submissionFile := range item.Files {
+			item.ExactDuplicate = item.ExactDuplicate || submissionFile.ExactDuplicate
+			item.Late = item.Late || submissionFile.Late
+		}
+		if file.GroupID != "" {
+			item.MemberIds = members[file.GroupID]
+			item.UploadedBy = file.StudentID
 		}
 
 		result = append(result, item)
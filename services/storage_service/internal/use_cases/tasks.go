@@ -0,0 +1,240 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+)
+
+// CreateTask заводит задачу со сроками сдачи. До этого загрузки в задачу отклоняются, если не разрешены незаведённые задачи.
+// Задача курса принимает сдачи только от студентов курса.
+func (f *FileService) CreateTask(ctx context.Context, taskId string, settings TaskSettings) (*SafeTaskInfo, error) {
+	const op = "Storage_Service.CreateTask"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+	)
+
+	if err := f.checkTaskCourse(ctx, settings.CourseId, logger); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	task := newTask(taskId, settings)
+	task.CreatedAt = now
+	task.UpdatedAt = now
+
+	if err := f.DB.SaveTask(ctx, task); err != nil {
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			logger.Warn("task already exists")
+			return nil, ErrTaskAlreadyExists
+		}
+		logger.Error("failed to save task", "error", err)
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	return toSafeTaskInfo(task), nil
+}
+
+// UpdateTask заменяет настройки задачи. Уже принятые версии не пересматриваются:
+// пометка опоздания ставится по правилам, действовавшим при проверке загрузки.
+func (f *FileService) UpdateTask(ctx context.Context, taskId string, settings TaskSettings) (*SafeTaskInfo, error) {
+	const op = "Storage_Service.UpdateTask"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+	)
+
+	if err := f.checkTaskCourse(ctx, settings.CourseId, logger); err != nil {
+		return nil, err
+	}
+
+	task := newTask(taskId, settings)
+	task.UpdatedAt = time.Now()
+
+	if err := f.DB.UpdateTask(ctx, task); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("task not found")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to update task", "error", err)
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return toSafeTaskInfo(task), nil
+}
+
+func (f *FileService) GetTask(ctx context.Context, taskId string) (*SafeTaskInfo, error) {
+	const op = "Storage_Service.GetTask"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+	)
+
+	task, err := f.DB.GetTask(ctx, taskId)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("task not found")
+			return nil, ErrTaskNotFound
+		}
+		logger.Error("failed to find task", "error", err)
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	return toSafeTaskInfo(task), nil
+}
+
+// ListTasks возвращает задачи курса, пустой courseId - все задачи.
+func (f *FileService) ListTasks(ctx context.Context, courseId string) ([]SafeTaskInfo, error) {
+	const op = "Storage_Service.ListTasks"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("course id", courseId),
+	)
+
+	tasks, err := f.DB.ListTasks(ctx, courseId)
+	if err != nil {
+		logger.Error("failed to find tasks", "error", err)
+		return nil, fmt.Errorf("failed to find tasks: %w", err)
+	}
+
+	result := make([]SafeTaskInfo, 0, len(tasks))
+	for i := range tasks {
+		result = append(result, *toSafeTaskInfo(&tasks[i]))
+	}
+
+	return result, nil
+}
+
+// DeleteTask удаляет настройки задачи. Сдачи остаются, а новые загрузки в задачу принимаются, только если
+// разрешены незаведённые задачи.
+func (f *FileService) DeleteTask(ctx context.Context, taskId string) error {
+	const op = "Storage_Service.DeleteTask"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.String("task id", taskId),
+	)
+
+	if err := f.DB.DeleteTask(ctx, taskId); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("task not found")
+			return ErrTaskNotFound
+		}
+		logger.Error("failed to delete task", "error", err)
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	return nil
+}
+
+// checkUploadWindow проверяет, принимает ли задача загрузку в момент at, и возвращает задачу и признак опоздания.
+// После дедлайна загрузка отклоняется или помечается опоздавшей по политике задачи. Загрузка в незаведённую задачу
+// отклоняется, а с allowUnregisteredTasks принимается без ограничений, и задача возвращается nil.
+func (f *FileService) checkUploadWindow(ctx context.Context, taskId string, at time.Time, logger *slog.Logger) (*domain.Task, bool, error) {
+	task, err := f.findTask(ctx, taskId, logger)
+	if err != nil {
+		return nil, false, err
+	}
+	if task == nil {
+		if f.allowUnregisteredTasks {
+			return nil, false, nil
+		}
+		logger.Warn("task not found")
+		return nil, false, ErrTaskNotFound
+	}
+
+	if task.OpensAt != nil && at.Before(*task.OpensAt) {
+		logger.Warn("task is not open yet", "opens at", *task.OpensAt)
+		return nil, false, ErrTaskNotOpen
+	}
+
+	if task.Deadline == nil || !at.After(*task.Deadline) {
+		return task, false, nil
+	}
+
+	if task.LatePolicy == domain.LatePolicyMarkLate {
+		logger.Info("late upload", "deadline", *task.Deadline)
+		return task, true, nil
+	}
+
+	logger.Warn("deadline has passed", "deadline", *task.Deadline)
+	return nil, false, ErrDeadlinePassed
+}
+
+// checkTaskCourse проверяет, что курс задачи заведён: по его списку проверяются сдачи. Пустой courseId - задача без курса.
+func (f *FileService) checkTaskCourse(ctx context.Context, courseId string, logger *slog.Logger) error {
+	if courseId == "" {
+		return nil
+	}
+
+	_, err := f.getCourse(ctx, courseId, logger)
+	return err
+}
+
+// newTask собирает задачу из настроек: политика по умолчанию - reject, типы файлов
+// приводятся к нижнему регистру без точки и без повторов, типы по содержимому - к нижнему регистру без повторов.
+func newTask(taskId string, settings TaskSettings) *domain.Task {
+	latePolicy := domain.LatePolicy(settings.LatePolicy)
+	if latePolicy == "" {
+		latePolicy = domain.LatePolicyReject
+	}
+
+	allowedTypes := make([]string, 0, len(settings.AllowedTypes))
+	for _, fileType := range settings.AllowedTypes {
+		fileType = strings.TrimPrefix(strings.ToLower(fileType), ".")
+		if !slices.Contains(allowedTypes, fileType) {
+			allowedTypes = append(allowedTypes, fileType)
+		}
+	}
+
+	allowedMimeTypes := make([]string, 0, len(settings.AllowedMimeTypes))
+	for _, mimeType := range settings.AllowedMimeTypes {
+		mimeType = strings.ToLower(mimeType)
+		if !slices.Contains(allowedMimeTypes, mimeType) {
+			allowedMimeTypes = append(allowedMimeTypes, mimeType)
+		}
+	}
+
+	return &domain.Task{
+		ID:           taskId,
+		Title:        settings.Title,
+		CourseID:     settings.CourseId,
+		OpensAt:      settings.OpensAt,
+		Deadline:     settings.Deadline,
+		LatePolicy:   latePolicy,
+		AllowedTypes: allowedTypes,
+
+		MaxFileSize:      settings.MaxFileSize,
+		AllowedMimeTypes: allowedMimeTypes,
+	}
+}
+
+func toSafeTaskInfo(task *domain.Task) *SafeTaskInfo {
+	return &SafeTaskInfo{
+		TaskId:       task.ID,
+		Title:        task.Title,
+		CourseId:     task.CourseID,
+		OpensAt:      task.OpensAt,
+		Deadline:     task.Deadline,
+		LatePolicy:   string(task.LatePolicy),
+		AllowedTypes: task.AllowedTypes,
+
+		MaxFileSize:      task.MaxFileSize,
+		AllowedMimeTypes: task.AllowedMimeTypes,
+
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+	}
+}
@@ -0,0 +1,74 @@
+package use_cases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+)
+
+// validateUpload прогоняет загруженный объект версии через проверки. Отклонённый объект удаляется,
+// а результат проверки сразу записывается в сдачу; результат принятой загрузки записывает recordValidation
+// после сохранения версии.
+func (f *FileService) validateUpload(ctx context.Context, fileInfo *domain.FileInfo, task *domain.Task, version *domain.FileVersion, digest *domain.ObjectDigest, content *spoolFile, logger *slog.Logger) (*domain.ValidationReport, error) {
+	report, err := f.Validator.Validate(ctx, &domain.UploadedObject{
+		Task:        task,
+		Name:        cmp.Or(version.OriginalName, version.Name),
+		ContentType: version.ContentType,
+		Digest:      *digest,
+		Content:     content,
+	})
+	if err != nil {
+		// объект не удаляется: после восстановления проверки ту же загрузку можно подтвердить ещё раз
+		logger.Error("failed to validate uploaded file", "error", err)
+		return nil, fmt.Errorf("%w: %w", ErrValidationUnavailable, err)
+	}
+	report.FileName = version.Name
+
+	reason := report.RejectionReason()
+	if reason == "" {
+		return report, nil
+	}
+
+	logger.Warn("uploaded file rejected", "version", version.Version, "reason", reason)
+
+	// объект уже отклонён, поэтому ошибка удаления только пишется в лог
+	if err := f.S3.DeleteObject(version.ObjectKey); err != nil {
+		logger.Error("failed to delete rejected file", "error", err)
+	}
+	f.recordValidation(ctx, fileInfo, report, logger)
+
+	return nil, fmt.Errorf("%w: %s", ErrFileRejected, reason)
+}
+
+// recordValidation записывает результат проверки в сдачу. Проверка уже выполнена и решение принято,
+// поэтому ошибка записи только пишется в лог.
+func (f *FileService) recordValidation(ctx context.Context, fileInfo *domain.FileInfo, report *domain.ValidationReport, logger *slog.Logger) {
+	if err := f.DB.SaveValidation(ctx, fileInfo.ID.String(), report); err != nil {
+		logger.Error("failed to save validation", "error", err)
+	}
+}
+
+// spoolFile - временный файл с содержимым загрузки, которое читают проверки. Close удаляет файл.
+type spoolFile struct {
+	*os.File
+}
+
+func newSpoolFile() (*spoolFile, error) {
+	file, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	return &spoolFile{File: file}, nil
+}
+
+func (s *spoolFile) Close() error {
+	err := s.File.Close()
+	if removeErr := os.Remove(s.Name()); removeErr != nil && err == nil {
+		err = removeErr
+	}
+	return err
+}
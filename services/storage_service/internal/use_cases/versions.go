@@ -0,0 +1,141 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/domain"
+	"github.com/Nikita-Smirnov-idk/storage-service/internal/infrastructure/repositories"
+)
+
+// ListFileVersions возвращает версии файлов сдачи студента (или его группы) в порядке загрузки
+// вместе со статусом сдачи и проверкой последней загрузки, чтобы студент видел причину отказа.
+// Непустое fileName оставляет только версии этого файла.
+func (f *FileService) ListFileVersions(ctx context.Context, studentId, taskId, fileName string) (*SafeFileHistory, error) {
+	const op = "Storage_Service.ListFileVersions"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+	)
+
+	fileInfo, _, err := f.findSubmission(ctx, studentId, taskId)
+	if err != nil {
+		logger.Error("failed to find file", "error", err)
+		return nil, ErrFileNotFound
+	}
+
+	versions, err := f.DB.ListFileVersions(ctx, fileInfo.ID.String())
+	if err != nil {
+		logger.Error("failed to find versions", "error", err)
+		return nil, fmt.Errorf("failed to find versions: %w", err)
+	}
+
+	result := make([]SafeFileVersion, 0, len(versions))
+	for i := range versions {
+		if fileName != "" && versions[i].Name != fileName {
+			continue
+		}
+		result = append(result, *toSafeFileVersion(&versions[i]))
+	}
+
+	history := &SafeFileHistory{
+		Status:   string(fileInfo.Status),
+		Versions: result,
+	}
+	if report := fileInfo.Validation; report != nil && (fileName == "" || report.FileName == fileName) {
+		history.Validation = toSafeValidationReport(report)
+	}
+
+	return history, nil
+}
+
+// GenerateVersionDownloadURL возвращает ссылку на скачивание конкретной версии файла сдачи.
+func (f *FileService) GenerateVersionDownloadURL(ctx context.Context, studentId, taskId, fileName string, version int, fromInside bool) (*SafeFileVersion, string, error) {
+	const op = "Storage_Service.GenerateVersionDownloadURL"
+
+	logger := f.logger.With(
+		slog.String("op", op),
+		slog.Int("version", version),
+	)
+
+	fileInfo, _, err := f.findSubmission(ctx, studentId, taskId)
+	if err != nil {
+		logger.Error("failed to find file", "error", err)
+		return nil, "", ErrFileNotFound
+	}
+
+	fileVersion, err := f.DB.GetFileVersion(ctx, fileInfo.ID.String(), fileName, version)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			logger.Warn("version not found")
+			return nil, "", ErrVersionNotFound
+		}
+		logger.Error("failed to find version", "error", err)
+		return nil, "", fmt.Errorf("failed to find version: %w", err)
+	}
+
+	urlToDownload, err := f.S3.GenerateDownloadURL(fileVersion.ObjectKey, fileVersion.DownloadName(), fromInside)
+	if err != nil {
+		logger.Error("failed to generate url", "error", err)
+		return nil, "", ErrFailedToGenerateURL
+	}
+
+	return toSafeFileVersion(fileVersion), urlToDownload, nil
+}
+
+func toSafeValidationReport(report *domain.ValidationReport) *SafeValidationReport {
+	if report == nil {
+		return nil
+	}
+
+	steps := make([]SafeValidationStep, 0, len(report.Steps))
+	for _, step := range report.Steps {
+		steps = append(steps, SafeValidationStep{
+			Validator: step.Validator,
+			Passed:    step.Passed,
+			Reason:    step.Reason,
+		})
+	}
+
+	return &SafeValidationReport{
+		FileName:        report.FileName,
+		RejectionReason: report.RejectionReason(),
+		Steps:           steps,
+		CheckedAt:       report.CheckedAt,
+	}
+}
+
+func toSafeFileVersion(version *domain.FileVersion) *SafeFileVersion {
+	return &SafeFileVersion{
+		FileName:   version.Name,
+		Version:    version.Version,
+		Size:       version.Size,
+		SHA256:     version.SHA256,
+		UploadedBy: version.UploadedBy,
+		CreatedAt:  version.CreatedAt,
+
+		OriginalName: version.OriginalName,
+		ContentType:  version.ContentType,
+		MimeType:     version.MimeType,
+		ETag:         version.ETag,
+		Late:         version.Late,
+
+		ExactDuplicate: version.ExactDuplicate,
+	}
+}
+
+func toSafeDuplicates(duplicates []domain.Duplicate) []SafeDuplicate {
+	var result []SafeDuplicate
+	for _, duplicate := range duplicates {
+		result = append(result, SafeDuplicate{
+			StudentId: duplicate.AuthorID,
+			TaskId:    duplicate.TaskID,
+			FileName:  duplicate.FileName,
+			Version:   duplicate.Version,
+			CreatedAt: duplicate.CreatedAt,
+		})
+	}
+	return result
+}
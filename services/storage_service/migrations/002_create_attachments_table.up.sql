@@ -0,0 +1,14 @@
+-- вспомогательные файлы (например, приложения к апелляциям); хранятся отдельно от сданных работ
+CREATE TABLE attachments (
+   id VARCHAR(36) PRIMARY KEY,
+
+   task_id VARCHAR(50) NOT NULL,
+   owner_id VARCHAR(50) NOT NULL,
+   file_name VARCHAR(255) NOT NULL,
+
+   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+   status VARCHAR(20) DEFAULT 'uploading'
+);
+
+CREATE INDEX idx_attachments_task_owner ON attachments(task_id, owner_id);
@@ -0,0 +1,10 @@
+DELETE FROM files WHERE group_id IS NOT NULL;
+
+DROP INDEX idx_files_group_task;
+DROP INDEX idx_files_student_task;
+
+ALTER TABLE files ADD CONSTRAINT files_student_id_task_id_key UNIQUE (student_id, task_id);
+ALTER TABLE files DROP COLUMN group_id;
+
+DROP TABLE task_group_members;
+DROP TABLE task_groups;
@@ -0,0 +1,29 @@
+-- группы студентов, сдающие одну работу на задачу
+CREATE TABLE task_groups (
+   id VARCHAR(50) NOT NULL,
+   task_id VARCHAR(50) NOT NULL,
+
+   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+   PRIMARY KEY (task_id, id)
+);
+
+-- студент состоит не больше чем в одной группе задачи
+CREATE TABLE task_group_members (
+   task_id VARCHAR(50) NOT NULL,
+   group_id VARCHAR(50) NOT NULL,
+   student_id VARCHAR(50) NOT NULL,
+
+   PRIMARY KEY (task_id, student_id),
+   FOREIGN KEY (task_id, group_id) REFERENCES task_groups(task_id, id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_group_members_group ON task_group_members(task_id, group_id);
+
+-- у сдачи группы group_id заполнен, а student_id - участник, который загрузил файл
+ALTER TABLE files ADD COLUMN group_id VARCHAR(50);
+
+ALTER TABLE files DROP CONSTRAINT files_student_id_task_id_key;
+
+CREATE UNIQUE INDEX idx_files_student_task ON files(student_id, task_id) WHERE group_id IS NULL;
+CREATE UNIQUE INDEX idx_files_group_task ON files(group_id, task_id) WHERE group_id IS NOT NULL;
@@ -0,0 +1,20 @@
+-- каждая подтверждённая загрузка сдачи - неизменяемая версия со своим объектом в бакете
+CREATE TABLE file_versions (
+   file_id VARCHAR(36) NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+   version INTEGER NOT NULL,
+
+   object_key VARCHAR(255) NOT NULL,
+   size BIGINT NOT NULL DEFAULT 0,
+   sha256 VARCHAR(64) NOT NULL DEFAULT '',
+   uploaded_by VARCHAR(50) NOT NULL DEFAULT '',
+
+   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+   PRIMARY KEY (file_id, version)
+);
+
+-- загруженные до версий сдачи становятся первой версией; размер и хэш их объектов неизвестны
+INSERT INTO file_versions (file_id, version, object_key, uploaded_by, created_at)
+SELECT id, 1, task_id || '/' || id, student_id, updated_at
+FROM files
+WHERE status = 'uploaded';
@@ -0,0 +1,2 @@
+-- поиск точных дубликатов по хэшу содержимого среди всех задач
+CREATE INDEX idx_file_versions_sha256 ON file_versions(sha256) WHERE sha256 <> '';
@@ -0,0 +1,6 @@
+DELETE FROM file_versions WHERE name <> '';
+
+ALTER TABLE file_versions DROP CONSTRAINT file_versions_pkey;
+ALTER TABLE file_versions ADD PRIMARY KEY (file_id, version);
+
+ALTER TABLE file_versions DROP COLUMN name;
@@ -0,0 +1,6 @@
+-- сдача может состоять из нескольких именованных файлов, у каждого своя история версий;
+-- пустое имя - основной файл сдачи (все версии, загруженные до этой миграции)
+ALTER TABLE file_versions ADD COLUMN name VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE file_versions DROP CONSTRAINT file_versions_pkey;
+ALTER TABLE file_versions ADD PRIMARY KEY (file_id, name, version);
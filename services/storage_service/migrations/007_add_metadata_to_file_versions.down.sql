@@ -0,0 +1,6 @@
+DROP TABLE pending_uploads;
+
+ALTER TABLE file_versions DROP COLUMN etag;
+ALTER TABLE file_versions DROP COLUMN mime_type;
+ALTER TABLE file_versions DROP COLUMN content_type;
+ALTER TABLE file_versions DROP COLUMN original_name;
@@ -0,0 +1,19 @@
+-- исходное имя и заявленный тип файла из запроса ссылки, а также тип по содержимому и ETag объекта,
+-- определённые при проверке загрузки; у версий, загруженных до этой миграции, они неизвестны
+ALTER TABLE file_versions ADD COLUMN original_name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE file_versions ADD COLUMN content_type VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE file_versions ADD COLUMN mime_type VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE file_versions ADD COLUMN etag VARCHAR(255) NOT NULL DEFAULT '';
+
+-- заявленные имя и тип загрузки, ссылка на которую выдана, но загрузка ещё не проверена
+CREATE TABLE pending_uploads (
+   file_id VARCHAR(36) NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+   name VARCHAR(255) NOT NULL DEFAULT '',
+
+   original_name VARCHAR(255) NOT NULL DEFAULT '',
+   content_type VARCHAR(255) NOT NULL DEFAULT '',
+
+   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+   PRIMARY KEY (file_id, name)
+);
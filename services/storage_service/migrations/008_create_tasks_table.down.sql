@@ -0,0 +1,3 @@
+ALTER TABLE file_versions DROP COLUMN late;
+
+DROP TABLE tasks;
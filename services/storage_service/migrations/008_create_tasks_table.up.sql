@@ -0,0 +1,22 @@
+-- задача со сроками сдачи; сдачи задач без записи здесь принимаются без ограничений
+CREATE TABLE tasks (
+   id VARCHAR(50) PRIMARY KEY,
+   title VARCHAR(255) NOT NULL,
+   course_id VARCHAR(50) NOT NULL DEFAULT '',
+
+   -- NULL - без ограничения с этой стороны
+   opens_at TIMESTAMP WITH TIME ZONE,
+   deadline TIMESTAMP WITH TIME ZONE,
+   -- reject - после дедлайна загрузки не принимаются, mark_late - принимаются с пометкой опоздания
+   late_policy VARCHAR(20) NOT NULL DEFAULT 'reject',
+   -- допустимые расширения файлов без точки; пустой список - любые
+   allowed_types TEXT[] NOT NULL DEFAULT '{}',
+
+   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tasks_course ON tasks(course_id);
+
+-- версия загружена после дедлайна задачи с политикой mark_late
+ALTER TABLE file_versions ADD COLUMN late BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,18 @@
+-- курс со списком студентов и преподавателей; задачи курса принимают сдачи только от его студентов
+CREATE TABLE courses (
+   id VARCHAR(50) PRIMARY KEY,
+   title VARCHAR(255) NOT NULL,
+
+   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE course_members (
+   course_id VARCHAR(50) NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+   user_id VARCHAR(50) NOT NULL,
+   -- student или teacher
+   role VARCHAR(20) NOT NULL,
+
+   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+   PRIMARY KEY (course_id, user_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN allowed_mime_types;
+ALTER TABLE tasks DROP COLUMN max_file_size;
@@ -0,0 +1,3 @@
+-- ограничения на загружаемые файлы задачи: 0 - предел сервиса по умолчанию, пустой список - любой тип по содержимому
+ALTER TABLE tasks ADD COLUMN max_file_size BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN allowed_mime_types TEXT[] NOT NULL DEFAULT '{}';
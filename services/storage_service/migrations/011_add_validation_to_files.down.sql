@@ -0,0 +1,3 @@
+UPDATE files SET status = 'uploading' WHERE status = 'rejected';
+
+ALTER TABLE files DROP COLUMN validation;
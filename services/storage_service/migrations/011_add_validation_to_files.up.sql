@@ -0,0 +1,3 @@
+-- результат проверки последней загрузки сдачи по шагам: {"file_name", "steps": [{"validator", "passed", "reason"}], "checked_at"};
+-- NULL - загрузки ещё не проверялись. Статус rejected получает сдача, у которой нет ни одной принятой версии
+ALTER TABLE files ADD COLUMN validation JSONB;
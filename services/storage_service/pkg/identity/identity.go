@@ -0,0 +1,112 @@
+// Package identity передаёт сервисам пользователя, от имени которого API Gateway делает вызов.
+// Гейтвей кладёт его в gRPC metadata, а перехватчики сервера - в контекст вызова. Metadata может
+// прислать любой клиент, поэтому перехватчики верят ей только от клиентов с проверенным сертификатом mTLS
+// из списка доверенных.
+package identity
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Nikita-Smirnov-idk/storage-service/pkg/mtls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Ключи gRPC metadata с id и ролью пользователя.
+const (
+	MetadataUserID   = "x-user-id"
+	MetadataUserRole = "x-user-role"
+)
+
+// Identity - пользователь, проверенный гейтвеем.
+type Identity struct {
+	UserID string
+	Role   string
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext возвращает пользователя вызова; nil, если вызов сделан не от имени пользователя
+// (другим сервисом или гейтвеем без аутентификации).
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
+
+// ActorID возвращает id пользователя вызова, а без него - requested из запроса.
+func ActorID(ctx context.Context, requested string) string {
+	if identity := FromContext(ctx); identity != nil {
+		return identity.UserID
+	}
+	return requested
+}
+
+// fromMetadata кладёт в контекст пользователя из metadata, если вызов сделал клиент из trusted.
+func fromMetadata(ctx context.Context, trusted []string) context.Context {
+	if !trustedPeer(ctx, trusted) {
+		return ctx
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	userIDs := md.Get(MetadataUserID)
+	if len(userIDs) == 0 || userIDs[0] == "" {
+		return ctx
+	}
+
+	identity := &Identity{UserID: userIDs[0]}
+	if roles := md.Get(MetadataUserRole); len(roles) > 0 {
+		identity.Role = roles[0]
+	}
+	return WithIdentity(ctx, identity)
+}
+
+// trustedPeer проверяет, что клиент вызова предъявил проверенный сертификат с именем из trusted.
+func trustedPeer(ctx context.Context, trusted []string) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return false
+	}
+
+	client := mtls.PeerIdentity(info.State)
+	return client != "" && slices.Contains(trusted, client)
+}
+
+// UnaryServerInterceptor кладёт пользователя из metadata вызова в его контекст, если вызов сделал
+// клиент из trusted (имя из сертификата, как в mtls.ACL). Metadata остальных клиентов игнорируется.
+func UnaryServerInterceptor(trusted []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(fromMetadata(ctx, trusted), req)
+	}
+}
+
+// StreamServerInterceptor - то же для потоковых вызовов.
+func StreamServerInterceptor(trusted []string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: fromMetadata(ss.Context(), trusted)})
+	}
+}
+
+// serverStream подменяет контекст потока.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,69 @@
+package identity
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// withPeer возвращает контекст вызова от клиента с проверенным сертификатом на имя client;
+// пустое имя - клиент без TLS.
+func withPeer(ctx context.Context, client string) context.Context {
+	p := &peer.Peer{}
+	if client != "" {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: client}}
+		p.AuthInfo = credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		}}
+	}
+	return peer.NewContext(ctx, p)
+}
+
+func TestFromMetadata(t *testing.T) {
+	trusted := []string{"api-gateway"}
+	userMD := metadata.Pairs(MetadataUserID, "t1", MetadataUserRole, "teacher")
+
+	tests := []struct {
+		name     string
+		client   string
+		md       metadata.MD
+		wantUser string
+	}{
+		{name: "trusted client", client: "api-gateway", md: userMD, wantUser: "t1"},
+		{name: "untrusted client", client: "plagiarism-service", md: userMD},
+		{name: "client without tls", md: userMD},
+		{name: "trusted client without user", client: "api-gateway", md: metadata.Pairs()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(withPeer(context.Background(), tt.client), tt.md)
+
+			got := FromContext(fromMetadata(ctx, trusted))
+			if tt.wantUser == "" {
+				if got != nil {
+					t.Fatalf("identity = %+v, want none", got)
+				}
+				return
+			}
+			if got == nil || got.UserID != tt.wantUser || got.Role != "teacher" {
+				t.Fatalf("identity = %+v, want %s/teacher", got, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestActorIDFallsBackToRequest(t *testing.T) {
+	ctx := metadata.NewIncomingContext(withPeer(context.Background(), "plagiarism-service"),
+		metadata.Pairs(MetadataUserID, "forged"))
+
+	if got := ActorID(fromMetadata(ctx, []string{"api-gateway"}), "s1"); got != "s1" {
+		t.Errorf("ActorID = %q, want s1", got)
+	}
+}
@@ -0,0 +1,92 @@
+package mtls
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ACL - какие RPC может вызывать каждый клиент (имя сервиса из его сертификата); "*" - все.
+// Пустой ACL ничего не ограничивает.
+type ACL map[string][]string
+
+// ParseACL разбирает строку вида "api-gateway=*;plagiarism-service=ListTaskFiles,GenerateDownloadURL".
+func ParseACL(s string) (ACL, error) {
+	acl := make(ACL)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		client, methods, ok := strings.Cut(entry, "=")
+		client = strings.TrimSpace(client)
+		if !ok || client == "" {
+			return nil, fmt.Errorf("invalid acl entry %q", entry)
+		}
+
+		for _, method := range strings.Split(methods, ",") {
+			if method = strings.TrimSpace(method); method != "" {
+				acl[client] = append(acl[client], method)
+			}
+		}
+	}
+	return acl, nil
+}
+
+func (a ACL) allows(client, fullMethod string) bool {
+	if len(a) == 0 {
+		return true
+	}
+	methods := a[client]
+	return slices.Contains(methods, "*") || slices.Contains(methods, path.Base(fullMethod))
+}
+
+// authorize пропускает вызов, только если клиент предъявил сертификат и ACL разрешает ему метод.
+func (a ACL) authorize(ctx context.Context, fullMethod string, logger *slog.Logger) error {
+	client := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			client = PeerIdentity(info.State)
+		}
+	}
+
+	if client == "" {
+		logger.Warn("client certificate is missing", "method", fullMethod)
+		return status.Error(codes.Unauthenticated, "client certificate is required")
+	}
+	if !a.allows(client, fullMethod) {
+		logger.Warn("call is not allowed for client", "client", client, "method", fullMethod)
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", client, path.Base(fullMethod))
+	}
+	return nil
+}
+
+// UnaryServerInterceptor проверяет каждый вызов по ACL.
+func (a ACL) UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.authorize(ctx, info.FullMethod, logger); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor - то же для потоковых вызовов.
+func (a ACL) StreamServerInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod, logger); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}